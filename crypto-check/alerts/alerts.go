@@ -0,0 +1,161 @@
+package alerts
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"crypto-check/signals"
+)
+
+// Alert kinds
+const (
+	KindDeviation  = "DEVIATION"  // Price is too far away from the hourly average
+	KindVolatility = "VOLATILITY" // Price moved more than the threshold since the last fetch
+	KindSigma      = "SIGMA"      // Price moved more standard deviations than the threshold since the last fetch
+	KindAnomaly    = "ANOMALY"    // A detector of the anomaly package scored the price
+	KindFunding    = "FUNDING"    // The annualized funding rate of the perpetual futures is extreme
+	KindSpread     = "SPREAD"     // The price gap between two exchanges is wide enough for arbitrage
+	KindTrend      = "TREND"      // The price rocketed or crashed from the hourly average
+)
+
+// DeviationLimit is the distance from the average, in percent, that raises a deviation alert
+const DeviationLimit = 1.0
+
+// Alert is raised by the price analysis
+type Alert struct {
+	Symbol  string
+	Kind    string
+	Price   float64
+	Change  float64 // Percent for deviations, dollars for volatility, standard deviations for sigma, the score for anomalies, annualized percent for funding, basis points for spreads, percent for trends
+	Time    time.Time
+	Message string
+}
+
+// CheckDeviation returns an alert if price deviates from average by more than DeviationLimit.
+// An average of 0 means there is no history yet.
+func CheckDeviation(symbol string, price, average float64, at time.Time) *Alert {
+	if average == 0 {
+		return nil
+	}
+
+	diffPercent := ((price - average) / average) * 100
+	if diffPercent > DeviationLimit || diffPercent < -DeviationLimit {
+		return &Alert{
+			Symbol:  symbol,
+			Kind:    KindDeviation,
+			Price:   price,
+			Change:  diffPercent,
+			Time:    at,
+			Message: fmt.Sprintf("Significant deviation from hourly average! Dev: %.2f%%", diffPercent),
+		}
+	}
+	return nil
+}
+
+// CheckVolatility returns an alert if the price moved by threshold dollars or more since last.
+// A last price of 0 means this is the first fetch.
+func CheckVolatility(symbol string, price, last, threshold float64, at time.Time) *Alert {
+	if last == 0 {
+		return nil
+	}
+
+	diff := price - last
+	absDiff := diff
+	if absDiff < 0 {
+		absDiff = -absDiff
+	}
+	if absDiff >= threshold {
+		return &Alert{
+			Symbol:  symbol,
+			Kind:    KindVolatility,
+			Price:   price,
+			Change:  diff,
+			Time:    at,
+			Message: fmt.Sprintf("VOLATILITY ALERT:Price changed by $%.2f (Threshold: $%.2f)", diff, threshold),
+		}
+	}
+	return nil
+}
+
+// CheckSigma returns an alert if the log return from last to price is threshold or
+// more standard deviations. sigma is the standard deviation of log returns over
+// the time between the two prices, 0 when it is not known yet.
+func CheckSigma(symbol string, price, last, sigma, threshold float64, at time.Time) *Alert {
+	if last == 0 || sigma <= 0 {
+		return nil
+	}
+
+	move := math.Log(price / last)
+	z := move / sigma
+	if math.Abs(z) >= threshold {
+		return &Alert{
+			Symbol:  symbol,
+			Kind:    KindSigma,
+			Price:   price,
+			Change:  z,
+			Time:    at,
+			Message: fmt.Sprintf("SIGMA ALERT: Price moved %+.1fσ (%+.2f%%) since the last fetch (Threshold: %.1fσ)", z, (math.Exp(move)-1)*100, threshold),
+		}
+	}
+	return nil
+}
+
+// CheckFunding returns an alert if the annualized funding rate, in percent, is
+// threshold or more in either direction. price is the mark price of the perpetual.
+func CheckFunding(symbol string, price, annualized, threshold float64, at time.Time) *Alert {
+	if threshold <= 0 || math.Abs(annualized) < threshold {
+		return nil
+	}
+
+	payer := "longs pay shorts"
+	if annualized < 0 {
+		payer = "shorts pay longs"
+	}
+	return &Alert{
+		Symbol:  symbol,
+		Kind:    KindFunding,
+		Price:   price,
+		Change:  annualized,
+		Time:    at,
+		Message: fmt.Sprintf("FUNDING ALERT: Funding is %+.2f%% a year, %s (Threshold: %.2f%%)", annualized, payer, threshold),
+	}
+}
+
+// CheckSpread returns an alert if the spread between the cheapest venue low and the
+// dearest venue high is threshold basis points or more. price is the reference price.
+func CheckSpread(symbol string, price, spreadBPS, threshold float64, low, high string, at time.Time) *Alert {
+	if threshold <= 0 || spreadBPS < threshold {
+		return nil
+	}
+	return &Alert{
+		Symbol:  symbol,
+		Kind:    KindSpread,
+		Price:   price,
+		Change:  spreadBPS,
+		Time:    at,
+		Message: fmt.Sprintf("SPREAD ALERT: %s is %.1f bps above %s (Threshold: %.1f bps)", high, spreadBPS, low, threshold),
+	}
+}
+
+// CheckTrend returns an alert if trend, the classification of the percent change
+// of price from its hourly average with thresholds, is a rocket or a crash
+func CheckTrend(symbol string, price, change float64, trend signals.Trend, thresholds signals.Config, at time.Time) *Alert {
+	var message string
+	switch trend {
+	case signals.TrendRocket:
+		message = fmt.Sprintf("ROCKET ALERT: Price is %+.2f%% from the hourly average (Threshold: +%.2f%%)", change, thresholds.Rocket)
+	case signals.TrendCrash:
+		message = fmt.Sprintf("CRASH ALERT: Price is %+.2f%% from the hourly average (Threshold: -%.2f%%)", change, thresholds.Crash)
+	default:
+		return nil
+	}
+	return &Alert{
+		Symbol:  symbol,
+		Kind:    KindTrend,
+		Price:   price,
+		Change:  change,
+		Time:    at,
+		Message: message,
+	}
+}
@@ -0,0 +1,172 @@
+package alerts
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"crypto-check/signals"
+)
+
+func TestCheckDeviation(t *testing.T) {
+	tests := []struct {
+		name    string
+		price   float64
+		average float64
+		want    bool
+	}{
+		{"No history", 100, 0, false},
+		{"Within the limit", 100.5, 100, false},
+		{"Above the average", 101.5, 100, true},
+		{"Below the average", 98, 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := CheckDeviation("BTCUSDT", tt.price, tt.average, time.Now())
+			if (alert != nil) != tt.want {
+				t.Fatalf("CheckDeviation() = %+v, want alert %v", alert, tt.want)
+			}
+			if alert != nil && alert.Kind != KindDeviation {
+				t.Errorf("Kind = %s, want %s", alert.Kind, KindDeviation)
+			}
+		})
+	}
+}
+
+func TestCheckVolatility(t *testing.T) {
+	tests := []struct {
+		name       string
+		price      float64
+		last       float64
+		wantChange float64
+		want       bool
+	}{
+		{"First fetch", 65000, 0, 0, false},
+		{"Small move", 65050, 65000, 0, false},
+		{"Jump up", 65200, 65000, 200, true},
+		{"Drop", 64500, 65000, -500, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := CheckVolatility("BTCUSDT", tt.price, tt.last, 200, time.Now())
+			if (alert != nil) != tt.want {
+				t.Fatalf("CheckVolatility() = %+v, want alert %v", alert, tt.want)
+			}
+			if alert != nil && alert.Change != tt.wantChange {
+				t.Errorf("Change = %v, want %v", alert.Change, tt.wantChange)
+			}
+		})
+	}
+}
+
+func TestCheckSigma(t *testing.T) {
+	// A 1% sigma makes the threshold of 3 a move of about 3% for BTC and DOGE alike
+	tests := []struct {
+		name   string
+		symbol string
+		price  float64
+		last   float64
+		sigma  float64
+		wantZ  float64
+		want   bool
+	}{
+		{"First fetch", "BTCUSDT", 65000, 0, 0.01, 0, false},
+		{"Sigma not known yet", "BTCUSDT", 70000, 65000, 0, 0, false},
+		{"Small move", "BTCUSDT", 65650, 65000, 0.01, 0, false},
+		{"Jump up", "BTCUSDT", 67000, 65000, 0.01, 3.0305, true},
+		{"Cheap coin drop", "DOGEUSDT", 0.096, 0.1, 0.01, -4.0822, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := CheckSigma(tt.symbol, tt.price, tt.last, tt.sigma, 3, time.Now())
+			if (alert != nil) != tt.want {
+				t.Fatalf("CheckSigma() = %+v, want alert %v", alert, tt.want)
+			}
+			if alert != nil && math.Abs(alert.Change-tt.wantZ) > 1e-4 {
+				t.Errorf("Change = %v, want %v", alert.Change, tt.wantZ)
+			}
+		})
+	}
+}
+
+func TestCheckFunding(t *testing.T) {
+	tests := []struct {
+		name       string
+		annualized float64
+		threshold  float64
+		want       bool
+	}{
+		{"Normal funding", 10.95, 50, false},
+		{"Longs paying", 54.75, 50, true},
+		{"Shorts paying", -60, 50, true},
+		{"Disabled", 500, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := CheckFunding("BTCUSDT", 60000, tt.annualized, tt.threshold, time.Now())
+			if (alert != nil) != tt.want {
+				t.Fatalf("CheckFunding() = %+v, want alert %v", alert, tt.want)
+			}
+			if alert != nil && (alert.Kind != KindFunding || alert.Change != tt.annualized) {
+				t.Errorf("alert = %+v, want kind %s and change %v", alert, KindFunding, tt.annualized)
+			}
+		})
+	}
+}
+
+func TestCheckSpread(t *testing.T) {
+	tests := []struct {
+		name      string
+		spread    float64
+		threshold float64
+		want      bool
+	}{
+		{"Narrow", 12, 50, false},
+		{"At the threshold", 50, 50, true},
+		{"Wide", 120, 50, true},
+		{"Disabled", 120, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := CheckSpread("BTCUSDT", 60000, tt.spread, tt.threshold, "binance", "okx", time.Now())
+			if (alert != nil) != tt.want {
+				t.Fatalf("CheckSpread() = %+v, want alert %v", alert, tt.want)
+			}
+			if alert != nil && (alert.Kind != KindSpread || alert.Change != tt.spread) {
+				t.Errorf("alert = %+v, want kind %s and change %v", alert, KindSpread, tt.spread)
+			}
+		})
+	}
+}
+
+func TestCheckTrend(t *testing.T) {
+	tests := []struct {
+		name        string
+		change      float64
+		trend       signals.Trend
+		wantMessage string
+	}{
+		{"Stable", 1.2, signals.TrendStable, ""},
+		{"Waiting", 0, signals.TrendWaiting, ""},
+		{"Rocket", 6.5, signals.TrendRocket, "ROCKET ALERT: Price is +6.50% from the hourly average (Threshold: +5.00%)"},
+		{"Crash", -8, signals.TrendCrash, "CRASH ALERT: Price is -8.00% from the hourly average (Threshold: -7.00%)"},
+	}
+	thresholds := signals.Config{Oversold: 30, Overbought: 70, Rocket: 5, Crash: 7}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert := CheckTrend("BTCUSDT", 60000, tt.change, tt.trend, thresholds, time.Now())
+			if (alert != nil) != (tt.wantMessage != "") {
+				t.Fatalf("CheckTrend() = %+v, want alert %v", alert, tt.wantMessage != "")
+			}
+			if alert != nil && (alert.Kind != KindTrend || alert.Change != tt.change || alert.Message != tt.wantMessage) {
+				t.Errorf("alert = %+v, want kind %s, change %v and message %q", alert, KindTrend, tt.change, tt.wantMessage)
+			}
+		})
+	}
+}
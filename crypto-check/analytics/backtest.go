@@ -0,0 +1,130 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"crypto-check/backtest"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Backtest replays the stored price history of a symbol through a strategy
+func (s *Server) Backtest(ctx context.Context, req *analyticsv1.BacktestRequest) (*analyticsv1.BacktestResponse, error) {
+	log.Printf("[gRPC] Received a backtest request for the symbol: %s", req.Symbol)
+	if err := checkSymbol("symbol", req.Symbol); err != nil {
+		return nil, err
+	}
+	if err := checkBacktestAccount(req); err != nil {
+		return nil, err
+	}
+	strategy, err := strategyFor(req, s.signals.ConfigFor(req.Symbol))
+	if err != nil {
+		return nil, err
+	}
+
+	// Zero times leave the range open
+	from, err := timeOf("start_time", req.StartTime, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	to, err := timeOf("end_time", req.EndTime, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.store.History(ctx, req.Symbol, from, to)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+	if len(points) == 0 {
+		return nil, notFound(resourcePrices, req.Symbol, "no price history for %s in the requested range", req.Symbol)
+	}
+
+	bars := make([]backtest.Bar, len(points))
+	for i, p := range points {
+		bars[i] = backtest.Bar{Time: p.Time, Price: p.Price}
+	}
+	bars = backtest.Resample(bars, time.Duration(req.IntervalSeconds)*time.Second)
+
+	result := backtest.Run(bars, strategy, backtest.Config{
+		InitialCash: req.InitialCash,
+		FeeRate:     req.FeeRate,
+		Slippage:    req.Slippage,
+	})
+	return toBacktestResponse(req.Symbol, result), nil
+}
+
+// strategyFor builds the requested strategy, unset thresholds use the ones GetRSI
+// classifies the symbol with
+func strategyFor(req *analyticsv1.BacktestRequest, thresholds signals.Config) (backtest.Strategy, error) {
+	switch req.Strategy {
+	case analyticsv1.BacktestStrategy_BACKTEST_STRATEGY_UNSPECIFIED, analyticsv1.BacktestStrategy_BACKTEST_STRATEGY_RSI:
+		strategy := backtest.DefaultRSIStrategy()
+		strategy.Oversold, strategy.Overbought = thresholds.Oversold, thresholds.Overbought
+		if req.RsiPeriod > 0 {
+			strategy.Period = int(req.RsiPeriod)
+		}
+		if req.Oversold > 0 {
+			strategy.Oversold = req.Oversold
+		}
+		if req.Overbought > 0 {
+			strategy.Overbought = req.Overbought
+		}
+		if strategy.Period < 2 {
+			return nil, invalidArgument("rsi_period", "rsi_period must be at least 2")
+		}
+		if strategy.Oversold >= strategy.Overbought {
+			return nil, invalidArgument("oversold", "oversold %g must be below overbought %g", strategy.Oversold, strategy.Overbought)
+		}
+		return strategy, nil
+	default:
+		return nil, invalidArgument("strategy", "unknown strategy %s", req.Strategy)
+	}
+}
+
+func toBacktestResponse(symbol string, r backtest.Result) *analyticsv1.BacktestResponse {
+	res := &analyticsv1.BacktestResponse{
+		Symbol:      symbol,
+		Strategy:    r.Strategy,
+		InitialCash: r.InitialCash,
+		FinalEquity: r.FinalEquity,
+		TotalReturn: r.TotalReturn,
+		MaxDrawdown: r.MaxDrawdown,
+		Sharpe:      r.Sharpe,
+	}
+	for _, p := range r.Equity {
+		res.Equity = append(res.Equity, &analyticsv1.EquityPoint{Time: timestamppb.New(p.Time), Equity: p.Equity})
+	}
+	for _, t := range r.Trades {
+		res.Trades = append(res.Trades, &analyticsv1.Trade{
+			Time:     timestamppb.New(t.Time),
+			Side:     enumOf[analyticsv1.TradeSide](t.Side),
+			Price:    t.Price,
+			Quantity: t.Quantity,
+			Fee:      t.Fee,
+			Pnl:      t.PnL,
+		})
+	}
+	return res
+}
+
+// checkBacktestAccount rejects negative numbers, 0 selects the default of each
+func checkBacktestAccount(req *analyticsv1.BacktestRequest) error {
+	for _, err := range []error{
+		checkNotNegative("interval_seconds", req.IntervalSeconds),
+		checkNotNegative("rsi_period", req.RsiPeriod),
+		checkNotNegative("oversold", req.Oversold),
+		checkNotNegative("overbought", req.Overbought),
+		checkNotNegative("initial_cash", req.InitialCash),
+		checkNotNegative("fee_rate", req.FeeRate),
+		checkNotNegative("slippage", req.Slippage),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-check/indicators"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/store"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Defaults for unset CorrelationRequest fields
+const (
+	defaultCorrelationWindow = 24 * time.Hour
+	defaultResolution        = time.Minute
+	defaultBetaWindow        = 30
+	maxAlignedSamples        = 100000
+)
+
+// GetCorrelation correlates the log returns of several symbols over a window and
+// measures their beta against a benchmark
+func (s *Server) GetCorrelation(ctx context.Context, req *analyticsv1.GetCorrelationRequest) (*analyticsv1.GetCorrelationResponse, error) {
+	log.Printf("[gRPC] Received a correlation request for the symbols: %v", req.Symbols)
+	if len(req.Symbols) < 2 {
+		return nil, invalidArgument("symbols", "at least two symbols are required")
+	}
+	for i, symbol := range req.Symbols {
+		field := fmt.Sprintf("symbols[%d]", i)
+		if err := checkSymbol(field, symbol); err != nil {
+			return nil, err
+		}
+		if slices.Contains(req.Symbols[:i], symbol) {
+			return nil, invalidArgument(field, "%s is requested twice", symbol)
+		}
+	}
+
+	method := req.Method
+	if method == analyticsv1.CorrelationMethod_CORRELATION_METHOD_UNSPECIFIED {
+		method = analyticsv1.CorrelationMethod_CORRELATION_METHOD_PEARSON
+	}
+	correlate := indicators.Pearson
+	switch method {
+	case analyticsv1.CorrelationMethod_CORRELATION_METHOD_PEARSON:
+	case analyticsv1.CorrelationMethod_CORRELATION_METHOD_SPEARMAN:
+		correlate = indicators.Spearman
+	default:
+		return nil, invalidArgument("method", "unknown correlation method %s", req.Method)
+	}
+
+	benchmark := req.Benchmark
+	if benchmark == "" {
+		benchmark = req.Symbols[0]
+	}
+	benchIdx := slices.Index(req.Symbols, benchmark)
+	if benchIdx < 0 {
+		return nil, invalidArgument("benchmark", "benchmark %s must be one of the symbols", benchmark)
+	}
+
+	to, err := timeOf("end_time", req.EndTime, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range []error{
+		checkNotNegative("window_seconds", req.WindowSeconds),
+		checkNotNegative("resolution_seconds", req.ResolutionSeconds),
+		checkNotNegative("beta_window", req.BetaWindow),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+	window, resolution := defaultCorrelationWindow, defaultResolution
+	if req.WindowSeconds > 0 {
+		window = time.Duration(req.WindowSeconds) * time.Second
+	}
+	if req.ResolutionSeconds > 0 {
+		resolution = time.Duration(req.ResolutionSeconds) * time.Second
+	}
+	if window/resolution > maxAlignedSamples {
+		return nil, invalidArgument("resolution_seconds", "a %s window at %s resolution is more than %d samples", window, resolution, maxAlignedSamples)
+	}
+	betaWindow := defaultBetaWindow
+	if req.BetaWindow > 0 {
+		betaWindow = int(req.BetaWindow)
+	}
+
+	histories := make([][]store.Point, len(req.Symbols))
+	for i, symbol := range req.Symbols {
+		points, err := s.store.History(ctx, symbol, to.Add(-window), to)
+		if err != nil {
+			return nil, internalError(ctx, symbol, err)
+		}
+		if len(points) == 0 {
+			return nil, notFound(resourcePrices, symbol, "no price history for %s in the requested window", symbol)
+		}
+		histories[i] = points
+	}
+
+	times, prices := align(histories, resolution)
+	times, returns := alignedReturns(times, prices, resolution)
+	if len(times) < 2 {
+		return nil, failedPrecondition(strings.Join(req.Symbols, ","), "only %d returns between adjacent buckets in the window, need at least 2", len(times))
+	}
+
+	res := &analyticsv1.GetCorrelationResponse{
+		Symbols:   req.Symbols,
+		Method:    method,
+		Benchmark: benchmark,
+		StartTime: timestamppb.New(times[0].Add(-resolution)),
+		EndTime:   timestamppb.New(times[len(times)-1]),
+		Samples:   int32(len(times)),
+	}
+	for i, symbol := range req.Symbols {
+		row := &analyticsv1.CorrelationRow{Symbol: symbol, Values: make([]float64, len(req.Symbols))}
+		for j := range req.Symbols {
+			if i == j {
+				row.Values[j] = 1
+				continue
+			}
+			row.Values[j] = correlate(returns[i], returns[j])
+		}
+		res.Matrix = append(res.Matrix, row)
+
+		beta := &analyticsv1.SymbolBeta{Symbol: symbol, Beta: indicators.Beta(returns[i], returns[benchIdx])}
+		// Rolling value k covers returns k..k+betaWindow-1
+		for k, b := range indicators.RollingBeta(returns[i], returns[benchIdx], betaWindow) {
+			beta.Rolling = append(beta.Rolling, &analyticsv1.BetaPoint{Time: timestamppb.New(times[k+betaWindow-1]), Beta: b})
+		}
+		res.Betas = append(res.Betas, beta)
+	}
+	return res, nil
+}
+
+// alignedReturns returns the log returns of the aligned closes with the bucket
+// each one ends at. Only neighbouring buckets one resolution apart make a
+// return: across a dropped bucket a return would span several resolutions and
+// skew the correlation and beta.
+func alignedReturns(times []time.Time, prices [][]float64, resolution time.Duration) ([]time.Time, [][]float64) {
+	var ends []time.Time
+	returns := make([][]float64, len(prices))
+	for k := 1; k < len(times); k++ {
+		if times[k].Sub(times[k-1]) != resolution {
+			continue
+		}
+		ends = append(ends, times[k])
+		for i, p := range prices {
+			returns[i] = append(returns[i], math.Log(p[k]/p[k-1]))
+		}
+	}
+	return ends, returns
+}
+
+// align puts the series on a common grid of closes. Each fetcher stores its price
+// at a slightly different time, so prices are bucketed by resolution and a
+// symbol missing from a bucket carries its close over from the bucket right
+// before. Buckets where a symbol has no price that recent are dropped.
+func align(series [][]store.Point, resolution time.Duration) ([]time.Time, [][]float64) {
+	closes := make([]map[time.Time]float64, len(series))
+	var buckets []time.Time
+	seen := make(map[time.Time]bool)
+	for i, points := range series {
+		closes[i] = make(map[time.Time]float64)
+		for _, p := range points {
+			bucket := p.Time.Truncate(resolution)
+			closes[i][bucket] = p.Price // Points are in insert order, the last one wins
+			if !seen[bucket] {
+				seen[bucket] = true
+				buckets = append(buckets, bucket)
+			}
+		}
+	}
+	sort.Slice(buckets, func(a, b int) bool { return buckets[a].Before(buckets[b]) })
+
+	var times []time.Time
+	prices := make([][]float64, len(series))
+	for _, bucket := range buckets {
+		row := make([]float64, len(series))
+		complete := true
+		for i := range series {
+			if price, ok := closes[i][bucket]; ok {
+				row[i] = price
+			} else if price, ok := closes[i][bucket.Add(-resolution)]; ok {
+				row[i] = price
+			} else {
+				complete = false
+				break
+			}
+		}
+		if !complete {
+			continue
+		}
+		times = append(times, bucket)
+		for i, price := range row {
+			prices[i] = append(prices[i], price)
+		}
+	}
+	return times, prices
+}
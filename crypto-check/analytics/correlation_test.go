@@ -0,0 +1,108 @@
+package analytics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"crypto-check/store"
+)
+
+func TestAlign(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds float64) time.Time { return start.Add(time.Duration(seconds * float64(time.Second))) }
+
+	tests := []struct {
+		name       string
+		series     [][]store.Point
+		wantTimes  []time.Time
+		wantPrices string
+	}{
+		{
+			"Fetchers a few milliseconds apart",
+			[][]store.Point{
+				{{Time: at(0.01), Price: 1}, {Time: at(60.02), Price: 2}, {Time: at(120.01), Price: 3}},
+				{{Time: at(0.3), Price: 10}, {Time: at(60.4), Price: 20}, {Time: at(120.2), Price: 30}},
+			},
+			[]time.Time{at(0), at(60), at(120)},
+			"[[1 2 3] [10 20 30]]",
+		},
+		{
+			"Last price in a bucket is the close",
+			[][]store.Point{
+				{{Time: at(0), Price: 1}, {Time: at(30), Price: 1.5}, {Time: at(60), Price: 2}},
+				{{Time: at(0), Price: 10}, {Time: at(60), Price: 20}},
+			},
+			[]time.Time{at(0), at(60)},
+			"[[1.5 2] [10 20]]",
+		},
+		{
+			"A missed poll carries the previous close",
+			[][]store.Point{
+				{{Time: at(0), Price: 1}, {Time: at(60), Price: 2}, {Time: at(120), Price: 3}},
+				{{Time: at(0), Price: 10}, {Time: at(120), Price: 30}},
+			},
+			[]time.Time{at(0), at(60), at(120)},
+			"[[1 2 3] [10 10 30]]",
+		},
+		{
+			"Buckets without a recent price are dropped",
+			[][]store.Point{
+				{{Time: at(0), Price: 1}, {Time: at(60), Price: 2}, {Time: at(120), Price: 3}, {Time: at(180), Price: 4}},
+				{{Time: at(120), Price: 30}, {Time: at(180), Price: 40}},
+			},
+			[]time.Time{at(120), at(180)},
+			"[[3 4] [30 40]]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, prices := align(tt.series, time.Minute)
+			if fmt.Sprint(times) != fmt.Sprint(tt.wantTimes) || fmt.Sprint(prices) != tt.wantPrices {
+				t.Errorf("align() = %v, %v, want %v, %s", times, prices, tt.wantTimes, tt.wantPrices)
+			}
+		})
+	}
+}
+
+func TestAlignedReturns(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	tests := []struct {
+		name        string
+		series      [][]store.Point
+		wantTimes   []time.Time
+		wantReturns string
+	}{
+		{
+			"Adjacent buckets",
+			[][]store.Point{
+				{{Time: at(0), Price: 1}, {Time: at(1), Price: 2}, {Time: at(2), Price: 4}},
+				{{Time: at(0), Price: 10}, {Time: at(1), Price: 10}, {Time: at(2), Price: 10}},
+			},
+			[]time.Time{at(1), at(2)},
+			"[[0.693 0.693] [0 0]]",
+		},
+		{
+			// Nothing was stored for minutes 2 to 4, so no return spans them
+			"Missing buckets",
+			[][]store.Point{
+				{{Time: at(0), Price: 1}, {Time: at(1), Price: 2}, {Time: at(5), Price: 8}, {Time: at(6), Price: 16}},
+				{{Time: at(0), Price: 10}, {Time: at(1), Price: 10}, {Time: at(5), Price: 20}, {Time: at(6), Price: 20}},
+			},
+			[]time.Time{at(1), at(6)},
+			"[[0.693 0.693] [0 0]]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, prices := align(tt.series, time.Minute)
+			times, returns := alignedReturns(times, prices, time.Minute)
+			rounded := fmt.Sprintf("%.3g", returns)
+			if fmt.Sprint(times) != fmt.Sprint(tt.wantTimes) || rounded != tt.wantReturns {
+				t.Errorf("alignedReturns() = %v, %s, want %v, %s", times, rounded, tt.wantTimes, tt.wantReturns)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"crypto-check/indicators"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Defaults for unset DerivativesRequest fields
+const (
+	defaultDerivativesWindow = 24 * time.Hour
+	defaultFundingInterval   = 8 * time.Hour
+)
+
+// GetDerivatives reports the latest perpetual futures sample of a symbol with its
+// basis against spot and annualized funding, and their averages over a window
+func (s *Server) GetDerivatives(ctx context.Context, req *analyticsv1.GetDerivativesRequest) (*analyticsv1.GetDerivativesResponse, error) {
+	log.Printf("[gRPC] Received a derivatives request for the symbol: %s", req.Symbol)
+	if err := checkSymbol("symbol", req.Symbol); err != nil {
+		return nil, err
+	}
+	window := defaultDerivativesWindow
+	if req.WindowSeconds > 0 {
+		window = time.Duration(req.WindowSeconds) * time.Second
+	}
+	fundingInterval := defaultFundingInterval
+	if req.FundingIntervalHours > 0 {
+		fundingInterval = time.Duration(req.FundingIntervalHours) * time.Hour
+	}
+	for _, err := range []error{
+		checkNotNegative("window_seconds", req.WindowSeconds),
+		checkNotNegative("funding_interval_hours", req.FundingIntervalHours),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+	to, err := timeOf("end_time", req.EndTime, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	samples, err := s.store.Derivatives(ctx, req.Symbol, to.Add(-window), to)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+	if len(samples) == 0 {
+		return nil, notFound(resourceDerivatives, req.Symbol, "no futures data of %s in the requested range", req.Symbol)
+	}
+
+	res := &analyticsv1.GetDerivativesResponse{Symbol: req.Symbol}
+	var fundingSum, basisSum float64
+	var basisCount int
+	for _, d := range samples {
+		point := &analyticsv1.DerivativesPoint{
+			Time:              timestamppb.New(d.Time),
+			MarkPrice:         d.MarkPrice,
+			SpotPrice:         d.SpotPrice,
+			BasisBps:          indicators.BasisBPS(d.MarkPrice, d.SpotPrice),
+			FundingRate:       d.FundingRate,
+			AnnualizedFunding: indicators.AnnualizedFunding(d.FundingRate, fundingInterval),
+			OpenInterest:      d.OpenInterest,
+		}
+		fundingSum += point.AnnualizedFunding
+		if d.SpotPrice > 0 {
+			basisSum += point.BasisBps
+			basisCount++
+		}
+		res.Points = append(res.Points, point)
+	}
+	res.AverageAnnualizedFunding = fundingSum / float64(len(samples))
+	if basisCount > 0 {
+		res.AverageBasisBps = basisSum / float64(basisCount)
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	res.Time = timestamppb.New(last.Time)
+	res.MarkPrice = last.MarkPrice
+	res.IndexPrice = last.IndexPrice
+	res.SpotPrice = last.SpotPrice
+	if last.SpotPrice > 0 {
+		res.Basis = last.MarkPrice - last.SpotPrice
+	}
+	res.BasisBps = indicators.BasisBPS(last.MarkPrice, last.SpotPrice)
+	res.PremiumBps = indicators.BasisBPS(last.MarkPrice, last.IndexPrice)
+	res.FundingRate = last.FundingRate
+	res.AnnualizedFunding = indicators.AnnualizedFunding(last.FundingRate, fundingInterval)
+	if !last.NextFundingTime.IsZero() {
+		res.NextFundingTime = timestamppb.New(last.NextFundingTime)
+	}
+	res.OpenInterest = last.OpenInterest
+	res.OpenInterestNotional = last.OpenInterest * last.MarkPrice
+	if first.OpenInterest > 0 {
+		res.OpenInterestChange = (last.OpenInterest - first.OpenInterest) / first.OpenInterest * 100
+	}
+	return res, nil
+}
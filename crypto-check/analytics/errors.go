@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"crypto-check/currency"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Resource types named by NotFound errors
+const (
+	resourcePrices      = "price_history"
+	resourceOrderBooks  = "order_book"
+	resourceTrades      = "trade_volume"
+	resourceDerivatives = "derivatives"
+)
+
+// withDetails attaches details to a status, falling back to the bare status if they cannot be encoded
+func withDetails(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(details...); err == nil {
+		return detailed.Err()
+	}
+	return st.Err()
+}
+
+// invalidArgument rejects a request, naming the field at fault in a BadRequest detail
+func invalidArgument(field, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	return withDetails(codes.InvalidArgument, message, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: message}},
+	})
+}
+
+// notFound reports a symbol without the data asked for in a ResourceInfo detail
+func notFound(resource, symbol, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	return withDetails(codes.NotFound, message, &errdetails.ResourceInfo{
+		ResourceType: resource,
+		ResourceName: symbol,
+		Description:  message,
+	})
+}
+
+// failedPrecondition reports data too short for the request in a PreconditionFailure detail
+func failedPrecondition(symbol, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	return withDetails(codes.FailedPrecondition, message, &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{Type: "NOT_ENOUGH_DATA", Subject: symbol, Description: message}},
+	})
+}
+
+// internalError logs a failed query and hides it from the caller, as it may
+// expose the database. A cancelled request keeps its own code.
+func internalError(ctx context.Context, symbol string, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	log.Printf("[ERROR] [%s] Database query failed: %v", symbol, err)
+	return status.Error(codes.Internal, "database query failed")
+}
+
+// checkSymbol requires field to hold a symbol
+func checkSymbol(field, symbol string) error {
+	if symbol == "" {
+		return invalidArgument(field, "%s is required", field)
+	}
+	if !currency.ValidSymbol(symbol) {
+		return invalidArgument(field, "%s %q is not a symbol like BTCUSDT", field, symbol)
+	}
+	return nil
+}
+
+// checkNotNegative rejects negative values of numeric fields, where 0 selects the default
+func checkNotNegative[N int32 | int64 | float64](field string, value N) error {
+	if value < 0 {
+		return invalidArgument(field, "%s must not be negative", field)
+	}
+	return nil
+}
+
+// timeOf returns the time of a timestamp field, or fallback when it is unset
+func timeOf(field string, ts *timestamppb.Timestamp, fallback time.Time) (time.Time, error) {
+	if ts == nil {
+		return fallback, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, invalidArgument(field, "%s is not a valid timestamp", field)
+	}
+	return ts.AsTime(), nil
+}
@@ -0,0 +1,228 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"crypto-check/forecast"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Defaults for unset ForecastRequest and EvaluationRequest fields
+const (
+	defaultForecastInterval = 5 * time.Minute
+	defaultForecastHistory  = 288
+	defaultForecastSteps    = 12
+	defaultOrigins          = 100
+	maxForecastSteps        = 1000
+	maxOrigins              = 10000
+)
+
+// Forecast fits a model to the latest candles of a symbol and forecasts the next ones
+func (s *Server) Forecast(ctx context.Context, req *analyticsv1.ForecastRequest) (*analyticsv1.ForecastResponse, error) {
+	log.Printf("[gRPC] Received a forecast request for the symbol: %s", req.Symbol)
+	p, err := forecastParams(req)
+	if err != nil {
+		return nil, err
+	}
+	candles, err := s.forecastCandles(ctx, req.Symbol, p.to, p.interval, p.history)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := forecast.Predict(closes(candles), p.steps, p.config)
+	if err != nil {
+		return nil, forecastError(req.Symbol, err)
+	}
+	last := candles[len(candles)-1]
+	out := &analyticsv1.ForecastResponse{
+		Symbol:          req.Symbol,
+		Model:           enumOf[analyticsv1.ForecastModel](res.Model),
+		IntervalSeconds: int64(p.interval / time.Second),
+		Candles:         int32(len(candles)),
+		Alpha:           res.Alpha,
+		Beta:            res.Beta,
+		Coefficients:    res.Coefficients,
+		Sigma:           res.Sigma,
+		Level:           p.config.Level,
+		LastClose:       last.Close,
+		Time:            timestamppb.New(last.Time),
+	}
+	for _, pt := range res.Points {
+		out.Points = append(out.Points, &analyticsv1.ForecastPoint{
+			Time:  timestamppb.New(last.Time.Add(time.Duration(pt.Step) * p.interval)),
+			Value: pt.Value,
+			Lower: pt.Lower,
+			Upper: pt.Upper,
+		})
+	}
+	return out, nil
+}
+
+// EvaluateForecast walks a model forward through the stored candles, refitting
+// it on the history before every origin, and reports its errors per step
+func (s *Server) EvaluateForecast(ctx context.Context, req *analyticsv1.EvaluateForecastRequest) (*analyticsv1.EvaluateForecastResponse, error) {
+	if req.Forecast == nil {
+		return nil, invalidArgument("forecast", "forecast is required")
+	}
+	log.Printf("[gRPC] Received a forecast evaluation request for the symbol: %s", req.Forecast.Symbol)
+	p, err := forecastParams(req.Forecast)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNotNegative("origins", req.Origins); err != nil {
+		return nil, err
+	}
+	origins := defaultOrigins
+	if req.Origins > 0 {
+		origins = min(int(req.Origins), maxOrigins)
+	}
+
+	// The last origin still needs every step after it
+	candles, err := s.forecastCandles(ctx, req.Forecast.Symbol, p.to, p.interval, p.history+origins+p.steps-1)
+	if err != nil {
+		return nil, err
+	}
+	accuracy, err := forecast.Evaluate(closes(candles), p.steps, p.history, p.config)
+	if err != nil {
+		return nil, forecastError(req.Forecast.Symbol, err)
+	}
+
+	out := &analyticsv1.EvaluateForecastResponse{
+		Symbol:          req.Forecast.Symbol,
+		Model:           enumOf[analyticsv1.ForecastModel](p.config.Model),
+		IntervalSeconds: int64(p.interval / time.Second),
+		Origins:         int32(accuracy[0].Forecasts),
+		StartTime:       timestamppb.New(candles[p.history].Time),
+		EndTime:         timestamppb.New(candles[len(candles)-1].Time),
+	}
+	for _, a := range accuracy {
+		out.Steps = append(out.Steps, &analyticsv1.StepAccuracy{
+			Step:      int32(a.Step),
+			Forecasts: int32(a.Forecasts),
+			Mae:       a.MAE,
+			Rmse:      a.RMSE,
+			Mape:      a.MAPE,
+			Coverage:  a.Coverage,
+			NaiveMae:  a.NaiveMAE,
+		})
+	}
+	return out, nil
+}
+
+// forecastRequest is a ForecastRequest with defaults applied
+type forecastRequest struct {
+	to       time.Time
+	interval time.Duration
+	history  int
+	steps    int
+	config   forecast.Config
+}
+
+// forecastParams validates req and applies the defaults. The ranges of the model
+// parameters are checked here to name the field at fault.
+func forecastParams(req *analyticsv1.ForecastRequest) (forecastRequest, error) {
+	if err := checkSymbol("symbol", req.Symbol); err != nil {
+		return forecastRequest{}, err
+	}
+	to, err := timeOf("end_time", req.EndTime, time.Now().UTC())
+	if err != nil {
+		return forecastRequest{}, err
+	}
+	for _, err := range []error{
+		checkNotNegative("interval_seconds", req.IntervalSeconds),
+		checkNotNegative("history", req.History),
+		checkNotNegative("steps", req.Steps),
+		checkNotNegative("order", req.Order),
+		checkFraction("alpha", req.Alpha),
+		checkFraction("beta", req.Beta),
+		checkFraction("level", req.Level),
+	} {
+		if err != nil {
+			return forecastRequest{}, err
+		}
+	}
+	model := strings.ToLower(analyticsv1.EnumName(req.Model))
+	if model == "" && req.Model != analyticsv1.ForecastModel_FORECAST_MODEL_UNSPECIFIED {
+		return forecastRequest{}, invalidArgument("model", "unknown model %s", req.Model)
+	}
+	p := forecastRequest{
+		to:       to,
+		interval: defaultForecastInterval,
+		history:  defaultForecastHistory,
+		steps:    defaultForecastSteps,
+		config: forecast.Config{
+			Model: model,
+			Alpha: req.Alpha,
+			Beta:  req.Beta,
+			Order: int(req.Order),
+			Level: 0.95,
+		},
+	}
+	if p.config.Model == "" {
+		p.config.Model = forecast.ModelHolt
+	}
+	if req.IntervalSeconds > 0 {
+		p.interval = time.Duration(req.IntervalSeconds) * time.Second
+	}
+	if req.History > 0 {
+		p.history = int(req.History)
+	}
+	if req.Steps > 0 {
+		p.steps = int(req.Steps)
+	}
+	if p.steps > maxForecastSteps {
+		return forecastRequest{}, invalidArgument("steps", "steps must be at most %d", maxForecastSteps)
+	}
+	if req.Level != 0 {
+		p.config.Level = req.Level
+	}
+	return p, nil
+}
+
+// forecastCandles returns up to the last n candles of symbol ending at to
+func (s *Server) forecastCandles(ctx context.Context, symbol string, to time.Time, interval time.Duration, n int) ([]store.Candle, error) {
+	candles, err := s.store.Candles(ctx, symbol, to.Add(-time.Duration(n)*interval), to, interval)
+	if err != nil {
+		return nil, internalError(ctx, symbol, err)
+	}
+	if len(candles) == 0 {
+		return nil, notFound(resourcePrices, symbol, "no price history for %s in the requested range", symbol)
+	}
+	return candles[max(0, len(candles)-n):], nil
+}
+
+// forecastError maps the errors of the forecast package to gRPC codes
+func forecastError(symbol string, err error) error {
+	switch {
+	case errors.Is(err, forecast.ErrInvalidConfig):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, forecast.ErrNotEnoughData):
+		return failedPrecondition(symbol, "%v", err)
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// checkFraction requires a value between 0 and 1, where 0 selects the default
+func checkFraction(field string, value float64) error {
+	if value < 0 || value >= 1 {
+		return invalidArgument(field, "%s must be between 0 and 1", field)
+	}
+	return nil
+}
+
+func closes(candles []store.Candle) []float64 {
+	out := make([]float64, len(candles))
+	for i, c := range candles {
+		out[i] = c.Close
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultLiquidityWindow = time.Hour
+
+// GetLiquidity describes the latest stored order book of a symbol and how its
+// spread and imbalance behaved over the window
+func (s *Server) GetLiquidity(ctx context.Context, req *analyticsv1.GetLiquidityRequest) (*analyticsv1.GetLiquidityResponse, error) {
+	log.Printf("[gRPC] Received a liquidity request for the symbol: %s", req.Symbol)
+	if err := checkSymbol("symbol", req.Symbol); err != nil {
+		return nil, err
+	}
+	window := defaultLiquidityWindow
+	if req.WindowSeconds > 0 {
+		window = time.Duration(req.WindowSeconds) * time.Second
+	}
+	for _, err := range []error{
+		checkNotNegative("window_seconds", req.WindowSeconds),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+	to, err := timeOf("end_time", req.EndTime, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	snaps, err := s.store.BookSnapshots(ctx, req.Symbol, to.Add(-window), to)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+	if len(snaps) == 0 {
+		return nil, notFound(resourceOrderBooks, req.Symbol, "no order book of %s in the requested range", req.Symbol)
+	}
+
+	last := snaps[len(snaps)-1]
+	res := &analyticsv1.GetLiquidityResponse{
+		Symbol:          req.Symbol,
+		Time:            timestamppb.New(last.Time),
+		BestBid:         last.BestBid,
+		BestBidQuantity: last.BestBidQuantity,
+		BestAsk:         last.BestAsk,
+		BestAskQuantity: last.BestAskQuantity,
+		Mid:             last.Mid(),
+		Spread:          last.Spread(),
+		SpreadBps:       last.SpreadBPS(),
+		Imbalance:       last.Imbalance(),
+		Snapshots:       int32(len(snaps)),
+	}
+	for _, b := range last.Bands {
+		res.Bands = append(res.Bands, &analyticsv1.DepthBand{
+			Bps:         b.BPS,
+			BidQuantity: b.BidQuantity,
+			AskQuantity: b.AskQuantity,
+			BidNotional: b.BidNotional,
+			AskNotional: b.AskNotional,
+			Imbalance:   b.Imbalance(),
+		})
+	}
+
+	var spreads, imbalances float64
+	for _, snap := range snaps {
+		spreadBPS, imbalance := snap.SpreadBPS(), snap.Imbalance()
+		spreads += spreadBPS
+		imbalances += imbalance
+		res.MaxSpreadBps = max(res.MaxSpreadBps, spreadBPS)
+		res.Series = append(res.Series, &analyticsv1.LiquidityPoint{
+			Time:      timestamppb.New(snap.Time),
+			Mid:       snap.Mid(),
+			SpreadBps: spreadBPS,
+			Imbalance: imbalance,
+		})
+	}
+	res.AvgSpreadBps = spreads / float64(len(snaps))
+	res.AvgImbalance = imbalances / float64(len(snaps))
+	return res, nil
+}
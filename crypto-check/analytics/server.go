@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"crypto-check/indicators"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+	"crypto-check/store"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements the AnalyticsService gRPC API on top of the shared price database
+type Server struct {
+	analyticsv1.UnimplementedAnalyticsServiceServer
+	store   *store.Store
+	signals *signals.Classifier
+}
+
+// NewServer creates the service. classifier holds the thresholds of the RSI and trend
+// signals, and of the RSI strategy of backtests left at zero.
+func NewServer(st *store.Store, classifier *signals.Classifier) *Server {
+	return &Server{store: st, signals: classifier}
+}
+
+// Bounds of GetRSIRequest.period
+const (
+	defaultRSIPeriod = 14
+	maxRSIPeriod     = 1000
+)
+
+func (s *Server) GetRSI(ctx context.Context, req *analyticsv1.GetRSIRequest) (*analyticsv1.GetRSIResponse, error) {
+
+	log.Printf("[gRPC] Received a request for the symbol: %s", req.Symbol)
+	if err := checkSymbol("symbol", req.Symbol); err != nil {
+		return nil, err
+	}
+	period := defaultRSIPeriod
+	if req.Period != 0 {
+		period = int(req.Period)
+	}
+	if period < 2 || period > maxRSIPeriod {
+		return nil, invalidArgument("period", "period must be between 2 and %d", maxRSIPeriod)
+	}
+
+	thresholds := s.signals.ConfigFor(req.Symbol)
+	res := &analyticsv1.GetRSIResponse{
+		Symbol:     req.Symbol,
+		RsiValue:   50.0,
+		Signal:     toRSISignal(signals.RSIWaiting),
+		Trend:      toPriceTrend(signals.TrendWaiting),
+		Thresholds: toSignalThresholds(thresholds),
+	}
+
+	// We take the last period prices, oldest first
+	prices, err := s.store.RecentPrices(ctx, req.Symbol, period)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+	// If there is little data (for example, it has just been launched), the RSI cannot be calculated
+	if len(prices) < 2 {
+		return res, nil
+	}
+
+	// Count RSI
+	res.CurrentPrice = prices[len(prices)-1] // Last price
+	res.RsiValue = indicators.CalculateRSI(prices)
+	res.Signal = toRSISignal(thresholds.RSI(res.RsiValue))
+
+	// The hour before the last price rather than before now, so replays see their own history
+	last, ok, err := s.store.LastPrice(ctx, req.Symbol)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+	if ok {
+		res.Time = timestamppb.New(last.Time)
+		average, err := s.store.AveragePrice(ctx, req.Symbol, last.Time.Add(-time.Hour))
+		if err != nil {
+			return nil, internalError(ctx, req.Symbol, err)
+		}
+		if average > 0 {
+			res.ChangePercent = (last.Price - average) / average * 100
+			res.Trend = toPriceTrend(thresholds.Trend(res.ChangePercent))
+		}
+	}
+	return res, nil
+}
+
+func toRSISignal(r signals.RSI) analyticsv1.RSISignal {
+	return analyticsv1.RSISignal(analyticsv1.RSISignal_value[r.Enum()])
+}
+
+func toPriceTrend(t signals.Trend) analyticsv1.PriceTrend {
+	return analyticsv1.PriceTrend(analyticsv1.PriceTrend_value[t.Enum()])
+}
+
+// enumOf returns the value of E named like a domain constant, e.g. "spearman" or "BUY",
+// and the unspecified value for names the API does not know
+func enumOf[E interface {
+	~int32
+	protoreflect.Enum
+}](name string) E {
+	value, _ := analyticsv1.ParseEnum[E](name)
+	return value
+}
+
+func toSignalThresholds(c signals.Config) *analyticsv1.SignalThresholds {
+	return &analyticsv1.SignalThresholds{
+		Oversold:      c.Oversold,
+		Overbought:    c.Overbought,
+		RocketPercent: c.Rocket,
+		CrashPercent:  c.Crash,
+	}
+}
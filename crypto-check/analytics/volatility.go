@@ -0,0 +1,129 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"crypto-check/indicators"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/store"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Defaults for unset VolatilityRequest fields
+const (
+	defaultVolatilityInterval = time.Hour
+	defaultVolatilityWindow   = 24
+	defaultHistoryDays        = 30
+)
+
+// Volatility estimators
+const (
+	EstimatorCloseToClose = "close_to_close"
+	EstimatorParkinson    = "parkinson"
+	EstimatorGarmanKlass  = "garman_klass"
+)
+
+// GetVolatility estimates the annualized realized volatility of a symbol from
+// candles built on the stored prices and ranks it against its own history
+func (s *Server) GetVolatility(ctx context.Context, req *analyticsv1.GetVolatilityRequest) (*analyticsv1.GetVolatilityResponse, error) {
+	log.Printf("[gRPC] Received a volatility request for the symbol: %s", req.Symbol)
+	if err := checkSymbol("symbol", req.Symbol); err != nil {
+		return nil, err
+	}
+	estimator := strings.ToLower(analyticsv1.EnumName(req.Estimator))
+	if req.Estimator == analyticsv1.VolatilityEstimator_VOLATILITY_ESTIMATOR_UNSPECIFIED {
+		estimator = EstimatorCloseToClose
+	}
+	if estimator != EstimatorCloseToClose && estimator != EstimatorParkinson && estimator != EstimatorGarmanKlass {
+		return nil, invalidArgument("estimator", "unknown estimator %s", req.Estimator)
+	}
+	for _, err := range []error{
+		checkNotNegative("interval_seconds", req.IntervalSeconds),
+		checkNotNegative("window", req.Window),
+		checkNotNegative("history_days", req.HistoryDays),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	interval := defaultVolatilityInterval
+	if req.IntervalSeconds > 0 {
+		interval = time.Duration(req.IntervalSeconds) * time.Second
+	}
+	window := defaultVolatilityWindow
+	if req.Window > 0 {
+		window = int(req.Window)
+	}
+	if window < 3 {
+		return nil, invalidArgument("window", "window must be at least 3 candles")
+	}
+	historyDays := defaultHistoryDays
+	if req.HistoryDays > 0 {
+		historyDays = int(req.HistoryDays)
+	}
+	to, err := timeOf("end_time", req.EndTime, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	// The first estimate of the history needs a full window before it
+	from := to.Add(-time.Duration(historyDays)*24*time.Hour - time.Duration(window)*interval)
+	candles, err := s.store.Candles(ctx, req.Symbol, from, to, interval)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+	if len(candles) == 0 {
+		return nil, notFound(resourcePrices, req.Symbol, "no price history for %s in the requested range", req.Symbol)
+	}
+	if len(candles) < 3 {
+		return nil, failedPrecondition(req.Symbol, "only %d candles of %s for %s, need at least 3", len(candles), interval, req.Symbol)
+	}
+
+	current := candles[max(0, len(candles)-window):]
+	res := &analyticsv1.GetVolatilityResponse{
+		Symbol:          req.Symbol,
+		IntervalSeconds: int64(interval / time.Second),
+		Candles:         int32(len(current)),
+		CloseToClose:    indicators.Annualize(estimate(current, EstimatorCloseToClose), interval),
+		Parkinson:       indicators.Annualize(estimate(current, EstimatorParkinson), interval),
+		GarmanKlass:     indicators.Annualize(estimate(current, EstimatorGarmanKlass), interval),
+		Estimator:       enumOf[analyticsv1.VolatilityEstimator](estimator),
+		Time:            timestamppb.New(candles[len(candles)-1].Time),
+	}
+
+	// Rank against every full window in the history, the current one included
+	var history []float64
+	for end := window; end <= len(candles); end++ {
+		history = append(history, estimate(candles[end-window:end], estimator))
+	}
+	if len(history) > 0 {
+		res.Percentile = indicators.PercentileRank(history[len(history)-1], history)
+	}
+	res.HistorySamples = int32(len(history))
+	res.Regime = enumOf[analyticsv1.VolatilityRegime](indicators.Regime(res.Percentile, len(history)))
+	return res, nil
+}
+
+// estimate returns the volatility per candle
+func estimate(candles []store.Candle, estimator string) float64 {
+	opens := make([]float64, len(candles))
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		opens[i], highs[i], lows[i], closes[i] = c.Open, c.High, c.Low, c.Close
+	}
+	switch estimator {
+	case EstimatorParkinson:
+		return indicators.Parkinson(highs, lows)
+	case EstimatorGarmanKlass:
+		return indicators.GarmanKlass(opens, highs, lows, closes)
+	default:
+		return indicators.CloseToClose(closes)
+	}
+}
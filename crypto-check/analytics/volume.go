@@ -0,0 +1,115 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"crypto-check/indicators"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/store"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Defaults for unset VolumeRequest fields
+const (
+	defaultVolumeWindow   = 24 * time.Hour
+	defaultVolumeInterval = time.Hour
+)
+
+// GetVolume sums the stored trade volumes of a symbol over a window, with its
+// VWAP and taker buy/sell pressure, and the volume of each candle
+func (s *Server) GetVolume(ctx context.Context, req *analyticsv1.GetVolumeRequest) (*analyticsv1.GetVolumeResponse, error) {
+	log.Printf("[gRPC] Received a volume request for the symbol: %s", req.Symbol)
+	if err := checkSymbol("symbol", req.Symbol); err != nil {
+		return nil, err
+	}
+	window := defaultVolumeWindow
+	if req.WindowSeconds > 0 {
+		window = time.Duration(req.WindowSeconds) * time.Second
+	}
+	interval := defaultVolumeInterval
+	if req.IntervalSeconds > 0 {
+		interval = time.Duration(req.IntervalSeconds) * time.Second
+	}
+	for _, err := range []error{
+		checkNotNegative("window_seconds", req.WindowSeconds),
+		checkNotNegative("interval_seconds", req.IntervalSeconds),
+	} {
+		if err != nil {
+			return nil, err
+		}
+	}
+	to, err := timeOf("end_time", req.EndTime, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	from := to.Add(-window)
+
+	volumes, err := s.store.Volumes(ctx, req.Symbol, from, to)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+	if len(volumes) == 0 {
+		return nil, notFound(resourceTrades, req.Symbol, "no trades of %s in the requested range", req.Symbol)
+	}
+	candles, err := s.store.Candles(ctx, req.Symbol, from, to, interval)
+	if err != nil {
+		return nil, internalError(ctx, req.Symbol, err)
+	}
+
+	var total store.Volume
+	for _, v := range volumes {
+		total.Add(v)
+	}
+	res := &analyticsv1.GetVolumeResponse{
+		Symbol:          req.Symbol,
+		StartTime:       timestamppb.New(from),
+		EndTime:         timestamppb.New(to),
+		Volume:          total.Volume,
+		BuyVolume:       total.BuyVolume,
+		SellVolume:      total.SellVolume(),
+		QuoteVolume:     total.QuoteVolume,
+		Trades:          int32(total.Trades),
+		Vwap:            total.VWAP(),
+		Cvd:             total.Delta(),
+		IntervalSeconds: int64(interval / time.Second),
+	}
+	if total.Volume > 0 {
+		res.BuyRatio = total.BuyVolume / total.Volume
+	}
+
+	closes := make([]float64, len(candles))
+	amounts := make([]float64, len(candles))
+	buys := make([]float64, len(candles))
+	sells := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i], amounts[i], buys[i], sells[i] = c.Close, c.Volume, c.BuyVolume, c.Volume-c.BuyVolume
+	}
+	obv := indicators.OBV(closes, amounts)
+	cvd := indicators.CVD(buys, sells)
+	for i, c := range candles {
+		candle := &analyticsv1.VolumeCandle{
+			Time:        timestamppb.New(c.Time),
+			Open:        c.Open,
+			High:        c.High,
+			Low:         c.Low,
+			Close:       c.Close,
+			Volume:      c.Volume,
+			BuyVolume:   c.BuyVolume,
+			QuoteVolume: c.QuoteVolume,
+			Trades:      int32(c.Trades),
+			Cvd:         cvd[i],
+			Obv:         obv[i],
+		}
+		if c.Volume > 0 {
+			candle.Vwap = c.QuoteVolume / c.Volume
+		}
+		res.Candles = append(res.Candles, candle)
+	}
+	if len(obv) > 0 {
+		res.Obv = obv[len(obv)-1]
+	}
+	return res, nil
+}
@@ -0,0 +1,262 @@
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Detection methods, all applied to the log returns between ticks
+const (
+	MethodZScore = "zscore" // Return far from the rolling mean in standard deviations
+	MethodEWMA   = "ewma"   // Smoothed returns outside the control limits, catches slow drifts
+	MethodMAD    = "mad"    // Return far from the rolling median in median absolute deviations
+)
+
+// minSamples is the number of returns needed before anything is scored
+const minSamples = 20
+
+// Config tunes the detectors. Zero fields take the defaults, a negative
+// threshold turns its method off.
+type Config struct {
+	Window    int     `json:"window"`     // Returns the statistics are computed over
+	ZScore    float64 `json:"zscore"`     // Standard deviations from the mean
+	EWMAAlpha float64 `json:"ewma_alpha"` // Weight of the newest return in the smoothed return
+	EWMALimit float64 `json:"ewma_limit"` // Control limit in standard deviations of the smoothed return
+	MAD       float64 `json:"mad"`        // Modified z-score limit, above the textbook 3.5 as tick returns have fat tails
+}
+
+// DefaultConfig returns the settings used for zero fields
+func DefaultConfig() Config {
+	return Config{Window: 60, ZScore: 4, EWMAAlpha: 0.1, EWMALimit: 3, MAD: 5}
+}
+
+// Merge returns c with the non-zero fields of o
+func (c Config) Merge(o Config) Config {
+	if o.Window != 0 {
+		c.Window = o.Window
+	}
+	if o.ZScore != 0 {
+		c.ZScore = o.ZScore
+	}
+	if o.EWMAAlpha != 0 {
+		c.EWMAAlpha = o.EWMAAlpha
+	}
+	if o.EWMALimit != 0 {
+		c.EWMALimit = o.EWMALimit
+	}
+	if o.MAD != 0 {
+		c.MAD = o.MAD
+	}
+	return c
+}
+
+// withDefaults fills zero fields from DefaultConfig
+func (c Config) withDefaults() Config {
+	d := DefaultConfig()
+	if c.Window <= 0 {
+		c.Window = d.Window
+	}
+	if c.ZScore == 0 {
+		c.ZScore = d.ZScore
+	}
+	if c.EWMAAlpha <= 0 || c.EWMAAlpha > 1 {
+		c.EWMAAlpha = d.EWMAAlpha
+	}
+	if c.EWMALimit == 0 {
+		c.EWMALimit = d.EWMALimit
+	}
+	if c.MAD == 0 {
+		c.MAD = d.MAD
+	}
+	return c
+}
+
+// Event is a scored anomaly. Score is signed, its magnitude is compared with Threshold.
+type Event struct {
+	ID          int64     `json:"id"`
+	Symbol      string    `json:"symbol"`
+	Method      string    `json:"method"`
+	Score       float64   `json:"score"`
+	Threshold   float64   `json:"threshold"`
+	Price       float64   `json:"price"`
+	Return      float64   `json:"return"` // Log return from the previous tick
+	Time        time.Time `json:"time"`
+	Explanation string    `json:"explanation"`
+}
+
+// Detector scores the ticks of one symbol
+type Detector struct {
+	symbol    string
+	config    Config
+	lastPrice float64
+	returns   []float64 // The last Window returns, oldest first
+	ewma      float64
+}
+
+// NewDetector creates a detector for symbol
+func NewDetector(symbol string, config Config) *Detector {
+	return &Detector{symbol: symbol, config: config.withDefaults()}
+}
+
+// Observe scores a new tick against the returns before it and adds it to the window
+func (d *Detector) Observe(price float64, at time.Time) []Event {
+	if price <= 0 {
+		return nil
+	}
+	if d.lastPrice == 0 {
+		d.lastPrice = price
+		return nil
+	}
+	r := math.Log(price / d.lastPrice)
+	d.lastPrice = price
+
+	var events []Event
+	if len(d.returns) >= min(minSamples, d.config.Window) {
+		events = d.score(r, price, at)
+	}
+
+	d.returns = append(d.returns, r)
+	if len(d.returns) > d.config.Window {
+		d.returns = d.returns[1:]
+	}
+	return events
+}
+
+func (d *Detector) score(r, price float64, at time.Time) []Event {
+	c := d.config
+	mean, std := meanStd(d.returns)
+	event := func(method string, score, threshold float64, explanation string) Event {
+		return Event{Symbol: d.symbol, Method: method, Score: score, Threshold: threshold, Price: price, Return: r, Time: at, Explanation: explanation}
+	}
+
+	var events []Event
+	if c.ZScore > 0 && std > 0 {
+		if z := (r - mean) / std; math.Abs(z) >= c.ZScore {
+			events = append(events, event(MethodZScore, z, c.ZScore, fmt.Sprintf(
+				"Move of %+.3f%% is %.1f standard deviations from the mean of the last %d (σ %.3f%%)",
+				percent(r), z, len(d.returns), percent(std))))
+		}
+	}
+
+	if c.MAD > 0 {
+		med := median(d.returns)
+		deviations := make([]float64, len(d.returns))
+		for i, v := range d.returns {
+			deviations[i] = math.Abs(v - med)
+		}
+		if mad := medianOf(deviations); mad > 0 {
+			if mz := 0.6745 * (r - med) / mad; math.Abs(mz) >= c.MAD {
+				events = append(events, event(MethodMAD, mz, c.MAD, fmt.Sprintf(
+					"Move of %+.3f%% scores %.1f robust deviations from the median of the last %d (MAD %.3f%%)",
+					percent(r), mz, len(d.returns), percent(mad))))
+			}
+		}
+	}
+
+	// Control chart around a zero mean return, the limit is that of the smoothed
+	// return once it has settled. Returns are clipped to 3σ first so that a single
+	// spike, already caught above, does not read as a drift.
+	clipped := r
+	if std > 0 {
+		clipped = max(-3*std, min(3*std, r))
+	}
+	d.ewma = c.EWMAAlpha*clipped + (1-c.EWMAAlpha)*d.ewma
+	if c.EWMALimit > 0 && std > 0 {
+		sigma := std * math.Sqrt(c.EWMAAlpha/(2-c.EWMAAlpha))
+		if s := d.ewma / sigma; math.Abs(s) >= c.EWMALimit {
+			direction := "up"
+			if s < 0 {
+				direction = "down"
+			}
+			events = append(events, event(MethodEWMA, s, c.EWMALimit, fmt.Sprintf(
+				"Smoothed move of %+.4f%% per tick is beyond the ±%.4f%% control limit, prices are drifting %s",
+				percent(d.ewma), percent(c.EWMALimit*sigma), direction)))
+			d.ewma = 0 // Restart the chart so a long drift is reported again later, not on every tick
+		}
+	}
+	return events
+}
+
+// Engine keeps a detector per symbol. It is safe for concurrent use.
+type Engine struct {
+	defaults  Config
+	overrides map[string]Config
+	mu        sync.Mutex
+	detectors map[string]*Detector
+}
+
+// NewEngine creates an engine. The non-zero fields of a symbol's override take
+// precedence over defaults.
+func NewEngine(defaults Config, overrides map[string]Config) *Engine {
+	return &Engine{defaults: defaults, overrides: overrides, detectors: make(map[string]*Detector)}
+}
+
+// ConfigFor returns the config of symbol with defaults applied
+func (e *Engine) ConfigFor(symbol string) Config {
+	return e.defaults.Merge(e.overrides[symbol]).withDefaults()
+}
+
+// Known reports whether symbol has a detector, i.e. whether it has seen a tick or was warmed up
+func (e *Engine) Known(symbol string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.detectors[symbol] != nil
+}
+
+// Warm feeds stored prices, oldest first, through a new detector without reporting anything
+func (e *Engine) Warm(symbol string, prices []float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	d := NewDetector(symbol, e.ConfigFor(symbol))
+	for _, p := range prices {
+		d.Observe(p, time.Time{})
+	}
+	e.detectors[symbol] = d
+}
+
+// Observe scores a tick of symbol
+func (e *Engine) Observe(symbol string, price float64, at time.Time) []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	d, ok := e.detectors[symbol]
+	if !ok {
+		d = NewDetector(symbol, e.ConfigFor(symbol))
+		e.detectors[symbol] = d
+	}
+	return d.Observe(price, at)
+}
+
+func meanStd(values []float64) (mean, std float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		std += (v - mean) * (v - mean)
+	}
+	if len(values) > 1 {
+		std = math.Sqrt(std / float64(len(values)-1))
+	}
+	return mean, std
+}
+
+func median(values []float64) float64 {
+	return medianOf(append([]float64(nil), values...))
+}
+
+// medianOf sorts values in place
+func medianOf(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+func percent(r float64) float64 {
+	return r * 100
+}
@@ -0,0 +1,103 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// ticks turns log returns into prices starting at 100
+func ticks(returns ...float64) []float64 {
+	prices := []float64{100}
+	for _, r := range returns {
+		prices = append(prices, prices[len(prices)-1]*math.Exp(r))
+	}
+	return prices
+}
+
+// noise alternates between +0.1% and -0.1% moves
+func noise(n int) []float64 {
+	returns := make([]float64, n)
+	for i := range returns {
+		returns[i] = 0.001
+		if i%2 == 1 {
+			returns[i] = -0.001
+		}
+	}
+	return returns
+}
+
+func repeat(r float64, n int) []float64 {
+	returns := make([]float64, n)
+	for i := range returns {
+		returns[i] = r
+	}
+	return returns
+}
+
+func TestDetector(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		returns     []float64
+		wantMethods []string // Of every event, in order
+	}{
+		{"Quiet market", Config{}, noise(60), nil},
+		{"Spike", Config{}, append(noise(40), 0.02), []string{MethodZScore, MethodMAD}},
+		{"Spike before warm-up", Config{}, append(noise(10), 0.02), nil},
+		{"Spike with zscore off", Config{ZScore: -1}, append(noise(40), 0.02), []string{MethodMAD}},
+		{"Crash", Config{}, append(noise(40), -0.03), []string{MethodZScore, MethodMAD}},
+		{"Slow drift", Config{}, append(noise(40), repeat(0.0008, 20)...), []string{MethodEWMA}},
+		{"Slow drift with ewma off", Config{EWMALimit: -1}, append(noise(40), repeat(0.0008, 20)...), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDetector("BTCUSDT", tt.config)
+			start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			var events []Event
+			for i, p := range ticks(tt.returns...) {
+				events = append(events, d.Observe(p, start.Add(time.Duration(i)*time.Second))...)
+			}
+			if len(events) != len(tt.wantMethods) {
+				t.Fatalf("events = %+v, want methods %v", events, tt.wantMethods)
+			}
+			for i, e := range events {
+				if e.Method != tt.wantMethods[i] || math.Abs(e.Score) < e.Threshold || e.Explanation == "" {
+					t.Errorf("event %d = %+v, want method %s", i, e, tt.wantMethods[i])
+				}
+				if math.Signbit(e.Score) != math.Signbit(e.Return) {
+					t.Errorf("event %d score %v has the wrong sign", i, e.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestEngineOverridesAndWarm(t *testing.T) {
+	e := NewEngine(Config{Window: 50}, map[string]Config{"DOGEUSDT": {ZScore: -1, MAD: -1}})
+	want := DefaultConfig()
+	want.Window = 50
+	if got := e.ConfigFor("BTCUSDT"); got != want {
+		t.Errorf("ConfigFor(BTCUSDT) = %+v, want %+v", got, want)
+	}
+	want.ZScore, want.MAD = -1, -1
+	if got := e.ConfigFor("DOGEUSDT"); got != want {
+		t.Errorf("ConfigFor(DOGEUSDT) = %+v, want %+v", got, want)
+	}
+
+	prices := ticks(append(noise(40), 0.02)...)
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, symbol := range []string{"BTCUSDT", "DOGEUSDT"} {
+		e.Warm(symbol, prices[:len(prices)-1])
+		if !e.Known(symbol) {
+			t.Fatalf("%s not known after Warm", symbol)
+		}
+	}
+
+	if events := e.Observe("BTCUSDT", prices[len(prices)-1], at); len(events) != 2 {
+		t.Errorf("BTCUSDT events = %+v, want zscore and mad", events)
+	}
+	if events := e.Observe("DOGEUSDT", prices[len(prices)-1], at); len(events) != 0 {
+		t.Errorf("DOGEUSDT events = %+v, want none with both methods off", events)
+	}
+}
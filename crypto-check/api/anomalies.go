@@ -0,0 +1,50 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/store"
+)
+
+// Number of anomalies returned by GET /api/anomalies without and with limit=
+const (
+	defaultAnomalies = 100
+	maxAnomalies     = 1000
+)
+
+// getAnomaliesHandler lists the stored anomalies newest first, of every symbol or
+// of symbol=, detected after since= (RFC 3339)
+func getAnomaliesHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var since time.Time
+		if v := q.Get("since"); v != "" {
+			var err error
+			if since, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, "since must be an RFC 3339 time", http.StatusBadRequest)
+				return
+			}
+		}
+		limit := defaultAnomalies
+		if v := q.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+				return
+			}
+			limit = min(n, maxAnomalies)
+		}
+
+		events, err := st.Anomalies(r.Context(), strings.ToUpper(q.Get("symbol")), since, limit)
+		if err != nil {
+			log.Printf("[ERROR] API Anomalies error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, events)
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/anomaly"
+	"crypto-check/store"
+)
+
+func TestAnomaliesEndpoint(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, symbol := range []string{"BTCUSDT", "ETHUSDT", "BTCUSDT"} {
+		e := anomaly.Event{Symbol: symbol, Method: anomaly.MethodZScore, Score: 5, Threshold: 4, Price: 100, Time: start.Add(time.Duration(i) * time.Hour)}
+		if _, err := st.InsertAnomaly(ctx, e); err != nil {
+			t.Fatalf("InsertAnomaly() error: %v", err)
+		}
+	}
+	router := NewRouter(st, nil, RouterConfig{})
+
+	tests := []struct {
+		path    string
+		wantIDs []int64
+	}{
+		{"/api/anomalies", []int64{3, 2, 1}},
+		{"/api/anomalies?symbol=btcusdt", []int64{3, 1}},
+		{"/api/anomalies?since=2024-01-01T12:30:00Z", []int64{3, 2}},
+		{"/api/anomalies?limit=1", []int64{3}},
+		{"/api/anomalies?symbol=SOLUSDT", []int64{}},
+	}
+	for _, tt := range tests {
+		var events []anomaly.Event
+		get(t, router, tt.path, &events)
+		if len(events) != len(tt.wantIDs) {
+			t.Errorf("GET %s returned %d anomalies, want %d", tt.path, len(events), len(tt.wantIDs))
+			continue
+		}
+		for i, e := range events {
+			if e.ID != tt.wantIDs[i] {
+				t.Errorf("GET %s [%d] = anomaly %d, want %d", tt.path, i, e.ID, tt.wantIDs[i])
+			}
+		}
+	}
+
+	for _, path := range []string{"/api/anomalies?limit=0", "/api/anomalies?since=yesterday"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want %d", path, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/auth"
+	"crypto-check/store"
+	"crypto-check/web"
+)
+
+// sessionCookie holds the dashboard session token
+const sessionCookie = "crypto_check_session"
+
+// Number of audit entries returned by GET /api/audit without and with limit=
+const (
+	defaultAuditEntries = 100
+	maxAuditEntries     = 1000
+)
+
+// keyRequest is the body of POST /api/keys
+type keyRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// newKeyResponse is the body answering POST /api/keys, the only time the secret is shown
+type newKeyResponse struct {
+	auth.Key
+	Secret string `json:"secret"`
+}
+
+// loginRequest is the body of POST /api/session
+type loginRequest struct {
+	Key string `json:"key"`
+}
+
+// actor is filled in by authenticator.authenticate for the audit log of the request
+type actor struct {
+	key *auth.Key
+}
+
+type actorKey struct{}
+
+// authenticator checks the API keys and sessions of requests. A nil config
+// disables authentication, every request is then allowed.
+type authenticator struct {
+	st  *store.Store
+	cfg *auth.Config
+	now func() time.Time
+}
+
+// require lets requests through whose key has at least role. Keys are sent as
+// "Authorization: Bearer <key>" or X-API-Key, browsers use the session cookie
+// set by POST /api/session.
+func (a *authenticator) require(role auth.Role, h http.HandlerFunc) http.HandlerFunc {
+	if a.cfg == nil {
+		return h
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, ok := a.authenticate(w, r, role)
+		if !ok {
+			return
+		}
+		if key == nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h(w, r.WithContext(context.WithValue(r.Context(), keyContext{}, key)))
+	}
+}
+
+// page is require(viewer) for the dashboard, showing the login form of site instead of an error
+func (a *authenticator) page(site *web.Site, h http.HandlerFunc) http.HandlerFunc {
+	if a.cfg == nil {
+		return h
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, ok := a.authenticate(w, r, auth.RoleViewer)
+		if !ok {
+			return
+		}
+		if key == nil {
+			site.Render(w, http.StatusUnauthorized, "login.html", nil)
+			return
+		}
+		h(w, r.WithContext(context.WithValue(r.Context(), keyContext{}, key)))
+	}
+}
+
+type keyContext struct{}
+
+// requestKey returns the key a request was authenticated with, nil without one
+func requestKey(r *http.Request) *auth.Key {
+	key, _ := r.Context().Value(keyContext{}).(*auth.Key)
+	return key
+}
+
+// authenticate finds the key of a request and checks it may act as role. It
+// answers the request itself and returns false when it must not go on. A nil
+// key with true is a request without credentials the caller must answer.
+func (a *authenticator) authenticate(w http.ResponseWriter, r *http.Request, role auth.Role) (*auth.Key, bool) {
+	key, fromCookie, err := a.credentials(r)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="crypto-check"`)
+		http.Error(w, "Invalid or revoked API key", http.StatusUnauthorized)
+		return nil, false
+	case err != nil:
+		log.Printf("[ERROR] API authentication error: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return nil, false
+	case key == nil && a.cfg.PublicRead && role == auth.RoleViewer:
+		return &auth.Key{Name: "public", Role: auth.RoleViewer}, true
+	case key == nil:
+		w.Header().Set("WWW-Authenticate", `Bearer realm="crypto-check"`)
+		return nil, true
+	}
+
+	if act, ok := r.Context().Value(actorKey{}).(*actor); ok {
+		act.key = key
+	}
+	if !key.Role.Allows(role) {
+		http.Error(w, "Forbidden, this needs the "+string(role)+" role", http.StatusForbidden)
+		return nil, false
+	}
+	// SameSite cookies are not sent cross-site, the origin check covers older browsers
+	if fromCookie && !safeMethod(r.Method) && !sameOrigin(r) {
+		http.Error(w, "Forbidden, cross-origin request", http.StatusForbidden)
+		return nil, false
+	}
+	if err := a.st.TouchKey(r.Context(), key.ID, a.now()); err != nil {
+		log.Printf("[WARNING] Could not record the use of key %d: %v", key.ID, err)
+	}
+	return key, true
+}
+
+// credentials returns the key of the API key header or the session cookie of r,
+// nil when it has neither and store.ErrNotFound when the key is not valid
+func (a *authenticator) credentials(r *http.Request) (key *auth.Key, fromCookie bool, err error) {
+	secret := r.Header.Get("X-API-Key")
+	if h := r.Header.Get("Authorization"); h != "" {
+		scheme, token, _ := strings.Cut(h, " ")
+		if !strings.EqualFold(scheme, "Bearer") {
+			return nil, false, store.ErrNotFound
+		}
+		secret = strings.TrimSpace(token)
+	}
+	if secret != "" {
+		k, err := a.st.KeyByHash(r.Context(), auth.Hash(secret))
+		if err != nil {
+			return nil, false, err
+		}
+		return &k, false, nil
+	}
+
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return nil, false, nil
+	}
+	// An expired session is no session, so the dashboard shows the login form again
+	k, err := a.st.SessionKey(r.Context(), auth.Hash(cookie.Value), a.now())
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	return &k, true, nil
+}
+
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// sameOrigin reports whether the Origin of a browser request, if it sent one, is this server
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// audit records every request that may change something in the audit log, with
+// the key it was made with and how it was answered, refused ones included
+func (a *authenticator) audit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if safeMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		act := &actor{}
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), actorKey{}, act)))
+
+		entry := auth.AuditEntry{
+			Time:       a.now(),
+			Actor:      "anonymous",
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Status:     rec.Status(),
+			RemoteAddr: r.RemoteAddr,
+		}
+		if act.key != nil {
+			entry.Actor, entry.KeyID, entry.Role = act.key.Name, act.key.ID, act.key.Role
+		}
+		// Recorded even when the client went away before the answer
+		if err := a.st.InsertAudit(context.WithoutCancel(r.Context()), entry); err != nil {
+			log.Printf("[ERROR] Audit log error: %v", err)
+		}
+	})
+}
+
+// register adds the session, key and audit endpoints, which exist only with authentication enabled
+func (a *authenticator) register(mux *http.ServeMux) {
+	if a.cfg == nil {
+		return
+	}
+	mux.HandleFunc("POST /api/session", a.loginHandler())
+	mux.HandleFunc("GET /api/session", a.require(auth.RoleViewer, sessionHandler))
+	mux.HandleFunc("DELETE /api/session", a.logoutHandler())
+	mux.HandleFunc("GET /api/keys", a.require(auth.RoleAdmin, listKeysHandler(a.st)))
+	mux.HandleFunc("POST /api/keys", a.require(auth.RoleAdmin, a.createKeyHandler()))
+	mux.HandleFunc("DELETE /api/keys/{id}", a.require(auth.RoleAdmin, a.revokeKeyHandler()))
+	mux.HandleFunc("GET /api/audit", a.require(auth.RoleAdmin, auditHandler(a.st)))
+}
+
+// loginHandler exchanges an API key for a session cookie, so the dashboard
+// does not keep the key itself
+func (a *authenticator) loginHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req loginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" {
+			http.Error(w, "Invalid JSON body, want a key", http.StatusBadRequest)
+			return
+		}
+		key, err := a.st.KeyByHash(r.Context(), auth.Hash(strings.TrimSpace(req.Key)))
+		if errors.Is(err, store.ErrNotFound) {
+			http.Error(w, "Invalid or revoked API key", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			log.Printf("[ERROR] API Login error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if act, ok := r.Context().Value(actorKey{}).(*actor); ok {
+			act.key = &key
+		}
+
+		token, err := auth.NewSessionToken()
+		if err != nil {
+			log.Printf("[ERROR] API Login error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		expires := a.now().Add(a.cfg.TTL())
+		if err := a.st.InsertSession(r.Context(), auth.Hash(token), key.ID, expires); err != nil {
+			log.Printf("[ERROR] API Login error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookie,
+			Value:    token,
+			Path:     "/",
+			Expires:  expires,
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteStrictMode,
+		})
+		writeJSON(w, http.StatusOK, key)
+	}
+}
+
+// sessionHandler returns the key the request was made with
+func sessionHandler(w http.ResponseWriter, r *http.Request) {
+	key := requestKey(r)
+	if key == nil || key.ID == 0 {
+		http.Error(w, "Not logged in", http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, http.StatusOK, key)
+}
+
+// logoutHandler ends the session of the cookie, if any, and clears it
+func (a *authenticator) logoutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie(sessionCookie); err == nil {
+			if err := a.st.DeleteSession(r.Context(), auth.Hash(cookie.Value)); err != nil {
+				log.Printf("[ERROR] API Logout error: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		}
+		http.SetCookie(w, &http.Cookie{Name: sessionCookie, Path: "/", MaxAge: -1, HttpOnly: true, Secure: r.TLS != nil, SameSite: http.SameSiteStrictMode})
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func listKeysHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keys, err := st.Keys(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] API Keys error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, keys)
+	}
+}
+
+func (a *authenticator) createKeyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req keyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			http.Error(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		role, err := auth.ParseRole(req.Role)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		key, secret, err := createKey(r.Context(), a.st, name, role, a.now())
+		if err != nil {
+			log.Printf("[ERROR] API Keys error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[INFO] API key %d %q (%s) created by %s", key.ID, key.Name, key.Role, requestKey(r).Name)
+		writeJSON(w, http.StatusCreated, newKeyResponse{Key: key, Secret: secret})
+	}
+}
+
+func (a *authenticator) revokeKeyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid key id", http.StatusBadRequest)
+			return
+		}
+		switch err := a.st.RevokeKey(r.Context(), id, a.now()); {
+		case errors.Is(err, store.ErrNotFound):
+			http.Error(w, "Key not found", http.StatusNotFound)
+		case errors.Is(err, store.ErrLastAdmin):
+			http.Error(w, "The last admin key cannot be revoked", http.StatusConflict)
+		case err != nil:
+			log.Printf("[ERROR] API Keys error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		default:
+			log.Printf("[INFO] API key %d revoked by %s", id, requestKey(r).Name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+// auditHandler lists the audit log newest first, entries recorded after since=
+// (RFC 3339) and at most limit= of them
+func auditHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var since time.Time
+		if v := q.Get("since"); v != "" {
+			var err error
+			if since, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, "since must be an RFC 3339 time", http.StatusBadRequest)
+				return
+			}
+		}
+		limit := defaultAuditEntries
+		if v := q.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+				return
+			}
+			limit = min(n, maxAuditEntries)
+		}
+
+		entries, err := st.AuditLog(r.Context(), since, limit)
+		if err != nil {
+			log.Printf("[ERROR] API Audit error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, entries)
+	}
+}
+
+// createKey stores a new key and returns it with its secret
+func createKey(ctx context.Context, st *store.Store, name string, role auth.Role, now time.Time) (auth.Key, string, error) {
+	secret, prefix, err := auth.NewSecret()
+	if err != nil {
+		return auth.Key{}, "", err
+	}
+	key, err := st.InsertKey(ctx, auth.Key{Name: name, Role: role, Prefix: prefix, CreatedAt: now}, auth.Hash(secret))
+	return key, secret, err
+}
+
+// BootstrapAdminKey creates an admin key when there is no active one, so a fresh
+// install can be administered, and returns its secret. It returns "" when an
+// admin key already exists.
+func BootstrapAdminKey(ctx context.Context, st *store.Store) (string, error) {
+	ok, err := st.HasAdmin(ctx)
+	if err != nil || ok {
+		return "", err
+	}
+	_, secret, err := createKey(ctx, st, "bootstrap admin", auth.RoleAdmin, time.Now())
+	return secret, err
+}
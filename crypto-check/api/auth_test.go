@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"crypto-check/auth"
+	"crypto-check/store"
+)
+
+func TestAuthRoles(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	if err := st.InsertPrice(ctx, "BTCUSDT", 100, time.Now()); err != nil {
+		t.Fatalf("InsertPrice() error: %v", err)
+	}
+	secrets := map[auth.Role]string{}
+	for _, role := range []auth.Role{auth.RoleViewer, auth.RoleOperator, auth.RoleAdmin} {
+		_, secret, err := createKey(ctx, st, string(role), role, time.Now())
+		if err != nil {
+			t.Fatalf("createKey() error: %v", err)
+		}
+		secrets[role] = secret
+	}
+	trade := `{"symbol":"BTCUSDT","side":"BUY","quantity":1}`
+
+	tests := []struct {
+		name       string
+		config     auth.Config
+		method     string
+		path       string
+		header     string // Authorization header, "<role>" is replaced by its key
+		body       string
+		wantStatus int
+	}{
+		{"No key", auth.Config{}, "GET", "/api/portfolio", "", "", http.StatusUnauthorized},
+		{"Unknown key", auth.Config{}, "GET", "/api/portfolio", "Bearer cck_unknown", "", http.StatusUnauthorized},
+		{"Other scheme", auth.Config{}, "GET", "/api/portfolio", "Basic <viewer>", "", http.StatusUnauthorized},
+		{"Viewer reads", auth.Config{}, "GET", "/api/portfolio", "Bearer <viewer>", "", http.StatusOK},
+		{"Viewer cannot trade", auth.Config{}, "POST", "/api/portfolio/trades", "Bearer <viewer>", trade, http.StatusForbidden},
+		{"Operator trades", auth.Config{}, "POST", "/api/portfolio/trades", "Bearer <operator>", trade, http.StatusCreated},
+		{"Operator cannot list keys", auth.Config{}, "GET", "/api/keys", "Bearer <operator>", "", http.StatusForbidden},
+		{"Admin lists keys", auth.Config{}, "GET", "/api/keys", "Bearer <admin>", "", http.StatusOK},
+		{"Admin creates a key without role", auth.Config{}, "POST", "/api/keys", "Bearer <admin>", `{"name":"ci"}`, http.StatusBadRequest},
+		{"Dashboard shows the login form", auth.Config{}, "GET", "/", "", "", http.StatusUnauthorized},
+		{"Dashboard with a key", auth.Config{}, "GET", "/", "Bearer <viewer>", "", http.StatusOK},
+		{"Public read without key", auth.Config{PublicRead: true}, "GET", "/api/portfolio", "", "", http.StatusOK},
+		{"Public read with an unknown key", auth.Config{PublicRead: true}, "GET", "/api/portfolio", "Bearer cck_unknown", "", http.StatusUnauthorized},
+		{"Public read cannot trade", auth.Config{PublicRead: true}, "POST", "/api/portfolio/trades", "", trade, http.StatusUnauthorized},
+		{"Public read cannot list keys", auth.Config{PublicRead: true}, "GET", "/api/keys", "", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(st, nil, RouterConfig{Auth: &tt.config})
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.header != "" {
+				header := tt.header
+				for role, secret := range secrets {
+					header = strings.ReplaceAll(header, "<"+string(role)+">", secret)
+				}
+				req.Header.Set("Authorization", header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.wantStatus, rec.Body)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Errorf("401 without WWW-Authenticate")
+			}
+		})
+	}
+
+	// Mutating requests were audited with their key, refused ones included
+	router := NewRouter(st, nil, RouterConfig{Auth: &auth.Config{}})
+	req := httptest.NewRequest("GET", "/api/audit", nil)
+	req.Header.Set("X-API-Key", secrets[auth.RoleAdmin])
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	var entries []auth.AuditEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("GET /api/audit: %v (%s)", err, rec.Body)
+	}
+	statuses := map[int]string{}
+	for _, e := range entries {
+		statuses[e.Status] = e.Actor
+	}
+	if len(entries) != 4 || statuses[http.StatusCreated] != "operator" || statuses[http.StatusForbidden] != "viewer" ||
+		statuses[http.StatusUnauthorized] != "anonymous" || statuses[http.StatusBadRequest] != "admin" {
+		t.Errorf("audit log = %+v", entries)
+	}
+}
+
+func TestAuthSessionsAndKeys(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	admin, err := BootstrapAdminKey(ctx, st)
+	if err != nil || admin == "" {
+		t.Fatalf("BootstrapAdminKey() = %q, %v", admin, err)
+	}
+	if again, err := BootstrapAdminKey(ctx, st); err != nil || again != "" {
+		t.Errorf("BootstrapAdminKey() with an admin = %q, %v, want none", again, err)
+	}
+	router := NewRouter(st, nil, RouterConfig{Auth: &auth.Config{}})
+	do := func(method, path, body string, set func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if set != nil {
+			set(req)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	asAdmin := func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+admin) }
+
+	rec := do("POST", "/api/keys", `{"name":"desk","role":"Operator"}`, asAdmin)
+	var created newKeyResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || rec.Code != http.StatusCreated {
+		t.Fatalf("POST /api/keys = %d: %s", rec.Code, rec.Body)
+	}
+	if created.Role != auth.RoleOperator || !strings.HasPrefix(created.Secret, created.Prefix) {
+		t.Errorf("created key = %+v", created)
+	}
+
+	if rec := do("POST", "/api/session", `{"key":"cck_wrong"}`, nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("login with a wrong key = %d, want 401", rec.Code)
+	}
+	rec = do("POST", "/api/session", `{"key":"`+created.Secret+`"}`, nil)
+	cookies := rec.Result().Cookies()
+	if rec.Code != http.StatusOK || len(cookies) != 1 || !cookies[0].HttpOnly || cookies[0].SameSite != http.SameSiteStrictMode {
+		t.Fatalf("login = %d, cookies %+v: %s", rec.Code, cookies, rec.Body)
+	}
+	withCookie := func(origin string) func(*http.Request) {
+		return func(r *http.Request) {
+			r.AddCookie(cookies[0])
+			if origin != "" {
+				r.Header.Set("Origin", origin)
+			}
+		}
+	}
+	var key auth.Key
+	if rec := do("GET", "/api/session", "", withCookie("")); rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &key) != nil || key.Name != "desk" {
+		t.Errorf("GET /api/session = %d: %s", rec.Code, rec.Body)
+	}
+	if rec := do("DELETE", "/api/portfolio/trades/99", "", withCookie("https://evil.example")); rec.Code != http.StatusForbidden {
+		t.Errorf("cross-origin DELETE with the cookie = %d, want 403", rec.Code)
+	}
+	if rec := do("DELETE", "/api/portfolio/trades/99", "", withCookie("http://example.com")); rec.Code != http.StatusNotFound {
+		t.Errorf("same-origin DELETE with the cookie = %d, want 404: %s", rec.Code, rec.Body)
+	}
+
+	// Revoking the key ends its session, the last admin key cannot be revoked
+	path := "/api/keys/" + strconv.FormatInt(created.ID, 10)
+	if rec := do("DELETE", path, "", asAdmin); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE %s = %d: %s", path, rec.Code, rec.Body)
+	}
+	if rec := do("GET", "/api/session", "", withCookie("")); rec.Code != http.StatusUnauthorized {
+		t.Errorf("session of a revoked key = %d, want 401", rec.Code)
+	}
+	if rec := do("DELETE", path, "", asAdmin); rec.Code != http.StatusNotFound {
+		t.Errorf("revoking twice = %d, want 404", rec.Code)
+	}
+	if rec := do("DELETE", "/api/keys/1", "", asAdmin); rec.Code != http.StatusConflict {
+		t.Errorf("revoking the last admin = %d, want 409", rec.Code)
+	}
+	if rec := do("DELETE", "/api/session", "", withCookie("")); rec.Code != http.StatusNoContent {
+		t.Errorf("logout = %d, want 204", rec.Code)
+	}
+}
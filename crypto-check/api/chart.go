@@ -0,0 +1,371 @@
+package api
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/auth"
+	"crypto-check/currency"
+	"crypto-check/indicators"
+	"crypto-check/store"
+	"crypto-check/web"
+)
+
+// Candle intervals of GET /api/chart
+var chartIntervals = map[string]time.Duration{
+	"1m":  time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"4h":  4 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// Limits of GET /api/chart and GET /api/sparklines
+const (
+	defaultChartCandles = 300
+	maxChartCandles     = 2000
+	maxChartPeriod      = 200 // Of ma= and bb=
+	maxChartMarkers     = 500
+	defaultSparkPoints  = 48
+	maxSparkPoints      = 200
+	maxSparkWindow      = 30 * 24 * time.Hour
+)
+
+// Indicator settings of the chart, the usual ones
+const (
+	defaultMAPeriod = 20
+	bollingerK      = 2
+	chartRSIPeriod  = 14
+	macdFast        = 12
+	macdSlow        = 26
+	macdSignal      = 9
+)
+
+// chartResponse is the body of GET /api/chart. Prices are in Quote, which is the
+// quote asset of the symbol unless another one was requested.
+type chartResponse struct {
+	Symbol          string               `json:"symbol"`
+	Interval        string               `json:"interval"`
+	IntervalSeconds int64                `json:"interval_seconds"`
+	Quote           string               `json:"quote"`
+	Conversion      *currency.Conversion `json:"conversion,omitempty"`
+	Candles         []chartCandle        `json:"candles"`
+	Indicators      chartIndicators      `json:"indicators"`
+	Markers         []chartMarker        `json:"markers"`
+}
+
+type chartCandle struct {
+	Time   time.Time `json:"time"`
+	Open   float64   `json:"open"`
+	High   float64   `json:"high"`
+	Low    float64   `json:"low"`
+	Close  float64   `json:"close"`
+	Volume float64   `json:"volume"` // Zero unless trades are collected
+}
+
+// chartIndicators has one value per candle, null where there is not enough history
+type chartIndicators struct {
+	MAPeriod        int        `json:"ma_period"`
+	MA              []*float64 `json:"ma"`
+	BollingerPeriod int        `json:"bollinger_period"`
+	BollingerUpper  []*float64 `json:"bollinger_upper"`
+	BollingerLower  []*float64 `json:"bollinger_lower"`
+	RSI             []*float64 `json:"rsi"` // Over 14 candles
+	MACD            []*float64 `json:"macd"`
+	MACDSignal      []*float64 `json:"macd_signal"`
+	MACDHistogram   []*float64 `json:"macd_histogram"`
+}
+
+// chartMarker is an event drawn on the chart: an alert, an anomaly or a portfolio trade
+type chartMarker struct {
+	Time  time.Time `json:"time"`
+	Kind  string    `json:"kind"` // alert, anomaly, buy or sell
+	Price float64   `json:"price"`
+	Text  string    `json:"text"`
+}
+
+// symbolPage is the data of the symbol template
+type symbolPage struct {
+	Symbol string
+	Key    *auth.Key
+}
+
+// getSymbolPageHandler renders the chart page of a symbol with stored prices
+func getSymbolPageHandler(st *store.Store, site *web.Site) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		symbol := strings.ToUpper(r.PathValue("symbol"))
+		_, ok, err := st.LastPrice(r.Context(), symbol)
+		if err != nil {
+			log.Printf("[ERROR] Symbol page error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		page := symbolPage{Symbol: symbol}
+		if key := requestKey(r); key != nil && key.ID != 0 {
+			page.Key = key
+		}
+		site.Render(w, http.StatusOK, "symbol.html", page)
+	}
+}
+
+// getChartHandler returns the candles of symbol= at interval= (1m, 5m, 15m, 1h, 4h
+// or 1d) between from= and to= (RFC 3339, the last 300 candles by default), the
+// moving average of ma= candles, Bollinger bands of bb= candles, the RSI and the
+// MACD (12, 26, 9), with the alerts, anomalies and trades of the symbol as
+// markers. Indicators are warmed up on the candles before from. With quote= prices
+// are converted at the latest rate, as GET /api/stats does.
+func getChartHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		symbol := strings.ToUpper(q.Get("symbol"))
+		if symbol == "" {
+			http.Error(w, "symbol is required", http.StatusBadRequest)
+			return
+		}
+		name := q.Get("interval")
+		if name == "" {
+			name = "5m"
+		}
+		interval, ok := chartIntervals[name]
+		if !ok {
+			http.Error(w, "Invalid interval, want 1m, 5m, 15m, 1h, 4h or 1d", http.StatusBadRequest)
+			return
+		}
+		to := time.Now()
+		var err error
+		if v := q.Get("to"); v != "" {
+			if to, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, "Invalid to, want RFC 3339", http.StatusBadRequest)
+				return
+			}
+		}
+		from := to.Add(-defaultChartCandles * interval)
+		if v := q.Get("from"); v != "" {
+			if from, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, "Invalid from, want RFC 3339", http.StatusBadRequest)
+				return
+			}
+		}
+		if !to.After(from) {
+			http.Error(w, "from must be before to", http.StatusBadRequest)
+			return
+		}
+		if to.Sub(from)/interval > maxChartCandles {
+			http.Error(w, "Too many candles, use a longer interval or a shorter range", http.StatusBadRequest)
+			return
+		}
+		maPeriod, ok := chartPeriod(w, q.Get("ma"), "ma")
+		if !ok {
+			return
+		}
+		bbPeriod, ok := chartPeriod(w, q.Get("bb"), "bb")
+		if !ok {
+			return
+		}
+
+		// Enough candles before from for every indicator to have a value at from
+		warmup := max(maPeriod, bbPeriod, chartRSIPeriod+1, macdSlow+macdSignal)
+		candles, err := st.Candles(r.Context(), symbol, from.Add(-time.Duration(warmup)*interval), to, interval)
+		if err != nil {
+			log.Printf("[ERROR] API Chart error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		markers, err := chartMarkers(r, st, symbol, from, to)
+		if err != nil {
+			log.Printf("[ERROR] API Chart error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		quote := symbolQuote(symbol)
+		var conversion *currency.Conversion
+		if requested := requestedQuote(q.Get("quote")); requested != "" {
+			conv, _, err := latestConverter(r.Context(), st)
+			if err != nil {
+				log.Printf("[ERROR] API Chart error: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			c, err := conv.ConvertSymbol(symbol, requested)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			for i := range candles {
+				candles[i].Open, candles[i].High = c.Apply(candles[i].Open), c.Apply(candles[i].High)
+				candles[i].Low, candles[i].Close = c.Apply(candles[i].Low), c.Apply(candles[i].Close)
+			}
+			for i := range markers {
+				markers[i].Price = c.Apply(markers[i].Price)
+			}
+			quote, conversion = requested, &c
+		}
+
+		resp := buildChart(symbol, name, interval, candles, from.Truncate(interval), maPeriod, bbPeriod, markers)
+		resp.Quote, resp.Conversion = quote, conversion
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// chartPeriod parses an indicator period, answering the request when it is invalid
+func chartPeriod(w http.ResponseWriter, v, field string) (int, bool) {
+	if v == "" {
+		return defaultMAPeriod, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 2 || n > maxChartPeriod {
+		http.Error(w, field+" must be a number of candles from 2 to "+strconv.Itoa(maxChartPeriod), http.StatusBadRequest)
+		return 0, false
+	}
+	return n, true
+}
+
+// buildChart computes the indicators over every candle, then keeps those from start
+func buildChart(symbol, name string, interval time.Duration, candles []store.Candle, start time.Time, maPeriod, bbPeriod int, markers []chartMarker) chartResponse {
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+	ma := indicators.SMA(closes, maPeriod)
+	_, upper, lower := indicators.Bollinger(closes, bbPeriod, bollingerK)
+	rsi := indicators.RSISeries(closes, chartRSIPeriod)
+	macd, signal, histogram := indicators.MACD(closes, macdFast, macdSlow, macdSignal)
+
+	first := 0
+	for first < len(candles) && candles[first].Time.Before(start) {
+		first++
+	}
+	resp := chartResponse{
+		Symbol:          symbol,
+		Interval:        name,
+		IntervalSeconds: int64(interval / time.Second),
+		Candles:         make([]chartCandle, 0, len(candles)-first),
+		Indicators: chartIndicators{
+			MAPeriod:        maPeriod,
+			MA:              nullable(ma[first:]),
+			BollingerPeriod: bbPeriod,
+			BollingerUpper:  nullable(upper[first:]),
+			BollingerLower:  nullable(lower[first:]),
+			RSI:             nullable(rsi[first:]),
+			MACD:            nullable(macd[first:]),
+			MACDSignal:      nullable(signal[first:]),
+			MACDHistogram:   nullable(histogram[first:]),
+		},
+		Markers: markers,
+	}
+	for _, c := range candles[first:] {
+		resp.Candles = append(resp.Candles, chartCandle{Time: c.Time, Open: c.Open, High: c.High, Low: c.Low, Close: c.Close, Volume: c.Volume})
+	}
+	return resp
+}
+
+// chartMarkers returns the alerts, anomalies and trades of symbol in [from, to],
+// oldest first. Anomaly alerts are left out, the anomaly itself says more.
+func chartMarkers(r *http.Request, st *store.Store, symbol string, from, to time.Time) ([]chartMarker, error) {
+	markers := []chartMarker{}
+	trades, err := st.TradesBetween(r.Context(), symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range trades {
+		side := strings.ToLower(t.Side)
+		text := side + " " + strconv.FormatFloat(t.Quantity, 'f', -1, 64) + " at " + strconv.FormatFloat(t.Price, 'f', -1, 64)
+		if t.Source != "" {
+			text += " (" + t.Source + ")"
+		}
+		markers = append(markers, chartMarker{Time: t.Time, Kind: side, Price: t.Price, Text: text})
+	}
+
+	events, err := st.AnomaliesBetween(r.Context(), symbol, from, to, maxChartMarkers)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		markers = append(markers, chartMarker{Time: e.Time, Kind: "anomaly", Price: e.Price, Text: e.Explanation})
+	}
+
+	raised, err := st.AlertsBetween(r.Context(), symbol, from, to, maxChartMarkers)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range raised {
+		if a.Kind != alerts.KindAnomaly {
+			markers = append(markers, chartMarker{Time: a.Time, Kind: "alert", Price: a.Price, Text: a.Message})
+		}
+	}
+	slices.SortStableFunc(markers, func(a, b chartMarker) int { return a.Time.Compare(b.Time) })
+	return markers, nil
+}
+
+// getSparklinesHandler returns the closes of every symbol over window= (24h by
+// default), in points= candles (48 by default), for the dashboard cards
+func getSparklinesHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		window := 24 * time.Hour
+		if v := q.Get("window"); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 || d > maxSparkWindow {
+				http.Error(w, "Invalid window, want a duration like 24h, at most 720h", http.StatusBadRequest)
+				return
+			}
+			window = d
+		}
+		points := defaultSparkPoints
+		if v := q.Get("points"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 2 || n > maxSparkPoints {
+				http.Error(w, "points must be a number from 2 to "+strconv.Itoa(maxSparkPoints), http.StatusBadRequest)
+				return
+			}
+			points = n
+		}
+
+		snapshots, err := st.LatestStats(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] API Sparklines error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		now := time.Now()
+		interval := max(window/time.Duration(points), time.Second)
+		sparklines := make(map[string][]float64, len(snapshots))
+		for _, s := range snapshots {
+			history, err := st.History(r.Context(), s.Symbol, now.Add(-window), now)
+			if err != nil {
+				log.Printf("[ERROR] API Sparklines error: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			closes := []float64{}
+			for _, c := range store.BuildCandles(history, interval) {
+				closes = append(closes, c.Close)
+			}
+			sparklines[s.Symbol] = closes
+		}
+		writeJSON(w, http.StatusOK, sparklines)
+	}
+}
+
+// nullable turns the NaN of a series into null
+func nullable(series []float64) []*float64 {
+	out := make([]*float64, len(series))
+	for i := range series {
+		if !math.IsNaN(series[i]) {
+			out[i] = &series[i]
+		}
+	}
+	return out
+}
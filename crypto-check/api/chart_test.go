@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/anomaly"
+	"crypto-check/portfolio"
+	"crypto-check/store"
+)
+
+func TestChartEndpoints(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	// Four hours of BTC prices a minute apart, charted over the last one
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 240 {
+		if err := st.InsertPrice(ctx, "BTCUSDT", 100+float64(i%10), start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("InsertPrice() error: %v", err)
+		}
+	}
+	for _, tr := range []portfolio.Trade{
+		{Symbol: "BTCUSDT", Side: "BUY", Quantity: 1, Price: 104, Time: start.Add(3*time.Hour + 30*time.Minute)},
+		{Symbol: "BTCUSDT", Side: "SELL", Quantity: 1, Price: 105, Time: start.Add(time.Hour)}, // Before the range
+	} {
+		if _, err := st.InsertTrade(ctx, tr); err != nil {
+			t.Fatalf("InsertTrade() error: %v", err)
+		}
+	}
+	for _, at := range []time.Duration{3*time.Hour + 10*time.Minute, 5 * time.Hour} { // The second one after the range
+		e := anomaly.Event{Symbol: "BTCUSDT", Method: anomaly.MethodZScore, Score: 5, Threshold: 4, Price: 109, Time: start.Add(at), Explanation: "spike"}
+		if _, err := st.InsertAnomaly(ctx, e); err != nil {
+			t.Fatalf("InsertAnomaly() error: %v", err)
+		}
+	}
+	for _, a := range []alerts.Alert{
+		{Symbol: "BTCUSDT", Kind: alerts.KindSigma, Price: 108, Time: start.Add(3*time.Hour + 20*time.Minute), Message: "SIGMA ALERT"},
+		{Symbol: "BTCUSDT", Kind: alerts.KindAnomaly, Price: 109, Time: start.Add(3*time.Hour + 10*time.Minute), Message: "ANOMALY"}, // Marked by the anomaly
+		{Symbol: "ETHUSDT", Kind: alerts.KindSigma, Price: 2000, Time: start.Add(3*time.Hour + 20*time.Minute), Message: "SIGMA ALERT"},
+	} {
+		if err := st.InsertAlert(ctx, a); err != nil {
+			t.Fatalf("InsertAlert() error: %v", err)
+		}
+	}
+	router := NewRouter(st, nil, RouterConfig{})
+
+	var chart chartResponse
+	get(t, router, "/api/chart?symbol=btcusdt&interval=5m&from=2024-01-01T15:00:00Z&to=2024-01-01T16:00:00Z", &chart)
+	if chart.Symbol != "BTCUSDT" || chart.IntervalSeconds != 300 {
+		t.Errorf("chart = %s every %ds, want BTCUSDT every 300s", chart.Symbol, chart.IntervalSeconds)
+	}
+	if len(chart.Candles) != 12 {
+		t.Fatalf("got %d candles, want 12", len(chart.Candles))
+	}
+	if first := chart.Candles[0]; !first.Time.Equal(start.Add(3*time.Hour)) || first.Open != 100 || first.High != 104 {
+		t.Errorf("first candle = %+v", first)
+	}
+	// Warmed up on the candles before from, every indicator has a first value
+	ind := chart.Indicators
+	for name, series := range map[string][]*float64{
+		"ma": ind.MA, "bollinger_upper": ind.BollingerUpper, "bollinger_lower": ind.BollingerLower,
+		"rsi": ind.RSI, "macd": ind.MACD, "macd_signal": ind.MACDSignal, "macd_histogram": ind.MACDHistogram,
+	} {
+		if len(series) != len(chart.Candles) {
+			t.Errorf("%s has %d values, want %d", name, len(series), len(chart.Candles))
+		} else if series[0] == nil {
+			t.Errorf("%s[0] is null", name)
+		}
+	}
+	var kinds []string
+	for _, m := range chart.Markers {
+		kinds = append(kinds, m.Kind)
+	}
+	if fmt.Sprint(kinds) != "[anomaly alert buy]" {
+		t.Errorf("markers = %+v, want the anomaly, the sigma alert then the buy", chart.Markers)
+	}
+	if chart.Quote != "USDT" || chart.Conversion != nil {
+		t.Errorf("chart in %s with conversion %v, want USDT unconverted", chart.Quote, chart.Conversion)
+	}
+
+	// Converted at the latest rate, two dollars to the euro
+	if err := st.InsertPrice(ctx, "EURUSDT", 2, start.Add(4*time.Hour)); err != nil {
+		t.Fatalf("InsertPrice() error: %v", err)
+	}
+	var converted chartResponse
+	get(t, router, "/api/chart?symbol=BTCUSDT&interval=5m&from=2024-01-01T15:00:00Z&to=2024-01-01T16:00:00Z&quote=eur", &converted)
+	if converted.Quote != "EUR" || converted.Conversion == nil || converted.Candles[0].Open != 50 || converted.Markers[0].Price != 54.5 {
+		t.Errorf("chart in EUR = %s %+v, first candle %+v, markers %+v", converted.Quote, converted.Conversion, converted.Candles[0], converted.Markers)
+	}
+	if got, want := *converted.Indicators.MA[0], *chart.Indicators.MA[0]/2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("MA in EUR = %v, want %v", got, want)
+	}
+
+	// Without warm-up candles the indicators start null
+	get(t, router, "/api/chart?symbol=BTCUSDT&interval=1m&from=2024-01-01T12:00:00Z&to=2024-01-01T12:30:00Z&ma=5", &chart)
+	if ind := chart.Indicators; ind.MAPeriod != 5 || ind.MA[3] != nil || ind.MA[4] == nil || ind.MACD[24] != nil {
+		t.Errorf("indicators without history = %+v", ind)
+	}
+
+	for _, path := range []string{
+		"/api/chart",
+		"/api/chart?symbol=BTCUSDT&interval=2m",
+		"/api/chart?symbol=BTCUSDT&from=yesterday",
+		"/api/chart?symbol=BTCUSDT&from=2024-01-02T00:00:00Z&to=2024-01-01T00:00:00Z",
+		"/api/chart?symbol=BTCUSDT&interval=1m&from=2024-01-01T00:00:00Z&to=2024-01-03T00:00:00Z",
+		"/api/chart?symbol=BTCUSDT&ma=1",
+		"/api/chart?symbol=BTCUSDT&bb=500",
+		"/api/chart?symbol=BTCUSDT&quote=JPY",
+		"/api/sparklines?points=1",
+		"/api/sparklines?window=-1h",
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want %d", path, rec.Code, http.StatusBadRequest)
+		}
+	}
+
+	now := time.Now()
+	for _, at := range []time.Time{now.Add(-90 * time.Minute), now.Add(-10 * time.Minute)} {
+		if err := st.InsertPrice(ctx, "ETHUSDT", 2000, at); err != nil {
+			t.Fatalf("InsertPrice() error: %v", err)
+		}
+	}
+	var sparklines map[string][]float64
+	get(t, router, "/api/sparklines?window=2h&points=4", &sparklines)
+	if len(sparklines["ETHUSDT"]) != 2 || len(sparklines["BTCUSDT"]) != 0 {
+		t.Errorf("sparklines = %v, want 2 ETHUSDT closes and none for BTCUSDT", sparklines)
+	}
+
+	tests := []struct {
+		path       string
+		wantStatus int
+	}{
+		{"/symbol/btcusdt", http.StatusOK},
+		{"/symbol/SOLUSDT", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+		if rec.Code != tt.wantStatus {
+			t.Errorf("GET %s = %d, want %d", tt.path, rec.Code, tt.wantStatus)
+		}
+	}
+}
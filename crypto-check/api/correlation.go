@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Correlation is the body of GET /api/correlation, Matrix follows the order of Symbols
+type Correlation struct {
+	Symbols   []string     `json:"symbols"`
+	Method    string       `json:"method"`
+	Benchmark string       `json:"benchmark"`
+	From      time.Time    `json:"from"`
+	To        time.Time    `json:"to"`
+	Samples   int          `json:"samples"`
+	Matrix    [][]float64  `json:"matrix"`
+	Betas     []SymbolBeta `json:"betas"`
+}
+
+// SymbolBeta is the beta of one symbol against the benchmark
+type SymbolBeta struct {
+	Symbol  string      `json:"symbol"`
+	Beta    float64     `json:"beta"`
+	Rolling []BetaPoint `json:"rolling"`
+}
+
+// BetaPoint is one rolling beta value, at the end of the returns it covers
+type BetaPoint struct {
+	Time time.Time `json:"time"`
+	Beta float64   `json:"beta"`
+}
+
+// getCorrelationHandler asks the analytics service for the correlation of the
+// symbols= list, every tracked symbol by default. window and resolution are
+// durations like 24h and 5m, the window ends at to= (RFC 3339) or now.
+func getCorrelationHandler(st *store.Store, client analyticsv1.AnalyticsServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		req := &analyticsv1.GetCorrelationRequest{Benchmark: strings.ToUpper(q.Get("benchmark"))}
+		if v := q.Get("method"); v != "" {
+			method, ok := analyticsv1.ParseEnum[analyticsv1.CorrelationMethod](v)
+			if !ok {
+				http.Error(w, "Invalid method, want pearson or spearman", http.StatusBadRequest)
+				return
+			}
+			req.Method = method
+		}
+
+		if v := q.Get("symbols"); v != "" {
+			for _, symbol := range strings.Split(v, ",") {
+				req.Symbols = append(req.Symbols, strings.ToUpper(strings.TrimSpace(symbol)))
+			}
+		} else {
+			snapshots, err := st.LatestStats(r.Context())
+			if err != nil {
+				log.Printf("[ERROR] API Correlation error: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			for _, s := range snapshots {
+				req.Symbols = append(req.Symbols, s.Symbol)
+			}
+		}
+
+		for name, field := range map[string]*int64{"window": &req.WindowSeconds, "resolution": &req.ResolutionSeconds} {
+			if v := q.Get(name); v != "" {
+				d, err := time.ParseDuration(v)
+				if err != nil || d < time.Second {
+					http.Error(w, "Invalid "+name+", want a duration like 5m", http.StatusBadRequest)
+					return
+				}
+				*field = int64(d / time.Second)
+			}
+		}
+		if v := q.Get("to"); v != "" {
+			to, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				http.Error(w, "Invalid to, want RFC 3339", http.StatusBadRequest)
+				return
+			}
+			req.EndTime = timestamppb.New(to)
+		}
+		if v := q.Get("beta_window"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 2 {
+				http.Error(w, "Invalid beta_window, want a number of returns of at least 2", http.StatusBadRequest)
+				return
+			}
+			req.BetaWindow = int32(n)
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+		res, err := client.GetCorrelation(ctx, req)
+		if err != nil {
+			switch status.Code(err) {
+			case codes.InvalidArgument, codes.FailedPrecondition:
+				http.Error(w, status.Convert(err).Message(), http.StatusBadRequest)
+			case codes.NotFound:
+				http.Error(w, status.Convert(err).Message(), http.StatusNotFound)
+			default:
+				log.Printf("[ERROR] API Correlation error: %v", err)
+				http.Error(w, "Analytics service unavailable", http.StatusBadGateway)
+			}
+			return
+		}
+
+		corr := Correlation{
+			Symbols:   res.Symbols,
+			Method:    strings.ToLower(analyticsv1.EnumName(res.Method)),
+			Benchmark: res.Benchmark,
+			From:      res.StartTime.AsTime(),
+			To:        res.EndTime.AsTime(),
+			Samples:   int(res.Samples),
+			Matrix:    make([][]float64, len(res.Matrix)),
+			Betas:     make([]SymbolBeta, len(res.Betas)),
+		}
+		for i, row := range res.Matrix {
+			corr.Matrix[i] = row.Values
+		}
+		for i, b := range res.Betas {
+			corr.Betas[i] = SymbolBeta{Symbol: b.Symbol, Beta: b.Beta, Rolling: make([]BetaPoint, len(b.Rolling))}
+			for j, p := range b.Rolling {
+				corr.Betas[i].Rolling[j] = BetaPoint{Time: p.Time.AsTime(), Beta: p.Beta}
+			}
+		}
+		writeJSON(w, http.StatusOK, corr)
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"crypto-check/currency"
+	"crypto-check/portfolio"
+	"crypto-check/store"
+)
+
+// requestedQuote returns the upper-cased quote= parameter, empty when values stay
+// in the quote asset of each symbol
+func requestedQuote(q string) string {
+	return strings.ToUpper(strings.TrimSpace(q))
+}
+
+// latestConverter derives rates from the last stored price of every symbol
+func latestConverter(ctx context.Context, st *store.Store) (*currency.Converter, map[string]float64, error) {
+	prices, err := st.LatestPrices(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return currency.NewConverter(prices), prices, nil
+}
+
+// symbolQuote is the quote asset of symbol, empty if it cannot be told
+func symbolQuote(symbol string) string {
+	_, quote, err := currency.Split(symbol)
+	if err != nil {
+		return ""
+	}
+	return quote
+}
+
+// convertSummary restates every position in quote and adds the totals up again
+func convertSummary(s portfolio.Summary, conv *currency.Converter, quote string) (portfolio.Summary, map[string]currency.Conversion, error) {
+	conversions := make(map[string]currency.Conversion, len(s.Positions))
+	converted := portfolio.Summary{Method: s.Method, Positions: make([]portfolio.Position, len(s.Positions))}
+	for i, p := range s.Positions {
+		c, err := conv.ConvertSymbol(p.Symbol, quote)
+		if err != nil {
+			return portfolio.Summary{}, nil, err
+		}
+		conversions[p.Symbol] = c
+		p.CostBasis = c.Apply(p.CostBasis)
+		p.AvgCost = c.Apply(p.AvgCost)
+		p.MarketPrice = c.Apply(p.MarketPrice)
+		p.MarketValue = c.Apply(p.MarketValue)
+		p.RealizedPnL = c.Apply(p.RealizedPnL)
+		p.UnrealizedPnL = c.Apply(p.UnrealizedPnL)
+		converted.Positions[i] = p
+
+		converted.CostBasis += p.CostBasis
+		converted.MarketValue += p.MarketValue
+		converted.RealizedPnL += p.RealizedPnL
+		converted.UnrealizedPnL += p.UnrealizedPnL
+	}
+	return converted, conversions, nil
+}
+
+// historyRates converts the amounts of each symbol at the rate of the time being
+// valued. series holds the stored prices of every tracked symbol over the range;
+// a symbol without a price by then uses its first price in the range, or the
+// latest one. The conversion of each traded symbol is checked against latest
+// first so that a missing path is reported before any work is done.
+func historyRates(series map[string][]portfolio.PricePoint, latest map[string]float64, symbols []string, quote string) (func(string, time.Time) float64, map[string]currency.Conversion, error) {
+	current := currency.NewConverter(latest)
+	conversions := make(map[string]currency.Conversion, len(symbols))
+	for _, symbol := range symbols {
+		c, err := current.ConvertSymbol(symbol, quote)
+		if err != nil {
+			return nil, nil, err
+		}
+		conversions[symbol] = c
+	}
+
+	var cachedAt time.Time
+	var cached *currency.Converter
+	rate := func(symbol string, at time.Time) float64 {
+		if cached == nil || !at.Equal(cachedAt) {
+			cached, cachedAt = currency.NewConverter(pricesAt(series, latest, at)), at
+		}
+		c, err := cached.ConvertSymbol(symbol, quote)
+		if err != nil {
+			return conversions[symbol].Rate
+		}
+		return c.Rate
+	}
+	return rate, conversions, nil
+}
+
+func pricesAt(series map[string][]portfolio.PricePoint, latest map[string]float64, at time.Time) map[string]float64 {
+	prices := make(map[string]float64, len(latest))
+	for symbol, price := range latest {
+		points := series[symbol]
+		if len(points) == 0 {
+			prices[symbol] = price
+			continue
+		}
+		i := sort.Search(len(points), func(i int) bool { return points[i].Time.After(at) })
+		if i == 0 {
+			i = 1
+		}
+		prices[symbol] = points[i-1].Price
+	}
+	return prices
+}
+
+// conversionHeader describes a conversion as BTCUSDT=USDT>EUR (EURUSDT)
+func conversionHeader(symbol string, c currency.Conversion) string {
+	return fmt.Sprintf("%s=%s (%s)", symbol, strings.Join(c.Path, ">"), strings.Join(c.Pairs, ","))
+}
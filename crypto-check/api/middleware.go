@@ -0,0 +1,186 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets of clients gone quiet are dropped
+const sweepInterval = time.Minute
+
+// statusRecorder remembers the status and the size of the response written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	n, err := s.ResponseWriter.Write(b)
+	s.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the connection, e.g. to flush
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// Status returns the status written, 200 if the handler wrote nothing
+func (s *statusRecorder) Status() int {
+	if s.status == 0 {
+		return http.StatusOK
+	}
+	return s.status
+}
+
+// protect wraps the router in the middleware of the server, outermost first:
+// access log, panic recovery, rate limiting and the request size limit. Rate
+// limiting comes before the audit log of the router, so a flood of refused
+// requests does not fill the database.
+func protect(h http.Handler, cfg ServerConfig) http.Handler {
+	h = limitBody(h, cfg.maxBodyBytes())
+	if rate := cfg.rate(); rate > 0 {
+		h = rateLimit(h, newClientLimiter(rate, cfg.burst(), time.Now))
+	}
+	return accessLog(recoverPanics(h))
+}
+
+// accessLog logs every request with its status, response size and duration
+func accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+		log.Printf("[HTTP] %s %s %s %d %dB %v", clientIP(r), r.Method, r.URL.RequestURI(), rec.Status(), rec.bytes, time.Since(start).Round(time.Microsecond))
+	})
+}
+
+// recoverPanics answers a request whose handler panicked with a 500 and logs the
+// stack, instead of dropping the connection without a word
+func recoverPanics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w}
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			if e, ok := err.(error); ok && errors.Is(e, http.ErrAbortHandler) {
+				panic(err) // Deliberate abort, the server handles it silently
+			}
+			log.Printf("[ERROR] Panic serving %s %s: %v\n%s", r.Method, r.URL.Path, err, debug.Stack())
+			if rec.status == 0 {
+				http.Error(rec, "Internal Server Error", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// limitBody fails reading request bodies larger than maxBytes, so a client cannot
+// make a handler buffer an unbounded body
+func limitBody(next http.Handler, maxBytes int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBytes {
+			http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimit answers 429 with Retry-After to clients that used up their tokens
+func rateLimit(next http.Handler, l *clientLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, wait := l.allow(clientIP(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the IP address a request came from. Proxy headers are not
+// trusted, behind a proxy every client shares its address.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// bucket holds the tokens of one client
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// clientLimiter is a token bucket per client: each holds up to burst tokens,
+// refilled at rate per second, and every request takes one
+type clientLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	clients   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newClientLimiter(rate float64, burst int, now func() time.Time) *clientLimiter {
+	return &clientLimiter{rate: rate, burst: float64(max(burst, 1)), clients: make(map[string]*bucket), lastSweep: now(), now: now}
+}
+
+// allow takes a token of client, or returns how long until it has one
+func (l *clientLimiter) allow(client string) (bool, time.Duration) {
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.clients[client]
+	if !ok {
+		b = &bucket{tokens: l.burst, updated: now}
+		l.clients[client] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops the buckets that are full again, a new bucket starts full anyway
+func (l *clientLimiter) sweep(now time.Time) {
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for client, b := range l.clients {
+		if now.Sub(b.updated) >= full {
+			delete(l.clients, client)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := newClientLimiter(2, 3, func() time.Time { return now })
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		client   string
+		want     bool
+		wantWait time.Duration
+	}{
+		{"Burst 1", 0, "a", true, 0},
+		{"Burst 2", 0, "a", true, 0},
+		{"Burst 3", 0, "a", true, 0},
+		{"Burst used up", 0, "a", false, 500 * time.Millisecond},
+		{"Other client", 0, "b", true, 0},
+		{"Half a token later", 250 * time.Millisecond, "a", false, 250 * time.Millisecond},
+		{"Refilled one token", 250 * time.Millisecond, "a", true, 0},
+		{"Never more than burst", time.Hour, "a", true, 0},
+		{"Still two left", 0, "a", true, 0},
+		{"Last one", 0, "a", true, 0},
+		{"Empty again", 0, "a", false, 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			got, wait := l.allow(tt.client)
+			if got != tt.want || wait != tt.wantWait {
+				t.Errorf("allow(%q) = %v, %v, want %v, %v", tt.client, got, wait, tt.want, tt.wantWait)
+			}
+		})
+	}
+	if _, ok := l.clients["b"]; ok {
+		t.Errorf("the bucket of a client quiet for an hour was not swept")
+	}
+}
+
+func TestProtect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	h := protect(mux, ServerConfig{RateLimit: 1, RateBurst: 3, MaxBodyBytes: 16})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		remote     string
+		wantStatus int
+	}{
+		{"Allowed", "GET", "/ok", "", "10.0.0.1:1000", http.StatusOK},
+		{"Panic recovered", "GET", "/panic", "", "10.0.0.1:1001", http.StatusInternalServerError},
+		{"Body too large", "POST", "/ok", strings.Repeat("x", 17), "10.0.0.1:1002", http.StatusRequestEntityTooLarge},
+		{"Rate limited, whatever the port", "GET", "/ok", "", "10.0.0.1:1003", http.StatusTooManyRequests},
+		{"Other client", "GET", "/ok", "", "10.0.0.2:1000", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.RemoteAddr = tt.remote
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.wantStatus, rec.Body)
+			}
+			if rec.Code == http.StatusTooManyRequests && rec.Header().Get("Retry-After") != "1" {
+				t.Errorf("Retry-After = %q, want 1", rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestStartServer(t *testing.T) {
+	// A busy address fails right away instead of killing the process
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error: %v", err)
+	}
+	defer busy.Close()
+	if err := StartServer(context.Background(), busy.Addr().String(), http.NotFoundHandler(), ServerConfig{}); err == nil {
+		t.Errorf("StartServer() on a busy address returned no error")
+	}
+
+	// A cancelled context waits for the request in flight, then returns nil
+	addr := busy.Addr().String()
+	busy.Close()
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- StartServer(ctx, addr, handler, ServerConfig{}) }()
+
+	resp := make(chan *http.Response, 1)
+	go func() {
+		for range 50 {
+			r, err := http.Get("http://" + addr + "/")
+			if err == nil {
+				resp <- r
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		close(resp)
+	}()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the server never answered")
+	}
+	cancel()
+	r, ok := <-resp
+	if !ok {
+		t.Fatal("the request in flight failed during shutdown")
+	}
+	r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		t.Errorf("request in flight = %d, want 200", r.StatusCode)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("StartServer() after shutdown = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("StartServer() did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/auth"
+	"crypto-check/currency"
+	"crypto-check/portfolio"
+	"crypto-check/store"
+)
+
+// maxHistoryPoints bounds /api/portfolio/history, the step grows to stay under it
+const maxHistoryPoints = 1000
+
+// tradeRequest is the body of POST /api/portfolio/trades. Price and time default
+// to the latest stored price and now.
+type tradeRequest struct {
+	Symbol   string    `json:"symbol"`
+	Side     string    `json:"side"`
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price"`
+	Fee      float64   `json:"fee"`
+	Time     time.Time `json:"time"`
+}
+
+// portfolioResponse is the body of GET /api/portfolio. With quote= every amount is
+// converted and Conversions holds the path used for each symbol.
+type portfolioResponse struct {
+	portfolio.Summary
+	Quote       string                         `json:"quote,omitempty"`
+	Conversions map[string]currency.Conversion `json:"conversions,omitempty"`
+}
+
+func registerPortfolio(mux *http.ServeMux, st *store.Store, a *authenticator) {
+	mux.HandleFunc("GET /api/portfolio", a.require(auth.RoleViewer, getPortfolioHandler(st)))
+	mux.HandleFunc("GET /api/portfolio/trades", a.require(auth.RoleViewer, listTradesHandler(st)))
+	mux.HandleFunc("POST /api/portfolio/trades", a.require(auth.RoleOperator, addTradeHandler(st)))
+	mux.HandleFunc("DELETE /api/portfolio/trades/{id}", a.require(auth.RoleOperator, deleteTradeHandler(st)))
+	mux.HandleFunc("GET /api/portfolio/history", a.require(auth.RoleViewer, portfolioHistoryHandler(st)))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		log.Printf("[ERROR] JSON encoding error: %v", err)
+	}
+}
+
+func getPortfolioHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		trades, err := st.Trades(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] Portfolio trades error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		prices, err := st.LatestPrices(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] Portfolio prices error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		summary, err := portfolio.Compute(trades, prices, r.URL.Query().Get("method"))
+		if err != nil {
+			portfolioError(w, err)
+			return
+		}
+
+		resp := portfolioResponse{Summary: summary}
+		if quote := requestedQuote(r.URL.Query().Get("quote")); quote != "" {
+			resp.Summary, resp.Conversions, err = convertSummary(summary, currency.NewConverter(prices), quote)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			resp.Quote = quote
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func listTradesHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		trades, err := st.Trades(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] Portfolio trades error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, trades)
+	}
+}
+
+func addTradeHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req tradeRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		trade := portfolio.Trade{
+			Symbol:   strings.ToUpper(strings.TrimSpace(req.Symbol)),
+			Side:     strings.ToUpper(req.Side),
+			Quantity: req.Quantity,
+			Price:    req.Price,
+			Fee:      req.Fee,
+			Time:     req.Time,
+			Source:   portfolio.SourceManual,
+		}
+		if !currency.ValidSymbol(trade.Symbol) {
+			http.Error(w, "Invalid symbol, want letters and digits like BTCUSDT", http.StatusBadRequest)
+			return
+		}
+		if trade.Time.IsZero() {
+			trade.Time = time.Now()
+		}
+		if trade.Price == 0 {
+			prices, err := st.LatestPrices(r.Context())
+			if err != nil {
+				log.Printf("[ERROR] Portfolio prices error: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			trade.Price = prices[trade.Symbol]
+		}
+		if err := trade.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Reject trades that would sell more than is held at that time
+		trades, err := st.Trades(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] Portfolio trades error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if _, err := portfolio.Compute(append(trades, trade), nil, portfolio.MethodFIFO); err != nil {
+			portfolioError(w, err)
+			return
+		}
+
+		trade, err = st.InsertTrade(r.Context(), trade)
+		if err != nil {
+			log.Printf("[ERROR] Portfolio insert error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Printf("[INFO] [%s] Manual %s %g at $%.2f recorded", trade.Symbol, trade.Side, trade.Quantity, trade.Price)
+		writeJSON(w, http.StatusCreated, trade)
+	}
+}
+
+func deleteTradeHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid trade id", http.StatusBadRequest)
+			return
+		}
+
+		trades, err := st.Trades(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] Portfolio trades error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		remaining := make([]portfolio.Trade, 0, len(trades))
+		for _, t := range trades {
+			if t.ID != id {
+				remaining = append(remaining, t)
+			}
+		}
+		if _, err := portfolio.Compute(remaining, nil, portfolio.MethodFIFO); err != nil {
+			http.Error(w, "Deleting this trade would leave later sells uncovered: "+err.Error(), http.StatusConflict)
+			return
+		}
+
+		if err := st.DeleteTrade(r.Context(), id); errors.Is(err, store.ErrNotFound) {
+			http.Error(w, "Trade not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			log.Printf("[ERROR] Portfolio delete error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// portfolioHistoryHandler values the portfolio every step between from and to.
+// From defaults to the first trade, to to now and step to one hour. With quote=
+// each point is converted at the rates of its time and the paths are reported
+// in X-Conversion-Path headers.
+func portfolioHistoryHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		trades, err := st.Trades(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] Portfolio trades error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if len(trades) == 0 {
+			writeJSON(w, http.StatusOK, []portfolio.ValuePoint{})
+			return
+		}
+
+		from, to := trades[0].Time, time.Now()
+		step := time.Hour
+		if v := q.Get("from"); v != "" {
+			if from, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, "Invalid from, want RFC 3339", http.StatusBadRequest)
+				return
+			}
+		}
+		if v := q.Get("to"); v != "" {
+			if to, err = time.Parse(time.RFC3339, v); err != nil {
+				http.Error(w, "Invalid to, want RFC 3339", http.StatusBadRequest)
+				return
+			}
+		}
+		if v := q.Get("step"); v != "" {
+			if step, err = time.ParseDuration(v); err != nil || step <= 0 {
+				http.Error(w, "Invalid step, want a duration like 15m", http.StatusBadRequest)
+				return
+			}
+		}
+		if !to.After(from) {
+			http.Error(w, "from must be before to", http.StatusBadRequest)
+			return
+		}
+		for to.Sub(from)/step >= maxHistoryPoints {
+			step *= 2
+		}
+
+		var times []time.Time
+		for t := from; !t.After(to); t = t.Add(step) {
+			times = append(times, t)
+		}
+
+		// One step of lookback so the first point has a price to use
+		prices := make(map[string][]portfolio.PricePoint)
+		for _, t := range trades {
+			if _, ok := prices[t.Symbol]; ok {
+				continue
+			}
+			if prices[t.Symbol], err = pricePoints(r.Context(), st, t.Symbol, from.Add(-step), to); err != nil {
+				log.Printf("[ERROR] [%s] Portfolio history error: %v", t.Symbol, err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		var rate func(string, time.Time) float64
+		if quote := requestedQuote(q.Get("quote")); quote != "" {
+			latest, err := st.LatestPrices(r.Context())
+			if err != nil {
+				log.Printf("[ERROR] Portfolio prices error: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			// Rates can go through symbols that were never traded
+			series := make(map[string][]portfolio.PricePoint, len(latest))
+			for symbol := range latest {
+				if s, ok := prices[symbol]; ok {
+					series[symbol] = s
+					continue
+				}
+				if series[symbol], err = pricePoints(r.Context(), st, symbol, from.Add(-step), to); err != nil {
+					log.Printf("[ERROR] [%s] Portfolio history error: %v", symbol, err)
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					return
+				}
+			}
+
+			symbols := make([]string, 0, len(prices))
+			for symbol := range prices {
+				symbols = append(symbols, symbol)
+			}
+			sort.Strings(symbols)
+			var conversions map[string]currency.Conversion
+			if rate, conversions, err = historyRates(series, latest, symbols, quote); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			for _, symbol := range symbols {
+				w.Header().Add("X-Conversion-Path", conversionHeader(symbol, conversions[symbol]))
+			}
+		}
+
+		history, err := portfolio.ValueHistory(trades, prices, times, q.Get("method"), rate)
+		if err != nil {
+			portfolioError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, history)
+	}
+}
+
+func pricePoints(ctx context.Context, st *store.Store, symbol string, from, to time.Time) ([]portfolio.PricePoint, error) {
+	points, err := st.History(ctx, symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	series := make([]portfolio.PricePoint, len(points))
+	for i, p := range points {
+		series[i] = portfolio.PricePoint{Time: p.Time, Price: p.Price}
+	}
+	return series, nil
+}
+
+func portfolioError(w http.ResponseWriter, err error) {
+	if errors.Is(err, portfolio.ErrInvalidTrade) || errors.Is(err, portfolio.ErrUnknownMethod) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Printf("[ERROR] Portfolio error: %v", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"crypto-check/currency"
+	"crypto-check/portfolio"
+	"crypto-check/store"
+)
+
+func TestPortfolioEndpoints(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, price := range []float64{100, 200, 300} {
+		if err := st.InsertPrice(ctx, "BTCUSDT", price, start.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatalf("InsertPrice() error: %v", err)
+		}
+	}
+	router := NewRouter(st, nil, RouterConfig{})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"Buy at the given price", "POST", "/api/portfolio/trades", `{"symbol":"btcusdt","side":"buy","quantity":2,"price":100,"time":"2024-01-01T12:00:00Z"}`, http.StatusCreated},
+		{"Sell at the latest price", "POST", "/api/portfolio/trades", `{"symbol":"BTCUSDT","side":"SELL","quantity":1,"time":"2024-01-01T13:00:00Z"}`, http.StatusCreated},
+		{"Selling more than held", "POST", "/api/portfolio/trades", `{"symbol":"BTCUSDT","side":"SELL","quantity":5,"price":300}`, http.StatusBadRequest},
+		{"Markup as symbol", "POST", "/api/portfolio/trades", `{"symbol":"<img src=x>","side":"BUY","quantity":1,"price":100}`, http.StatusBadRequest},
+		{"Missing symbol", "POST", "/api/portfolio/trades", `{"side":"BUY","quantity":1,"price":100}`, http.StatusBadRequest},
+		{"No price known", "POST", "/api/portfolio/trades", `{"symbol":"ETHUSDT","side":"BUY","quantity":1}`, http.StatusBadRequest},
+		{"Malformed body", "POST", "/api/portfolio/trades", `{"symbol":`, http.StatusBadRequest},
+		{"Unknown method", "GET", "/api/portfolio?method=lifo", "", http.StatusBadRequest},
+		{"Deleting the buy would uncover the sell", "DELETE", "/api/portfolio/trades/1", "", http.StatusConflict},
+		{"Deleting a missing trade", "DELETE", "/api/portfolio/trades/99", "", http.StatusNotFound},
+		{"Invalid trade id", "DELETE", "/api/portfolio/trades/abc", "", http.StatusBadRequest},
+		{"Invalid history step", "GET", "/api/portfolio/history?step=soon", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.wantStatus, rec.Body)
+			}
+		})
+	}
+
+	// One unit left at a cost of 100, sold the other at the latest price of 300
+	var summary portfolio.Summary
+	get(t, router, "/api/portfolio", &summary)
+	if len(summary.Positions) != 1 || summary.Positions[0].Quantity != 1 {
+		t.Fatalf("positions = %+v", summary.Positions)
+	}
+	if summary.CostBasis != 100 || summary.MarketValue != 300 || summary.RealizedPnL != 200 || summary.UnrealizedPnL != 200 {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	var history []portfolio.ValuePoint
+	get(t, router, "/api/portfolio/history?to=2024-01-01T14:00:00Z", &history)
+	wantValues := []float64{200, 200, 300} // Two units at 100, one at 200, one at 300
+	if len(history) != len(wantValues) {
+		t.Fatalf("got %d history points, want %d", len(history), len(wantValues))
+	}
+	for i, want := range wantValues {
+		if history[i].MarketValue != want {
+			t.Errorf("point %d = %+v, want market value %g", i, history[i], want)
+		}
+	}
+
+	// In BTC the one unit held is worth exactly one BTC at every point
+	var converted struct {
+		portfolio.Summary
+		Quote       string
+		Conversions map[string]currency.Conversion
+	}
+	get(t, router, "/api/portfolio?quote=btc", &converted)
+	if converted.Quote != "BTC" || converted.MarketValue != 1 || converted.Conversions["BTCUSDT"].Pairs[0] != "BTCUSDT" {
+		t.Errorf("portfolio in BTC = %+v", converted)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/api/portfolio/history?to=2024-01-01T14:00:00Z&quote=BTC", nil))
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatalf("history in BTC: %v (%s)", err, rec.Body)
+	}
+	for i, want := range []float64{2, 1, 1} {
+		if history[i].MarketValue != want {
+			t.Errorf("point %d in BTC = %+v, want market value %g", i, history[i], want)
+		}
+	}
+	if got := rec.Header().Get("X-Conversion-Path"); got != "BTCUSDT=USDT>BTC (BTCUSDT)" {
+		t.Errorf("X-Conversion-Path = %q", got)
+	}
+}
+
+func get(t *testing.T, h http.Handler, path string, v any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d: %s", path, rec.Code, rec.Body)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+}
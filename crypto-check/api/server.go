@@ -0,0 +1,315 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"crypto-check/auth"
+	"crypto-check/currency"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+	"crypto-check/store"
+	"crypto-check/web"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SymbolStats is one entry of the /api/stats response. Prices are in Quote, which
+// is the quote asset of the symbol unless another one was requested.
+type SymbolStats struct {
+	Symbol     string               `json:"symbol"`
+	Price      float64              `json:"current_price"`
+	AvgPrice   float64              `json:"avg_price_1h"`
+	RSI        float64              `json:"rsi"`
+	Signal     signals.RSI          `json:"signal,omitempty"`     // Absent when the analytics service could not be reached
+	Change     float64              `json:"change_percent"`       // From the average of the hour before the latest price
+	Trend      signals.Trend        `json:"trend,omitempty"`      // Absent when the analytics service could not be reached
+	Thresholds *signals.Config      `json:"thresholds,omitempty"` // The signal and the trend were classified with
+	Forecast   *NextCandle          `json:"forecast,omitempty"`   // Absent until enough candles are stored
+	Liquidity  *Liquidity           `json:"liquidity,omitempty"`  // Absent unless order books are collected
+	Quote      string               `json:"quote"`
+	Conversion *currency.Conversion `json:"conversion,omitempty"`
+}
+
+// NextCandle is the forecast close of the next candle with its prediction interval
+type NextCandle struct {
+	Model           string    `json:"model"`
+	IntervalSeconds int64     `json:"interval_seconds"`
+	Time            time.Time `json:"time"` // Start of the candle
+	Value           float64   `json:"value"`
+	Lower           float64   `json:"lower"`
+	Upper           float64   `json:"upper"`
+	Level           float64   `json:"level"`
+}
+
+// Liquidity describes the latest order book snapshot of a symbol
+type Liquidity struct {
+	Time      time.Time `json:"time"`
+	SpreadBPS float64   `json:"spread_bps"`
+	Imbalance float64   `json:"imbalance"` // Of the best bid and ask quantities, from -1 to 1
+	Bands     []Depth   `json:"bands"`
+}
+
+// Depth is the notional resting within BPS of the mid price, in the quote of the stats
+type Depth struct {
+	BPS       float64 `json:"bps"`
+	Bid       float64 `json:"bid"`
+	Ask       float64 `json:"ask"`
+	Imbalance float64 `json:"imbalance"`
+}
+
+// ServerConfig is the "http" block of config.json, zero fields take the defaults
+type ServerConfig struct {
+	ReadTimeout     int     `json:"read_timeout"`     // Seconds to read a whole request, 0 for 10
+	WriteTimeout    int     `json:"write_timeout"`    // Seconds to answer a request once read, 0 for 30
+	IdleTimeout     int     `json:"idle_timeout"`     // Seconds a keep-alive connection waits for its next request, 0 for 120
+	ShutdownTimeout int     `json:"shutdown_timeout"` // Seconds requests in flight get to finish on shutdown, 0 for 10
+	RateLimit       float64 `json:"rate_limit"`       // Requests per second per client IP, 0 for 10, negative disables the limit
+	RateBurst       int     `json:"rate_burst"`       // Requests a client may make at once, 0 for 40
+	MaxBodyBytes    int64   `json:"max_body_bytes"`   // Largest request body, 0 for 1 MiB
+}
+
+// readHeaderTimeout bounds slow clients trickling in headers, whatever the read timeout
+const readHeaderTimeout = 5 * time.Second
+
+func (c ServerConfig) rate() float64 {
+	if c.RateLimit == 0 {
+		return 10
+	}
+	return c.RateLimit
+}
+
+func (c ServerConfig) burst() int {
+	if c.RateBurst > 0 {
+		return c.RateBurst
+	}
+	return 40
+}
+
+func (c ServerConfig) maxBodyBytes() int64 {
+	if c.MaxBodyBytes > 0 {
+		return c.MaxBodyBytes
+	}
+	return 1 << 20
+}
+
+// seconds returns v seconds, or def when v is not positive
+func seconds(v int, def time.Duration) time.Duration {
+	if v > 0 {
+		return time.Duration(v) * time.Second
+	}
+	return def
+}
+
+// StartServer serves handler on addr until ctx is cancelled, then shuts down
+// gracefully: it stops accepting connections and waits for the requests in
+// flight. It returns when the server has stopped, with the error that stopped
+// it, e.g. the address being in use, or nil after a shutdown.
+func StartServer(ctx context.Context, addr string, handler http.Handler, cfg ServerConfig) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           protect(handler, cfg),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       seconds(cfg.ReadTimeout, 10*time.Second),
+		WriteTimeout:      seconds(cfg.WriteTimeout, 30*time.Second),
+		IdleTimeout:       seconds(cfg.IdleTimeout, 120*time.Second),
+		MaxHeaderBytes:    1 << 16,
+	}
+	log.Printf("[INFO] Web server starting on http://localhost%s/stats", addr)
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.ListenAndServe()
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("[INFO] Web server shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), seconds(cfg.ShutdownTimeout, 10*time.Second))
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close() // Requests still running are cut off
+		return fmt.Errorf("web server shutdown: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// RouterConfig holds the optional parts of the router
+type RouterConfig struct {
+	Auth *auth.Config // API keys, nil leaves the dashboard and the API open
+	Site *web.Site    // Dashboard pages and static files, nil for the ones built in
+}
+
+// NewRouter registers the dashboard and API handlers. Reading needs a viewer key
+// and changing data an operator key, unless cfg.Auth is nil. Requests that may
+// change data are recorded in the audit log either way.
+func NewRouter(st *store.Store, client analyticsv1.AnalyticsServiceClient, cfg RouterConfig) http.Handler {
+	site := cfg.Site
+	if site == nil {
+		site = web.Embedded()
+	}
+	a := &authenticator{st: st, cfg: cfg.Auth, now: time.Now}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/stats", a.require(auth.RoleViewer, getStatsHandler(st, client)))
+	mux.HandleFunc("GET /api/correlation", a.require(auth.RoleViewer, getCorrelationHandler(st, client)))
+	mux.HandleFunc("GET /api/anomalies", a.require(auth.RoleViewer, getAnomaliesHandler(st)))
+	mux.HandleFunc("GET /api/spreads", a.require(auth.RoleViewer, getSpreadsHandler(st)))
+	mux.HandleFunc("GET /api/chart", a.require(auth.RoleViewer, getChartHandler(st)))
+	mux.HandleFunc("GET /api/sparklines", a.require(auth.RoleViewer, getSparklinesHandler(st)))
+	registerPortfolio(mux, st, a)
+	a.register(mux)
+	// Scripts and styles are public, the login form needs them
+	mux.Handle("GET /static/", site)
+	mux.HandleFunc("GET /symbol/{symbol}", a.page(site, getSymbolPageHandler(st, site)))
+	mux.HandleFunc("/", a.page(site, getIndexHandler(site)))
+	return a.audit(mux)
+}
+
+// indexPage is the data of the dashboard template
+type indexPage struct {
+	Key *auth.Key // Logged in with, nil when open or read without a key
+}
+
+func getIndexHandler(site *web.Site) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var page indexPage
+		if key := requestKey(r); key != nil && key.ID != 0 {
+			page.Key = key
+		}
+		site.Render(w, http.StatusOK, "index.html", page)
+	}
+}
+
+func getStatsHandler(st *store.Store, client analyticsv1.AnalyticsServiceClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		snapshots, err := st.LatestStats(r.Context())
+		if err != nil {
+			log.Printf("[ERROR] API Stats error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		stats := make([]SymbolStats, len(snapshots))
+		for i, s := range snapshots {
+			stats[i] = SymbolStats{Symbol: s.Symbol, Price: s.Price, AvgPrice: s.AvgPrice, Quote: symbolQuote(s.Symbol)}
+		}
+
+		if quote := requestedQuote(r.URL.Query().Get("quote")); quote != "" {
+			conv, _, err := latestConverter(r.Context(), st)
+			if err != nil {
+				log.Printf("[ERROR] API Stats error: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+			for i := range stats {
+				c, err := conv.ConvertSymbol(stats[i].Symbol, quote)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				stats[i].Price = c.Apply(stats[i].Price)
+				stats[i].AvgPrice = c.Apply(stats[i].AvgPrice)
+				stats[i].Quote = quote
+				stats[i].Conversion = &c
+			}
+		}
+
+		for i := range stats {
+
+			ctx, cancel := context.WithTimeout(r.Context(), 1*time.Second)
+			defer cancel()
+
+			res, err := client.GetRSI(ctx, &analyticsv1.GetRSIRequest{
+				Symbol: stats[i].Symbol,
+				Period: 14,
+			})
+
+			if err == nil {
+				t := res.GetThresholds()
+				stats[i].RSI = res.RsiValue
+				stats[i].Signal = signals.ParseRSI(res.Signal.String())
+				stats[i].Change = res.ChangePercent
+				stats[i].Trend = signals.ParseTrend(res.Trend.String())
+				stats[i].Thresholds = &signals.Config{Oversold: t.GetOversold(), Overbought: t.GetOverbought(), Rocket: t.GetRocketPercent(), Crash: t.GetCrashPercent()}
+			} else {
+				log.Printf("[WARN] Could not get RSI for %s: %v", stats[i].Symbol, err)
+
+			}
+
+			stats[i].Forecast = nextCandle(ctx, client, stats[i])
+			stats[i].Liquidity = liquidity(ctx, client, stats[i])
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(stats); err != nil {
+			log.Printf("[ERROR] JSON encoding error: %v", err)
+		}
+	}
+}
+
+// nextCandle forecasts the next close of s with the service defaults, in the quote of s
+func nextCandle(ctx context.Context, client analyticsv1.AnalyticsServiceClient, s SymbolStats) *NextCandle {
+	res, err := client.Forecast(ctx, &analyticsv1.ForecastRequest{Symbol: s.Symbol, Steps: 1})
+	if err != nil {
+		// Missing history is expected for a while after the collector starts
+		if code := status.Code(err); code != codes.NotFound && code != codes.FailedPrecondition {
+			log.Printf("[WARN] Could not get a forecast for %s: %v", s.Symbol, err)
+		}
+		return nil
+	}
+	if len(res.Points) == 0 {
+		return nil
+	}
+	p := res.Points[0]
+	next := &NextCandle{
+		Model:           strings.ToLower(analyticsv1.EnumName(res.Model)),
+		IntervalSeconds: res.IntervalSeconds,
+		Time:            p.Time.AsTime(),
+		Value:           p.Value,
+		Lower:           p.Lower,
+		Upper:           p.Upper,
+		Level:           res.Level,
+	}
+	if s.Conversion != nil {
+		next.Value = s.Conversion.Apply(next.Value)
+		next.Lower = s.Conversion.Apply(next.Lower)
+		next.Upper = s.Conversion.Apply(next.Upper)
+	}
+	return next
+}
+
+// liquidity summarizes the latest order book of s, in the quote of s
+func liquidity(ctx context.Context, client analyticsv1.AnalyticsServiceClient, s SymbolStats) *Liquidity {
+	res, err := client.GetLiquidity(ctx, &analyticsv1.GetLiquidityRequest{Symbol: s.Symbol})
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			log.Printf("[WARN] Could not get the liquidity of %s: %v", s.Symbol, err)
+		}
+		return nil
+	}
+	l := &Liquidity{Time: res.Time.AsTime(), SpreadBPS: res.SpreadBps, Imbalance: res.Imbalance}
+	for _, b := range res.Bands {
+		d := Depth{BPS: b.Bps, Bid: b.BidNotional, Ask: b.AskNotional, Imbalance: b.Imbalance}
+		if s.Conversion != nil {
+			d.Bid, d.Ask = s.Conversion.Apply(d.Bid), s.Conversion.Apply(d.Ask)
+		}
+		l.Bands = append(l.Bands, d)
+	}
+	return l
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/store"
+	"crypto-check/venues"
+)
+
+// Number of spreads returned by GET /api/spreads?since= without and with limit=
+const (
+	defaultSpreads = 100
+	maxSpreads     = 1000
+)
+
+// getSpreadsHandler returns the latest cross-venue spread of every symbol, or of
+// symbol=. With since= (RFC 3339) it returns the spreads taken after it instead,
+// newest first.
+func getSpreadsHandler(st *store.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		symbol := strings.ToUpper(q.Get("symbol"))
+		limit := defaultSpreads
+		if v := q.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+				return
+			}
+			limit = min(n, maxSpreads)
+		}
+
+		var spreads []venues.Spread
+		var err error
+		if v := q.Get("since"); v != "" {
+			since, parseErr := time.Parse(time.RFC3339, v)
+			if parseErr != nil {
+				http.Error(w, "since must be an RFC 3339 time", http.StatusBadRequest)
+				return
+			}
+			spreads, err = st.Spreads(r.Context(), symbol, since, limit)
+		} else {
+			spreads, err = st.LatestSpreads(r.Context(), symbol)
+		}
+		if err != nil {
+			log.Printf("[ERROR] API Spreads error: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, spreads)
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/store"
+	"crypto-check/venues"
+)
+
+func TestSpreadsEndpoint(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, symbol := range []string{"BTCUSDT", "ETHUSDT", "BTCUSDT"} {
+		sp := venues.Spread{
+			Symbol: symbol, Time: start.Add(time.Duration(i) * time.Minute), Reference: float64(i + 1), Method: venues.MethodMedian,
+			Quotes: []venues.Quote{{Venue: "binance", Price: 100}, {Venue: "okx", Price: 100.1}},
+		}
+		if err := st.InsertSpread(ctx, sp); err != nil {
+			t.Fatalf("InsertSpread() error: %v", err)
+		}
+	}
+	router := NewRouter(st, nil, RouterConfig{})
+
+	// Spreads are told apart by their reference price
+	tests := []struct {
+		path    string
+		wantRef []float64
+	}{
+		{"/api/spreads", []float64{3, 2}},
+		{"/api/spreads?symbol=ethusdt", []float64{2}},
+		{"/api/spreads?symbol=BTCUSDT&since=2024-01-01T11:00:00Z", []float64{3, 1}},
+		{"/api/spreads?since=2024-01-01T12:00:30Z&limit=1", []float64{3}},
+		{"/api/spreads?symbol=SOLUSDT", []float64{}},
+	}
+	for _, tt := range tests {
+		var spreads []venues.Spread
+		get(t, router, tt.path, &spreads)
+		if len(spreads) != len(tt.wantRef) {
+			t.Errorf("GET %s returned %d spreads, want %d", tt.path, len(spreads), len(tt.wantRef))
+			continue
+		}
+		for i, sp := range spreads {
+			if sp.Reference != tt.wantRef[i] || len(sp.Quotes) != 2 {
+				t.Errorf("GET %s [%d] = %+v, want reference %v with 2 quotes", tt.path, i, sp, tt.wantRef[i])
+			}
+		}
+	}
+
+	for _, path := range []string{"/api/spreads?limit=-1", "/api/spreads?since=today"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want %d", path, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
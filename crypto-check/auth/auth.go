@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Role is what a key may do, every role may do what the ones below it may
+type Role string
+
+const (
+	RoleViewer   Role = "viewer"   // Reads the dashboard and the API
+	RoleOperator Role = "operator" // Also changes data, e.g. records portfolio trades
+	RoleAdmin    Role = "admin"    // Also manages keys and reads the audit log
+)
+
+var ranks = map[Role]int{RoleViewer: 1, RoleOperator: 2, RoleAdmin: 3}
+
+// ParseRole returns the role named name in any case
+func ParseRole(name string) (Role, error) {
+	r := Role(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := ranks[r]; !ok {
+		return "", fmt.Errorf("unknown role %q, want viewer, operator or admin", name)
+	}
+	return r, nil
+}
+
+// Allows reports whether r may do what required may
+func (r Role) Allows(required Role) bool {
+	return ranks[r] >= ranks[required] && ranks[r] > 0
+}
+
+// Key is a stored API key. The secret itself is only known when it is created.
+type Key struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Role      Role       `json:"role"`
+	Prefix    string     `json:"prefix"` // Start of the secret, to tell keys apart
+	CreatedAt time.Time  `json:"created_at"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Active reports whether the key has not been revoked
+func (k Key) Active() bool {
+	return k.RevokedAt == nil
+}
+
+// keyPrefix starts every secret, so leaked keys are easy to search for
+const keyPrefix = "cck_"
+
+// NewSecret returns a new random API key and the prefix stored to recognize it
+func NewSecret() (secret, prefix string, err error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	secret = keyPrefix + token
+	return secret, secret[:len(keyPrefix)+6], nil
+}
+
+// NewSessionToken returns a random session cookie value
+func NewSessionToken() (string, error) {
+	return randomToken()
+}
+
+// Hash returns what is stored of a secret or session token. Both are 256 random
+// bits, so a fast unsalted hash cannot be reversed.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Config is the "auth" block of config.json
+type Config struct {
+	PublicRead bool `json:"public_read"` // Viewer endpoints and the dashboard need no key
+	SessionTTL int  `json:"session_ttl"` // Seconds a dashboard login lasts, 0 for 12 hours
+}
+
+// TTL returns how long a session lasts
+func (c Config) TTL() time.Duration {
+	if c.SessionTTL > 0 {
+		return time.Duration(c.SessionTTL) * time.Second
+	}
+	return 12 * time.Hour
+}
+
+// AuditEntry records one request that changed, or tried to change, something
+type AuditEntry struct {
+	ID         int64     `json:"id"`
+	Time       time.Time `json:"time"`
+	Actor      string    `json:"actor"`            // Name of the key, "anonymous" without one
+	KeyID      int64     `json:"key_id,omitempty"` // 0 without a key
+	Role       Role      `json:"role,omitempty"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status"`
+	RemoteAddr string    `json:"remote_addr"`
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoles(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{RoleViewer, RoleViewer, true},
+		{RoleViewer, RoleOperator, false},
+		{RoleOperator, RoleViewer, true},
+		{RoleOperator, RoleAdmin, false},
+		{RoleAdmin, RoleOperator, true},
+		{Role(""), RoleViewer, false},
+		{Role("root"), Role("root"), false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" as "+string(tt.required), func(t *testing.T) {
+			if got := tt.role.Allows(tt.required); got != tt.want {
+				t.Errorf("%q.Allows(%q) = %v, want %v", tt.role, tt.required, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Role
+		wantErr bool
+	}{
+		{"viewer", RoleViewer, false},
+		{" Operator ", RoleOperator, false},
+		{"ADMIN", RoleAdmin, false},
+		{"", "", true},
+		{"root", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRole(tt.name)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("ParseRole(%q) = %q, %v, want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	secret, prefix, err := NewSecret()
+	if err != nil {
+		t.Fatalf("NewSecret() error: %v", err)
+	}
+	if !strings.HasPrefix(secret, prefix) || !strings.HasPrefix(prefix, keyPrefix) || len(secret) < 40 {
+		t.Errorf("NewSecret() = %q, %q", secret, prefix)
+	}
+	other, _, err := NewSecret()
+	if err != nil || other == secret {
+		t.Errorf("NewSecret() returned %q twice, %v", other, err)
+	}
+	if Hash(secret) == Hash(other) || Hash(secret) != Hash(secret) || len(Hash(secret)) != 64 {
+		t.Errorf("Hash() is not a stable SHA-256 of the secret")
+	}
+}
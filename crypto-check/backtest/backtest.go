@@ -0,0 +1,172 @@
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+const defaultInitialCash = 10000
+
+// Trade sides
+const (
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+)
+
+// Bar is the price of one step of the backtest
+type Bar struct {
+	Time  time.Time
+	Price float64
+}
+
+// Config describes the simulated account and execution costs
+type Config struct {
+	InitialCash float64 // Quote currency, defaults to 10000
+	FeeRate     float64 // Fraction of the traded value, 0.001 is Binance's 0.1% spot fee
+	Slippage    float64 // Fraction of the price paid above it on buys and given up on sells
+}
+
+// Trade is one simulated fill
+type Trade struct {
+	Time     time.Time
+	Side     string
+	Price    float64 // Fill price after slippage
+	Quantity float64
+	Fee      float64
+	PnL      float64 // Realised profit after fees, only set on sells
+}
+
+// EquityPoint is the account value at the close of a bar
+type EquityPoint struct {
+	Time   time.Time
+	Equity float64
+}
+
+// Result summarises a backtest
+type Result struct {
+	Strategy    string
+	InitialCash float64
+	FinalEquity float64
+	TotalReturn float64 // Fraction, 0.05 is +5%
+	MaxDrawdown float64 // Largest fall from a peak as a fraction of the peak
+	Sharpe      float64 // Annualised from per-bar returns with a zero risk-free rate
+	Equity      []EquityPoint
+	Trades      []Trade
+}
+
+// Run simulates a long-only strategy that goes all in on Buy and closes the whole
+// position on Sell. Signals are filled at the price of the following bar, so a
+// strategy never trades on the price it has just seen. An open position at the
+// end is valued at the last price.
+func Run(bars []Bar, strategy Strategy, config Config) Result {
+	cash := config.InitialCash
+	if cash <= 0 {
+		cash = defaultInitialCash
+	}
+	result := Result{Strategy: strategy.Name(), InitialCash: cash, FinalEquity: cash}
+
+	var quantity, costBasis float64
+	pending := Hold
+	prices := make([]float64, 0, len(bars))
+
+	for _, bar := range bars {
+		switch {
+		case pending == Buy && quantity == 0:
+			fill := bar.Price * (1 + config.Slippage)
+			quantity = cash / (fill * (1 + config.FeeRate))
+			fee := quantity * fill * config.FeeRate
+			costBasis = cash
+			cash = 0
+			result.Trades = append(result.Trades, Trade{Time: bar.Time, Side: SideBuy, Price: fill, Quantity: quantity, Fee: fee})
+		case pending == Sell && quantity > 0:
+			fill := bar.Price * (1 - config.Slippage)
+			proceeds := quantity * fill
+			fee := proceeds * config.FeeRate
+			cash = proceeds - fee
+			result.Trades = append(result.Trades, Trade{Time: bar.Time, Side: SideSell, Price: fill, Quantity: quantity, Fee: fee, PnL: cash - costBasis})
+			quantity = 0
+		}
+
+		prices = append(prices, bar.Price)
+		pending = strategy.Signal(prices)
+		result.Equity = append(result.Equity, EquityPoint{Time: bar.Time, Equity: cash + quantity*bar.Price})
+	}
+
+	if len(result.Equity) > 0 {
+		result.FinalEquity = result.Equity[len(result.Equity)-1].Equity
+	}
+	result.TotalReturn = result.FinalEquity/result.InitialCash - 1
+	result.MaxDrawdown = maxDrawdown(result.Equity)
+	result.Sharpe = sharpe(result.Equity)
+	return result
+}
+
+// Resample keeps the last price of every interval, stamped with the start of the
+// interval, turning raw price history into candle closes. A zero interval returns bars unchanged.
+func Resample(bars []Bar, interval time.Duration) []Bar {
+	if interval <= 0 {
+		return bars
+	}
+	var out []Bar
+	for _, bar := range bars {
+		start := bar.Time.Truncate(interval)
+		if n := len(out); n > 0 && out[n-1].Time.Equal(start) {
+			out[n-1].Price = bar.Price
+			continue
+		}
+		out = append(out, Bar{Time: start, Price: bar.Price})
+	}
+	return out
+}
+
+func maxDrawdown(equity []EquityPoint) float64 {
+	var peak, worst float64
+	for _, p := range equity {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if peak > 0 {
+			if dd := (peak - p.Equity) / peak; dd > worst {
+				worst = dd
+			}
+		}
+	}
+	return worst
+}
+
+// sharpe annualises the mean over the standard deviation of the bar returns,
+// using the average bar spacing to count bars per year
+func sharpe(equity []EquityPoint) float64 {
+	if len(equity) < 3 {
+		return 0
+	}
+
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		if prev := equity[i-1].Equity; prev > 0 {
+			returns = append(returns, equity[i].Equity/prev-1)
+		}
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	std := math.Sqrt(variance / float64(len(returns)-1))
+
+	spacing := equity[len(equity)-1].Time.Sub(equity[0].Time) / time.Duration(len(equity)-1)
+	if std == 0 || spacing <= 0 {
+		return 0
+	}
+	barsPerYear := float64(365*24*time.Hour) / float64(spacing)
+	return mean / std * math.Sqrt(barsPerYear)
+}
@@ -0,0 +1,136 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// scripted returns a fixed signal per bar
+type scripted []Signal
+
+func (s scripted) Name() string { return "scripted" }
+
+func (s scripted) Signal(prices []float64) Signal {
+	return s[len(prices)-1]
+}
+
+func makeBars(prices ...float64) []Bar {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bars := make([]Bar, len(prices))
+	for i, p := range prices {
+		bars[i] = Bar{Time: start.Add(time.Duration(i) * time.Hour), Price: p}
+	}
+	return bars
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestRunFillsOnNextBar(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     Config
+		wantEquity float64
+		wantPnL    float64
+	}{
+		{"No costs", Config{InitialCash: 1000}, 1200, 200},
+		{"Fees", Config{InitialCash: 1000, FeeRate: 0.01}, 1200 / 1.01 * 0.99, 1200/1.01*0.99 - 1000},
+		{"Slippage", Config{InitialCash: 1000, Slippage: 0.01}, 1000 / 101.0 * 118.8, 1000/101.0*118.8 - 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Buy seen at 90 fills at 100, sell seen at 130 fills at 120
+			bars := makeBars(90, 100, 130, 120, 150)
+			result := Run(bars, scripted{Buy, Hold, Sell, Hold, Hold}, tt.config)
+
+			if len(result.Trades) != 2 || result.Trades[0].Side != SideBuy || result.Trades[1].Side != SideSell {
+				t.Fatalf("unexpected trades: %+v", result.Trades)
+			}
+			if !result.Trades[0].Time.Equal(bars[1].Time) || !result.Trades[1].Time.Equal(bars[3].Time) {
+				t.Errorf("trades filled at %s and %s, want the bars after the signals", result.Trades[0].Time, result.Trades[1].Time)
+			}
+			if !almostEqual(result.FinalEquity, tt.wantEquity) || !almostEqual(result.Trades[1].PnL, tt.wantPnL) {
+				t.Errorf("final equity %.4f and PnL %.4f, want %.4f and %.4f", result.FinalEquity, result.Trades[1].PnL, tt.wantEquity, tt.wantPnL)
+			}
+			if !almostEqual(result.TotalReturn, tt.wantEquity/1000-1) {
+				t.Errorf("total return = %v", result.TotalReturn)
+			}
+		})
+	}
+}
+
+func TestRunDrawdownAndOpenPosition(t *testing.T) {
+	result := Run(makeBars(100, 100, 150, 75, 90), scripted{Buy, Hold, Hold, Hold, Hold}, Config{InitialCash: 1000})
+
+	if len(result.Trades) != 1 {
+		t.Fatalf("got %d trades, want 1", len(result.Trades))
+	}
+	// The open position is valued at the last price
+	if !almostEqual(result.FinalEquity, 900) {
+		t.Errorf("final equity = %v, want 900", result.FinalEquity)
+	}
+	if !almostEqual(result.MaxDrawdown, 0.5) {
+		t.Errorf("max drawdown = %v, want 0.5", result.MaxDrawdown)
+	}
+	if len(result.Equity) != 5 {
+		t.Errorf("equity curve has %d points, want 5", len(result.Equity))
+	}
+}
+
+func TestSharpe(t *testing.T) {
+	steady := Run(makeBars(100, 101, 102, 103, 104, 105), scripted{Buy, Hold, Hold, Hold, Hold, Hold}, Config{})
+	flat := Run(makeBars(100, 101, 102, 103), scripted{Hold, Hold, Hold, Hold}, Config{})
+
+	if steady.Sharpe <= 0 {
+		t.Errorf("Sharpe of a rising position = %v, want positive", steady.Sharpe)
+	}
+	if flat.Sharpe != 0 {
+		t.Errorf("Sharpe without trades = %v, want 0", flat.Sharpe)
+	}
+}
+
+func TestRSIStrategy(t *testing.T) {
+	strategy := RSIStrategy{Period: 3, Oversold: 30, Overbought: 70}
+
+	tests := []struct {
+		name   string
+		prices []float64
+		want   Signal
+	}{
+		{"Not enough data", []float64{100, 90}, Hold},
+		{"Falling", []float64{100, 90, 80}, Buy},
+		{"Rising", []float64{80, 90, 100}, Sell},
+		{"Only the last period counts", []float64{200, 100, 110, 105}, Hold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strategy.Signal(tt.prices); got != tt.want {
+				t.Errorf("Signal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResample(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bars := []Bar{
+		{start.Add(10 * time.Second), 1},
+		{start.Add(50 * time.Second), 2},
+		{start.Add(70 * time.Second), 3},
+		{start.Add(3 * time.Minute), 4},
+	}
+
+	got := Resample(bars, time.Minute)
+	want := []Bar{{start, 2}, {start.Add(time.Minute), 3}, {start.Add(3 * time.Minute), 4}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d bars, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) || got[i].Price != want[i].Price {
+			t.Errorf("bar %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package backtest
+
+import (
+	"fmt"
+
+	"crypto-check/indicators"
+)
+
+// Signal is what a strategy wants to do after seeing a bar
+type Signal int
+
+const (
+	Hold Signal = iota
+	Buy
+	Sell
+)
+
+// Strategy decides on every bar. prices holds the closes up to and including the
+// current bar, oldest first. The engine fills the signal on the next bar.
+type Strategy interface {
+	Name() string
+	Signal(prices []float64) Signal
+}
+
+// RSIStrategy buys when the RSI is at or below Oversold and sells at or above
+// Overbought, like the OVERSOLD and OVERBOUGHT signals of GetRSI
+type RSIStrategy struct {
+	Period     int // Number of prices the RSI is calculated over
+	Oversold   float64
+	Overbought float64
+}
+
+// DefaultRSIStrategy matches the default thresholds of the signals package
+func DefaultRSIStrategy() RSIStrategy {
+	return RSIStrategy{Period: 14, Oversold: 30, Overbought: 70}
+}
+
+func (s RSIStrategy) Name() string {
+	return fmt.Sprintf("rsi(%d, %g/%g)", s.Period, s.Oversold, s.Overbought)
+}
+
+func (s RSIStrategy) Signal(prices []float64) Signal {
+	if len(prices) < s.Period || s.Period < 2 {
+		return Hold // Not enough data, GetRSI would say WAITING_FOR_DATA
+	}
+
+	rsi := indicators.CalculateRSI(prices[len(prices)-s.Period:])
+	switch {
+	case rsi <= s.Oversold:
+		return Buy
+	case rsi >= s.Overbought:
+		return Sell
+	}
+	return Hold
+}
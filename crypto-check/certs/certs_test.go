@@ -0,0 +1,143 @@
+package certs
+
+import (
+	"crypto/tls"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// handshake connects a client and a server over a pipe and returns the
+// certificate the client saw and the error of each side
+func handshake(t *testing.T, server, client *tls.Config) (serial string, serverErr, clientErr error) {
+	t.Helper()
+	sc, cc := net.Pipe()
+	defer sc.Close()
+	defer cc.Close()
+	done := make(chan error, 1)
+	go func() {
+		err := tls.Server(sc, server).Handshake()
+		sc.Close() // Unblocks the client, also when the server gives up first
+		done <- err
+	}()
+	conn := tls.Client(cc, client)
+	clientErr = conn.Handshake()
+	if clientErr != nil {
+		cc.Close()
+	} else {
+		serial = conn.ConnectionState().PeerCertificates[0].SerialNumber.String()
+		// TLS 1.3 servers verify the client certificate after the client is done
+		conn.Read(make([]byte, 1))
+	}
+	return serial, <-done, clientErr
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	if err := Generate(dir, []string{"analytics", "127.0.0.1"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	server, err := ServerConfig(ServerFiles(dir))
+	if err != nil {
+		t.Fatalf("ServerConfig() error: %v", err)
+	}
+
+	other := t.TempDir()
+	if err := Generate(other, []string{"analytics"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		files      Files
+		serverName string
+		wantErr    bool
+	}{
+		{"Client certificate", ClientFiles(dir), "analytics", false},
+		{"IP address", ClientFiles(dir), "127.0.0.1", false},
+		{"No client certificate", Files{CA: filepath.Join(dir, CAFile)}, "analytics", true},
+		{"Other CA", Files{Cert: filepath.Join(other, ClientFile), Key: filepath.Join(other, ClientKeyFile), CA: filepath.Join(dir, CAFile)}, "analytics", true},
+		{"Server untrusted", Files{Cert: filepath.Join(dir, ClientFile), Key: filepath.Join(dir, ClientKeyFile), CA: filepath.Join(other, CAFile)}, "analytics", true},
+		{"Wrong host", ClientFiles(dir), "collector", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := ClientConfig(tt.files, tt.serverName)
+			if err != nil {
+				t.Fatalf("ClientConfig() error: %v", err)
+			}
+			_, serverErr, clientErr := handshake(t, server, client)
+			if gotErr := serverErr != nil || clientErr != nil; gotErr != tt.wantErr {
+				t.Errorf("handshake errors = %v / %v, wantErr %v", serverErr, clientErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	if err := Generate(dir, []string{"analytics"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	server, err := ServerConfig(ServerFiles(dir))
+	if err != nil {
+		t.Fatalf("ServerConfig() error: %v", err)
+	}
+	client, err := ClientConfig(ClientFiles(dir), "analytics")
+	if err != nil {
+		t.Fatalf("ClientConfig() error: %v", err)
+	}
+	before, serverErr, clientErr := handshake(t, server, client)
+	if serverErr != nil || clientErr != nil {
+		t.Fatalf("handshake errors = %v / %v", serverErr, clientErr)
+	}
+
+	// Renewed with the same CA, as a cron job would. The times are moved on in
+	// case the file system keeps them too coarsely to tell the renewal apart.
+	if err := Generate(dir, []string{"analytics"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	for _, name := range []string{ServerFile, ServerKeyFile, ClientFile, ClientKeyFile} {
+		if err := os.Chtimes(filepath.Join(dir, name), later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+	after, serverErr, clientErr := handshake(t, server, client)
+	if serverErr != nil || clientErr != nil {
+		t.Fatalf("handshake after the renewal errors = %v / %v", serverErr, clientErr)
+	}
+	if after == before {
+		t.Errorf("the server still presents certificate %s after the renewal", before)
+	}
+
+	// A broken file keeps the previous certificate in use
+	if err := os.WriteFile(filepath.Join(dir, ServerFile), []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	kept, serverErr, clientErr := handshake(t, server, client)
+	if serverErr != nil || clientErr != nil || kept != after {
+		t.Errorf("handshake with a broken file = %s, %v / %v, want %s", kept, serverErr, clientErr, after)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := Generate(dir, []string{"analytics"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	if _, err := ServerConfig(Files{CA: filepath.Join(dir, CAFile)}); err == nil {
+		t.Error("ServerConfig() without a certificate succeeded")
+	}
+	if _, err := ClientConfig(Files{Cert: filepath.Join(dir, ClientFile)}, ""); err == nil {
+		t.Error("ClientConfig() without a key succeeded")
+	}
+	if _, err := ClientConfig(Files{CA: filepath.Join(dir, "missing.pem")}, ""); err == nil {
+		t.Error("ClientConfig() with a missing CA succeeded")
+	}
+	if _, err := ServerConfig(Files{Cert: filepath.Join(dir, ServerFile), Key: filepath.Join(dir, ClientKeyFile)}); err == nil {
+		t.Error("ServerConfig() with the key of another certificate succeeded")
+	}
+}
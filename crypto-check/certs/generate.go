@@ -0,0 +1,190 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Names of the files written by Generate
+const (
+	CAFile        = "ca.pem"
+	CAKeyFile     = "ca-key.pem"
+	ServerFile    = "server.pem"
+	ServerKeyFile = "server-key.pem"
+	ClientFile    = "client.pem"
+	ClientKeyFile = "client-key.pem"
+)
+
+const (
+	caName     = "crypto-check dev CA"
+	caValidity = 10 * 365 * 24 * time.Hour
+	clientName = "crypto-check client"
+)
+
+// ServerFiles returns the files of the server certificate written by Generate to dir
+func ServerFiles(dir string) Files {
+	return Files{Cert: filepath.Join(dir, ServerFile), Key: filepath.Join(dir, ServerKeyFile), CA: filepath.Join(dir, CAFile)}
+}
+
+// ClientFiles returns the files of the client certificate written by Generate to dir
+func ClientFiles(dir string) Files {
+	return Files{Cert: filepath.Join(dir, ClientFile), Key: filepath.Join(dir, ClientKeyFile), CA: filepath.Join(dir, CAFile)}
+}
+
+// Generate writes a server and a client certificate valid for validity to dir,
+// signed by a development CA. The server certificate covers hosts, names or IP
+// addresses. The CA in dir is reused if there is one, so that certificates can
+// be renewed without distributing a new CA. It is meant for local setups only.
+func Generate(dir string, hosts []string, validity time.Duration) error {
+	if len(hosts) == 0 {
+		return errors.New("the server certificate needs at least one host")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	ca, caKey, err := loadCA(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		ca, caKey, err = newCA(dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	server := leafTemplate(hosts[0], validity, x509.ExtKeyUsageServerAuth)
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+	if err := issue(server, ca, caKey, filepath.Join(dir, ServerFile), filepath.Join(dir, ServerKeyFile)); err != nil {
+		return err
+	}
+	client := leafTemplate(clientName, validity, x509.ExtKeyUsageClientAuth)
+	return issue(client, ca, caKey, filepath.Join(dir, ClientFile), filepath.Join(dir, ClientKeyFile))
+}
+
+func newCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: caName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writeKeyPair(der, key, filepath.Join(dir, CAFile), filepath.Join(dir, CAKeyFile)); err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	return ca, key, err
+}
+
+func loadCA(dir string) (*x509.Certificate, crypto.Signer, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, CAFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		return nil, nil, err
+	}
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, fmt.Errorf("%s: invalid CA files", dir)
+	}
+	ca, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: the CA key cannot sign", dir)
+	}
+	return ca, signer, nil
+}
+
+func leafTemplate(name string, validity time.Duration, usage x509.ExtKeyUsage) *x509.Certificate {
+	now := time.Now()
+	return &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		NotBefore:   now.Add(-time.Hour), // Tolerates clocks a little behind
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{usage},
+	}
+}
+
+// issue signs template with the CA and writes the certificate and its new key
+func issue(template, ca *x509.Certificate, caKey crypto.Signer, certFile, keyFile string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	if template.SerialNumber, err = serialNumber(); err != nil {
+		return err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	return writeKeyPair(der, key, certFile, keyFile)
+}
+
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// writeKeyPair writes the key before the certificate, so a reloader seeing the
+// new certificate finds its key. Keys are readable by the owner only.
+func writeKeyPair(der []byte, key crypto.Signer, certFile, keyFile string) error {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(keyFile, "PRIVATE KEY", keyDER, 0600); err != nil {
+		return err
+	}
+	return writePEM(certFile, "CERTIFICATE", der, 0644)
+}
+
+// writePEM replaces name through a rename, so readers never see a partial file
+func writePEM(name, blockType string, der []byte, perm os.FileMode) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,238 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Files names the PEM files of one side of a TLS connection
+type Files struct {
+	Cert string // Certificate chain, leaf first
+	Key  string // Private key of Cert
+	CA   string // CA certificates the peer must be signed by
+}
+
+// IsZero reports whether no file is set, i.e. the connection is unencrypted
+func (f Files) IsZero() bool {
+	return f == Files{}
+}
+
+// stamp tells whether a file changed since it was loaded
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// reloader keeps the certificate and CA pool of Files, reading them again
+// whenever one of the files changes on disk. Connections already established
+// keep the certificates they were made with.
+type reloader struct {
+	files  Files
+	mu     sync.Mutex
+	cert   *tls.Certificate
+	pool   *x509.CertPool
+	stamps map[string]stamp
+}
+
+// newReloader loads the files once, so missing or invalid ones fail at startup
+func newReloader(files Files) (*reloader, error) {
+	if (files.Cert == "") != (files.Key == "") {
+		return nil, errors.New("a certificate needs both a cert and a key file")
+	}
+	r := &reloader{files: files}
+	stamps, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(stamps); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// stat returns the stamps of the files that are set
+func (r *reloader) stat() (map[string]stamp, error) {
+	stamps := make(map[string]stamp)
+	for _, name := range []string{r.files.Cert, r.files.Key, r.files.CA} {
+		if name == "" {
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		stamps[name] = stamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
+
+// load reads the files and replaces the certificate and pool. Callers hold r.mu
+// except in newReloader.
+func (r *reloader) load(stamps map[string]stamp) error {
+	var cert *tls.Certificate
+	if r.files.Cert != "" {
+		pair, err := tls.LoadX509KeyPair(r.files.Cert, r.files.Key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.files.Cert, err)
+		}
+		cert = &pair
+	}
+	var pool *x509.CertPool
+	if r.files.CA != "" {
+		data, err := os.ReadFile(r.files.CA)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("%s: no PEM certificates", r.files.CA)
+		}
+	}
+	r.cert, r.pool, r.stamps = cert, pool, stamps
+	return nil
+}
+
+// current returns the certificate and pool, reloaded first if a file changed.
+// A failed reload, e.g. while a renewal is half written, keeps the previous ones.
+func (r *reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stamps, err := r.stat()
+	if err == nil && !sameStamps(stamps, r.stamps) {
+		err = r.load(stamps)
+		if err == nil {
+			log.Printf("[INFO] Reloaded the TLS certificates from %s", r.files.describe())
+		}
+	}
+	if err != nil {
+		log.Printf("[ERROR] Could not reload the TLS certificates, keeping the previous ones: %v", err)
+	}
+	return r.cert, r.pool
+}
+
+func sameStamps(a, b map[string]stamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, s := range a {
+		if b[name] != s {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Files) describe() string {
+	if f.Cert != "" {
+		return f.Cert
+	}
+	return f.CA
+}
+
+// ServerConfig returns the TLS config of a gRPC server presenting the certificate
+// of files. With files.CA set it is mutual TLS: clients must present a
+// certificate signed by one of the CAs. Changed files are used from the next handshake.
+func ServerConfig(files Files) (*tls.Config, error) {
+	if files.Cert == "" {
+		return nil, errors.New("a TLS server needs a cert and a key file")
+	}
+	r, err := newReloader(files)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := r.current()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				NextProtos:   []string{"h2"}, // Required by gRPC, and not inherited from the outer config
+			}
+			if pool != nil {
+				config.ClientCAs = pool
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}, nil
+}
+
+// ClientConfig returns the TLS config of a gRPC client. files.CA verifies the
+// server instead of the system roots, and files.Cert is presented to servers
+// asking for one. serverName overrides the host name verified, which is taken
+// from the dialed address when empty. Changed files are used from the next handshake.
+func ClientConfig(files Files, serverName string) (*tls.Config, error) {
+	r, err := newReloader(files)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: serverName}
+	if files.Cert != "" {
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.current()
+			return cert, nil
+		}
+	}
+	if files.CA != "" {
+		// The standard verification reads RootCAs once, so it is done here with the current pool
+		config.InsecureSkipVerify = true
+		config.VerifyConnection = func(state tls.ConnectionState) error {
+			_, pool := r.current()
+			return verifyServer(state, pool)
+		}
+	}
+	return config, nil
+}
+
+// verifyServer does what crypto/tls does with RootCAs set to pool
+func verifyServer(state tls.ConnectionState, pool *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("the server sent no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         pool,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// ServerCredentials returns the transport credentials of a gRPC server, TLS
+// as in ServerConfig or plaintext when files is zero
+func ServerCredentials(files Files) (credentials.TransportCredentials, error) {
+	if files.IsZero() {
+		return insecure.NewCredentials(), nil
+	}
+	config, err := ServerConfig(files)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// ClientCredentials returns the transport credentials of a gRPC client, TLS as
+// in ClientConfig or plaintext when files is zero and serverName empty
+func ClientCredentials(files Files, serverName string) (credentials.TransportCredentials, error) {
+	if files.IsZero() && serverName == "" {
+		return insecure.NewCredentials(), nil
+	}
+	config, err := ClientConfig(files, serverName)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(config), nil
+}
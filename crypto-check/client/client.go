@@ -0,0 +1,681 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"crypto-check/backtest"
+	"crypto-check/forecast"
+	"crypto-check/orderbook"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultPeriod is the number of prices the collector uses for RSI
+const DefaultPeriod = 14
+
+// RSI is the analytics service's view of a symbol
+type RSI struct {
+	Symbol string
+	Price  float64 // Latest stored price
+	Value  float64
+	Signal signals.RSI
+	Change float64 // Percent from the average of the last hour
+	Trend  signals.Trend
+	// Thresholds the signal and the trend were classified with
+	Thresholds signals.Config
+}
+
+// Client wraps the analytics gRPC API for services embedding it
+type Client struct {
+	conn *grpc.ClientConn // nil when built from an existing connection
+	rpc  analyticsv1.AnalyticsServiceClient
+}
+
+// MaxRecvMsgSize bounds the responses Dial accepts, well above gRPC's 4 MiB
+// default so a backtest over months of minute closes fits
+const MaxRecvMsgSize = 64 << 20
+
+// Dial connects to the analytics service at addr. Without options the
+// connection is unencrypted, like the one between our own containers.
+func Dial(addr string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	// First, so the caller's options can still override it
+	opts = append([]grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxRecvMsgSize))}, opts...)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, rpc: analyticsv1.NewAnalyticsServiceClient(conn)}, nil
+}
+
+// New wraps a connection owned by the caller
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: analyticsv1.NewAnalyticsServiceClient(conn)}
+}
+
+// RSI calculates the RSI of symbol over the last period prices. A period of 0 uses DefaultPeriod.
+func (c *Client) RSI(ctx context.Context, symbol string, period int) (RSI, error) {
+	if period <= 0 {
+		period = DefaultPeriod
+	}
+	res, err := c.rpc.GetRSI(ctx, &analyticsv1.GetRSIRequest{Symbol: symbol, Period: int32(period)})
+	if err != nil {
+		return RSI{}, err
+	}
+	t := res.GetThresholds()
+	return RSI{
+		Symbol:     res.Symbol,
+		Price:      res.CurrentPrice,
+		Value:      res.RsiValue,
+		Signal:     signals.ParseRSI(res.Signal.String()),
+		Change:     res.ChangePercent,
+		Trend:      signals.ParseTrend(res.Trend.String()),
+		Thresholds: signals.Config{Oversold: t.GetOversold(), Overbought: t.GetOverbought(), Rocket: t.GetRocketPercent(), Crash: t.GetCrashPercent()},
+	}, nil
+}
+
+// BacktestRequest selects the history and the RSI strategy to backtest
+type BacktestRequest struct {
+	Symbol   string
+	From, To time.Time            // Zero values leave the range open
+	Interval time.Duration        // Resample the history into closes of this interval, 0 uses every stored price
+	RSI      backtest.RSIStrategy // Zero fields use the GetRSI defaults
+	Account  backtest.Config
+}
+
+// Backtest runs the RSI strategy over the price history stored by the service
+func (c *Client) Backtest(ctx context.Context, req BacktestRequest) (backtest.Result, error) {
+	in := &analyticsv1.BacktestRequest{
+		Symbol:          req.Symbol,
+		IntervalSeconds: int64(req.Interval / time.Second),
+		Strategy:        analyticsv1.BacktestStrategy_BACKTEST_STRATEGY_RSI,
+		RsiPeriod:       int32(req.RSI.Period),
+		Oversold:        req.RSI.Oversold,
+		Overbought:      req.RSI.Overbought,
+		InitialCash:     req.Account.InitialCash,
+		FeeRate:         req.Account.FeeRate,
+		Slippage:        req.Account.Slippage,
+		StartTime:       timestamp(req.From),
+		EndTime:         timestamp(req.To),
+	}
+
+	res, err := c.rpc.Backtest(ctx, in)
+	if err != nil {
+		return backtest.Result{}, err
+	}
+
+	result := backtest.Result{
+		Strategy:    res.Strategy,
+		InitialCash: res.InitialCash,
+		FinalEquity: res.FinalEquity,
+		TotalReturn: res.TotalReturn,
+		MaxDrawdown: res.MaxDrawdown,
+		Sharpe:      res.Sharpe,
+	}
+	for _, p := range res.Equity {
+		result.Equity = append(result.Equity, backtest.EquityPoint{Time: p.Time.AsTime(), Equity: p.Equity})
+	}
+	for _, t := range res.Trades {
+		result.Trades = append(result.Trades, backtest.Trade{
+			Time:     t.Time.AsTime(),
+			Side:     analyticsv1.EnumName(t.Side),
+			Price:    t.Price,
+			Quantity: t.Quantity,
+			Fee:      t.Fee,
+			PnL:      t.Pnl,
+		})
+	}
+	return result, nil
+}
+
+// CorrelationRequest selects the symbols and window of a correlation; zero
+// fields use the service defaults (24h window, 1m resolution, pearson, beta
+// against the first symbol over 30 returns)
+type CorrelationRequest struct {
+	Symbols    []string
+	To         time.Time
+	Window     time.Duration
+	Resolution time.Duration
+	Method     string // "pearson" or "spearman"
+	Benchmark  string
+	BetaWindow int
+}
+
+// BetaPoint is one rolling beta value, at the end of the returns it covers
+type BetaPoint struct {
+	Time time.Time
+	Beta float64
+}
+
+// Beta is the sensitivity of one symbol to the benchmark
+type Beta struct {
+	Symbol  string
+	Beta    float64
+	Rolling []BetaPoint
+}
+
+// Correlation holds the matrix in the order of Symbols
+type Correlation struct {
+	Symbols   []string
+	Method    string
+	Benchmark string
+	From, To  time.Time
+	Samples   int
+	Matrix    [][]float64
+	Betas     []Beta
+}
+
+// Correlation correlates the log returns of the symbols over the stored history
+func (c *Client) Correlation(ctx context.Context, req CorrelationRequest) (Correlation, error) {
+	method, err := enumOf[analyticsv1.CorrelationMethod]("method", req.Method)
+	if err != nil {
+		return Correlation{}, err
+	}
+	in := &analyticsv1.GetCorrelationRequest{
+		Symbols:           req.Symbols,
+		WindowSeconds:     int64(req.Window / time.Second),
+		ResolutionSeconds: int64(req.Resolution / time.Second),
+		Method:            method,
+		Benchmark:         req.Benchmark,
+		BetaWindow:        int32(req.BetaWindow),
+		EndTime:           timestamp(req.To),
+	}
+
+	res, err := c.rpc.GetCorrelation(ctx, in)
+	if err != nil {
+		return Correlation{}, err
+	}
+
+	result := Correlation{
+		Symbols:   res.Symbols,
+		Method:    nameOf(res.Method),
+		Benchmark: res.Benchmark,
+		From:      res.StartTime.AsTime(),
+		To:        res.EndTime.AsTime(),
+		Samples:   int(res.Samples),
+	}
+	for _, row := range res.Matrix {
+		result.Matrix = append(result.Matrix, row.Values)
+	}
+	for _, b := range res.Betas {
+		beta := Beta{Symbol: b.Symbol, Beta: b.Beta}
+		for _, p := range b.Rolling {
+			beta.Rolling = append(beta.Rolling, BetaPoint{Time: p.Time.AsTime(), Beta: p.Beta})
+		}
+		result.Betas = append(result.Betas, beta)
+	}
+	return result, nil
+}
+
+// VolatilityRequest selects the candles of a volatility estimate; zero fields
+// use the service defaults (1h candles, 24 per estimate, ranked over 30 days)
+type VolatilityRequest struct {
+	Symbol      string
+	Interval    time.Duration
+	Window      int
+	HistoryDays int
+	Estimator   string // "close_to_close", "parkinson" or "garman_klass"
+	To          time.Time
+}
+
+// Volatility holds annualized estimates as fractions, 0.6 is 60% a year
+type Volatility struct {
+	Symbol         string
+	Interval       time.Duration
+	Candles        int
+	CloseToClose   float64
+	Parkinson      float64
+	GarmanKlass    float64
+	Estimator      string
+	Percentile     float64 // Of Estimator among its values over the history
+	Regime         string
+	HistorySamples int
+	Time           time.Time // Start of the last candle
+}
+
+// Volatility estimates the realized volatility of a symbol
+func (c *Client) Volatility(ctx context.Context, req VolatilityRequest) (Volatility, error) {
+	estimator, err := enumOf[analyticsv1.VolatilityEstimator]("estimator", req.Estimator)
+	if err != nil {
+		return Volatility{}, err
+	}
+	in := &analyticsv1.GetVolatilityRequest{
+		Symbol:          req.Symbol,
+		IntervalSeconds: int64(req.Interval / time.Second),
+		Window:          int32(req.Window),
+		HistoryDays:     int32(req.HistoryDays),
+		Estimator:       estimator,
+		EndTime:         timestamp(req.To),
+	}
+
+	res, err := c.rpc.GetVolatility(ctx, in)
+	if err != nil {
+		return Volatility{}, err
+	}
+	return Volatility{
+		Symbol:         res.Symbol,
+		Interval:       time.Duration(res.IntervalSeconds) * time.Second,
+		Candles:        int(res.Candles),
+		CloseToClose:   res.CloseToClose,
+		Parkinson:      res.Parkinson,
+		GarmanKlass:    res.GarmanKlass,
+		Estimator:      nameOf(res.Estimator),
+		Percentile:     res.Percentile,
+		Regime:         analyticsv1.EnumName(res.Regime),
+		HistorySamples: int(res.HistorySamples),
+		Time:           res.Time.AsTime(),
+	}, nil
+}
+
+// ForecastRequest selects the candles and the model of a forecast; zero fields
+// use the service defaults (holt on the last 288 candles of 5m, 12 steps ahead)
+type ForecastRequest struct {
+	Symbol   string
+	To       time.Time
+	Interval time.Duration
+	History  int // Candles the model is fitted to
+	Steps    int
+	Model    forecast.Config
+}
+
+// ForecastPoint is the close forecast for the candle starting at Time
+type ForecastPoint struct {
+	Time  time.Time
+	Value float64
+	Lower float64
+	Upper float64
+}
+
+// Forecast is a fitted model and its forecast
+type Forecast struct {
+	Symbol       string
+	Model        string
+	Interval     time.Duration
+	Candles      int
+	Alpha        float64
+	Beta         float64
+	Coefficients []float64
+	Sigma        float64 // Of the one-step errors, in log price
+	Level        float64 // Coverage of the intervals
+	LastClose    float64
+	Time         time.Time // Start of the last candle
+	Points       []ForecastPoint
+}
+
+// Evaluation is the walk-forward accuracy of a model, one entry per step ahead
+type Evaluation struct {
+	Symbol   string
+	Model    string
+	Interval time.Duration
+	Origins  int
+	From, To time.Time // Starts of the first and last forecast candles
+	Steps    []forecast.Accuracy
+}
+
+func (req ForecastRequest) proto() (*analyticsv1.ForecastRequest, error) {
+	model, err := enumOf[analyticsv1.ForecastModel]("model", req.Model.Model)
+	if err != nil {
+		return nil, err
+	}
+	return &analyticsv1.ForecastRequest{
+		Symbol:          req.Symbol,
+		IntervalSeconds: int64(req.Interval / time.Second),
+		History:         int32(req.History),
+		Steps:           int32(req.Steps),
+		Model:           model,
+		Level:           req.Model.Level,
+		Alpha:           req.Model.Alpha,
+		Beta:            req.Model.Beta,
+		Order:           int32(req.Model.Order),
+		EndTime:         timestamp(req.To),
+	}, nil
+}
+
+// Forecast forecasts the next closes of a symbol with prediction intervals
+func (c *Client) Forecast(ctx context.Context, req ForecastRequest) (Forecast, error) {
+	in, err := req.proto()
+	if err != nil {
+		return Forecast{}, err
+	}
+	res, err := c.rpc.Forecast(ctx, in)
+	if err != nil {
+		return Forecast{}, err
+	}
+	result := Forecast{
+		Symbol:       res.Symbol,
+		Model:        nameOf(res.Model),
+		Interval:     time.Duration(res.IntervalSeconds) * time.Second,
+		Candles:      int(res.Candles),
+		Alpha:        res.Alpha,
+		Beta:         res.Beta,
+		Coefficients: res.Coefficients,
+		Sigma:        res.Sigma,
+		Level:        res.Level,
+		LastClose:    res.LastClose,
+		Time:         res.Time.AsTime(),
+	}
+	for _, p := range res.Points {
+		result.Points = append(result.Points, ForecastPoint{Time: p.Time.AsTime(), Value: p.Value, Lower: p.Lower, Upper: p.Upper})
+	}
+	return result, nil
+}
+
+// EvaluateForecast measures the model of req on the stored history by walking it
+// forward through origins candles, 0 for the service default of 100
+func (c *Client) EvaluateForecast(ctx context.Context, req ForecastRequest, origins int) (Evaluation, error) {
+	in, err := req.proto()
+	if err != nil {
+		return Evaluation{}, err
+	}
+	res, err := c.rpc.EvaluateForecast(ctx, &analyticsv1.EvaluateForecastRequest{Forecast: in, Origins: int32(origins)})
+	if err != nil {
+		return Evaluation{}, err
+	}
+	result := Evaluation{
+		Symbol:   res.Symbol,
+		Model:    nameOf(res.Model),
+		Interval: time.Duration(res.IntervalSeconds) * time.Second,
+		Origins:  int(res.Origins),
+		From:     res.StartTime.AsTime(),
+		To:       res.EndTime.AsTime(),
+	}
+	for _, s := range res.Steps {
+		result.Steps = append(result.Steps, forecast.Accuracy{
+			Step:      int(s.Step),
+			Forecasts: int(s.Forecasts),
+			MAE:       s.Mae,
+			RMSE:      s.Rmse,
+			MAPE:      s.Mape,
+			Coverage:  s.Coverage,
+			NaiveMAE:  s.NaiveMae,
+		})
+	}
+	return result, nil
+}
+
+// Liquidity is the latest order book snapshot of a symbol, with the spread and
+// imbalance over the requested window
+type Liquidity struct {
+	Symbol       string
+	Snapshot     orderbook.Snapshot // Latest
+	Snapshots    int                // In the window
+	AvgSpreadBPS float64
+	MaxSpreadBPS float64
+	AvgImbalance float64
+	Series       []LiquidityPoint
+}
+
+// LiquidityPoint is one snapshot of the window
+type LiquidityPoint struct {
+	Time      time.Time
+	Mid       float64
+	SpreadBPS float64
+	Imbalance float64
+}
+
+// Liquidity fetches the order book metrics of symbol over window before to;
+// zero values use the service defaults (the last hour until now)
+func (c *Client) Liquidity(ctx context.Context, symbol string, to time.Time, window time.Duration) (Liquidity, error) {
+	in := &analyticsv1.GetLiquidityRequest{Symbol: symbol, WindowSeconds: int64(window / time.Second), EndTime: timestamp(to)}
+	res, err := c.rpc.GetLiquidity(ctx, in)
+	if err != nil {
+		return Liquidity{}, err
+	}
+	result := Liquidity{
+		Symbol: res.Symbol,
+		Snapshot: orderbook.Snapshot{
+			Time:            res.Time.AsTime(),
+			BestBid:         res.BestBid,
+			BestBidQuantity: res.BestBidQuantity,
+			BestAsk:         res.BestAsk,
+			BestAskQuantity: res.BestAskQuantity,
+		},
+		Snapshots:    int(res.Snapshots),
+		AvgSpreadBPS: res.AvgSpreadBps,
+		MaxSpreadBPS: res.MaxSpreadBps,
+		AvgImbalance: res.AvgImbalance,
+	}
+	for _, b := range res.Bands {
+		result.Snapshot.Bands = append(result.Snapshot.Bands, orderbook.Band{
+			BPS:         b.Bps,
+			BidQuantity: b.BidQuantity,
+			AskQuantity: b.AskQuantity,
+			BidNotional: b.BidNotional,
+			AskNotional: b.AskNotional,
+		})
+	}
+	for _, p := range res.Series {
+		result.Series = append(result.Series, LiquidityPoint{Time: p.Time.AsTime(), Mid: p.Mid, SpreadBPS: p.SpreadBps, Imbalance: p.Imbalance})
+	}
+	return result, nil
+}
+
+// VolumeRequest selects the window of a volume summary; zero fields use the
+// service defaults (hourly candles over the last 24 hours)
+type VolumeRequest struct {
+	Symbol   string
+	To       time.Time
+	Window   time.Duration
+	Interval time.Duration
+}
+
+// VolumeCandle is a candle with its traded volume and the running CVD and OBV of the window
+type VolumeCandle struct {
+	Time        time.Time // Start of the candle
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64 // In the base asset
+	BuyVolume   float64 // Bought by takers
+	QuoteVolume float64
+	Trades      int
+	VWAP        float64
+	CVD         float64
+	OBV         float64
+}
+
+// Volume is the traded volume of a symbol over a window
+type Volume struct {
+	Symbol      string
+	From, To    time.Time
+	Volume      float64
+	BuyVolume   float64
+	SellVolume  float64
+	QuoteVolume float64
+	Trades      int
+	VWAP        float64
+	CVD         float64 // Taker buy minus sell volume
+	BuyRatio    float64
+	OBV         float64
+	Interval    time.Duration
+	Candles     []VolumeCandle
+}
+
+// Volume summarizes the stored trades of a symbol
+func (c *Client) Volume(ctx context.Context, req VolumeRequest) (Volume, error) {
+	in := &analyticsv1.GetVolumeRequest{
+		Symbol:          req.Symbol,
+		WindowSeconds:   int64(req.Window / time.Second),
+		IntervalSeconds: int64(req.Interval / time.Second),
+		EndTime:         timestamp(req.To),
+	}
+	res, err := c.rpc.GetVolume(ctx, in)
+	if err != nil {
+		return Volume{}, err
+	}
+	result := Volume{
+		Symbol:      res.Symbol,
+		From:        res.StartTime.AsTime(),
+		To:          res.EndTime.AsTime(),
+		Volume:      res.Volume,
+		BuyVolume:   res.BuyVolume,
+		SellVolume:  res.SellVolume,
+		QuoteVolume: res.QuoteVolume,
+		Trades:      int(res.Trades),
+		VWAP:        res.Vwap,
+		CVD:         res.Cvd,
+		BuyRatio:    res.BuyRatio,
+		OBV:         res.Obv,
+		Interval:    time.Duration(res.IntervalSeconds) * time.Second,
+	}
+	for _, c := range res.Candles {
+		result.Candles = append(result.Candles, VolumeCandle{
+			Time:        c.Time.AsTime(),
+			Open:        c.Open,
+			High:        c.High,
+			Low:         c.Low,
+			Close:       c.Close,
+			Volume:      c.Volume,
+			BuyVolume:   c.BuyVolume,
+			QuoteVolume: c.QuoteVolume,
+			Trades:      int(c.Trades),
+			VWAP:        c.Vwap,
+			CVD:         c.Cvd,
+			OBV:         c.Obv,
+		})
+	}
+	return result, nil
+}
+
+// DerivativesRequest selects the window of a derivatives summary; zero fields use
+// the service defaults (the last 24 hours, funding paid every 8 hours)
+type DerivativesRequest struct {
+	Symbol          string
+	To              time.Time
+	Window          time.Duration
+	FundingInterval time.Duration
+}
+
+// DerivativesPoint is one perpetual futures sample
+type DerivativesPoint struct {
+	Time              time.Time
+	MarkPrice         float64
+	SpotPrice         float64
+	BasisBPS          float64
+	FundingRate       float64
+	AnnualizedFunding float64 // Percent a year
+	OpenInterest      float64
+}
+
+// Derivatives is the latest perpetual futures sample of a symbol with its basis
+// and funding, and their averages over the window
+type Derivatives struct {
+	Symbol                   string
+	Time                     time.Time
+	MarkPrice                float64
+	IndexPrice               float64
+	SpotPrice                float64
+	Basis                    float64 // Mark minus spot price
+	BasisBPS                 float64
+	PremiumBPS               float64 // Mark over index price
+	FundingRate              float64
+	AnnualizedFunding        float64 // Percent a year
+	NextFunding              time.Time
+	OpenInterest             float64
+	OpenInterestNotional     float64
+	OpenInterestChange       float64 // Percent over the window
+	AverageAnnualizedFunding float64
+	AverageBasisBPS          float64
+	Points                   []DerivativesPoint
+}
+
+// Derivatives summarizes the stored perpetual futures samples of a symbol
+func (c *Client) Derivatives(ctx context.Context, req DerivativesRequest) (Derivatives, error) {
+	in := &analyticsv1.GetDerivativesRequest{
+		Symbol:               req.Symbol,
+		WindowSeconds:        int64(req.Window / time.Second),
+		FundingIntervalHours: int32(req.FundingInterval / time.Hour),
+		EndTime:              timestamp(req.To),
+	}
+	res, err := c.rpc.GetDerivatives(ctx, in)
+	if err != nil {
+		return Derivatives{}, err
+	}
+	result := Derivatives{
+		Symbol:                   res.Symbol,
+		Time:                     res.Time.AsTime(),
+		MarkPrice:                res.MarkPrice,
+		IndexPrice:               res.IndexPrice,
+		SpotPrice:                res.SpotPrice,
+		Basis:                    res.Basis,
+		BasisBPS:                 res.BasisBps,
+		PremiumBPS:               res.PremiumBps,
+		FundingRate:              res.FundingRate,
+		AnnualizedFunding:        res.AnnualizedFunding,
+		NextFunding:              timeOf(res.NextFundingTime),
+		OpenInterest:             res.OpenInterest,
+		OpenInterestNotional:     res.OpenInterestNotional,
+		OpenInterestChange:       res.OpenInterestChange,
+		AverageAnnualizedFunding: res.AverageAnnualizedFunding,
+		AverageBasisBPS:          res.AverageBasisBps,
+	}
+	for _, p := range res.Points {
+		result.Points = append(result.Points, DerivativesPoint{
+			Time:              p.Time.AsTime(),
+			MarkPrice:         p.MarkPrice,
+			SpotPrice:         p.SpotPrice,
+			BasisBPS:          p.BasisBps,
+			FundingRate:       p.FundingRate,
+			AnnualizedFunding: p.AnnualizedFunding,
+			OpenInterest:      p.OpenInterest,
+		})
+	}
+	return result, nil
+}
+
+// timestamp converts t for a request, the zero time leaves the field unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timeOf converts an optional timestamp of a response, unset gives the zero time
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// enumOf returns the enum value named like "spearman" or "ewma", the empty name
+// leaving the choice to the service
+func enumOf[E interface {
+	~int32
+	protoreflect.Enum
+}](field, name string) (E, error) {
+	var zero E
+	if name == "" {
+		return zero, nil
+	}
+	value, ok := analyticsv1.ParseEnum[E](name)
+	if !ok {
+		return zero, fmt.Errorf("unknown %s %q", field, name)
+	}
+	return value, nil
+}
+
+// nameOf returns the lower case name of an enum value, e.g. "spearman"
+func nameOf(e protoreflect.Enum) string {
+	return strings.ToLower(analyticsv1.EnumName(e))
+}
+
+// Close closes the connection opened by Dial
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"crypto-check/backtest"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeAnalytics struct {
+	analyticsv1.UnimplementedAnalyticsServiceServer
+}
+
+func (fakeAnalytics) GetRSI(ctx context.Context, req *analyticsv1.GetRSIRequest) (*analyticsv1.GetRSIResponse, error) {
+	return &analyticsv1.GetRSIResponse{
+		Symbol:        req.Symbol,
+		CurrentPrice:  65000,
+		RsiValue:      float64(req.Period),
+		Signal:        analyticsv1.RSISignal_RSI_SIGNAL_NEUTRAL,
+		ChangePercent: 6,
+		Trend:         analyticsv1.PriceTrend_PRICE_TREND_ROCKET,
+		Thresholds:    &analyticsv1.SignalThresholds{Oversold: 25, Overbought: 75, RocketPercent: 5, CrashPercent: 8},
+	}, nil
+}
+
+// Backtest answers with an equity curve of half a year of minute closes, over gRPC's default 4 MiB
+func (fakeAnalytics) Backtest(ctx context.Context, req *analyticsv1.BacktestRequest) (*analyticsv1.BacktestResponse, error) {
+	res := &analyticsv1.BacktestResponse{Symbol: req.Symbol, InitialCash: req.InitialCash}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 260000 {
+		res.Equity = append(res.Equity, &analyticsv1.EquityPoint{Time: timestamppb.New(start.Add(time.Duration(i) * time.Minute)), Equity: 10000 + float64(i)/3})
+	}
+	return res, nil
+}
+
+func dialFake(t *testing.T) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	analyticsv1.RegisterAnalyticsServiceServer(s, fakeAnalytics{})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	c, err := Dial("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() error: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestClientRSI(t *testing.T) {
+	c := dialFake(t)
+
+	tests := []struct {
+		name   string
+		period int
+		want   float64 // The fake echoes the period as the RSI value
+	}{
+		{"Default period", 0, DefaultPeriod},
+		{"Custom period", 30, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.RSI(context.Background(), "BTCUSDT", tt.period)
+			if err != nil {
+				t.Fatalf("RSI() error: %v", err)
+			}
+			if got.Symbol != "BTCUSDT" || got.Price != 65000 || got.Value != tt.want || got.Signal != signals.RSINeutral {
+				t.Errorf("RSI() = %+v", got)
+			}
+			if got.Change != 6 || got.Trend != signals.TrendRocket || got.Thresholds != (signals.Config{Oversold: 25, Overbought: 75, Rocket: 5, Crash: 8}) {
+				t.Errorf("RSI() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestClientLargeBacktest(t *testing.T) {
+	c := dialFake(t)
+	got, err := c.Backtest(context.Background(), BacktestRequest{Symbol: "BTCUSDT", Account: backtest.Config{InitialCash: 10000}})
+	if err != nil {
+		t.Fatalf("Backtest() error: %v", err)
+	}
+	if len(got.Equity) != 260000 {
+		t.Errorf("got %d equity points, want 260000", len(got.Equity))
+	}
+}
@@ -0,0 +1,159 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so schedules can run on a virtual clock in tests and replays
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real is the wall clock
+type Real struct{}
+
+func (Real) Now() time.Time                   { return time.Now() }
+func (Real) NewTimer(d time.Duration) Timer   { return realTimer{time.NewTimer(d)} }
+func (Real) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+type realTimer struct{ t *time.Timer }
+
+func (r realTimer) C() <-chan time.Time { return r.t.C }
+func (r realTimer) Stop()               { r.t.Stop() }
+
+type realTicker struct{ t *time.Ticker }
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+// Virtual only moves when told to. Timers and tickers fire during
+// Advance, in order, and like real tickers drop ticks nobody received.
+type Virtual struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*virtualWaiter
+}
+
+type virtualWaiter struct {
+	clock  *Virtual
+	at     time.Time
+	period time.Duration // 0 for timers
+	c      chan time.Time
+}
+
+func NewVirtual(start time.Time) *Virtual {
+	c := &Virtual{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *Virtual) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Virtual) NewTimer(d time.Duration) Timer {
+	return c.addWaiter(d, 0)
+}
+
+func (c *Virtual) NewTicker(d time.Duration) Ticker {
+	return c.addWaiter(d, d)
+}
+
+func (c *Virtual) addWaiter(d, period time.Duration) *virtualWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &virtualWaiter{clock: c, at: c.now.Add(d), period: period, c: make(chan time.Time, 1)}
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+	return w
+}
+
+// Advance moves the clock forward by d, firing everything due on the way
+func (c *Virtual) Advance(d time.Duration) {
+	c.AdvanceTo(c.Now().Add(d))
+}
+
+// AdvanceTo moves the clock to t. Going backwards is ignored.
+func (c *Virtual) AdvanceTo(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		sort.Slice(c.waiters, func(i, j int) bool { return c.waiters[i].at.Before(c.waiters[j].at) })
+		if len(c.waiters) == 0 || c.waiters[0].at.After(t) {
+			break
+		}
+		w := c.waiters[0]
+		if w.at.After(c.now) {
+			c.now = w.at
+		}
+		select {
+		case w.c <- c.now:
+		default: // Nobody is listening, drop the tick
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			c.waiters = c.waiters[1:]
+		}
+	}
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// NextDeadline returns when the earliest timer or ticker fires
+func (c *Virtual) NextDeadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.waiters) == 0 {
+		return time.Time{}, false
+	}
+	next := c.waiters[0].at
+	for _, w := range c.waiters[1:] {
+		if w.at.Before(next) {
+			next = w.at
+		}
+	}
+	return next, true
+}
+
+// BlockUntil waits until at least n timers or tickers are pending
+func (c *Virtual) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func (w *virtualWaiter) C() <-chan time.Time { return w.c }
+
+func (w *virtualWaiter) Stop() {
+	c := w.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, other := range c.waiters {
+		if other == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return
+		}
+	}
+}
@@ -1,93 +1,61 @@
 package main
 
 import (
-	"context"
 	"log"
 	"net"
+	"os"
 
-	"crypto-check/pb"
+	"crypto-check/analytics"
+	"crypto-check/certs"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+	"crypto-check/store"
 
-	"database/sql"
-
-	_ "github.com/glebarez/go-sqlite"
 	"google.golang.org/grpc"
 )
 
-// server
-type server struct {
-	pb.UnimplementedAnalyticsServiceServer
-	db *sql.DB
-}
-
-func (s *server) GetRSI(ctx context.Context, req *pb.AnalyticRequest) (*pb.AnalyticResponse, error) {
-
-	log.Printf("[gRPC] Received a request for the symbol: %s", req.Symbol)
-	// We take the last 14 prices (ORDER BY timestamp DESC will give us the most recent ones on top)
-	query := `SELECT price FROM price_history WHERE symbol = ? ORDER BY timestamp DESC LIMIT ?`
-	rows, err := s.db.Query(query, req.Symbol, req.Period)
-	if err != nil {
-		log.Printf("[ERROR] Database query failed: %v", err)
-		return nil, err
-	}
-	defer rows.Close()
-
-	var prices []float64
-	for rows.Next() {
-		var p float64
-		if err := rows.Scan(&p); err != nil {
-			continue
-		}
-		prices = append(prices, p)
-	}
-
-	// If there is little data (for example, it has just been launched), the RSI cannot be calculated
-	if len(prices) < 2 {
-		return &pb.AnalyticResponse{
-			Symbol:   req.Symbol,
-			RsiValue: 50.0,
-			Status:   "WAITING_FOR_DATA",
-		}, nil
+func main() {
+	dbPath := os.Getenv("DB_PATH")
+	if dbPath == "" {
+		dbPath = "/root/crypto.db"
 	}
-
-	// For the RSI, we need [Old -> New]. Turning the slice over:
-	for i, j := 0, len(prices)-1; i < j; i, j = i+1, j-1 {
-		prices[i], prices[j] = prices[j], prices[i]
+	st, err := store.Open(dbPath)
+	if err != nil {
+		log.Fatalf("Failed to connect to DB: %v", err)
 	}
+	defer st.Close()
 
-	// Count RSI
-	rsi := CalculateRSI(prices)
-
-	status := "NEUTRAL"
-	if rsi >= 70 {
-		status = "OVERBOUGHT (SELL)"
-	} else if rsi <= 30 {
-		status = "OVERSOLD (BUY)"
+	// The signal thresholds live in the config.json shared with the collector
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = "config.json"
 	}
-
-	return &pb.AnalyticResponse{
-		Symbol:       req.Symbol,
-		CurrentPrice: prices[len(prices)-1], // Last price
-		RsiValue:     rsi,
-		Status:       status,
-	}, nil
-}
-
-func main() {
-	db, err := sql.Open("sqlite", "/root/crypto.db")
+	classifier, err := signals.Load(configPath)
 	if err != nil {
-		log.Fatalf("Failed to connect to DB: %v", err)
+		log.Fatalf("Invalid signal thresholds: %v", err)
 	}
-	defer db.Close()
 
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterAnalyticsServiceServer(s, &server{db: db})
+	// TLS when a certificate is given, mutual TLS when client certificates must
+	// also be signed by TLS_CLIENT_CA_FILE. Renewed files are picked up without a restart.
+	tlsFiles := certs.Files{
+		Cert: os.Getenv("TLS_CERT_FILE"),
+		Key:  os.Getenv("TLS_KEY_FILE"),
+		CA:   os.Getenv("TLS_CLIENT_CA_FILE"),
+	}
+	creds, err := certs.ServerCredentials(tlsFiles)
+	if err != nil {
+		log.Fatalf("Invalid TLS configuration: %v", err)
+	}
+
+	s := grpc.NewServer(grpc.Creds(creds))
+	analyticsv1.RegisterAnalyticsServiceServer(s, analytics.NewServer(st, classifier))
 
-	log.Println("Analytics Service started on port :50051...")
+	log.Printf("Analytics Service started on port :50051 (%s)...", creds.Info().SecurityProtocol)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
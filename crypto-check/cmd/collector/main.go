@@ -1,20 +1,30 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
 	"time"
 
-	"crypto-check/pb"
+	"crypto-check/alerts"
+	"crypto-check/anomaly"
+	"crypto-check/api"
+	"crypto-check/certs"
+	"crypto-check/clock"
+	"crypto-check/collector"
+	"crypto-check/exchange"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/recording"
+	"crypto-check/store"
+	"crypto-check/web"
 
-	_ "github.com/glebarez/go-sqlite"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -35,43 +45,200 @@ func main() {
 	log.SetOutput(file)
 	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
 
-	db, err := initDB("crypto.db")
+	// The database is shared with the analytics service through the volume
+	dbPath := os.Getenv("DB_PATH")
+	if dbPath == "" {
+		dbPath = "/root/crypto.db"
+	}
+	st, err := store.Open(dbPath)
 	if err != nil {
 		log.Fatalf("[FATAL] Database initialization failed: %v", err)
 		return
 	}
-	defer db.Close()
+	defer st.Close()
 
-	config, err := loadConfig("config.json")
+	config, err := collector.LoadConfig("config.json")
 	if err != nil {
 		fmt.Printf("[FATAL] %v\n", err)
 		log.Fatalf("[FATAL] Configuration failed: %v", err)
 		return
 	}
+	// Allows pointing the collector at the mock exchange without editing the config
+	if apiUrl := os.Getenv("API_URL"); apiUrl != "" {
+		config.ApiUrl = apiUrl
+	}
+	if futuresUrl := os.Getenv("FUTURES_URL"); futuresUrl != "" {
+		config.FuturesURL = futuresUrl
+	}
+	if venueUrl := os.Getenv("VENUE_URL"); venueUrl != "" && config.Venues != nil {
+		config.Venues.URLs = map[string]string{}
+		for _, name := range config.Venues.Exchanges {
+			config.Venues.URLs[name] = venueUrl
+		}
+	}
+
 	// grpc connection to Analytics Service
 	addr := os.Getenv("ANALYTICS_ADDR")
 	if addr == "" {
 		addr = "localhost:50051"
 	}
 
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// TLS with ANALYTICS_CA_FILE, presenting ANALYTICS_CERT_FILE to a server asking for a client certificate
+	creds, err := certs.ClientCredentials(analyticsTLS())
+	if err != nil {
+		log.Fatalf("[FATAL] Invalid TLS configuration: %v", err)
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 	defer conn.Close()
 
 	// Create a gRPC client for the Analytics Service
-	analyticsClient := pb.NewAnalyticsServiceClient(conn)
+	analyticsClient := analyticsv1.NewAnalyticsServiceClient(conn)
 
 	fmt.Printf("Monitor started. Symbols: %v. Interval: %ds\n", config.Symbols, config.UpdateInterval)
 
-	go StartServer(db, analyticsClient, ":8080")
+	// A fresh install gets an admin key, shown once, to create the others with
+	if config.Auth != nil {
+		secret, err := api.BootstrapAdminKey(ctx, st)
+		if err != nil {
+			log.Fatalf("[FATAL] Could not create the admin API key: %v", err)
+		}
+		if secret != "" {
+			fmt.Printf("Created the admin API key %s, store it now, it is not shown again\n", secret)
+			log.Printf("[INFO] Created the bootstrap admin API key")
+		}
+	}
+
+	// WEB_DIR serves the dashboard from disk, e.g. WEB_DIR=web, reloading edits on every request
+	router := api.RouterConfig{Auth: config.Auth}
+	if dir := os.Getenv("WEB_DIR"); dir != "" {
+		if router.Site, err = web.Dir(dir); err != nil {
+			log.Fatalf("[FATAL] Could not load the dashboard from %s: %v", dir, err)
+		}
+		fmt.Printf("Serving the dashboard from %s\n", dir)
+	}
+
+	// Stopped with the rest on a signal, and the rest with it if it cannot start
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- api.StartServer(ctx, ":8080", api.NewRouter(st, analyticsClient, router), config.HTTP)
+	}()
+
+	// One client for all symbols so rate limits and bans are shared
+	binance := exchange.NewBinance(config.ExchangeOptions())
+	var clk clock.Clock = clock.Real{}
+	// Perpetual futures have their own API and rate limits
+	futures := exchange.NewFutures(config.FuturesURL, config.ExchangeOptions())
+	// Every REST client is recorded and replayed
+	transports := []interface{ SetTransport(http.RoundTripper) }{binance, futures}
+	// Binance is always one of the venues compared, the others get their own client
+	var venues []exchange.Venue
+	if config.Venues != nil {
+		venues = append(venues, binance)
+		for _, name := range config.Venues.Exchanges {
+			venue, err := exchange.NewVenue(name, config.VenueURL(name), config.ExchangeOptions())
+			if err != nil {
+				log.Fatalf("[FATAL] Configuration failed: %v", err)
+			}
+			venues = append(venues, venue)
+			transports = append(transports, venue.(interface{ SetTransport(http.RoundTripper) }))
+		}
+	}
+	// Order books and trades follow the streams, unless only the REST API is wanted
+	var streams exchange.Streams
+	if config.StreamURL != "" {
+		streams = exchange.NewWebSocket(config.StreamURL)
+	}
+	// Allows pointing the streams at the mock exchange as well
+	if streamURL := os.Getenv("STREAM_URL"); streamURL != "" {
+		streams = exchange.NewWebSocket(streamURL)
+	}
+
+	if config.ReplayFile != "" {
+		replay, err := recording.OpenReplay(config.ReplayFile, config.ReplaySpeed)
+		if err != nil {
+			log.Fatalf("[FATAL] Could not open replay %s: %v", config.ReplayFile, err)
+		}
+		clk = replay.Clock()
+		for _, client := range transports {
+			client.SetTransport(replay.Transport())
+		}
+		if streams != nil {
+			streams = exchange.ReplayStreams(replay)
+		}
+		go runReplay(ctx, replay, config.ReplaySpeed)
+		fmt.Printf("Replaying %s\n", config.ReplayFile)
+	} else if config.RecordFile != "" {
+		recorder, err := recording.NewRecorder(config.RecordFile, clk.Now)
+		if err != nil {
+			log.Fatalf("[FATAL] Could not create recording %s: %v", config.RecordFile, err)
+		}
+		defer recorder.Close()
+		for _, client := range transports {
+			client.SetTransport(recorder.Transport(http.DefaultTransport))
+		}
+		if ws, ok := streams.(*exchange.WebSocket); ok {
+			ws.Record(recorder)
+		}
+		fmt.Printf("Recording exchange responses to %s\n", config.RecordFile)
+	}
 
 	dataChannel := make(chan string)
-	var wg sync.WaitGroup
-	for _, s := range config.Symbols {
-		wg.Add(1) // Increment WaitGroup counter for each goroutine
-		go fetchPrice(ctx, &wg, db, analyticsClient, config.ApiUrl, s, config.UpdateInterval, config.AlertThreshold, dataChannel)
+	monitor := collector.NewMonitor(st, analyticsClient, binance, clk, config.AlertThreshold, dataChannel)
+	// Alerts are kept for the markers of the symbol charts
+	monitor.OnAlert(func(a alerts.Alert) {
+		if err := st.InsertAlert(context.WithoutCancel(ctx), a); err != nil {
+			log.Printf("[ERROR] [%s] Could not save the alert: %v", a.Symbol, err)
+		}
+	})
+	if config.AlertSigma > 0 {
+		monitor.AlertOnSigma(config.AlertSigma)
+	}
+	if config.Anomaly != nil {
+		monitor.DetectAnomalies(anomaly.NewEngine(*config.Anomaly, config.SymbolAnomaly))
+	}
+	if config.FuturesInterval > 0 {
+		interval := time.Duration(config.FuturesInterval) * time.Second
+		monitor.TrackDerivatives(futures, interval, time.Duration(config.FundingInterval)*time.Hour, config.FundingAlert)
+		fmt.Printf("Perpetual futures sampled every %v\n", interval)
+	}
+	if config.Venues != nil {
+		monitor.CompareVenues(venues, *config.Venues)
+		fmt.Printf("Comparing prices on %d venues\n", len(venues))
+	}
+	if config.PaperAmount > 0 {
+		monitor.OnRSI(collector.NewPaperTrader(st, config.PaperAmount, config.PaperFeeRate).Observe)
+		fmt.Printf("Paper trading %.2f per position on RSI signals\n", config.PaperAmount)
+	}
+
+	// Every collector writes to the store, which is closed once they all returned
+	var collectors sync.WaitGroup
+	collectors.Add(1)
+	go func() {
+		defer collectors.Done()
+		monitor.Run(ctx, config)
+	}()
+
+	if config.DepthInterval > 0 {
+		interval := time.Duration(config.DepthInterval) * time.Second
+		depth := collector.NewDepthCollector(st, binance, streams, clk, interval, config.DepthLimit, config.DepthBandsBPS)
+		collectors.Add(1)
+		go func() {
+			defer collectors.Done()
+			depth.Run(ctx, config.Symbols)
+		}()
+		fmt.Printf("Order books snapshotted every %v\n", interval)
+	}
+	if config.TradeInterval > 0 {
+		trades := collector.NewTradeCollector(st, binance, streams, clk, time.Duration(config.TradeInterval)*time.Second)
+		collectors.Add(1)
+		go func() {
+			defer collectors.Done()
+			trades.Run(ctx, config.Symbols)
+		}()
+		fmt.Println("Collecting trades")
 	}
 
 	go func() {
@@ -80,13 +247,51 @@ func main() {
 		}
 	}()
 
-	sig := <-sigChan
-	log.Printf("[INFO] Received signal: %v. Shutting down...", sig)
-	fmt.Printf("[INFO] Received signal: %v. Shutting down...\n", sig)
-	cancel()
-	wg.Wait() // Wait for all fetchPrice goroutines to finish
+	select {
+	case sig := <-sigChan:
+		log.Printf("[INFO] Received signal: %v. Shutting down...", sig)
+		fmt.Printf("[INFO] Received signal: %v. Shutting down...\n", sig)
+		cancel()
+		if err := <-serverDone; err != nil {
+			log.Printf("[ERROR] %v", err)
+		}
+	case err := <-serverDone:
+		log.Printf("[ERROR] Web server failed: %v. Shutting down...", err)
+		fmt.Printf("[ERROR] Web server failed: %v. Shutting down...\n", err)
+		cancel()
+	}
+	collectors.Wait() // Fetchers, order book snapshots and buffered trades are saved
 	log.Println("[INFO] Shutdown complete.")
 	close(dataChannel)
 
 	fmt.Println("Program terminated gracefully. All data was saved.")
 }
+
+// analyticsTLS returns the TLS files and server name of the connection to the
+// analytics service, all empty for a plaintext connection
+func analyticsTLS() (certs.Files, string) {
+	files := certs.Files{
+		Cert: os.Getenv("ANALYTICS_CERT_FILE"),
+		Key:  os.Getenv("ANALYTICS_KEY_FILE"),
+		CA:   os.Getenv("ANALYTICS_CA_FILE"),
+	}
+	return files, os.Getenv("ANALYTICS_SERVER_NAME")
+}
+
+// runReplay drives a replay at its speed, or one step per line on stdin when the speed is 0
+func runReplay(ctx context.Context, replay *recording.Replay, speed float64) {
+	if speed > 0 {
+		err := replay.Run(ctx)
+		log.Printf("[INFO] Replay stopped: %v", err)
+		return
+	}
+
+	fmt.Println("Step-by-step replay: press Enter to advance")
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if !replay.Step() {
+			break
+		}
+	}
+	log.Println("[INFO] Replay finished")
+}
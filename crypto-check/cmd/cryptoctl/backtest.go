@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"crypto-check/backtest"
+	"crypto-check/client"
+)
+
+func runBacktest(args []string) error {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	addr := fs.String("addr", analyticsAddr(), "analytics service address")
+	symbol := fs.String("symbol", "BTCUSDT", "symbol to backtest")
+	from := fs.String("from", "", "start of the history, RFC 3339 or YYYY-MM-DD (default: everything stored)")
+	to := fs.String("to", "", "end of the history, RFC 3339 or YYYY-MM-DD (default: now)")
+	interval := fs.Duration("interval", time.Minute, "resample prices into closes of this interval, e.g. 5m or 1h, 0 for every stored price")
+	period := fs.Int("period", 14, "RSI period")
+	oversold := fs.Float64("oversold", 0, "buy when the RSI is at or below this (default: the symbol's configured thresholds)")
+	overbought := fs.Float64("overbought", 0, "sell when the RSI is at or above this (default: the symbol's configured thresholds)")
+	cash := fs.Float64("cash", 10000, "initial cash in the quote currency")
+	fee := fs.Float64("fee", 0.001, "fee as a fraction of the traded value")
+	slippage := fs.Float64("slippage", 0.0005, "slippage as a fraction of the price")
+	showTrades := fs.Bool("trades", false, "print every trade")
+	equityFile := fs.String("equity", "", "write the equity curve as CSV to this file")
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+	fs.Parse(args)
+
+	req := client.BacktestRequest{
+		Symbol:   strings.ToUpper(*symbol),
+		Interval: *interval,
+		RSI:      backtest.RSIStrategy{Period: *period, Oversold: *oversold, Overbought: *overbought},
+		Account:  backtest.Config{InitialCash: *cash, FeeRate: *fee, Slippage: *slippage},
+	}
+	var err error
+	if req.From, err = parseTime(*from); err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+	if req.To, err = parseTime(*to); err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	c, err := dial(*addr)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	result, err := c.Backtest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	printReport(os.Stdout, req.Symbol, result, *showTrades)
+	if *equityFile != "" {
+		return writeEquity(*equityFile, result.Equity)
+	}
+	return nil
+}
+
+// parseTime accepts RFC 3339 timestamps and plain dates (UTC). Empty means unset.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+func printReport(w io.Writer, symbol string, r backtest.Result, showTrades bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Symbol:\t%s\n", symbol)
+	fmt.Fprintf(tw, "Strategy:\t%s\n", r.Strategy)
+	if len(r.Equity) > 0 {
+		fmt.Fprintf(tw, "Period:\t%s - %s (%d bars)\n",
+			r.Equity[0].Time.Format(time.DateTime), r.Equity[len(r.Equity)-1].Time.Format(time.DateTime), len(r.Equity))
+	}
+	fmt.Fprintf(tw, "Initial cash:\t%.2f\n", r.InitialCash)
+	fmt.Fprintf(tw, "Final equity:\t%.2f\n", r.FinalEquity)
+	fmt.Fprintf(tw, "Total return:\t%+.2f%%\n", r.TotalReturn*100)
+	fmt.Fprintf(tw, "Max drawdown:\t%.2f%%\n", r.MaxDrawdown*100)
+	fmt.Fprintf(tw, "Sharpe ratio:\t%.2f\n", r.Sharpe)
+	fmt.Fprintf(tw, "Trades:\t%d\n", len(r.Trades))
+	tw.Flush()
+
+	if !showTrades || len(r.Trades) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Time\tSide\tPrice\tQuantity\tFee\tPnL\t")
+	for _, t := range r.Trades {
+		pnl := ""
+		if t.Side == backtest.SideSell {
+			pnl = fmt.Sprintf("%+.2f", t.PnL)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\t%.6f\t%.2f\t%s\t\n",
+			t.Time.Format(time.DateTime), t.Side, t.Price, t.Quantity, t.Fee, pnl)
+	}
+	tw.Flush()
+}
+
+func writeEquity(path string, equity []backtest.EquityPoint) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"time", "equity"})
+	for _, p := range equity {
+		w.Write([]string{p.Time.Format(time.RFC3339), strconv.FormatFloat(p.Equity, 'f', 2, 64)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"crypto-check/certs"
+)
+
+func runCerts(args []string) error {
+	fs := flag.NewFlagSet("certs", flag.ExitOnError)
+	dir := fs.String("dir", "tls", "directory the CA and certificates are written to, an existing CA in it is reused")
+	hosts := fs.String("hosts", "localhost,127.0.0.1,analytics", "comma separated names and IP addresses of the analytics server")
+	validity := fs.Duration("validity", 90*24*time.Hour, "validity of the server and client certificates")
+	fs.Parse(args)
+
+	var names []string
+	for _, host := range strings.Split(*hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			names = append(names, host)
+		}
+	}
+	if err := certs.Generate(*dir, names, *validity); err != nil {
+		return err
+	}
+
+	server, client := certs.ServerFiles(*dir), certs.ClientFiles(*dir)
+	fmt.Printf("Wrote a server certificate for %s and a client certificate to %s\n\n", strings.Join(names, ", "), *dir)
+	fmt.Printf("Analytics service:\n  TLS_CERT_FILE=%s\n  TLS_KEY_FILE=%s\n  TLS_CLIENT_CA_FILE=%s\n\n", server.Cert, server.Key, server.CA)
+	fmt.Printf("Collector and cryptoctl:\n  ANALYTICS_CA_FILE=%s\n  ANALYTICS_CERT_FILE=%s\n  ANALYTICS_KEY_FILE=%s\n\n", client.CA, client.Cert, client.Key)
+	fmt.Printf("Keep %s private, it can sign certificates both sides trust.\n", filepath.Join(*dir, certs.CAKeyFile))
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"crypto-check/client"
+	"crypto-check/forecast"
+)
+
+func runForecast(args []string) error {
+	fs := flag.NewFlagSet("forecast", flag.ExitOnError)
+	addr := fs.String("addr", analyticsAddr(), "analytics service address")
+	symbol := fs.String("symbol", "BTCUSDT", "symbol to forecast")
+	model := fs.String("model", forecast.ModelHolt, "model: ewma, holt or ar")
+	to := fs.String("to", "", "end of the history, RFC 3339 or YYYY-MM-DD (default: now)")
+	interval := fs.Duration("interval", 5*time.Minute, "candle size")
+	history := fs.Int("history", 288, "candles the model is fitted to")
+	steps := fs.Int("steps", 12, "candles ahead")
+	level := fs.Float64("level", 0.95, "coverage of the prediction intervals")
+	alpha := fs.Float64("alpha", 0, "level smoothing of ewma and holt (default: fitted)")
+	beta := fs.Float64("beta", 0, "trend smoothing of holt (default: fitted)")
+	order := fs.Int("order", 2, "lags of ar")
+	evaluate := fs.Bool("evaluate", false, "walk the model forward through the stored history and report its errors instead")
+	origins := fs.Int("origins", 100, "forecast origins walked through with -evaluate")
+	timeout := fs.Duration("timeout", 30*time.Second, "request timeout")
+	fs.Parse(args)
+
+	req := client.ForecastRequest{
+		Symbol:   strings.ToUpper(*symbol),
+		Interval: *interval,
+		History:  *history,
+		Steps:    *steps,
+		Model:    forecast.Config{Model: *model, Alpha: *alpha, Beta: *beta, Order: *order, Level: *level},
+	}
+	var err error
+	if req.To, err = parseTime(*to); err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+
+	c, err := dial(*addr)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if *evaluate {
+		result, err := c.EvaluateForecast(ctx, req, *origins)
+		if err != nil {
+			return err
+		}
+		printEvaluation(os.Stdout, result)
+		return nil
+	}
+	result, err := c.Forecast(ctx, req)
+	if err != nil {
+		return err
+	}
+	printForecast(os.Stdout, result)
+	return nil
+}
+
+func printForecast(w io.Writer, f client.Forecast) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Symbol:\t%s\n", f.Symbol)
+	fmt.Fprintf(tw, "Model:\t%s\n", modelName(f))
+	fmt.Fprintf(tw, "Fitted to:\t%d candles of %s\n", f.Candles, f.Interval)
+	fmt.Fprintf(tw, "Last close:\t%.2f (%s)\n", f.LastClose, f.Time.Format(time.DateTime))
+	fmt.Fprintf(tw, "One-step error:\t%.3f%%\n", f.Sigma*100)
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Time\tForecast\tLower %g%%\tUpper %g%%\t\n", f.Level*100, f.Level*100)
+	for _, p := range f.Points {
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%.2f\t\n", p.Time.Format(time.DateTime), p.Value, p.Lower, p.Upper)
+	}
+	tw.Flush()
+}
+
+// modelName includes the fitted parameters
+func modelName(f client.Forecast) string {
+	switch f.Model {
+	case forecast.ModelEWMA:
+		return fmt.Sprintf("ewma(alpha %.2f)", f.Alpha)
+	case forecast.ModelHolt:
+		return fmt.Sprintf("holt(alpha %.2f, beta %.2f)", f.Alpha, f.Beta)
+	case forecast.ModelAR:
+		return fmt.Sprintf("ar(%d) %.4g", len(f.Coefficients)-1, f.Coefficients)
+	}
+	return f.Model
+}
+
+func printEvaluation(w io.Writer, e client.Evaluation) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Symbol:\t%s\n", e.Symbol)
+	fmt.Fprintf(tw, "Model:\t%s\n", e.Model)
+	fmt.Fprintf(tw, "Forecasts:\t%s - %s (%d origins, %s candles)\n",
+		e.From.Format(time.DateTime), e.To.Format(time.DateTime), e.Origins, e.Interval)
+	tw.Flush()
+
+	// Skill compares with repeating the last close, above 0 beats it
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Step\tMAE\tRMSE\tMAPE\tCoverage\tNaive MAE\tSkill\t")
+	for _, a := range e.Steps {
+		skill := "-"
+		if a.NaiveMAE > 0 {
+			skill = fmt.Sprintf("%+.3f", 1-a.MAE/a.NaiveMAE)
+		}
+		fmt.Fprintf(tw, "%d\t%.4f\t%.4f\t%.3f%%\t%.1f%%\t%.4f\t%s\t\n",
+			a.Step, a.MAE, a.RMSE, a.MAPE, a.Coverage*100, a.NaiveMAE, skill)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"crypto-check/certs"
+	"crypto-check/client"
+
+	"google.golang.org/grpc"
+)
+
+const usage = `Usage: cryptoctl <command> [flags]
+
+Commands:
+  backtest   Run a strategy over the stored price history
+  forecast   Forecast the next candles, or evaluate a model with -evaluate
+  certs      Generate a development CA with server and client certificates
+
+Run "cryptoctl <command> -h" for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "backtest":
+		err = runBacktest(os.Args[2:])
+	case "forecast":
+		err = runForecast(os.Args[2:])
+	case "certs":
+		err = runCerts(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// analyticsAddr is the default for the -addr flags, matching the collector
+func analyticsAddr() string {
+	if addr := os.Getenv("ANALYTICS_ADDR"); addr != "" {
+		return addr
+	}
+	return "localhost:50051"
+}
+
+// dial connects to the analytics service, over TLS when the ANALYTICS_CA_FILE,
+// ANALYTICS_CERT_FILE and ANALYTICS_KEY_FILE variables of the collector are set
+func dial(addr string) (*client.Client, error) {
+	files := certs.Files{
+		Cert: os.Getenv("ANALYTICS_CERT_FILE"),
+		Key:  os.Getenv("ANALYTICS_KEY_FILE"),
+		CA:   os.Getenv("ANALYTICS_CA_FILE"),
+	}
+	creds, err := certs.ClientCredentials(files, os.Getenv("ANALYTICS_SERVER_NAME"))
+	if err != nil {
+		return nil, err
+	}
+	return client.Dial(addr, grpc.WithTransportCredentials(creds))
+}
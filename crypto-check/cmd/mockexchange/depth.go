@@ -0,0 +1,163 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/orderbook"
+)
+
+// bookLevels is the depth of the synthetic books on each side
+const bookLevels = 50
+
+// DepthUpdate is a diff of a synthetic book, like a Binance depthUpdate event
+type DepthUpdate struct {
+	Symbol        string
+	Time          time.Time
+	FirstUpdateID int64
+	FinalUpdateID int64
+	Bids          []orderbook.Level // A zero quantity removes the level
+	Asks          []orderbook.Level
+}
+
+// OnDepth registers a callback for every book diff. It must not block.
+func (m *Market) OnDepth(fn func(DepthUpdate)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onDepth = fn
+}
+
+// Depth returns the book of symbol with up to limit levels per side
+func (m *Market) Depth(symbol string, limit int) (int64, []orderbook.Level, []orderbook.Level, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.symbols[symbol]
+	if !ok {
+		return 0, nil, nil, false
+	}
+	bids, asks := s.book.Bids(), s.book.Asks()
+	return s.book.LastUpdateID(), bids[:min(limit, len(bids))], asks[:min(limit, len(asks))], true
+}
+
+// rebook moves the book of s around its current price and returns the diff.
+// It must be called with the lock held.
+func (m *Market) rebook(s *symbolState, now time.Time) DepthUpdate {
+	tick := tickSize(s.price)
+	bid := math.Floor(s.price/tick) * tick
+	target := func(price float64) float64 {
+		// Around 500 USDT per level, thicker away from the top
+		return math.Round(500/s.price*(0.2+m.rng.ExpFloat64())*(1+math.Abs(price-s.price)/s.price*100)*1e6) / 1e6
+	}
+	bids := make(map[float64]float64, bookLevels)
+	asks := make(map[float64]float64, bookLevels)
+	for i := range bookLevels {
+		bids[roundTick(bid-float64(i)*tick, tick)] = 0
+		asks[roundTick(bid+float64(i+1)*tick, tick)] = 0
+	}
+
+	update := DepthUpdate{Symbol: s.symbol, Time: now}
+	if s.book == nil {
+		s.book = orderbook.New(0, nil, nil)
+	}
+	update.Bids = diffLevels(s.book.Bids(), bids, target, m.rng)
+	update.Asks = diffLevels(s.book.Asks(), asks, target, m.rng)
+
+	// Several exchange events are merged into every diff
+	update.FirstUpdateID = s.book.LastUpdateID() + 1
+	update.FinalUpdateID = update.FirstUpdateID + int64(m.rng.IntN(5))
+	s.book.Apply(update.FirstUpdateID, update.FinalUpdateID, update.Bids, update.Asks)
+	return update
+}
+
+// diffLevels removes the levels of current missing from wanted, adds the new ones
+// and changes the quantity of some of the others
+func diffLevels(current []orderbook.Level, wanted map[float64]float64, quantity func(float64) float64, rng *rand.Rand) []orderbook.Level {
+	var diff []orderbook.Level
+	for _, l := range current {
+		if _, ok := wanted[l.Price]; !ok {
+			diff = append(diff, orderbook.Level{Price: l.Price})
+			continue
+		}
+		wanted[l.Price] = l.Quantity
+	}
+	for price, q := range wanted {
+		if q == 0 || rng.IntN(4) == 0 {
+			diff = append(diff, orderbook.Level{Price: price, Quantity: quantity(price)})
+		}
+	}
+	return diff
+}
+
+// tickSize gives prices about five significant digits, like most Binance pairs
+func tickSize(price float64) float64 {
+	return math.Pow(10, math.Floor(math.Log10(price))-4)
+}
+
+func roundTick(price, tick float64) float64 {
+	return math.Round(price/tick) * tick
+}
+
+// depthWeights are the request weights of /api/v3/depth by limit
+var depthWeights = []struct {
+	limit  int
+	weight int
+}{{100, 5}, {500, 25}, {1000, 50}, {5000, 250}}
+
+func (s *Server) depthHandler(w http.ResponseWriter, r *http.Request) int {
+	symbol := r.URL.Query().Get("symbol")
+	limit := 100
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 5000 {
+			writeJSON(w, http.StatusBadRequest, apiError{Code: -1100, Msg: "Illegal characters found in parameter 'limit'."})
+			return 1
+		}
+		limit = n
+	}
+	weight := depthWeights[len(depthWeights)-1].weight
+	for _, dw := range depthWeights {
+		if limit <= dw.limit {
+			weight = dw.weight
+			break
+		}
+	}
+
+	lastUpdateID, bids, asks, ok := s.market.Depth(symbol, limit)
+	if !ok {
+		invalidSymbol(w)
+		return weight
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"lastUpdateId": lastUpdateID,
+		"bids":         formatLevels(bids),
+		"asks":         formatLevels(asks),
+	})
+	return weight
+}
+
+// publishDepth is called by the market for every book diff
+func (h *Hub) publishDepth(u DepthUpdate) {
+	lower := strings.ToLower(u.Symbol)
+	if !h.hasSubscribers(lower+"@depth") && !h.hasSubscribers(lower+"@depth@100ms") {
+		return
+	}
+	payload := map[string]any{
+		"e": "depthUpdate", "E": u.Time.UnixMilli(), "s": u.Symbol,
+		"U": u.FirstUpdateID, "u": u.FinalUpdateID,
+		"b": formatLevels(u.Bids), "a": formatLevels(u.Asks),
+	}
+	h.publish(lower+"@depth", payload)
+	h.publish(lower+"@depth@100ms", payload)
+}
+
+func formatLevels(levels []orderbook.Level) [][2]string {
+	result := make([][2]string, len(levels))
+	for i, l := range levels {
+		result[i] = [2]string{formatPrice(l.Price), formatQuantity(l.Quantity)}
+	}
+	return result
+}
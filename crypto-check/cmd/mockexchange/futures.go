@@ -0,0 +1,89 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Perpetual futures follow the spot price with a premium cycling every few hours,
+// so funding swings between positive and negative like on a real exchange
+const (
+	premiumCycle    = 6 * time.Hour
+	premiumAmp      = 0.001 // Of the spot price
+	fundingInterval = 8 * time.Hour
+	interestRate    = 0.0001 // Per funding interval, as on Binance
+	fundingCap      = 0.0075
+)
+
+// Perpetual is the futures contract of a symbol
+type Perpetual struct {
+	MarkPrice       float64
+	IndexPrice      float64
+	FundingRate     float64
+	NextFundingTime time.Time
+	OpenInterest    float64 // In the base asset
+	Time            time.Time
+}
+
+// Perpetual derives the futures contract of symbol from its spot price. It uses no
+// randomness, so serving futures does not change the simulated prices.
+func (m *Market) Perpetual(symbol string) (Perpetual, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.symbols[symbol]
+	if !ok {
+		return Perpetual{}, false
+	}
+	now := m.lastTick
+	phase := 2 * math.Pi * float64(now.UnixNano()%int64(premiumCycle)) / float64(premiumCycle)
+	premium := premiumAmp * math.Sin(phase)
+	// Binance adds the clamped difference between the interest rate and the premium
+	funding := premium + math.Max(-0.0005, math.Min(0.0005, interestRate-premium))
+	// Around 50 million USDT of open interest, growing and shrinking with the premium
+	interest := 5e7 / s.price * (1 + 0.2*math.Sin(phase/2))
+	return Perpetual{
+		MarkPrice:       s.price * (1 + premium),
+		IndexPrice:      s.price,
+		FundingRate:     math.Max(-fundingCap, math.Min(fundingCap, funding)),
+		NextFundingTime: now.Truncate(fundingInterval).Add(fundingInterval),
+		OpenInterest:    math.Round(interest*1000) / 1000,
+		Time:            now,
+	}, true
+}
+
+func (s *Server) premiumIndexHandler(w http.ResponseWriter, r *http.Request) int {
+	symbol := r.URL.Query().Get("symbol")
+	p, ok := s.market.Perpetual(symbol)
+	if !ok {
+		invalidSymbol(w)
+		return 1
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"symbol":               symbol,
+		"markPrice":            formatPrice(p.MarkPrice),
+		"indexPrice":           formatPrice(p.IndexPrice),
+		"estimatedSettlePrice": formatPrice(p.IndexPrice),
+		"lastFundingRate":      strconv.FormatFloat(p.FundingRate, 'f', 8, 64),
+		"interestRate":         strconv.FormatFloat(interestRate, 'f', 8, 64),
+		"nextFundingTime":      p.NextFundingTime.UnixMilli(),
+		"time":                 p.Time.UnixMilli(),
+	})
+	return 1
+}
+
+func (s *Server) openInterestHandler(w http.ResponseWriter, r *http.Request) int {
+	symbol := r.URL.Query().Get("symbol")
+	p, ok := s.market.Perpetual(symbol)
+	if !ok {
+		invalidSymbol(w)
+		return 1
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"symbol":       symbol,
+		"openInterest": formatQuantity(p.OpenInterest),
+		"time":         p.Time.UnixMilli(),
+	})
+	return 1
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	symbolList := flag.String("symbols", "BTCUSDT:65000,ETHUSDT:3500,SOLUSDT:150,BNBUSDT:600,DOGEUSDT:0.15", "comma separated SYMBOL:initial_price pairs")
+	model := flag.String("model", "gbm", "price model: gbm or walk")
+	drift := flag.Float64("drift", 0, "annualized drift for the gbm model")
+	volatility := flag.Float64("volatility", 0.8, "annualized volatility (gbm) or daily volatility as a fraction of the initial price (walk)")
+	tick := flag.Duration("tick", time.Second, "how often prices move")
+	history := flag.Int("history", 24*60, "minutes of candles generated at startup")
+	seed := flag.Uint64("seed", uint64(time.Now().UnixNano()), "random seed for reproducible prices")
+	replayFile := flag.String("replay", "", "loop prices from a collector recording instead of generating them")
+	latency := flag.Duration("latency", 0, "latency added to every REST request")
+	jitter := flag.Duration("jitter", 0, "random extra latency up to this value")
+	rate429 := flag.Float64("rate-429", 0, "probability of answering 429 Too Many Requests")
+	rate418 := flag.Float64("rate-418", 0, "probability of banning the client with 418 for two minutes")
+	rate5xx := flag.Float64("rate-5xx", 0, "probability of answering 500 Internal Server Error")
+	rateMalformed := flag.Float64("rate-malformed", 0, "probability of sending truncated JSON")
+	weightLimit := flag.Int("weight-limit", 6000, "request weight per minute before answering 429, 0 disables")
+	disconnect := flag.Duration("ws-disconnect", 0, "drop WebSocket connections after a random time up to this value")
+	flag.Parse()
+
+	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
+
+	var prices map[string]float64
+	var models map[string]Model
+	var err error
+	if *replayFile != "" {
+		prices, models, err = LoadReplayModels(*replayFile)
+	} else {
+		prices, models, err = buildModels(*symbolList, *model, *drift, *volatility)
+	}
+	if err != nil {
+		log.Fatalf("[FATAL] %v", err)
+	}
+
+	market := NewMarket(prices, models, *seed, *history, time.Now())
+	hub := NewHub(market, *disconnect)
+	server := NewServer(market, Faults{
+		Latency:       *latency,
+		Jitter:        *jitter,
+		Rate429:       *rate429,
+		Rate418:       *rate418,
+		Rate5xx:       *rate5xx,
+		RateMalformed: *rateMalformed,
+		WeightLimit:   *weightLimit,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		ticker := time.NewTicker(*tick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				market.Tick(now)
+			}
+		}
+	}()
+
+	srv := &http.Server{Addr: *addr, Handler: server.Routes(hub)}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Mock exchange started on %s with symbols %v", *addr, market.Symbols())
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("[FATAL] Server failed: %v", err)
+	}
+}
+
+// buildModels parses SYMBOL:price pairs and gives every symbol the selected model
+func buildModels(list, model string, drift, volatility float64) (map[string]float64, map[string]Model, error) {
+	prices := make(map[string]float64)
+	models := make(map[string]Model)
+	for _, pair := range strings.Split(list, ",") {
+		symbol, value, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		price, err := strconv.ParseFloat(value, 64)
+		if !ok || err != nil || price <= 0 {
+			return nil, nil, fmt.Errorf("invalid symbol spec %q, want SYMBOL:price", pair)
+		}
+		symbol = strings.ToUpper(symbol)
+		prices[symbol] = price
+
+		switch model {
+		case "gbm":
+			models[symbol] = GBM{Drift: drift, Volatility: volatility}
+		case "walk":
+			// Daily volatility scaled to one second, so both models take the same flag
+			sigma := price * volatility / 293.9 // sqrt(86400)
+			models[symbol] = RandomWalk{Sigma: sigma, Floor: price / 100}
+		default:
+			return nil, nil, fmt.Errorf("unknown model %q, want gbm or walk", model)
+		}
+	}
+	return prices, models, nil
+}
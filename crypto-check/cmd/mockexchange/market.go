@@ -0,0 +1,260 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"crypto-check/orderbook"
+)
+
+const secondsPerYear = 365 * 24 * 60 * 60
+
+// recentTrades is the number of trades per symbol served by /api/v3/aggTrades
+const recentTrades = 1000
+
+// Model generates the next price of a symbol after dt has passed
+type Model interface {
+	Next(price float64, dt time.Duration, rng *rand.Rand) float64
+}
+
+// GBM is geometric Brownian motion with annualized drift and volatility
+type GBM struct {
+	Drift      float64
+	Volatility float64
+}
+
+func (m GBM) Next(price float64, dt time.Duration, rng *rand.Rand) float64 {
+	years := dt.Seconds() / secondsPerYear
+	return price * math.Exp((m.Drift-m.Volatility*m.Volatility/2)*years+m.Volatility*math.Sqrt(years)*rng.NormFloat64())
+}
+
+// RandomWalk adds normally distributed steps, Sigma is the standard deviation per second
+type RandomWalk struct {
+	Sigma float64
+	Floor float64 // The price never goes below this
+}
+
+func (m RandomWalk) Next(price float64, dt time.Duration, rng *rand.Rand) float64 {
+	next := price + m.Sigma*math.Sqrt(dt.Seconds())*rng.NormFloat64()
+	return math.Max(next, m.Floor)
+}
+
+// Candle is a one minute kline
+type Candle struct {
+	OpenTime    time.Time
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64
+	QuoteVolume float64
+	Trades      int
+}
+
+// Trade is a synthetic trade generated on every tick
+type Trade struct {
+	ID           int64
+	Symbol       string
+	Price        float64
+	Quantity     float64
+	Time         time.Time
+	BuyerIsMaker bool
+}
+
+type symbolState struct {
+	symbol  string
+	price   float64
+	model   Model
+	candles []Candle // The last candle is still open
+	tradeID int64
+	trades  []Trade // The most recent, for /api/v3/aggTrades
+	book    *orderbook.Book
+}
+
+// Market holds the simulated state of every symbol
+type Market struct {
+	mu         sync.RWMutex
+	rng        *rand.Rand
+	symbols    map[string]*symbolState
+	order      []string
+	maxCandles int
+	lastTick   time.Time
+	onTrade    func(Trade)
+	onDepth    func(DepthUpdate)
+}
+
+// NewMarket creates the symbols and simulates history minutes of candles up to now
+func NewMarket(prices map[string]float64, models map[string]Model, seed uint64, history int, now time.Time) *Market {
+	m := &Market{
+		rng:        rand.New(rand.NewPCG(seed, seed)),
+		symbols:    make(map[string]*symbolState),
+		maxCandles: max(history, 1) + 7*24*60, // Keep up to a week of live candles on top of the history
+	}
+	for symbol, price := range prices {
+		m.symbols[symbol] = &symbolState{symbol: symbol, price: price, model: models[symbol]}
+		m.order = append(m.order, symbol)
+	}
+	sort.Strings(m.order)
+
+	// Six steps per minute give the candles a realistic high and low
+	start := now.Truncate(time.Minute).Add(-time.Duration(history) * time.Minute)
+	for t := start; t.Before(now); t = t.Add(10 * time.Second) {
+		m.step(t, 10*time.Second)
+	}
+	for _, s := range m.symbols {
+		m.rebook(s, now)
+	}
+	m.lastTick = now
+	return m
+}
+
+// OnTrade registers a callback for every generated trade. It must not block.
+func (m *Market) OnTrade(fn func(Trade)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onTrade = fn
+}
+
+// Tick moves all prices to now
+func (m *Market) Tick(now time.Time) {
+	m.mu.Lock()
+	dt := now.Sub(m.lastTick)
+	if dt <= 0 {
+		m.mu.Unlock()
+		return
+	}
+	trades := m.step(now, dt)
+	updates := make([]DepthUpdate, 0, len(m.order))
+	for _, symbol := range m.order {
+		updates = append(updates, m.rebook(m.symbols[symbol], now))
+	}
+	m.lastTick = now
+	onTrade, onDepth := m.onTrade, m.onDepth
+	m.mu.Unlock()
+
+	if onTrade != nil {
+		for _, trade := range trades {
+			onTrade(trade)
+		}
+	}
+	if onDepth != nil {
+		for _, update := range updates {
+			onDepth(update)
+		}
+	}
+}
+
+// step must be called with the lock held
+func (m *Market) step(now time.Time, dt time.Duration) []Trade {
+	trades := make([]Trade, 0, len(m.order))
+	for _, symbol := range m.order {
+		s := m.symbols[symbol]
+		s.price = s.model.Next(s.price, dt, m.rng)
+		s.tradeID++
+
+		// Quantities are sized around 1000 USDT per trade
+		quantity := math.Round(1000/s.price*(0.2+m.rng.ExpFloat64())*1e6) / 1e6
+		trade := Trade{ID: s.tradeID, Symbol: symbol, Price: s.price, Quantity: quantity, Time: now, BuyerIsMaker: m.rng.IntN(2) == 0}
+		trades = append(trades, trade)
+		s.trades = append(s.trades, trade)
+		if len(s.trades) > 2*recentTrades {
+			s.trades = slices.Clone(s.trades[len(s.trades)-recentTrades:])
+		}
+
+		openTime := now.Truncate(time.Minute)
+		if n := len(s.candles); n == 0 || s.candles[n-1].OpenTime.Before(openTime) {
+			s.candles = append(s.candles, Candle{OpenTime: openTime, Open: s.price, High: s.price, Low: s.price})
+			if len(s.candles) > m.maxCandles {
+				s.candles = s.candles[len(s.candles)-m.maxCandles:]
+			}
+		}
+		c := &s.candles[len(s.candles)-1]
+		c.High = math.Max(c.High, s.price)
+		c.Low = math.Min(c.Low, s.price)
+		c.Close = s.price
+		c.Volume += quantity
+		c.QuoteVolume += quantity * s.price
+		c.Trades++
+	}
+	return trades
+}
+
+// Symbols returns all symbols in alphabetical order
+func (m *Market) Symbols() []string {
+	return m.order // Never modified after NewMarket
+}
+
+func (m *Market) Price(symbol string) (float64, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.symbols[symbol]
+	if !ok {
+		return 0, false
+	}
+	return s.price, true
+}
+
+// Klines aggregates one minute candles into the interval. Zero start or end means unbounded.
+func (m *Market) Klines(symbol string, interval time.Duration, start, end time.Time, limit int) ([]Candle, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.symbols[symbol]
+	if !ok {
+		return nil, false
+	}
+
+	var result []Candle
+	for _, c := range s.candles {
+		openTime := c.OpenTime.Truncate(interval)
+		if !start.IsZero() && openTime.Before(start.Truncate(interval)) {
+			continue
+		}
+		if !end.IsZero() && openTime.After(end) {
+			break
+		}
+		if n := len(result); n > 0 && result[n-1].OpenTime.Equal(openTime) {
+			agg := &result[n-1]
+			agg.High = math.Max(agg.High, c.High)
+			agg.Low = math.Min(agg.Low, c.Low)
+			agg.Close = c.Close
+			agg.Volume += c.Volume
+			agg.QuoteVolume += c.QuoteVolume
+			agg.Trades += c.Trades
+			continue
+		}
+		c.OpenTime = openTime
+		result = append(result, c)
+	}
+
+	// Like Binance, without a start time the most recent candles are returned
+	if limit > 0 && len(result) > limit {
+		if start.IsZero() {
+			result = result[len(result)-limit:]
+		} else {
+			result = result[:limit]
+		}
+	}
+	return result, true
+}
+
+// Stats24h summarizes the last 24 hours of a symbol for the mini ticker stream
+func (m *Market) Stats24h(symbol string, now time.Time) (Candle, bool) {
+	candles, ok := m.Klines(symbol, time.Minute, now.Add(-24*time.Hour), time.Time{}, 0)
+	if !ok || len(candles) == 0 {
+		return Candle{}, false
+	}
+	stats := candles[0]
+	for _, c := range candles[1:] {
+		stats.High = math.Max(stats.High, c.High)
+		stats.Low = math.Min(stats.Low, c.Low)
+		stats.Close = c.Close
+		stats.Volume += c.Volume
+		stats.QuoteVolume += c.QuoteVolume
+		stats.Trades += c.Trades
+	}
+	return stats, true
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"crypto-check/exchange"
+	"crypto-check/orderbook"
+)
+
+func newTestMarket(t *testing.T) *Market {
+	t.Helper()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	prices := map[string]float64{"BTCUSDT": 65000, "ETHUSDT": 3500}
+	models := map[string]Model{"BTCUSDT": GBM{Volatility: 0.8}, "ETHUSDT": RandomWalk{Sigma: 1, Floor: 1}}
+	return NewMarket(prices, models, 42, 60, now)
+}
+
+func TestMarketKlines(t *testing.T) {
+	market := newTestMarket(t)
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		limit    int
+		want     int
+	}{
+		{"One minute candles", time.Minute, 0, 60},
+		{"Five minute candles", 5 * time.Minute, 0, 12},
+		{"Limit keeps the latest", time.Minute, 10, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candles, ok := market.Klines("BTCUSDT", tt.interval, time.Time{}, time.Time{}, tt.limit)
+			if !ok || len(candles) != tt.want {
+				t.Fatalf("got %d candles, want %d", len(candles), tt.want)
+			}
+			for _, c := range candles {
+				if c.High < c.Low || c.Open > c.High || c.Close < c.Low || c.Trades != int(tt.interval/(10*time.Second)) {
+					t.Errorf("inconsistent candle %+v", c)
+				}
+			}
+		})
+	}
+
+	if _, ok := market.Klines("XRPUSDT", time.Minute, time.Time{}, time.Time{}, 0); ok {
+		t.Error("expected unknown symbol to fail")
+	}
+}
+
+func TestMarketIsReproducible(t *testing.T) {
+	a, _ := newTestMarket(t).Price("BTCUSDT")
+	b, _ := newTestMarket(t).Price("BTCUSDT")
+	if a != b {
+		t.Errorf("same seed produced different prices: %f and %f", a, b)
+	}
+}
+
+func TestMarketDepthDiffs(t *testing.T) {
+	market := newTestMarket(t)
+	var updates []DepthUpdate
+	market.OnDepth(func(u DepthUpdate) {
+		if u.Symbol == "BTCUSDT" {
+			updates = append(updates, u)
+		}
+	})
+
+	// A client following the diffs from a snapshot ends up with the market's book
+	lastUpdateID, bids, asks, _ := market.Depth("BTCUSDT", bookLevels)
+	book := orderbook.New(lastUpdateID, bids, asks)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 1; i <= 20; i++ {
+		market.Tick(now.Add(time.Duration(i) * time.Second))
+	}
+	for _, u := range updates {
+		if err := book.Apply(u.FirstUpdateID, u.FinalUpdateID, u.Bids, u.Asks); err != nil {
+			t.Fatalf("Apply(%d, %d) error: %v", u.FirstUpdateID, u.FinalUpdateID, err)
+		}
+	}
+
+	lastUpdateID, bids, asks, _ = market.Depth("BTCUSDT", bookLevels)
+	if book.LastUpdateID() != lastUpdateID || !slices.Equal(book.Bids(), bids) || !slices.Equal(book.Asks(), asks) {
+		t.Errorf("book after %d diffs differs from the snapshot at %d", len(updates), lastUpdateID)
+	}
+	price, _ := market.Price("BTCUSDT")
+	if len(bids) != bookLevels || len(asks) != bookLevels || bids[0].Price > price || asks[0].Price <= price {
+		t.Errorf("best bid %v and ask %v around %f, want %d levels each", bids[0], asks[0], price, bookLevels)
+	}
+}
+
+func TestDepthHandler(t *testing.T) {
+	server := NewServer(newTestMarket(t), Faults{WeightLimit: 1000})
+	srv := httptest.NewServer(server.Routes(NewHub(server.market, 0)))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantLevels int
+		wantWeight string
+	}{
+		{"Default limit", "?symbol=BTCUSDT", http.StatusOK, bookLevels, "5"},
+		{"Small limit", "?symbol=BTCUSDT&limit=5", http.StatusOK, 5, "10"},
+		{"Large limit", "?symbol=BTCUSDT&limit=1000", http.StatusOK, bookLevels, "60"},
+		{"Unknown symbol", "?symbol=XRPUSDT", http.StatusBadRequest, 0, "65"},
+		{"Bad limit", "?symbol=BTCUSDT&limit=0", http.StatusBadRequest, 0, "66"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/api/v3/depth" + tt.query)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get("X-MBX-USED-WEIGHT-1M"); got != tt.wantWeight {
+				t.Errorf("used weight = %s, want %s", got, tt.wantWeight)
+			}
+			var depth struct {
+				Bids [][]string `json:"bids"`
+				Asks [][]string `json:"asks"`
+			}
+			json.Unmarshal(mustRead(t, resp), &depth)
+			if len(depth.Bids) != tt.wantLevels || len(depth.Asks) != tt.wantLevels {
+				t.Errorf("got %d bids and %d asks, want %d", len(depth.Bids), len(depth.Asks), tt.wantLevels)
+			}
+		})
+	}
+}
+
+func TestAggTradesHandler(t *testing.T) {
+	server := NewServer(newTestMarket(t), Faults{})
+	srv := httptest.NewServer(server.Routes(NewHub(server.market, 0)))
+	defer srv.Close()
+
+	// An hour of history has 360 trades per symbol
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantIDs    []int64 // First and last
+	}{
+		{"Latest", "?symbol=BTCUSDT", http.StatusOK, []int64{1, 360}},
+		{"Latest with a limit", "?symbol=BTCUSDT&limit=10", http.StatusOK, []int64{351, 360}},
+		{"From id", "?symbol=ETHUSDT&fromId=100&limit=5", http.StatusOK, []int64{100, 104}},
+		{"Unknown symbol", "?symbol=XRPUSDT", http.StatusBadRequest, nil},
+		{"Bad limit", "?symbol=BTCUSDT&limit=1001", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/api/v3/aggTrades" + tt.query)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+			var trades []struct {
+				ID int64 `json:"a"`
+			}
+			json.Unmarshal(mustRead(t, resp), &trades)
+			if len(trades) == 0 || trades[0].ID != tt.wantIDs[0] || trades[len(trades)-1].ID != tt.wantIDs[1] {
+				t.Errorf("got %d trades %v, want ids %d to %d", len(trades), trades, tt.wantIDs[0], tt.wantIDs[1])
+			}
+		})
+	}
+}
+
+func TestFuturesHandlers(t *testing.T) {
+	server := NewServer(newTestMarket(t), Faults{})
+	srv := httptest.NewServer(server.Routes(NewHub(server.market, 0)))
+	defer srv.Close()
+
+	// The collector's own client must understand the responses
+	futures := exchange.NewFutures(srv.URL, exchange.Options{Timeout: time.Second, MaxRetries: 1})
+	ctx := context.Background()
+	spot, _ := server.market.Price("BTCUSDT")
+	premium, err := futures.GetPremiumIndex(ctx, "BTCUSDT")
+	if err != nil {
+		t.Fatalf("GetPremiumIndex() error: %v", err)
+	}
+	if math.Abs(premium.IndexPrice-spot) > 1e-6 || math.Abs(premium.MarkPrice/spot-1) > premiumAmp+1e-4 ||
+		math.Abs(premium.FundingRate) > fundingCap || !premium.NextFundingTime.After(premium.Time) {
+		t.Errorf("GetPremiumIndex() = %+v with a spot price of %v", premium, spot)
+	}
+	interest, err := futures.GetOpenInterest(ctx, "BTCUSDT")
+	if err != nil || interest.Quantity <= 0 {
+		t.Errorf("GetOpenInterest() = %+v, %v", interest, err)
+	}
+
+	resp, err := http.Get(srv.URL + "/fapi/v1/premiumIndex?symbol=XRPUSDT")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown symbol status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestVenueHandlers(t *testing.T) {
+	server := NewServer(newTestMarket(t), Faults{})
+	srv := httptest.NewServer(server.Routes(NewHub(server.market, 0)))
+	defer srv.Close()
+
+	// The collector's own clients must understand the responses
+	opts := exchange.Options{APIURL: srv.URL + "/api/v3/ticker/price?symbol=", Timeout: time.Second, MaxRetries: 1}
+	venues := []exchange.Venue{exchange.NewBinance(opts)}
+	for _, name := range []string{exchange.VenueOKX, exchange.VenueBybit, exchange.VenueCoinbase} {
+		venue, err := exchange.NewVenue(name, srv.URL, opts)
+		if err != nil {
+			t.Fatalf("NewVenue() error: %v", err)
+		}
+		venues = append(venues, venue)
+	}
+
+	ctx := context.Background()
+	spot, _ := server.market.Price("BTCUSDT")
+	for _, venue := range venues {
+		t.Run(venue.Name(), func(t *testing.T) {
+			q, err := venue.GetQuote(ctx, "BTCUSDT")
+			if err != nil {
+				t.Fatalf("GetQuote() error: %v", err)
+			}
+			if math.Abs(q.Price/spot-1) > venueDrifts[venue.Name()].amplitude+1e-6 || q.Volume <= 0 || q.Time.IsZero() {
+				t.Errorf("GetQuote() = %+v with a simulated price of %v", q, spot)
+			}
+			if venue.Name() == exchange.VenueBinance {
+				return
+			}
+			if _, err := venue.GetQuote(ctx, "SOLUSDT"); !errors.Is(err, exchange.ErrNotListed) {
+				t.Errorf("GetQuote() of an unknown symbol error = %v, want ErrNotListed", err)
+			}
+		})
+	}
+}
+
+func TestReplayModelLoops(t *testing.T) {
+	model := &ReplayModel{points: []pricePoint{{0, 100}, {5 * time.Second, 105}, {10 * time.Second, 110}}}
+	rng := rand.New(rand.NewPCG(1, 1))
+
+	tests := []struct {
+		step time.Duration
+		want float64
+	}{
+		{4 * time.Second, 100},
+		{time.Second, 105},
+		{4 * time.Second, 105},
+		{time.Second, 100}, // Wrapped around at 10s
+	}
+	for _, tt := range tests {
+		if got := model.Next(0, tt.step, rng); got != tt.want {
+			t.Errorf("after %v elapsed %v: got %f, want %f", tt.step, model.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestTickerPriceHandler(t *testing.T) {
+	server := NewServer(newTestMarket(t), Faults{WeightLimit: 8})
+	srv := httptest.NewServer(server.Routes(NewHub(server.market, 0)))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantWeight string
+	}{
+		{"Single symbol", "?symbol=BTCUSDT", http.StatusOK, "2"},
+		{"Symbol list", `?symbols=["BTCUSDT","ETHUSDT"]`, http.StatusOK, "6"},
+		{"Unknown symbol", "?symbol=XRPUSDT", http.StatusBadRequest, "8"},
+		{"Weight limit exceeded", "", http.StatusTooManyRequests, "12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/api/v3/ticker/price" + tt.query)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get("X-MBX-USED-WEIGHT-1M"); got != tt.wantWeight {
+				t.Errorf("used weight = %s, want %s", got, tt.wantWeight)
+			}
+			if !json.Valid(mustRead(t, resp)) {
+				t.Error("response is not valid JSON")
+			}
+		})
+	}
+}
+
+func mustRead(t *testing.T, resp *http.Response) []byte {
+	t.Helper()
+	var body json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	return body
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"time"
+
+	"crypto-check/recording"
+)
+
+type pricePoint struct {
+	offset time.Duration // Since the first event of the recording
+	price  float64
+}
+
+// ReplayModel plays recorded prices back in a loop instead of generating them
+type ReplayModel struct {
+	points  []pricePoint
+	elapsed time.Duration
+}
+
+func (m *ReplayModel) Next(price float64, dt time.Duration, rng *rand.Rand) float64 {
+	m.elapsed += dt
+	length := m.points[len(m.points)-1].offset
+	if length <= 0 {
+		return m.points[0].price
+	}
+	at := m.elapsed % length
+	i := sort.Search(len(m.points), func(i int) bool { return m.points[i].offset > at })
+	return m.points[max(i-1, 0)].price
+}
+
+// LoadReplayModels extracts the price series of every symbol from a collector recording
+func LoadReplayModels(path string) (map[string]float64, map[string]Model, error) {
+	events, err := recording.Read(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	series := make(map[string][]pricePoint)
+	var start time.Time
+	for _, event := range events {
+		prices := extractPrices(event)
+		if len(prices) == 0 {
+			continue
+		}
+		if start.IsZero() {
+			start = event.Time
+		}
+		for symbol, price := range prices {
+			series[symbol] = append(series[symbol], pricePoint{offset: event.Time.Sub(start), price: price})
+		}
+	}
+	if len(series) == 0 {
+		return nil, nil, errors.New("recording contains no prices")
+	}
+
+	initial := make(map[string]float64, len(series))
+	models := make(map[string]Model, len(series))
+	for symbol, points := range series {
+		initial[symbol] = points[0].price
+		models[symbol] = &ReplayModel{points: points}
+	}
+	return initial, models, nil
+}
+
+// extractPrices understands ticker responses and trade or mini ticker frames
+func extractPrices(event recording.Event) map[string]float64 {
+	prices := make(map[string]float64)
+	body := []byte(event.Body)
+
+	switch event.Kind {
+	case recording.EventHTTP:
+		if event.Status != 200 {
+			return nil
+		}
+		var list []tickerPrice
+		if json.Unmarshal(body, &list) != nil {
+			var single tickerPrice
+			if json.Unmarshal(body, &single) != nil {
+				return nil
+			}
+			list = []tickerPrice{single}
+		}
+		for _, t := range list {
+			if p, err := strconv.ParseFloat(t.Price, 64); err == nil && t.Symbol != "" {
+				prices[t.Symbol] = p
+			}
+		}
+
+	case recording.EventWebSocket:
+		var frame struct {
+			Data   json.RawMessage `json:"data"` // Combined streams wrap the payload
+			Symbol string          `json:"s"`
+			Close  string          `json:"c"`
+			Price  string          `json:"p"`
+		}
+		if json.Unmarshal(body, &frame) != nil {
+			return nil
+		}
+		if len(frame.Data) > 0 {
+			return extractPrices(recording.Event{Kind: recording.EventWebSocket, Body: string(frame.Data)})
+		}
+		value := frame.Price
+		if value == "" {
+			value = frame.Close
+		}
+		if p, err := strconv.ParseFloat(value, 64); err == nil && frame.Symbol != "" {
+			prices[frame.Symbol] = p
+		}
+	}
+	return prices
+}
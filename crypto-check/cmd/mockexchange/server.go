@@ -0,0 +1,340 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Faults configures the failures injected into REST responses
+type Faults struct {
+	Latency       time.Duration // Added to every request
+	Jitter        time.Duration // Random extra latency up to this value
+	Rate429       float64       // Probability of a rate limit response
+	Rate418       float64       // Probability of an IP ban response
+	Rate5xx       float64       // Probability of an internal error
+	RateMalformed float64       // Probability of a truncated JSON body
+	WeightLimit   int           // Request weight per minute, 0 disables the limit
+}
+
+type tickerPrice struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+type apiError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// Server implements the subset of the Binance spot and futures REST APIs the collector uses
+type Server struct {
+	market *Market
+	faults Faults
+
+	mu          sync.Mutex
+	weightMin   time.Time
+	usedWeight  int
+	bannedUntil time.Time
+}
+
+func NewServer(market *Market, faults Faults) *Server {
+	return &Server{market: market, faults: faults}
+}
+
+func (s *Server) Routes(hub *Hub) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/api/v3/ticker/price", s.withFaults(s.tickerPriceHandler))
+	mux.Handle("/api/v3/klines", s.withFaults(s.klinesHandler))
+	mux.Handle("/api/v3/exchangeInfo", s.withFaults(s.exchangeInfoHandler))
+	mux.Handle("/api/v3/depth", s.withFaults(s.depthHandler))
+	mux.Handle("/api/v3/aggTrades", s.withFaults(s.aggTradesHandler))
+	mux.Handle("/api/v3/ticker/24hr", s.withFaults(s.ticker24hHandler))
+	mux.Handle("/fapi/v1/premiumIndex", s.withFaults(s.premiumIndexHandler))
+	mux.Handle("/fapi/v1/openInterest", s.withFaults(s.openInterestHandler))
+	// Other venues, quoting the same market slightly apart
+	mux.Handle("/api/v5/market/ticker", s.withFaults(s.okxTickerHandler))
+	mux.Handle("/v5/market/tickers", s.withFaults(s.bybitTickersHandler))
+	mux.Handle("/products/{product}/ticker", s.withFaults(s.coinbaseTickerHandler))
+	mux.HandleFunc("/ws/", hub.rawStreamHandler)
+	mux.HandleFunc("/stream", hub.combinedStreamHandler)
+	return mux
+}
+
+// withFaults adds latency, weight accounting and random failures to a handler
+func (s *Server) withFaults(next func(w http.ResponseWriter, r *http.Request) int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delay := s.faults.Latency
+		if s.faults.Jitter > 0 {
+			delay += rand.N(s.faults.Jitter)
+		}
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		now := time.Now()
+		s.mu.Lock()
+		if now.Before(s.bannedUntil) {
+			s.mu.Unlock()
+			s.reject(w, http.StatusTeapot, s.bannedUntil.Sub(now), "Way too many requests; IP banned.")
+			return
+		}
+		s.mu.Unlock()
+
+		switch p := rand.Float64(); {
+		case p < s.faults.Rate418:
+			s.mu.Lock()
+			s.bannedUntil = now.Add(2 * time.Minute)
+			s.mu.Unlock()
+			s.reject(w, http.StatusTeapot, 2*time.Minute, "Way too many requests; IP banned.")
+			return
+		case p < s.faults.Rate418+s.faults.Rate429:
+			s.reject(w, http.StatusTooManyRequests, time.Second, "Too many requests.")
+			return
+		case p < s.faults.Rate418+s.faults.Rate429+s.faults.Rate5xx:
+			s.reject(w, http.StatusInternalServerError, 0, "Internal error.")
+			return
+		}
+
+		// The handler reports its weight after validating the request, so count it afterwards
+		buffered := &weightedWriter{ResponseWriter: w, server: s}
+		weight := next(buffered, r)
+		buffered.flush(weight)
+	})
+}
+
+func (s *Server) reject(w http.ResponseWriter, status int, retryAfter time.Duration, msg string) {
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second).Seconds())))
+	}
+	log.Printf("[FAULT] Responding with %d", status)
+	writeJSON(w, status, apiError{Code: -1003, Msg: msg})
+}
+
+// addWeight counts weight for the current minute and reports whether the limit was exceeded
+func (s *Server) addWeight(weight int) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	minute := time.Now().Truncate(time.Minute)
+	if !minute.Equal(s.weightMin) {
+		s.weightMin = minute
+		s.usedWeight = 0
+	}
+	s.usedWeight += weight
+	return s.usedWeight, s.faults.WeightLimit > 0 && s.usedWeight > s.faults.WeightLimit
+}
+
+// weightedWriter holds the response back until the request weight is known
+type weightedWriter struct {
+	http.ResponseWriter
+	server *Server
+	status int
+	body   []byte
+}
+
+func (w *weightedWriter) WriteHeader(status int) { w.status = status }
+
+func (w *weightedWriter) Write(b []byte) (int, error) {
+	w.body = append(w.body, b...)
+	return len(b), nil
+}
+
+func (w *weightedWriter) flush(weight int) {
+	used, exceeded := w.server.addWeight(weight)
+	w.Header().Set("X-MBX-USED-WEIGHT-1M", strconv.Itoa(used))
+	if exceeded {
+		next := time.Now().Truncate(time.Minute).Add(time.Minute)
+		w.server.reject(w.ResponseWriter, http.StatusTooManyRequests, time.Until(next), "Too much request weight used.")
+		return
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	body := w.body
+	if w.status == http.StatusOK && rand.Float64() < w.server.faults.RateMalformed {
+		log.Printf("[FAULT] Sending malformed JSON")
+		body = body[:len(body)/2]
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("[ERROR] JSON encoding error: %v", err)
+	}
+}
+
+func invalidSymbol(w http.ResponseWriter) {
+	writeJSON(w, http.StatusBadRequest, apiError{Code: -1121, Msg: "Invalid symbol."})
+}
+
+// requestedSymbols reads either symbol=X or symbols=["X","Y"]. Nil means all symbols.
+func requestedSymbols(r *http.Request) ([]string, bool) {
+	q := r.URL.Query()
+	if symbol := q.Get("symbol"); symbol != "" {
+		return []string{symbol}, true
+	}
+	if raw := q.Get("symbols"); raw != "" {
+		var symbols []string
+		if err := json.Unmarshal([]byte(raw), &symbols); err != nil || len(symbols) == 0 {
+			return nil, false
+		}
+		return symbols, true
+	}
+	return nil, true
+}
+
+func (s *Server) tickerPriceHandler(w http.ResponseWriter, r *http.Request) int {
+	symbols, ok := requestedSymbols(r)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, apiError{Code: -1100, Msg: "Illegal characters found in parameter 'symbols'."})
+		return 2
+	}
+
+	single := r.URL.Query().Get("symbol") != ""
+	if symbols == nil {
+		symbols = s.market.Symbols()
+	}
+
+	result := make([]tickerPrice, 0, len(symbols))
+	for _, symbol := range symbols {
+		price, ok := s.market.Price(symbol)
+		if !ok {
+			invalidSymbol(w)
+			return 2
+		}
+		result = append(result, tickerPrice{Symbol: symbol, Price: formatPrice(price)})
+	}
+
+	if single {
+		writeJSON(w, http.StatusOK, result[0])
+		return 2
+	}
+	writeJSON(w, http.StatusOK, result)
+	return 4
+}
+
+var klineIntervals = map[string]time.Duration{
+	"1m": time.Minute, "3m": 3 * time.Minute, "5m": 5 * time.Minute, "15m": 15 * time.Minute,
+	"30m": 30 * time.Minute, "1h": time.Hour, "2h": 2 * time.Hour, "4h": 4 * time.Hour,
+	"6h": 6 * time.Hour, "8h": 8 * time.Hour, "12h": 12 * time.Hour, "1d": 24 * time.Hour,
+}
+
+func (s *Server) klinesHandler(w http.ResponseWriter, r *http.Request) int {
+	q := r.URL.Query()
+	interval, ok := klineIntervals[q.Get("interval")]
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, apiError{Code: -1120, Msg: "Invalid interval."})
+		return 2
+	}
+
+	limit := 500
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 1000 {
+			writeJSON(w, http.StatusBadRequest, apiError{Code: -1100, Msg: "Illegal characters found in parameter 'limit'."})
+			return 2
+		}
+		limit = n
+	}
+
+	var start, end time.Time
+	if v, err := strconv.ParseInt(q.Get("startTime"), 10, 64); err == nil {
+		start = time.UnixMilli(v)
+	}
+	if v, err := strconv.ParseInt(q.Get("endTime"), 10, 64); err == nil {
+		end = time.UnixMilli(v)
+	}
+
+	candles, ok := s.market.Klines(q.Get("symbol"), interval, start, end, limit)
+	if !ok {
+		invalidSymbol(w)
+		return 2
+	}
+
+	// Binance encodes klines as positional arrays with prices as strings
+	rows := make([][]any, 0, len(candles))
+	for _, c := range candles {
+		rows = append(rows, []any{
+			c.OpenTime.UnixMilli(),
+			formatPrice(c.Open), formatPrice(c.High), formatPrice(c.Low), formatPrice(c.Close),
+			formatQuantity(c.Volume),
+			c.OpenTime.Add(interval).UnixMilli() - 1,
+			formatQuantity(c.QuoteVolume),
+			c.Trades,
+			formatQuantity(c.Volume / 2), formatQuantity(c.QuoteVolume / 2), // Taker buy volumes
+			"0",
+		})
+	}
+	writeJSON(w, http.StatusOK, rows)
+	return 2
+}
+
+type symbolInfo struct {
+	Symbol     string `json:"symbol"`
+	Status     string `json:"status"`
+	BaseAsset  string `json:"baseAsset"`
+	QuoteAsset string `json:"quoteAsset"`
+}
+
+func (s *Server) exchangeInfoHandler(w http.ResponseWriter, r *http.Request) int {
+	symbols, ok := requestedSymbols(r)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, apiError{Code: -1100, Msg: "Illegal characters found in parameter 'symbols'."})
+		return 20
+	}
+	if symbols == nil {
+		symbols = s.market.Symbols()
+	}
+
+	infos := make([]symbolInfo, 0, len(symbols))
+	for _, symbol := range symbols {
+		if _, ok := s.market.Price(symbol); !ok {
+			invalidSymbol(w)
+			return 20
+		}
+		base, quote := splitSymbol(symbol)
+		infos = append(infos, symbolInfo{Symbol: symbol, Status: "TRADING", BaseAsset: base, QuoteAsset: quote})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"timezone":   "UTC",
+		"serverTime": time.Now().UnixMilli(),
+		"rateLimits": []map[string]any{
+			{"rateLimitType": "REQUEST_WEIGHT", "interval": "MINUTE", "intervalNum": 1, "limit": s.faults.WeightLimit},
+		},
+		"symbols": infos,
+	})
+	return 20
+}
+
+// splitSymbol guesses base and quote assets from the common quote suffixes
+func splitSymbol(symbol string) (string, string) {
+	for _, quote := range []string{"USDT", "USDC", "FDUSD", "BUSD", "TUSD", "EUR", "BTC", "ETH", "BNB"} {
+		if base, ok := strings.CutSuffix(symbol, quote); ok && base != "" {
+			return base, quote
+		}
+	}
+	return symbol, ""
+}
+
+func formatPrice(p float64) string {
+	return strconv.FormatFloat(p, 'f', 8, 64)
+}
+
+func formatQuantity(q float64) string {
+	return strconv.FormatFloat(q, 'f', 6, 64)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Hub fans market events out to WebSocket clients subscribed to Binance style streams
+// such as btcusdt@trade, btcusdt@aggTrade, btcusdt@miniTicker and btcusdt@depth.
+type Hub struct {
+	market     *Market
+	disconnect time.Duration // Drop every connection after a random time up to this, 0 keeps them open
+
+	mu      sync.Mutex
+	clients map[*streamClient]struct{}
+}
+
+type streamClient struct {
+	streams  map[string]bool
+	combined bool
+	send     chan []byte
+}
+
+func NewHub(market *Market, disconnect time.Duration) *Hub {
+	h := &Hub{market: market, disconnect: disconnect, clients: make(map[*streamClient]struct{})}
+	market.OnTrade(h.publishTrade)
+	market.OnDepth(h.publishDepth)
+	return h
+}
+
+func (h *Hub) rawStreamHandler(w http.ResponseWriter, r *http.Request) {
+	streams := strings.Split(strings.TrimPrefix(r.URL.Path, "/ws/"), "/")
+	h.serve(w, r, streams, false)
+}
+
+func (h *Hub) combinedStreamHandler(w http.ResponseWriter, r *http.Request) {
+	streams := strings.Split(r.URL.Query().Get("streams"), "/")
+	h.serve(w, r, streams, true)
+}
+
+func (h *Hub) serve(w http.ResponseWriter, r *http.Request, streams []string, combined bool) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("[ERROR] WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	client := &streamClient{streams: make(map[string]bool), combined: combined, send: make(chan []byte, 256)}
+	for _, s := range streams {
+		if s != "" {
+			client.streams[s] = true
+		}
+	}
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.clients, client)
+		h.mu.Unlock()
+	}()
+	log.Printf("[INFO] WebSocket client connected to %v", streams)
+
+	// Reads are only needed to notice when the client goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	var disconnect <-chan time.Time
+	if h.disconnect > 0 {
+		disconnect = time.After(h.disconnect/2 + rand.N(h.disconnect/2+1))
+	}
+
+	for {
+		select {
+		case msg := <-client.send:
+			conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		case <-disconnect:
+			log.Printf("[FAULT] Dropping WebSocket connection to %v", streams)
+			return
+		case <-closed:
+			return
+		}
+	}
+}
+
+// publishTrade is called by the market for every trade and also emits the mini ticker
+func (h *Hub) publishTrade(t Trade) {
+	lower := strings.ToLower(t.Symbol)
+	eventTime := t.Time.UnixMilli()
+	price := formatPrice(t.Price)
+	quantity := formatQuantity(t.Quantity)
+
+	h.publish(lower+"@trade", map[string]any{
+		"e": "trade", "E": eventTime, "s": t.Symbol, "t": t.ID,
+		"p": price, "q": quantity, "T": eventTime, "m": t.BuyerIsMaker, "M": true,
+	})
+	h.publish(lower+"@aggTrade", map[string]any{
+		"e": "aggTrade", "E": eventTime, "s": t.Symbol, "a": t.ID,
+		"p": price, "q": quantity, "f": t.ID, "l": t.ID, "T": eventTime, "m": t.BuyerIsMaker, "M": true,
+	})
+
+	if h.hasSubscribers(lower + "@miniTicker") {
+		if stats, ok := h.market.Stats24h(t.Symbol, t.Time); ok {
+			h.publish(lower+"@miniTicker", map[string]any{
+				"e": "24hrMiniTicker", "E": eventTime, "s": t.Symbol,
+				"c": formatPrice(stats.Close), "o": formatPrice(stats.Open),
+				"h": formatPrice(stats.High), "l": formatPrice(stats.Low),
+				"v": formatQuantity(stats.Volume), "q": formatQuantity(stats.QuoteVolume),
+			})
+		}
+	}
+}
+
+func (h *Hub) hasSubscribers(stream string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		if c.streams[stream] {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Hub) publish(stream string, payload any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var raw, wrapped []byte
+	for c := range h.clients {
+		if !c.streams[stream] {
+			continue
+		}
+		if raw == nil {
+			raw, _ = json.Marshal(payload)
+			wrapped, _ = json.Marshal(map[string]any{"stream": stream, "data": json.RawMessage(raw)})
+		}
+		msg := raw
+		if c.combined {
+			msg = wrapped
+		}
+		select {
+		case c.send <- msg:
+		default: // Slow client, drop the message like an overloaded exchange would
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// AggTrades returns up to limit of the recent trades of symbol starting at id fromID,
+// or the latest ones when fromID is 0. Older trades are no longer available.
+func (m *Market) AggTrades(symbol string, fromID int64, limit int) ([]Trade, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.symbols[symbol]
+	if !ok {
+		return nil, false
+	}
+	trades := s.trades[max(0, len(s.trades)-recentTrades):]
+	if fromID == 0 {
+		return append([]Trade(nil), trades[max(0, len(trades)-limit):]...), true
+	}
+	var result []Trade
+	for _, t := range trades {
+		if t.ID >= fromID && len(result) < limit {
+			result = append(result, t)
+		}
+	}
+	return result, true
+}
+
+func (s *Server) aggTradesHandler(w http.ResponseWriter, r *http.Request) int {
+	q := r.URL.Query()
+	limit := 500
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 1000 {
+			writeJSON(w, http.StatusBadRequest, apiError{Code: -1100, Msg: "Illegal characters found in parameter 'limit'."})
+			return 4
+		}
+		limit = n
+	}
+	var fromID int64
+	if v := q.Get("fromId"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			writeJSON(w, http.StatusBadRequest, apiError{Code: -1100, Msg: "Illegal characters found in parameter 'fromId'."})
+			return 4
+		}
+		fromID = n
+	}
+
+	trades, ok := s.market.AggTrades(q.Get("symbol"), fromID, limit)
+	if !ok {
+		invalidSymbol(w)
+		return 4
+	}
+	result := make([]map[string]any, len(trades))
+	for i, t := range trades {
+		result[i] = map[string]any{
+			"a": t.ID, "p": formatPrice(t.Price), "q": formatQuantity(t.Quantity),
+			"f": t.ID, "l": t.ID, "T": t.Time.UnixMilli(), "m": t.BuyerIsMaker, "M": true,
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+	return 4
+}
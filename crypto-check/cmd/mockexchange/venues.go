@@ -0,0 +1,114 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// venueDrift makes another exchange quote a symbol slightly off the simulated
+// price, in a slow cycle of its own, so cross-venue spreads open and close
+type venueDrift struct {
+	amplitude float64 // Of the price
+	period    time.Duration
+	share     float64 // Of the simulated 24 hour volume traded there
+}
+
+var venueDrifts = map[string]venueDrift{
+	"binance":  {0, time.Hour, 1},
+	"okx":      {0.0015, 17 * time.Minute, 0.4},
+	"bybit":    {0.002, 23 * time.Minute, 0.3},
+	"coinbase": {0.004, 41 * time.Minute, 0.2},
+}
+
+// VenueQuote is the price and 24 hour volume of symbol on venue at the last tick
+func (m *Market) VenueQuote(venue, symbol string) (price, volume float64, at time.Time, ok bool) {
+	m.mu.RLock()
+	s, ok := m.symbols[symbol]
+	if ok {
+		price, at = s.price, m.lastTick
+	}
+	m.mu.RUnlock()
+	if !ok {
+		return 0, 0, time.Time{}, false
+	}
+
+	drift := venueDrifts[venue]
+	phase := 2 * math.Pi * float64(at.UnixNano()%int64(drift.period)) / float64(drift.period)
+	price *= 1 + drift.amplitude*math.Sin(phase)
+	stats, _ := m.Stats24h(symbol, at)
+	return price, stats.Volume * drift.share, at, true
+}
+
+func (s *Server) ticker24hHandler(w http.ResponseWriter, r *http.Request) int {
+	symbol := r.URL.Query().Get("symbol")
+	price, volume, at, ok := s.market.VenueQuote("binance", symbol)
+	if !ok {
+		invalidSymbol(w)
+		return 2
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"symbol":    symbol,
+		"lastPrice": formatPrice(price),
+		"volume":    formatQuantity(volume),
+		"openTime":  at.Add(-24 * time.Hour).UnixMilli(),
+		"closeTime": at.UnixMilli(),
+	})
+	return 2
+}
+
+// okxTickerHandler serves /api/v5/market/ticker, which reports errors in the body
+func (s *Server) okxTickerHandler(w http.ResponseWriter, r *http.Request) int {
+	instrument := r.URL.Query().Get("instId")
+	price, volume, at, ok := s.market.VenueQuote("okx", strings.ReplaceAll(instrument, "-", ""))
+	if !ok {
+		writeJSON(w, http.StatusOK, map[string]any{"code": "51001", "msg": "Instrument ID does not exist", "data": []any{}})
+		return 1
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"code": "0", "msg": "", "data": []map[string]any{{
+		"instType": "SPOT",
+		"instId":   instrument,
+		"last":     formatPrice(price),
+		"vol24h":   formatQuantity(volume),
+		"ts":       strconv.FormatInt(at.UnixMilli(), 10),
+	}}})
+	return 1
+}
+
+// bybitTickersHandler serves /v5/market/tickers, which reports errors in the body
+func (s *Server) bybitTickersHandler(w http.ResponseWriter, r *http.Request) int {
+	symbol := r.URL.Query().Get("symbol")
+	price, volume, at, ok := s.market.VenueQuote("bybit", symbol)
+	if !ok {
+		writeJSON(w, http.StatusOK, map[string]any{"retCode": 10001, "retMsg": "Not supported symbols", "result": map[string]any{}, "time": time.Now().UnixMilli()})
+		return 1
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"retCode": 0,
+		"retMsg":  "OK",
+		"result": map[string]any{"category": "spot", "list": []map[string]any{{
+			"symbol":    symbol,
+			"lastPrice": formatPrice(price),
+			"volume24h": formatQuantity(volume),
+		}}},
+		"time": at.UnixMilli(),
+	})
+	return 1
+}
+
+// coinbaseTickerHandler serves /products/{product}/ticker
+func (s *Server) coinbaseTickerHandler(w http.ResponseWriter, r *http.Request) int {
+	price, volume, at, ok := s.market.VenueQuote("coinbase", strings.ReplaceAll(r.PathValue("product"), "-", ""))
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "NotFound"})
+		return 1
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"price":  formatPrice(price),
+		"volume": formatQuantity(volume),
+		"time":   at.UTC().Format(time.RFC3339Nano),
+	})
+	return 1
+}
@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/anomaly"
+)
+
+// DetectAnomalies scores every fetched price with engine, stores the anomalies
+// found and raises them as alerts. Call it before Run.
+func (m *Monitor) DetectAnomalies(engine *anomaly.Engine) {
+	m.anomalies = engine
+}
+
+func (m *Monitor) detectAnomalies(ctx context.Context, symbol string, price float64, at time.Time) {
+	// After a restart, resume from the stored prices instead of waiting for a new window
+	if !m.anomalies.Known(symbol) {
+		prices, err := m.store.RecentPrices(ctx, symbol, m.anomalies.ConfigFor(symbol).Window+1)
+		if err != nil {
+			log.Printf("[ERROR] [%s] Could not load prices for anomaly detection: %v", symbol, err)
+		}
+		m.anomalies.Warm(symbol, prices)
+	}
+
+	for _, e := range m.anomalies.Observe(symbol, price, at) {
+		if _, err := m.store.InsertAnomaly(ctx, e); err != nil {
+			log.Printf("[ERROR] [%s] Could not store anomaly: %v", symbol, err)
+		}
+		m.emitAlert(alerts.Alert{
+			Symbol:  symbol,
+			Kind:    alerts.KindAnomaly,
+			Price:   price,
+			Change:  e.Score,
+			Time:    at,
+			Message: "ANOMALY (" + e.Method + "): " + e.Explanation,
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/anomaly"
+	"crypto-check/clock"
+	"crypto-check/store"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// History from before a restart, small oscillations around 100
+	for i := 0; i < 40; i++ {
+		price := 100 + 0.05*math.Sin(float64(i))
+		if err := st.InsertPrice(ctx, "BTCUSDT", price, start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("InsertPrice() error: %v", err)
+		}
+	}
+
+	m := NewMonitor(st, fakeAnalytics{}, nil, clock.NewVirtual(start), 0, nil)
+	m.DetectAnomalies(anomaly.NewEngine(anomaly.Config{EWMALimit: -1}, nil))
+	var raised []alerts.Alert
+	m.OnAlert(func(a alerts.Alert) { raised = append(raised, a) })
+
+	// Scored right away thanks to the stored history
+	at := start.Add(time.Minute)
+	m.detectAnomalies(ctx, "BTCUSDT", 103, at)
+
+	events, err := st.Anomalies(ctx, "BTCUSDT", time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("Anomalies() error: %v", err)
+	}
+	if len(events) != 2 || len(raised) != 2 {
+		t.Fatalf("got %d stored and %d raised anomalies, want 2 of each (z-score and MAD)", len(events), len(raised))
+	}
+	for _, a := range raised {
+		if a.Kind != alerts.KindAnomaly || a.Change < 4 || !a.Time.Equal(at) {
+			t.Errorf("alert = %+v, want an upward anomaly at %v", a, at)
+		}
+	}
+
+	// The detector keeps its state, a return to the usual range is not an anomaly
+	raised = nil
+	m.detectAnomalies(ctx, "BTCUSDT", 103.02, at.Add(time.Second))
+	if len(raised) != 0 {
+		t.Errorf("got %d alerts for a small move, want none", len(raised))
+	}
+}
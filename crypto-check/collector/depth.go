@@ -0,0 +1,225 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	"crypto-check/orderbook"
+	"crypto-check/store"
+)
+
+const (
+	// maxPendingUpdates bounds the diffs buffered while a snapshot is fetched
+	maxPendingUpdates = 1000
+	depthRetryDelay   = 5 * time.Second
+)
+
+// DepthSource fetches order book snapshots, implemented by exchange.Binance
+type DepthSource interface {
+	GetDepth(ctx context.Context, symbol string, limit int) (exchange.DepthSnapshot, error)
+}
+
+// DepthCollector stores a snapshot of the order book of every symbol each interval.
+// With streams, a local book per symbol follows the diff depth stream from a REST
+// snapshot and is rebuilt whenever an update is missed. Without streams, a new
+// snapshot is fetched every interval.
+type DepthCollector struct {
+	store    *store.Store
+	source   DepthSource
+	streams  exchange.Streams // nil polls snapshots
+	clock    clock.Clock
+	interval time.Duration
+	limit    int
+	bands    []float64
+
+	mu    sync.Mutex
+	books map[string]*depthBook
+}
+
+// depthBook is the local book of a symbol
+type depthBook struct {
+	book    *orderbook.Book // nil until the first sync
+	syncing bool
+	pending []exchange.DepthUpdate // Received while syncing
+}
+
+// NewDepthCollector creates a collector storing bandsBPS depth bands, nil for
+// orderbook.DefaultBandsBPS, from snapshots of limit levels per side
+func NewDepthCollector(st *store.Store, source DepthSource, streams exchange.Streams, clk clock.Clock, interval time.Duration, limit int, bandsBPS []float64) *DepthCollector {
+	if bandsBPS == nil {
+		bandsBPS = orderbook.DefaultBandsBPS
+	}
+	return &DepthCollector{
+		store:    st,
+		source:   source,
+		streams:  streams,
+		clock:    clk,
+		interval: interval,
+		limit:    limit,
+		bands:    bandsBPS,
+		books:    make(map[string]*depthBook),
+	}
+}
+
+// Run collects the books of symbols until ctx is cancelled
+func (d *DepthCollector) Run(ctx context.Context, symbols []string) {
+	if d.streams != nil {
+		names := make([]string, len(symbols))
+		for i, symbol := range symbols {
+			names[i] = exchange.DepthStream(symbol)
+		}
+		go func() {
+			err := d.streams.Run(ctx, names, func() { d.connected(ctx, symbols) },
+				func(f exchange.Frame) { d.handle(ctx, f) })
+			if ctx.Err() == nil {
+				log.Printf("[ERROR] Depth stream stopped: %v", err)
+			}
+		}()
+	}
+
+	ticker := d.clock.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			for _, symbol := range symbols {
+				d.snapshot(ctx, symbol)
+			}
+		}
+	}
+}
+
+// snapshot stores the current state of the book of symbol
+func (d *DepthCollector) snapshot(ctx context.Context, symbol string) {
+	var book *orderbook.Book
+	if d.streams == nil {
+		depth, err := d.source.GetDepth(ctx, symbol, d.limit)
+		if err != nil {
+			log.Printf("[ERROR] [%s] Could not fetch the order book: %v", symbol, err)
+			return
+		}
+		book = orderbook.New(depth.LastUpdateID, depth.Bids, depth.Asks)
+	}
+
+	d.mu.Lock()
+	if book == nil {
+		if b := d.books[symbol]; b != nil && !b.syncing {
+			book = b.book
+		}
+	}
+	snap, ok := orderbook.Snapshot{}, false
+	if book != nil {
+		snap, ok = book.Snapshot(d.clock.Now(), d.bands)
+	}
+	d.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if err := d.store.InsertBookSnapshot(ctx, symbol, snap); err != nil {
+		log.Printf("[ERROR] [%s] Could not save the order book: %v", symbol, err)
+	}
+}
+
+// connected rebuilds every book, as updates may have been missed while disconnected
+func (d *DepthCollector) connected(ctx context.Context, symbols []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, symbol := range symbols {
+		b := d.books[symbol]
+		if b == nil {
+			b = &depthBook{}
+			d.books[symbol] = b
+		}
+		b.pending = nil
+		d.resyncLocked(ctx, symbol, b)
+	}
+}
+
+// handle applies a diff to its book, or buffers it while the book is syncing
+func (d *DepthCollector) handle(ctx context.Context, f exchange.Frame) {
+	update, err := exchange.ParseDepthUpdate(f.Data)
+	if err != nil {
+		log.Printf("[ERROR] Depth stream %s: %v", f.Stream, err)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	b := d.books[update.Symbol]
+	if b == nil {
+		return
+	}
+	if b.syncing {
+		b.pending = append(b.pending, update)
+		if len(b.pending) > maxPendingUpdates {
+			b.pending = b.pending[1:] // The sync will notice the gap and start over
+		}
+		return
+	}
+
+	err = b.book.Apply(update.FirstUpdateID, update.FinalUpdateID, update.Bids, update.Asks)
+	if errors.Is(err, orderbook.ErrGap) {
+		log.Printf("[WARNING] [%s] Order book missed updates %d to %d, resyncing",
+			update.Symbol, b.book.LastUpdateID()+1, update.FirstUpdateID-1)
+		b.pending = []exchange.DepthUpdate{update}
+		d.resyncLocked(ctx, update.Symbol, b)
+	}
+}
+
+// resyncLocked starts fetching a snapshot unless one is already on its way.
+// It must be called with the lock held.
+func (d *DepthCollector) resyncLocked(ctx context.Context, symbol string, b *depthBook) {
+	if b.syncing {
+		return
+	}
+	b.syncing = true
+	go d.sync(ctx, symbol)
+}
+
+// sync follows Binance's recipe for a local book: fetch a snapshot, drop the
+// buffered diffs it already contains and apply the rest, which must continue it
+// without a gap. Otherwise the snapshot is too old and a newer one is fetched.
+func (d *DepthCollector) sync(ctx context.Context, symbol string) {
+	for {
+		depth, err := d.source.GetDepth(ctx, symbol, d.limit)
+		if err == nil {
+			d.mu.Lock()
+			b := d.books[symbol]
+			book := orderbook.New(depth.LastUpdateID, depth.Bids, depth.Asks)
+			for _, u := range b.pending {
+				if err = book.Apply(u.FirstUpdateID, u.FinalUpdateID, u.Bids, u.Asks); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				b.book, b.syncing, b.pending = book, false, nil
+				// The stream applies diffs to the book as soon as the lock is released
+				synced := book.LastUpdateID()
+				d.mu.Unlock()
+				log.Printf("[INFO] [%s] Order book synced at update %d", symbol, synced)
+				return
+			}
+			d.mu.Unlock()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[WARNING] [%s] Order book sync failed: %v. Retrying in %v", symbol, err, depthRetryDelay)
+
+		timer := d.clock.NewTimer(depthRetryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+	}
+}
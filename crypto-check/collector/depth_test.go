@@ -0,0 +1,147 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	"crypto-check/orderbook"
+	"crypto-check/store"
+)
+
+// fakeDepth hands out the snapshots sent on its channel
+type fakeDepth chan exchange.DepthSnapshot
+
+func (f fakeDepth) GetDepth(ctx context.Context, symbol string, limit int) (exchange.DepthSnapshot, error) {
+	select {
+	case depth := <-f:
+		return depth, nil
+	case <-ctx.Done():
+		return exchange.DepthSnapshot{}, ctx.Err()
+	}
+}
+
+// manualStreams never delivers anything, the test calls connected and handle itself
+type manualStreams struct{}
+
+func (manualStreams) Run(ctx context.Context, streams []string, connected func(), handle func(exchange.Frame)) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func depthFrame(first, final int64, bid, ask string) exchange.Frame {
+	data := fmt.Sprintf(`{"e":"depthUpdate","E":1700000000000,"s":"BTCUSDT","U":%d,"u":%d,"b":[%s],"a":[%s]}`, first, final, bid, ask)
+	return exchange.Frame{Stream: "btcusdt@depth@100ms", Data: []byte(data)}
+}
+
+// waitSynced waits for the sync goroutine to rebuild the book of symbol
+func waitSynced(t *testing.T, d *DepthCollector, symbol string) *orderbook.Book {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		d.mu.Lock()
+		b := d.books[symbol]
+		synced := b != nil && !b.syncing
+		d.mu.Unlock()
+		if synced {
+			return b.book
+		}
+	}
+	t.Fatalf("book of %s not synced", symbol)
+	return nil
+}
+
+func TestDepthCollectorReconciles(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := make(fakeDepth)
+	d := NewDepthCollector(st, source, manualStreams{}, clock.NewVirtual(start), time.Second, 0, []float64{10})
+
+	d.connected(ctx, []string{"BTCUSDT"})
+	// Buffered while the snapshot is on its way, the first is already in it
+	d.handle(ctx, depthFrame(5, 6, `["99","7"]`, ""))
+	d.handle(ctx, depthFrame(7, 8, `["99.99","2"]`, `["100.01","0"]`))
+	source <- exchange.DepthSnapshot{
+		LastUpdateID: 6,
+		Bids:         []orderbook.Level{{Price: 99.98, Quantity: 1}},
+		Asks:         []orderbook.Level{{Price: 100.01, Quantity: 1}, {Price: 100.03, Quantity: 3}},
+	}
+	book := waitSynced(t, d, "BTCUSDT")
+	if book.LastUpdateID() != 8 {
+		t.Errorf("LastUpdateID() = %d, want 8", book.LastUpdateID())
+	}
+
+	d.handle(ctx, depthFrame(9, 9, "", `["100.02","1"]`))
+	d.snapshot(ctx, "BTCUSDT")
+	snaps, err := st.BookSnapshots(ctx, "BTCUSDT", time.Time{}, start.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("BookSnapshots() error: %v", err)
+	}
+	if len(snaps) != 1 {
+		t.Fatalf("got %d snapshots, want 1", len(snaps))
+	}
+	snap := snaps[0]
+	if snap.BestBid != 99.99 || snap.BestBidQuantity != 2 || snap.BestAsk != 100.02 || snap.BestAskQuantity != 1 {
+		t.Errorf("top of book = %+v, want 99.99 x 2 / 100.02 x 1", snap)
+	}
+	// 10 bps around the mid of 100.005 reaches 99.905 to 100.105, 99 is out
+	if band, ok := snap.Band(10); !ok || band.BidQuantity != 3 || band.AskQuantity != 4 {
+		t.Errorf("Band(10) = %+v, want 3 bid and 4 ask", band)
+	}
+
+	// A missed update drops the book until a newer snapshot arrives
+	d.handle(ctx, depthFrame(12, 12, "", ""))
+	d.snapshot(ctx, "BTCUSDT")
+	if snaps, _ := st.BookSnapshots(ctx, "BTCUSDT", time.Time{}, start.Add(time.Minute)); len(snaps) != 1 {
+		t.Errorf("got %d snapshots while resyncing, want 1", len(snaps))
+	}
+	source <- exchange.DepthSnapshot{
+		LastUpdateID: 12,
+		Bids:         []orderbook.Level{{Price: 99, Quantity: 1}},
+		Asks:         []orderbook.Level{{Price: 101, Quantity: 1}},
+	}
+	if book := waitSynced(t, d, "BTCUSDT"); book.LastUpdateID() != 12 || book.Bids()[0].Price != 99 {
+		t.Errorf("resynced book at %d with bids %v, want update 12 and the new snapshot", book.LastUpdateID(), book.Bids())
+	}
+}
+
+func TestDepthCollectorPolls(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	source := make(fakeDepth, 1)
+	d := NewDepthCollector(st, source, nil, clock.NewVirtual(start), time.Second, 0, nil)
+
+	source <- exchange.DepthSnapshot{
+		LastUpdateID: 1,
+		Bids:         []orderbook.Level{{Price: 99, Quantity: 1}},
+		Asks:         []orderbook.Level{{Price: 101, Quantity: 3}},
+	}
+	d.snapshot(ctx, "BTCUSDT")
+
+	snaps, err := st.BookSnapshots(ctx, "BTCUSDT", time.Time{}, start.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("BookSnapshots() error: %v", err)
+	}
+	if len(snaps) != 1 || snaps[0].Mid() != 100 || len(snaps[0].Bands) != len(orderbook.DefaultBandsBPS) {
+		t.Fatalf("snapshots = %+v, want one with mid 100 and the default bands", snaps)
+	}
+	if imbalance := snaps[0].Imbalance(); imbalance != -0.5 {
+		t.Errorf("Imbalance() = %v, want -0.5", imbalance)
+	}
+}
@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/exchange"
+	"crypto-check/indicators"
+	"crypto-check/store"
+)
+
+// DefaultFundingInterval is how often most perpetuals settle funding
+const DefaultFundingInterval = 8 * time.Hour
+
+// DerivativesSource fetches perpetual futures data, implemented by exchange.Futures
+type DerivativesSource interface {
+	GetPremiumIndex(ctx context.Context, symbol string) (exchange.PremiumIndex, error)
+	GetOpenInterest(ctx context.Context, symbol string) (exchange.OpenInterest, error)
+}
+
+// derivativesTracker samples the perpetual of every symbol next to its spot price
+type derivativesTracker struct {
+	source          DerivativesSource
+	interval        time.Duration
+	fundingInterval time.Duration
+	fundingAlert    float64         // Annualized percent, 0 disables the alert
+	extreme         map[string]bool // Funding was extreme at the previous sample, only used by the fetcher
+}
+
+// TrackDerivatives stores the mark price, index price, funding rate and open interest
+// of the perpetual of every symbol each interval, with the last spot price for the
+// basis. An annualized funding of fundingAlert percent or more raises an alert once
+// until it calms down. A fundingInterval of 0 is DefaultFundingInterval. Call it before Run.
+func (m *Monitor) TrackDerivatives(source DerivativesSource, interval, fundingInterval time.Duration, fundingAlert float64) {
+	if fundingInterval <= 0 {
+		fundingInterval = DefaultFundingInterval
+	}
+	m.derivatives = &derivativesTracker{
+		source:          source,
+		interval:        interval,
+		fundingInterval: fundingInterval,
+		fundingAlert:    fundingAlert,
+		extreme:         make(map[string]bool),
+	}
+}
+
+// fetchDerivatives samples the perpetuals of symbols every interval
+func (m *Monitor) fetchDerivatives(ctx context.Context, wg *sync.WaitGroup, symbols []string) {
+	defer wg.Done()
+
+	scheduler := NewScheduler("futures", m.derivatives.interval, m.clock)
+	scheduler.Run(ctx, func(ctx context.Context, tick Tick) {
+		for _, symbol := range symbols {
+			m.sampleDerivatives(ctx, symbol, tick.Scheduled)
+		}
+	})
+
+	logSchedulerStats("futures", "derivatives fetcher", scheduler.Stats())
+}
+
+// sampleDerivatives stores one sample of the perpetual of symbol and checks its funding
+func (m *Monitor) sampleDerivatives(ctx context.Context, symbol string, at time.Time) {
+	t := m.derivatives
+	premium, err := t.source.GetPremiumIndex(ctx, symbol)
+	if err != nil {
+		m.logFetchError(symbol, fmt.Errorf("premium index: %w", err))
+		return
+	}
+	interest, err := t.source.GetOpenInterest(ctx, symbol)
+	if err != nil {
+		m.logFetchError(symbol, fmt.Errorf("open interest: %w", err))
+		return
+	}
+	sample := store.Derivative{
+		Time:            at,
+		MarkPrice:       premium.MarkPrice,
+		IndexPrice:      premium.IndexPrice,
+		FundingRate:     premium.FundingRate,
+		NextFundingTime: premium.NextFundingTime,
+		OpenInterest:    interest.Quantity,
+	}
+	prices, err := m.store.RecentPrices(ctx, symbol, 1)
+	if err != nil {
+		log.Printf("[ERROR] [%s] Could not read the spot price: %v", symbol, err)
+	} else if len(prices) > 0 {
+		sample.SpotPrice = prices[0]
+	}
+	if err := m.store.InsertDerivative(ctx, symbol, sample); err != nil {
+		log.Printf("[ERROR] [%s] Database insert error: %v", symbol, err)
+	}
+
+	annualized := indicators.AnnualizedFunding(premium.FundingRate, t.fundingInterval)
+	log.Printf("[INFO] [%s] Mark: $%.2f | Basis: %+.1f bps | Funding: %+.4f%% (%+.2f%%/y) | OI: %.2f",
+		symbol, premium.MarkPrice, indicators.BasisBPS(premium.MarkPrice, sample.SpotPrice),
+		premium.FundingRate*100, annualized, interest.Quantity)
+
+	alert := alerts.CheckFunding(symbol, premium.MarkPrice, annualized, t.fundingAlert, at)
+	if alert != nil && !t.extreme[symbol] {
+		m.emitAlert(*alert)
+	}
+	t.extreme[symbol] = alert != nil
+}
@@ -0,0 +1,71 @@
+package collector
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	"crypto-check/store"
+)
+
+// fakeFutures returns the funding rate set by the test
+type fakeFutures struct {
+	funding float64
+}
+
+func (f *fakeFutures) GetPremiumIndex(ctx context.Context, symbol string) (exchange.PremiumIndex, error) {
+	return exchange.PremiumIndex{Symbol: symbol, MarkPrice: 60030, IndexPrice: 60010, FundingRate: f.funding}, nil
+}
+
+func (f *fakeFutures) GetOpenInterest(ctx context.Context, symbol string) (exchange.OpenInterest, error) {
+	return exchange.OpenInterest{Symbol: symbol, Quantity: 1500}, nil
+}
+
+func TestSampleDerivatives(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := st.InsertPrice(ctx, "BTCUSDT", 60000, start); err != nil {
+		t.Fatalf("InsertPrice() error: %v", err)
+	}
+
+	source := &fakeFutures{}
+	m := NewMonitor(st, fakeAnalytics{}, nil, clock.NewVirtual(start), 0, nil)
+	m.TrackDerivatives(source, time.Minute, 0, 50)
+	var raised []alerts.Alert
+	m.OnAlert(func(a alerts.Alert) { raised = append(raised, a) })
+
+	// 0.01% every 8 hours is 10.95% a year, then 0.05% is 54.75% twice, then back to normal and extreme again
+	fundings := []float64{0.0001, 0.0005, 0.0005, 0.0001, -0.0005}
+	for i, funding := range fundings {
+		source.funding = funding
+		m.sampleDerivatives(ctx, "BTCUSDT", start.Add(time.Duration(i+1)*time.Minute))
+	}
+
+	if len(raised) != 2 {
+		t.Fatalf("got %d alerts, want 2 (one per extreme episode)", len(raised))
+	}
+	if raised[0].Kind != alerts.KindFunding || raised[0].Change < 54.7 || raised[1].Change > -54.7 {
+		t.Errorf("alerts = %+v, want funding alerts at +54.75%% and -54.75%%", raised)
+	}
+
+	samples, err := st.Derivatives(ctx, "BTCUSDT", start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Derivatives() error: %v", err)
+	}
+	if len(samples) != len(fundings) {
+		t.Fatalf("got %d samples, want %d", len(samples), len(fundings))
+	}
+	if s := samples[0]; s.SpotPrice != 60000 || s.MarkPrice != 60030 || s.OpenInterest != 1500 || s.FundingRate != 0.0001 {
+		t.Errorf("first sample = %+v", s)
+	}
+}
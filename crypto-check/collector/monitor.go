@@ -0,0 +1,282 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/anomaly"
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+	"crypto-check/store"
+)
+
+// Monitor holds the dependencies shared by all price fetchers
+type Monitor struct {
+	store          *store.Store
+	analytics      analyticsv1.AnalyticsServiceClient
+	exchange       exchange.Client
+	clock          clock.Clock
+	alertThreshold float64
+	stream         chan<- string
+	onAlert        func(alerts.Alert)
+	onRSI          func(context.Context, RSIReading)
+	sigma          *sigmaAlerts        // Set by AlertOnSigma
+	anomalies      *anomaly.Engine     // Set by DetectAnomalies
+	derivatives    *derivativesTracker // Set by TrackDerivatives
+	venues         *venueTracker       // Set by CompareVenues
+	trendsMu       sync.Mutex
+	trends         map[string]signals.Trend // Last trend of every symbol, to alert once per rocket or crash
+}
+
+// RSIReading is the analytics result for a freshly stored price
+type RSIReading struct {
+	Symbol string
+	Price  float64
+	RSI    float64
+	Signal signals.RSI // As classified by GetRSI
+	Time   time.Time
+}
+
+// NewMonitor creates a monitor. Console lines are sent to stream if it is not nil,
+// so the channel must be drained while the monitor runs.
+func NewMonitor(st *store.Store, analytics analyticsv1.AnalyticsServiceClient, ex exchange.Client, clk clock.Clock, alertThreshold float64, stream chan<- string) *Monitor {
+	return &Monitor{
+		store:          st,
+		analytics:      analytics,
+		exchange:       ex,
+		clock:          clk,
+		alertThreshold: alertThreshold,
+		stream:         stream,
+		trends:         make(map[string]signals.Trend),
+	}
+}
+
+// OnAlert registers a callback for every alert, in addition to the log. Call it before Run.
+func (m *Monitor) OnAlert(fn func(alerts.Alert)) {
+	m.onAlert = fn
+}
+
+// OnRSI registers a callback for every RSI calculated after a fetch. Call it before Run.
+func (m *Monitor) OnRSI(fn func(context.Context, RSIReading)) {
+	m.onRSI = fn
+}
+
+// Run starts a fetcher per symbol or per interval group depending on the poll mode
+// and blocks until ctx is cancelled and all fetchers stopped
+func (m *Monitor) Run(ctx context.Context, config Config) {
+	var wg sync.WaitGroup
+	if config.PollMode == PollModeBatch {
+		for _, group := range GroupSymbolsByInterval(config) {
+			wg.Add(1)
+			go m.fetchBatch(ctx, &wg, group.Symbols, group.Interval)
+		}
+	} else {
+		for _, s := range config.Symbols {
+			wg.Add(1) // Increment WaitGroup counter for each goroutine
+			go m.fetchPrice(ctx, &wg, s, config.IntervalFor(s))
+		}
+	}
+	if m.derivatives != nil {
+		wg.Add(1)
+		go m.fetchDerivatives(ctx, &wg, config.DerivativeSymbols())
+	}
+	if m.venues != nil {
+		wg.Add(1)
+		go m.fetchVenues(ctx, &wg, config.Symbols)
+	}
+	wg.Wait() // Wait for all fetchers to finish
+}
+
+// fetchPrice polls a single symbol with its own request (per_symbol poll mode)
+func (m *Monitor) fetchPrice(ctx context.Context, wg *sync.WaitGroup, symbol string, interval int) {
+	defer wg.Done() // Ensure we signal when this goroutine is done
+	var lastPrice float64
+
+	scheduler := NewScheduler(symbol, time.Duration(interval)*time.Second, m.clock)
+	scheduler.Run(ctx, func(ctx context.Context, tick Tick) {
+		log.Printf("[DEBUG] [%s] Requesting price", symbol)
+
+		// The client retries transient failures with backoff, so here we only wait for the next slot
+		result, err := m.exchange.GetPrice(ctx, symbol)
+		if err != nil {
+			m.logFetchError(symbol, err)
+			return
+		}
+
+		m.processPrice(ctx, symbol, result.Price, tick.Scheduled, lastPrice)
+		lastPrice = result.Price
+	})
+
+	logSchedulerStats(symbol, "price fetcher", scheduler.Stats())
+}
+
+// fetchBatch polls a group of symbols sharing the same interval with one request (batch poll mode)
+func (m *Monitor) fetchBatch(ctx context.Context, wg *sync.WaitGroup, symbols []string, interval int) {
+	defer wg.Done()
+	name := strings.Join(symbols, ",")
+	lastPrices := make(map[string]float64, len(symbols))
+
+	scheduler := NewScheduler(name, time.Duration(interval)*time.Second, m.clock)
+	scheduler.Run(ctx, func(ctx context.Context, tick Tick) {
+		log.Printf("[DEBUG] [%s] Requesting %d prices", name, len(symbols))
+
+		results, err := m.exchange.GetPrices(ctx, symbols)
+		if err != nil {
+			m.logFetchError(name, err)
+			return
+		}
+
+		prices := make(map[string]float64, len(results))
+		for _, r := range results {
+			prices[r.Symbol] = r.Price
+		}
+
+		for _, symbol := range symbols {
+			price, found := prices[symbol]
+			if !found {
+				log.Printf("[WARNING] [%s] Missing from batch response", symbol)
+				continue
+			}
+			m.processPrice(ctx, symbol, price, tick.Scheduled, lastPrices[symbol])
+			lastPrices[symbol] = price
+		}
+	})
+
+	logSchedulerStats(name, "batch fetcher", scheduler.Stats())
+}
+
+func logSchedulerStats(name, kind string, stats SchedulerStats) {
+	log.Printf("[INFO] [%s] Stopping %s (runs: %d, missed slots: %d, max lag: %v)",
+		name, kind, stats.Runs, stats.MissedSlots, stats.MaxLag)
+}
+
+func (m *Monitor) logFetchError(name string, err error) {
+	if errors.Is(err, exchange.ErrCircuitOpen) {
+		log.Printf("[WARNING] [%s] Fetching paused: %v", name, err)
+		return
+	}
+	log.Printf("[ERROR] [%s] Fetch error: %v", name, err)
+}
+
+// processPrice stores a fetched price under its slot time and runs the analysis for it
+func (m *Monitor) processPrice(ctx context.Context, symbol string, currentPrice float64, fetchedAt time.Time, lastPrice float64) {
+	// Score against the stored prices before this one joins them
+	if m.anomalies != nil {
+		m.detectAnomalies(ctx, symbol, currentPrice, fetchedAt)
+	}
+
+	// Save price to database
+	if err := m.store.InsertPrice(ctx, symbol, currentPrice, fetchedAt); err != nil {
+		log.Printf("[ERROR] [%s] Database insert error: %v", symbol, err)
+	}
+
+	if alert := m.analyzePrice(ctx, symbol, currentPrice, fetchedAt); alert != nil {
+		m.emitAlert(*alert)
+	}
+
+	var rsiInfo string = "RSI: N/A"
+	analyticResp, err := m.analytics.GetRSI(ctx, &analyticsv1.GetRSIRequest{
+		Symbol: symbol,
+		Period: 14,
+	})
+
+	if err != nil {
+		log.Printf("[ERROR] [%s] gRPC Analytics error: %v", symbol, err)
+	} else {
+		signal, trend, thresholds := signalsOf(analyticResp)
+		rsiInfo = fmt.Sprintf("RSI: %.2f (%s %g/%g) | %s %+.2f%%", analyticResp.RsiValue, signal,
+			thresholds.Oversold, thresholds.Overbought, trend, analyticResp.ChangePercent)
+		if m.onRSI != nil {
+			m.onRSI(ctx, RSIReading{Symbol: symbol, Price: currentPrice, RSI: analyticResp.RsiValue, Signal: signal, Time: fetchedAt})
+		}
+		if m.trendChanged(symbol, trend) {
+			if alert := alerts.CheckTrend(symbol, currentPrice, analyticResp.ChangePercent, trend, thresholds, fetchedAt); alert != nil {
+				m.emitAlert(*alert)
+			}
+		}
+	}
+
+	if m.sigma != nil {
+		if alert := m.checkSigma(ctx, symbol, currentPrice, lastPrice, fetchedAt); alert != nil {
+			m.emitAlert(*alert)
+		}
+	}
+
+	status := "INITIAL"
+	if lastPrice != 0 {
+		if m.sigma == nil {
+			if alert := alerts.CheckVolatility(symbol, currentPrice, lastPrice, m.alertThreshold, fetchedAt); alert != nil {
+				m.emitAlert(*alert)
+			}
+		}
+		diff := currentPrice - lastPrice
+		if currentPrice > lastPrice {
+			status = fmt.Sprintf("UP (+$%.2f)", diff)
+		} else if currentPrice < lastPrice {
+			status = fmt.Sprintf("DOWN (-$%.2f)", -diff)
+		} else {
+			status = "STABLE"
+		}
+	}
+
+	msg := fmt.Sprintf("%-9s | $%10.2f | %-15s | %s", symbol, currentPrice, status, rsiInfo)
+	log.Printf("[INFO] %s", msg)
+	if m.stream != nil {
+		m.stream <- msg
+	}
+}
+
+// signalsOf converts the signals of a GetRSI response
+func signalsOf(res *analyticsv1.GetRSIResponse) (signals.RSI, signals.Trend, signals.Config) {
+	t := res.GetThresholds()
+	return signals.ParseRSI(res.Signal.String()), signals.ParseTrend(res.Trend.String()),
+		signals.Config{Oversold: t.GetOversold(), Overbought: t.GetOverbought(), Rocket: t.GetRocketPercent(), Crash: t.GetCrashPercent()}
+}
+
+// trendChanged records the trend of symbol and reports whether it differs from the last one
+func (m *Monitor) trendChanged(symbol string, trend signals.Trend) bool {
+	m.trendsMu.Lock()
+	defer m.trendsMu.Unlock()
+	last := m.trends[symbol]
+	m.trends[symbol] = trend
+	return trend != last
+}
+
+func (m *Monitor) emitAlert(alert alerts.Alert) {
+	if alert.Kind == alerts.KindVolatility || alert.Kind == alerts.KindSigma {
+		log.Printf("[WARNING] [%s] %s", alert.Symbol, alert.Message)
+	} else {
+		log.Printf("[ALERT] [%s] %s", alert.Symbol, alert.Message)
+	}
+	if m.onAlert != nil {
+		m.onAlert(alert)
+	}
+}
+
+// analyzePrice compares the price with the hourly average and returns an alert if it deviates too much
+func (m *Monitor) analyzePrice(ctx context.Context, symbol string, currentPrice float64, now time.Time) *alerts.Alert {
+	// Get average price for the last hour
+	avgHour, err := m.store.AveragePrice(ctx, symbol, now.Add(-time.Hour))
+	if err != nil {
+		log.Printf("[ERROR] [%s] Failed to get average: %v", symbol, err)
+		return nil
+	}
+
+	if avgHour == 0 {
+		return nil // No data available for analysis
+	}
+
+	// Print current price, average, and deviation
+	fmt.Printf("[%s] Cur: $%.2f | Avg1h: $%.2f | Dev: %.2f%%\n",
+		symbol, currentPrice, avgHour, ((currentPrice-avgHour)/avgHour)*100)
+
+	return alerts.CheckDeviation(symbol, currentPrice, avgHour, now)
+}
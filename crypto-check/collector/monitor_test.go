@@ -1,7 +1,8 @@
-package main
+package collector
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -26,6 +27,25 @@ func TestValidateConfig(t *testing.T) {
 	}
 }
 
+func TestGroupSymbolsByInterval(t *testing.T) {
+	config := Config{
+		Symbols:         []string{"BTCUSDT", "ETHUSDT", "SOLUSDT", "DOGEUSDT"},
+		UpdateInterval:  5,
+		SymbolIntervals: map[string]int{"DOGEUSDT": 60, "SOLUSDT": 1, "ETHUSDT": 0},
+	}
+
+	want := []SymbolGroup{
+		{Interval: 1, Symbols: []string{"SOLUSDT"}},
+		{Interval: 5, Symbols: []string{"BTCUSDT", "ETHUSDT"}}, // Zero override falls back to default
+		{Interval: 60, Symbols: []string{"DOGEUSDT"}},
+	}
+
+	got := GroupSymbolsByInterval(config)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupSymbolsByInterval() = %v, want %v", got, want)
+	}
+}
+
 func TestFormatDisplayPrice(t *testing.T) {
 	tests := []struct {
 		price float64
@@ -68,28 +88,6 @@ func TestCalculatePercentageDiff(t *testing.T) {
 	}
 }
 
-func TestGetPriceStatus(t *testing.T) {
-	tests := []struct {
-		name string
-		diff float64
-		want string
-	}{
-		{"High growth", 7.5, "ROCKET"},
-		{"Small growth", 2.1, "STABLE"},
-		{"Big drop", -10.2, "CRASH"},
-		{"Small drop", -1.5, "STABLE"},
-		{"Exactly five", 5.0, "ROCKET"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := GetPriceStatus(tt.diff); got != tt.want {
-				t.Errorf("GetPriceStatus(%f) = %v, want %v", tt.diff, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestSymbolJSONParsing(t *testing.T) {
 	// Imitating the JSON response from Binance API for a symbol price
 	jsonData := `{"symbol":"BTCUSDT","price":"65000.00"}`
@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"crypto-check/portfolio"
+	"crypto-check/signals"
+	"crypto-check/store"
+)
+
+// PaperTrader records simulated trades in the portfolio when the RSI signals.
+// It opens one position per symbol with a fixed amount on OVERSOLD and closes
+// it on OVERBOUGHT. Manual trades of the same symbol are left alone.
+type PaperTrader struct {
+	store   *store.Store
+	amount  float64
+	feeRate float64
+	mu      sync.Mutex // Signals of different fetchers must not interleave
+}
+
+func NewPaperTrader(st *store.Store, amount, feeRate float64) *PaperTrader {
+	return &PaperTrader{store: st, amount: amount, feeRate: feeRate}
+}
+
+// Observe is registered with Monitor.OnRSI
+func (p *PaperTrader) Observe(ctx context.Context, r RSIReading) {
+	if r.Signal != signals.RSIOversold && r.Signal != signals.RSIOverbought {
+		return
+	}
+	if r.Price <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	trades, err := p.store.Trades(ctx)
+	if err != nil {
+		log.Printf("[ERROR] [%s] Paper trader could not load trades: %v", r.Symbol, err)
+		return
+	}
+	paper, held := paperHoldings(trades, r.Symbol)
+
+	trade := portfolio.Trade{Symbol: r.Symbol, Price: r.Price, Time: r.Time, Source: portfolio.SourcePaper}
+	switch {
+	case r.Signal == signals.RSIOversold && paper == 0:
+		trade.Side = portfolio.SideBuy
+		trade.Quantity = p.amount / (r.Price * (1 + p.feeRate))
+	case r.Signal == signals.RSIOverbought && paper > 0:
+		trade.Side = portfolio.SideSell
+		trade.Quantity = min(paper, held) // Manual sells may have taken part of it already
+		if trade.Quantity <= 0 {
+			return
+		}
+	default:
+		return
+	}
+	trade.Fee = trade.Quantity * r.Price * p.feeRate
+
+	if _, err := p.store.InsertTrade(ctx, trade); err != nil {
+		log.Printf("[ERROR] [%s] Paper trade failed: %v", r.Symbol, err)
+		return
+	}
+	log.Printf("[INFO] [%s] Paper %s %.8f at $%.2f (RSI %.2f)", r.Symbol, trade.Side, trade.Quantity, r.Price, r.RSI)
+}
+
+// paperHoldings returns the quantity bought by the paper trader and still open,
+// and the total quantity held of symbol
+func paperHoldings(trades []portfolio.Trade, symbol string) (paper, held float64) {
+	for _, t := range trades {
+		if t.Symbol != symbol {
+			continue
+		}
+		q := t.Quantity
+		if t.Side == portfolio.SideSell {
+			q = -q
+		}
+		held += q
+		if t.Source == portfolio.SourcePaper {
+			paper += q
+		}
+	}
+	// Float sums of equal buys and sells rarely end at exactly 0
+	if paper < 1e-12 {
+		paper = 0
+	}
+	return paper, held
+}
@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/portfolio"
+	"crypto-check/signals"
+	"crypto-check/store"
+)
+
+func TestPaperTrader(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	trader := NewPaperTrader(st, 1000, 0)
+
+	// A manual holding of the same symbol is neither counted nor sold
+	if _, err := st.InsertTrade(ctx, portfolio.Trade{Symbol: "BTCUSDT", Side: portfolio.SideBuy, Quantity: 5, Price: 50, Time: start, Source: portfolio.SourceManual}); err != nil {
+		t.Fatalf("InsertTrade() error: %v", err)
+	}
+
+	readings := []struct {
+		signal signals.RSI
+		price  float64
+	}{
+		{signals.RSINeutral, 90},
+		{signals.RSIOverbought, 95}, // Nothing bought by the trader yet
+		{signals.RSIOversold, 100},
+		{signals.RSIOversold, 80}, // Already in a position
+		{signals.RSIOverbought, 125},
+		{signals.RSIOverbought, 130}, // Already closed
+	}
+	for i, r := range readings {
+		trader.Observe(ctx, RSIReading{Symbol: "BTCUSDT", Price: r.price, Signal: r.signal, Time: start.Add(time.Duration(i+1) * time.Minute)})
+	}
+
+	trades, err := st.Trades(ctx)
+	if err != nil {
+		t.Fatalf("Trades() error: %v", err)
+	}
+	want := []struct {
+		side     string
+		quantity float64
+		price    float64
+	}{
+		{portfolio.SideBuy, 5, 50},
+		{portfolio.SideBuy, 10, 100},
+		{portfolio.SideSell, 10, 125},
+	}
+	if len(trades) != len(want) {
+		t.Fatalf("got %d trades, want %d: %+v", len(trades), len(want), trades)
+	}
+	for i, w := range want {
+		got := trades[i]
+		if got.Side != w.side || math.Abs(got.Quantity-w.quantity) > 1e-9 || got.Price != w.price {
+			t.Errorf("trade %d = %+v, want %s %g at %g", i, got, w.side, w.quantity, w.price)
+		}
+	}
+}
+
+func TestPaperHoldings(t *testing.T) {
+	buy := func(q float64, source string) portfolio.Trade {
+		return portfolio.Trade{Symbol: "BTCUSDT", Side: portfolio.SideBuy, Quantity: q, Source: source}
+	}
+	sell := func(q float64, source string) portfolio.Trade {
+		return portfolio.Trade{Symbol: "BTCUSDT", Side: portfolio.SideSell, Quantity: q, Source: source}
+	}
+
+	tests := []struct {
+		name      string
+		trades    []portfolio.Trade
+		wantPaper float64
+		wantHeld  float64
+	}{
+		{"No trades", nil, 0, 0},
+		{"Open paper position", []portfolio.Trade{buy(2, portfolio.SourcePaper)}, 2, 2},
+		{"Closed paper position", []portfolio.Trade{buy(0.1, portfolio.SourcePaper), buy(0.2, portfolio.SourcePaper), sell(0.3, portfolio.SourcePaper)}, 0, 0},
+		{"Manual sell of paper holding", []portfolio.Trade{buy(2, portfolio.SourcePaper), sell(1.5, portfolio.SourceManual)}, 2, 0.5},
+		{"Other symbol", []portfolio.Trade{{Symbol: "ETHUSDT", Side: portfolio.SideBuy, Quantity: 1, Source: portfolio.SourcePaper}}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paper, held := paperHoldings(tt.trades, "BTCUSDT")
+			if paper != tt.wantPaper || math.Abs(held-tt.wantHeld) > 1e-9 {
+				t.Errorf("paperHoldings() = %g, %g, want %g, %g", paper, held, tt.wantPaper, tt.wantHeld)
+			}
+		})
+	}
+}
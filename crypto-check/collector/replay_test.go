@@ -0,0 +1,176 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/recording"
+	"crypto-check/store"
+
+	"google.golang.org/grpc"
+)
+
+type fakeAnalytics struct{}
+
+func (fakeAnalytics) GetRSI(ctx context.Context, in *analyticsv1.GetRSIRequest, opts ...grpc.CallOption) (*analyticsv1.GetRSIResponse, error) {
+	return &analyticsv1.GetRSIResponse{Symbol: in.Symbol, RsiValue: 50, Signal: analyticsv1.RSISignal_RSI_SIGNAL_NEUTRAL, Trend: analyticsv1.PriceTrend_PRICE_TREND_STABLE}, nil
+}
+
+func (fakeAnalytics) Backtest(ctx context.Context, in *analyticsv1.BacktestRequest, opts ...grpc.CallOption) (*analyticsv1.BacktestResponse, error) {
+	return &analyticsv1.BacktestResponse{Symbol: in.Symbol}, nil
+}
+
+func (fakeAnalytics) GetCorrelation(ctx context.Context, in *analyticsv1.GetCorrelationRequest, opts ...grpc.CallOption) (*analyticsv1.GetCorrelationResponse, error) {
+	return &analyticsv1.GetCorrelationResponse{Symbols: in.Symbols}, nil
+}
+
+// GetVolatility reports 50% a year, a standard deviation of ~0.0199% over 5 seconds
+func (fakeAnalytics) GetVolatility(ctx context.Context, in *analyticsv1.GetVolatilityRequest, opts ...grpc.CallOption) (*analyticsv1.GetVolatilityResponse, error) {
+	return &analyticsv1.GetVolatilityResponse{Symbol: in.Symbol, CloseToClose: 0.5}, nil
+}
+
+func (fakeAnalytics) Forecast(ctx context.Context, in *analyticsv1.ForecastRequest, opts ...grpc.CallOption) (*analyticsv1.ForecastResponse, error) {
+	return &analyticsv1.ForecastResponse{Symbol: in.Symbol}, nil
+}
+
+func (fakeAnalytics) EvaluateForecast(ctx context.Context, in *analyticsv1.EvaluateForecastRequest, opts ...grpc.CallOption) (*analyticsv1.EvaluateForecastResponse, error) {
+	return &analyticsv1.EvaluateForecastResponse{Symbol: in.Forecast.GetSymbol()}, nil
+}
+
+func (fakeAnalytics) GetLiquidity(ctx context.Context, in *analyticsv1.GetLiquidityRequest, opts ...grpc.CallOption) (*analyticsv1.GetLiquidityResponse, error) {
+	return &analyticsv1.GetLiquidityResponse{Symbol: in.Symbol}, nil
+}
+
+func (fakeAnalytics) GetVolume(ctx context.Context, in *analyticsv1.GetVolumeRequest, opts ...grpc.CallOption) (*analyticsv1.GetVolumeResponse, error) {
+	return &analyticsv1.GetVolumeResponse{Symbol: in.Symbol}, nil
+}
+
+func (fakeAnalytics) GetDerivatives(ctx context.Context, in *analyticsv1.GetDerivativesRequest, opts ...grpc.CallOption) (*analyticsv1.GetDerivativesResponse, error) {
+	return &analyticsv1.GetDerivativesResponse{Symbol: in.Symbol}, nil
+}
+
+// recordSession records three batch responses 5 seconds apart, each 100ms after its slot
+func recordSession(t *testing.T, path string, start time.Time) {
+	t.Helper()
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := n.Add(1)
+		fmt.Fprintf(w, `[{"symbol":"BTCUSDT","price":"%d.00"},{"symbol":"ETHUSDT","price":"%d.00"}]`, 60000+i, 3000+i)
+	}))
+	defer srv.Close()
+
+	clk := clock.NewVirtual(start)
+	recorder, err := recording.NewRecorder(path, clk.Now)
+	if err != nil {
+		t.Fatalf("NewRecorder() error: %v", err)
+	}
+	client := exchange.NewBinance(exchange.Options{APIURL: srv.URL + "/api/v3/ticker/price?symbol="})
+	client.SetTransport(recorder.Transport(http.DefaultTransport))
+
+	for i := 0; i < 3; i++ {
+		clk.AdvanceTo(start.Add(time.Duration(i)*5*time.Second + 100*time.Millisecond))
+		if _, err := client.GetPrices(context.Background(), []string{"BTCUSDT", "ETHUSDT"}); err != nil {
+			t.Fatalf("GetPrices() error: %v", err)
+		}
+	}
+	if err := recorder.RecordFrame("btcusdt@trade", []byte(`{"p":"60001.50"}`)); err != nil {
+		t.Fatalf("RecordFrame() error: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+}
+
+func TestRecordAndReplayThroughCollector(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "session.jsonl.gz")
+	recordSession(t, path, start)
+
+	events, err := recording.Read(path)
+	if err != nil {
+		t.Fatalf("recording.Read() error: %v", err)
+	}
+	if len(events) != 4 || events[0].Kind != recording.EventHTTP || events[3].Kind != recording.EventWebSocket {
+		t.Fatalf("unexpected recording: %+v", events)
+	}
+
+	replay, err := recording.OpenReplay(path, 0)
+	if err != nil {
+		t.Fatalf("OpenReplay() error: %v", err)
+	}
+
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ex := exchange.NewBinance(exchange.Options{APIURL: "http://replay.invalid/api/v3/ticker/price?symbol="})
+	ex.SetTransport(replay.Transport())
+	stream := make(chan string)
+	monitor := NewMonitor(st, fakeAnalytics{}, ex, replay.Clock(), 100, stream)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go monitor.fetchBatch(ctx, &wg, []string{"BTCUSDT", "ETHUSDT"}, 5)
+
+	frames := replay.Subscribe("btcusdt@trade")
+	received := make(chan string, 1)
+	go func() { received <- string(<-frames) }()
+
+	// Step-by-step: every slot produces one message per symbol
+	replay.Clock().BlockUntil(1)
+	for slot := 0; slot < 3; slot++ {
+		if !replay.Step() {
+			t.Fatalf("replay ended before slot %d", slot)
+		}
+		<-stream
+		<-stream
+	}
+	for replay.Step() {
+	}
+	cancel()
+	wg.Wait()
+
+	if frame := <-received; frame != `{"p":"60001.50"}` {
+		t.Errorf("replayed frame = %s", frame)
+	}
+
+	want := map[string][]store.Point{
+		"BTCUSDT": {
+			{Symbol: "BTCUSDT", Price: 60001, Time: start},
+			{Symbol: "BTCUSDT", Price: 60002, Time: start.Add(5 * time.Second)},
+			{Symbol: "BTCUSDT", Price: 60003, Time: start.Add(10 * time.Second)},
+		},
+		"ETHUSDT": {
+			{Symbol: "ETHUSDT", Price: 3001, Time: start},
+			{Symbol: "ETHUSDT", Price: 3002, Time: start.Add(5 * time.Second)},
+			{Symbol: "ETHUSDT", Price: 3003, Time: start.Add(10 * time.Second)},
+		},
+	}
+	for symbol, points := range want {
+		got, err := st.History(context.Background(), symbol, start.Add(-time.Hour), time.Time{})
+		if err != nil {
+			t.Fatalf("History() error: %v", err)
+		}
+		if len(got) != len(points) {
+			t.Fatalf("%s: stored %d rows, want %d", symbol, len(got), len(points))
+		}
+		for i, p := range points {
+			if got[i].Price != p.Price || !got[i].Time.Equal(p.Time) {
+				t.Errorf("%s row %d = %.2f %s, want %.2f %s", symbol, i, got[i].Price, got[i].Time, p.Price, p.Time)
+			}
+		}
+	}
+}
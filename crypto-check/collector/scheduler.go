@@ -0,0 +1,121 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"crypto-check/clock"
+)
+
+// Tick describes one scheduled run
+type Tick struct {
+	Scheduled time.Time // Boundary the run belongs to
+	Actual    time.Time // When the run actually started
+	Missed    int       // Slots skipped since the previous run
+}
+
+func (t Tick) Lag() time.Duration {
+	return t.Actual.Sub(t.Scheduled)
+}
+
+// SchedulerStats summarizes how well a schedule keeps up
+type SchedulerStats struct {
+	Runs          int
+	MissedSlots   int
+	LastScheduled time.Time
+	LastActual    time.Time
+	MaxLag        time.Duration
+}
+
+// Scheduler runs work on wall-clock boundaries that are multiples of the
+// interval (every :00, :05, :10... for 5s), so all symbols sharing an
+// interval get the same timestamps no matter how long each fetch takes.
+type Scheduler struct {
+	name     string
+	interval time.Duration
+	clock    clock.Clock
+
+	mu    sync.Mutex
+	stats SchedulerStats
+}
+
+func NewScheduler(name string, interval time.Duration, clk clock.Clock) *Scheduler {
+	return &Scheduler{name: name, interval: interval, clock: clk}
+}
+
+// NextBoundary returns the first multiple of interval at or after t
+func NextBoundary(t time.Time, interval time.Duration) time.Time {
+	boundary := t.Truncate(interval)
+	if boundary.Before(t) {
+		boundary = boundary.Add(interval)
+	}
+	return boundary
+}
+
+// Run calls fn on every boundary until ctx is cancelled. Runs never overlap:
+// if fn takes longer than the interval the slots in between are skipped and reported.
+func (s *Scheduler) Run(ctx context.Context, fn func(ctx context.Context, tick Tick)) {
+	now := s.clock.Now()
+	scheduled := NextBoundary(now, s.interval)
+
+	// Wait for the first boundary, then let a ticker keep the phase
+	timer := s.clock.NewTimer(scheduled.Sub(now))
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return
+	case <-timer.C():
+	}
+
+	ticker := s.clock.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	missed := 0
+	for {
+		tick := Tick{Scheduled: scheduled, Actual: s.clock.Now(), Missed: missed}
+		s.record(tick)
+		fn(ctx, tick)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+
+		// Tickers drop ticks while fn is busy, so work out the current slot from the clock
+		next := scheduled.Add(s.interval)
+		current := s.clock.Now().Truncate(s.interval)
+		if current.Before(next) {
+			current = next
+		}
+		missed = int(current.Sub(next) / s.interval)
+		if missed > 0 {
+			log.Printf("[WARNING] [%s] Missed %d slot(s) after %s, the previous run took too long",
+				s.name, missed, scheduled.Format("15:04:05"))
+		}
+		scheduled = current
+	}
+}
+
+func (s *Scheduler) record(tick Tick) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Runs++
+	s.stats.MissedSlots += tick.Missed
+	s.stats.LastScheduled = tick.Scheduled
+	s.stats.LastActual = tick.Actual
+	if lag := tick.Lag(); lag > s.stats.MaxLag {
+		s.stats.MaxLag = lag
+	}
+	log.Printf("[DEBUG] [%s] Run for %s started %v late", s.name, tick.Scheduled.Format("15:04:05"), tick.Lag())
+}
+
+// Stats returns a snapshot of the schedule statistics
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
@@ -0,0 +1,104 @@
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"crypto-check/clock"
+)
+
+func TestNextBoundary(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		now      time.Time
+		interval time.Duration
+		want     time.Time
+	}{
+		{"Exactly on boundary", base, 5 * time.Second, base},
+		{"Just after boundary", base.Add(time.Millisecond), 5 * time.Second, base.Add(5 * time.Second)},
+		{"Middle of slot", base.Add(3 * time.Second), 5 * time.Second, base.Add(5 * time.Second)},
+		{"Minute interval", base.Add(61 * time.Second), time.Minute, base.Add(2 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextBoundary(tt.now, tt.interval); !got.Equal(tt.want) {
+				t.Errorf("NextBoundary() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedulerAlignsAndReportsMissedSlots(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clk := clock.NewVirtual(base.Add(3200 * time.Millisecond))
+	scheduler := NewScheduler("test", 5*time.Second, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticks := make(chan Tick)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Run(ctx, func(ctx context.Context, tick Tick) {
+			if tick.Scheduled.Equal(base.Add(10 * time.Second)) {
+				clk.Advance(12 * time.Second) // Simulate a run that overruns two slots
+			}
+			ticks <- tick
+		})
+	}()
+
+	clk.BlockUntil(1)
+	clk.Advance(1800 * time.Millisecond)
+	if tick := <-ticks; !tick.Scheduled.Equal(base.Add(5*time.Second)) || tick.Missed != 0 {
+		t.Fatalf("first tick = %+v, want aligned to :05 with no missed slots", tick)
+	}
+
+	clk.Advance(5 * time.Second)
+	if tick := <-ticks; !tick.Scheduled.Equal(base.Add(10 * time.Second)) {
+		t.Fatalf("second tick scheduled at %s, want :10", tick.Scheduled)
+	}
+
+	// The slow run ended at :22, so :15 was skipped and the next run belongs to :20
+	tick := <-ticks
+	if !tick.Scheduled.Equal(base.Add(20*time.Second)) || tick.Missed != 1 {
+		t.Fatalf("third tick = %+v, want scheduled at :20 with 1 missed slot", tick)
+	}
+	if tick.Lag() != 2*time.Second {
+		t.Errorf("Lag() = %v, want 2s", tick.Lag())
+	}
+
+	cancel()
+	<-done
+
+	stats := scheduler.Stats()
+	if stats.Runs != 3 || stats.MissedSlots != 1 || stats.MaxLag != 2*time.Second {
+		t.Errorf("Stats() = %+v, want 3 runs, 1 missed slot, 2s max lag", stats)
+	}
+}
+
+func TestSchedulerStopsImmediately(t *testing.T) {
+	clk := clock.NewVirtual(time.Date(2024, 1, 1, 12, 0, 1, 0, time.UTC))
+	scheduler := NewScheduler("test", time.Minute, clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Run(ctx, func(ctx context.Context, tick Tick) {
+			t.Error("run should not start before the first boundary")
+		})
+	}()
+
+	clk.BlockUntil(1)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop on cancel")
+	}
+}
@@ -0,0 +1,97 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/exchange"
+	"crypto-check/venues"
+)
+
+// venueTracker quotes every symbol on several venues and compares them
+type venueTracker struct {
+	venues     []exchange.Venue
+	aggregator *venues.Aggregator
+	wide       map[string]bool // The spread was alerted on at the previous round, only used by the fetcher
+}
+
+// CompareVenues quotes every symbol on each of vs every interval of config, and
+// stores the reference price over the venues with the spread between them. A
+// spread of spread_alert_bps or more raises an alert once until it narrows.
+// Call it before Run.
+func (m *Monitor) CompareVenues(vs []exchange.Venue, config venues.Config) {
+	m.venues = &venueTracker{venues: vs, aggregator: venues.NewAggregator(config), wide: make(map[string]bool)}
+}
+
+// fetchVenues quotes symbols on every venue each interval
+func (m *Monitor) fetchVenues(ctx context.Context, wg *sync.WaitGroup, symbols []string) {
+	defer wg.Done()
+
+	interval := time.Duration(m.venues.aggregator.Config().Interval) * time.Second
+	scheduler := NewScheduler("venues", interval, m.clock)
+	scheduler.Run(ctx, func(ctx context.Context, tick Tick) {
+		for _, symbol := range symbols {
+			m.compareVenues(ctx, symbol, tick.Scheduled)
+		}
+	})
+
+	logSchedulerStats("venues", "venue fetcher", scheduler.Stats())
+}
+
+// compareVenues quotes symbol on every venue at once, then stores and checks the spread
+func (m *Monitor) compareVenues(ctx context.Context, symbol string, at time.Time) {
+	t := m.venues
+	quotes := make([]venues.Quote, len(t.venues))
+	var wg sync.WaitGroup
+	for i, v := range t.venues {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q, err := v.GetQuote(ctx, symbol)
+			if err != nil {
+				quotes[i] = venues.Quote{Venue: v.Name(), Error: err.Error()}
+				return
+			}
+			quotes[i] = venues.Quote{Venue: v.Name(), Price: q.Price, Volume: q.Volume, Updated: q.Time}
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return // Shutting down, the failures are not the venues' fault
+	}
+
+	spread := t.aggregator.Aggregate(symbol, quotes, at)
+	if err := m.store.InsertSpread(ctx, spread); err != nil {
+		log.Printf("[ERROR] [%s] Database insert error: %v", symbol, err)
+	}
+
+	var healthy, outliers, stale []string
+	for _, q := range spread.Quotes {
+		switch {
+		case q.Stale:
+			stale = append(stale, q.Venue)
+		case q.Outlier:
+			outliers = append(outliers, fmt.Sprintf("%s %+.0f bps", q.Venue, q.DeviationBPS))
+		default:
+			healthy = append(healthy, q.Venue)
+		}
+	}
+	msg := fmt.Sprintf("[%s] Reference: $%.2f (%s of %s) | Spread: %.1f bps", symbol, spread.Reference, spread.Method, strings.Join(healthy, ", "), spread.SpreadBPS)
+	if len(outliers) > 0 || len(stale) > 0 {
+		log.Printf("[WARNING] %s | Outliers: %s | Stale: %s", msg, strings.Join(outliers, ", "), strings.Join(stale, ", "))
+	} else {
+		log.Printf("[INFO] %s", msg)
+	}
+
+	threshold := t.aggregator.Config().SpreadAlertBPS
+	alert := alerts.CheckSpread(symbol, spread.Reference, spread.SpreadBPS, threshold, spread.Low, spread.High, at)
+	if alert != nil && !t.wide[symbol] {
+		m.emitAlert(*alert)
+	}
+	t.wide[symbol] = alert != nil
+}
@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	"crypto-check/store"
+	"crypto-check/venues"
+)
+
+// fakeVenue quotes the price set by the test, or fails without one
+type fakeVenue struct {
+	name  string
+	price float64
+}
+
+func (v *fakeVenue) Name() string { return v.name }
+
+func (v *fakeVenue) GetQuote(ctx context.Context, symbol string) (exchange.Quote, error) {
+	if v.price == 0 {
+		return exchange.Quote{}, errors.New("symbol not listed")
+	}
+	return exchange.Quote{Venue: v.name, Symbol: symbol, Price: v.price, Volume: 10}, nil
+}
+
+func TestCompareVenues(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	binance, okx, bybit := &fakeVenue{name: "binance"}, &fakeVenue{name: "okx"}, &fakeVenue{name: "bybit"}
+	m := NewMonitor(st, fakeAnalytics{}, nil, clock.NewVirtual(start), 0, nil)
+	m.CompareVenues([]exchange.Venue{binance, okx, bybit}, venues.Config{SpreadAlertBPS: 50, OutlierBPS: 500})
+	var raised []alerts.Alert
+	m.OnAlert(func(a alerts.Alert) { raised = append(raised, a) })
+
+	// Bybit does not list the symbol. The spread widens past 50 bps twice in a row,
+	// narrows, then widens again.
+	rounds := [][2]float64{{100, 100.1}, {100, 100.6}, {100.1, 100.8}, {100, 100.2}, {100.2, 99.5}}
+	for i, prices := range rounds {
+		binance.price, okx.price = prices[0], prices[1]
+		m.compareVenues(ctx, "BTCUSDT", start.Add(time.Duration(i)*10*time.Second))
+	}
+
+	if len(raised) != 2 {
+		t.Fatalf("got %d alerts, want 2 (one per wide episode)", len(raised))
+	}
+	if raised[0].Kind != alerts.KindSpread || raised[0].Change < 59 || raised[1].Change < 70 {
+		t.Errorf("alerts = %+v, want spread alerts of 60 and 70 bps", raised)
+	}
+
+	latest, err := st.LatestSpreads(ctx, "BTCUSDT")
+	if err != nil || len(latest) != 1 {
+		t.Fatalf("LatestSpreads() = %v, %v", latest, err)
+	}
+	sp := latest[0]
+	if sp.Low != "okx" || sp.High != "binance" || len(sp.Quotes) != 3 || !sp.Quotes[2].Stale || sp.Quotes[2].Error == "" {
+		t.Errorf("latest spread = %+v, want okx below binance and bybit stale", sp)
+	}
+}
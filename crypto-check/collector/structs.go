@@ -0,0 +1,53 @@
+package collector
+
+import (
+	"crypto-check/anomaly"
+	"crypto-check/api"
+	"crypto-check/auth"
+	"crypto-check/venues"
+)
+
+// Poll modes: one request per symbol, or one request per group of symbols with the same interval
+const (
+	PollModePerSymbol = "per_symbol"
+	PollModeBatch     = "batch"
+)
+
+type Config struct {
+	ApiUrl          string                    `json:"api_url"`
+	Symbols         []string                  `json:"symbols"`
+	UpdateInterval  int                       `json:"update_interval"`
+	SymbolIntervals map[string]int            `json:"symbol_intervals"` // Optional per-symbol override of UpdateInterval
+	PollMode        string                    `json:"poll_mode"`        // PollModePerSymbol (default) or PollModeBatch
+	AlertThreshold  float64                   `json:"alert_threshold"`
+	AlertSigma      float64                   `json:"alert_sigma"`  // Alert on moves of this many standard deviations instead of alert_threshold dollars, 0 disables it
+	HTTPTimeout     int                       `json:"http_timeout"` // Seconds
+	MaxRetries      int                       `json:"max_retries"`
+	WeightLimit     int                       `json:"weight_limit"`     // Request weight per minute before pausing
+	RecordFile      string                    `json:"record_file"`      // Capture raw exchange responses to this gzip file
+	ReplayFile      string                    `json:"replay_file"`      // Feed a recording instead of calling the exchange
+	ReplaySpeed     float64                   `json:"replay_speed"`     // 1 is the original pace, 10 ten times faster, 0 steps on every Enter
+	PaperAmount     float64                   `json:"paper_amount"`     // Quote currency the paper trader spends per RSI buy signal, 0 disables it
+	PaperFeeRate    float64                   `json:"paper_fee_rate"`   // Fee charged on paper trades as a fraction of the traded value
+	Anomaly         *anomaly.Config           `json:"anomaly"`          // Anomaly detection on every fetched price, absent disables it
+	SymbolAnomaly   map[string]anomaly.Config `json:"symbol_anomaly"`   // Optional per-symbol override of the non-zero fields of Anomaly
+	DepthInterval   int                       `json:"depth_interval"`   // Seconds between order book snapshots, 0 disables them
+	DepthLimit      int                       `json:"depth_limit"`      // Levels per side of REST snapshots, 0 for the exchange default
+	DepthBandsBPS   []float64                 `json:"depth_bands_bps"`  // Depth measured within these distances of the mid price, in basis points
+	StreamURL       string                    `json:"stream_url"`       // WebSocket base URL keeping order books and trades live, empty polls the REST API instead
+	TradeInterval   int                       `json:"trade_interval"`   // Seconds between storing the collected trades, 0 disables trade collection
+	FuturesURL      string                    `json:"futures_url"`      // Perpetual futures API, empty for Binance USDⓈ-M futures
+	FuturesSymbols  []string                  `json:"futures_symbols"`  // Perpetuals to track, empty tracks all symbols
+	FuturesInterval int                       `json:"futures_interval"` // Seconds between funding, mark price and open interest samples, 0 disables them
+	FundingInterval int                       `json:"funding_interval"` // Hours between funding payments, 0 for 8
+	FundingAlert    float64                   `json:"funding_alert"`    // Alert when the annualized funding reaches this percent either way, 0 disables it
+	Venues          *venues.Config            `json:"venues"`           // Compare prices across exchanges, absent disables it
+	Auth            *auth.Config              `json:"auth"`             // API keys for the dashboard and the API, absent leaves them open
+	HTTP            api.ServerConfig          `json:"http"`             // Timeouts, rate and size limits of the web server
+}
+
+// SymbolGroup is a set of symbols fetched together on the same interval
+type SymbolGroup struct {
+	Interval int
+	Symbols  []string
+}
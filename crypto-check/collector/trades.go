@@ -0,0 +1,211 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	"crypto-check/store"
+)
+
+const (
+	// maxBackfillPages bounds the trades fetched to fill a gap, older ones are given up on
+	maxBackfillPages = 10
+	// maxPendingTrades bounds the trades kept per symbol while the store fails, the oldest are dropped
+	maxPendingTrades = 20000
+)
+
+// TradeSource fetches aggregate trades, implemented by exchange.Binance
+type TradeSource interface {
+	GetAggTrades(ctx context.Context, symbol string, fromID int64, limit int) ([]exchange.AggTrade, error)
+}
+
+// TradeCollector adds the aggregate trades of every symbol to the stored volumes.
+// With streams, trades arrive on the aggTrade streams and the ones missed while
+// disconnected are fetched from the REST API. Without streams, new trades are
+// fetched every interval. Trades are buffered and stored every interval.
+type TradeCollector struct {
+	store    *store.Store
+	source   TradeSource
+	streams  exchange.Streams // nil polls trades
+	clock    clock.Clock
+	interval time.Duration
+
+	mu      sync.Mutex
+	last    map[string]int64 // Id of the last trade buffered per symbol, 0 when unknown
+	pending map[string][]store.MarketTrade
+}
+
+func NewTradeCollector(st *store.Store, source TradeSource, streams exchange.Streams, clk clock.Clock, interval time.Duration) *TradeCollector {
+	return &TradeCollector{
+		store:    st,
+		source:   source,
+		streams:  streams,
+		clock:    clk,
+		interval: interval,
+		last:     make(map[string]int64),
+		pending:  make(map[string][]store.MarketTrade),
+	}
+}
+
+// Run collects the trades of symbols until ctx is cancelled, storing the buffered ones on the way out
+func (c *TradeCollector) Run(ctx context.Context, symbols []string) {
+	c.resume(ctx, symbols)
+	if c.streams != nil {
+		names := make([]string, len(symbols))
+		for i, symbol := range symbols {
+			names[i] = exchange.AggTradeStream(symbol)
+		}
+		go func() {
+			err := c.streams.Run(ctx, names, func() { c.connected(ctx, symbols) },
+				func(f exchange.Frame) { c.handle(ctx, f) })
+			if ctx.Err() == nil {
+				log.Printf("[ERROR] Trade stream stopped: %v", err)
+			}
+		}()
+	}
+
+	ticker := c.clock.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush(context.WithoutCancel(ctx))
+			return
+		case <-ticker.C():
+			if c.streams == nil {
+				for _, symbol := range symbols {
+					c.backfill(ctx, symbol, 0)
+				}
+			}
+			c.flush(ctx)
+		}
+	}
+}
+
+// resume continues after the last stored trade, so a restart leaves no gap
+func (c *TradeCollector) resume(ctx context.Context, symbols []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, symbol := range symbols {
+		id, err := c.store.LastTradeID(ctx, symbol)
+		if err != nil {
+			log.Printf("[ERROR] [%s] Could not read the last stored trade: %v", symbol, err)
+		}
+		c.last[symbol] = id
+	}
+}
+
+// connected fetches the trades missed since the last one received
+func (c *TradeCollector) connected(ctx context.Context, symbols []string) {
+	for _, symbol := range symbols {
+		c.backfill(ctx, symbol, 0)
+	}
+}
+
+// handle buffers a streamed trade, filling the gap first if trades were skipped
+func (c *TradeCollector) handle(ctx context.Context, f exchange.Frame) {
+	trade, err := exchange.ParseAggTrade(f.Data)
+	if err != nil {
+		log.Printf("[ERROR] Trade stream %s: %v", f.Stream, err)
+		return
+	}
+
+	c.mu.Lock()
+	last, known := c.last[trade.Symbol]
+	c.mu.Unlock()
+	if !known || trade.ID <= last {
+		return // Not subscribed, or already fetched by a backfill
+	}
+	if last > 0 && trade.ID > last+1 {
+		log.Printf("[WARNING] [%s] Trade stream skipped trades %d to %d, fetching them", trade.Symbol, last+1, trade.ID-1)
+		c.backfill(ctx, trade.Symbol, trade.ID-1)
+	}
+	c.buffer(trade.Symbol, []exchange.AggTrade{trade})
+}
+
+// backfill fetches the trades of symbol after the last one buffered, up to id until
+// or until caught up when it is 0. Without any trade yet, only the latest are fetched.
+func (c *TradeCollector) backfill(ctx context.Context, symbol string, until int64) {
+	for page := 0; page < maxBackfillPages; page++ {
+		c.mu.Lock()
+		from := c.last[symbol] + 1
+		c.mu.Unlock()
+		if until > 0 && from > until {
+			return
+		}
+		if from == 1 {
+			from = 0 // Unknown, the latest page sets it
+		}
+
+		trades, err := c.source.GetAggTrades(ctx, symbol, from, exchange.MaxTradesLimit)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("[ERROR] [%s] Could not fetch trades from %d: %v", symbol, from, err)
+			}
+			return
+		}
+		if until > 0 {
+			for i, t := range trades {
+				if t.ID > until {
+					trades = trades[:i]
+					break
+				}
+			}
+		}
+		c.buffer(symbol, trades)
+		if from == 0 || len(trades) < exchange.MaxTradesLimit {
+			return
+		}
+	}
+	log.Printf("[WARNING] [%s] Still behind after %d pages of trades, giving up on the older ones", symbol, maxBackfillPages)
+	c.mu.Lock()
+	c.last[symbol] = 0 // The next backfill starts from the latest trades
+	c.mu.Unlock()
+}
+
+// buffer queues trades newer than the last one of their symbol
+func (c *TradeCollector) buffer(symbol string, trades []exchange.AggTrade) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range trades {
+		if t.ID <= c.last[symbol] {
+			continue
+		}
+		c.last[symbol] = t.ID
+		c.pending[symbol] = append(c.pending[symbol], store.MarketTrade{
+			ID:           t.ID,
+			Price:        t.Price,
+			Quantity:     t.Quantity,
+			Time:         t.Time,
+			BuyerIsMaker: t.BuyerIsMaker,
+		})
+	}
+}
+
+// flush stores the buffered trades. Trades that could not be saved go back in
+// front of the buffer for the next flush, last has already moved past them so
+// no backfill would fetch them again. Past maxPendingTrades the oldest are dropped.
+func (c *TradeCollector) flush(ctx context.Context) {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = make(map[string][]store.MarketTrade)
+	c.mu.Unlock()
+
+	for symbol, trades := range pending {
+		if err := c.store.AddTrades(ctx, symbol, trades); err != nil {
+			log.Printf("[ERROR] [%s] Could not save %d trades, retrying on the next flush: %v", symbol, len(trades), err)
+			c.mu.Lock()
+			retry := append(trades, c.pending[symbol]...)
+			if dropped := len(retry) - maxPendingTrades; dropped > 0 {
+				log.Printf("[WARNING] [%s] Dropping the %d oldest unsaved trades, keeping %d", symbol, dropped, maxPendingTrades)
+				retry = retry[dropped:]
+			}
+			c.pending[symbol] = retry
+			c.mu.Unlock()
+		}
+	}
+}
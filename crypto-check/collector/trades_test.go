@@ -0,0 +1,164 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"crypto-check/clock"
+	"crypto-check/exchange"
+	"crypto-check/store"
+)
+
+// fakeTrades serves consecutive trades 1 to n of BTCUSDT, one second apart
+type fakeTrades struct {
+	mu    sync.Mutex
+	start time.Time
+	n     int64
+	calls []int64 // fromID of every request
+}
+
+func (f *fakeTrades) trade(id int64) exchange.AggTrade {
+	return exchange.AggTrade{ID: id, Symbol: "BTCUSDT", Price: 100, Quantity: 1, Time: f.start.Add(time.Duration(id) * time.Second), BuyerIsMaker: id%2 == 0}
+}
+
+func (f *fakeTrades) GetAggTrades(ctx context.Context, symbol string, fromID int64, limit int) ([]exchange.AggTrade, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fromID)
+	first := fromID
+	if first == 0 {
+		first = max(1, f.n-int64(limit)+1)
+	}
+	var trades []exchange.AggTrade
+	for id := first; id <= f.n && len(trades) < limit; id++ {
+		trades = append(trades, f.trade(id))
+	}
+	return trades, nil
+}
+
+func tradeFrame(f *fakeTrades, id int64) exchange.Frame {
+	t := f.trade(id)
+	data := fmt.Sprintf(`{"e":"aggTrade","E":%d,"s":"BTCUSDT","a":%d,"p":"100","q":"1","T":%d,"m":%t,"M":true}`,
+		t.Time.UnixMilli(), id, t.Time.UnixMilli(), t.BuyerIsMaker)
+	return exchange.Frame{Stream: "btcusdt@aggTrade", Data: []byte(data)}
+}
+
+func TestTradeCollector(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	symbols := []string{"BTCUSDT"}
+
+	tests := []struct {
+		name      string
+		stored    int64 // Trades stored before a restart
+		available int64 // On the exchange when connecting
+		streamed  []int64
+		wantCalls []int64
+		wantFirst int64
+		wantLast  int64
+	}{
+		{"First start keeps the latest page", 0, 1500, []int64{1501}, []int64{0}, 501, 1501},
+		{"Restart fills the gap page by page", 10, 2600, []int64{2601}, []int64{11, 1011, 2011}, 1, 2601},
+		{"Skipped trades are fetched", 10, 20, []int64{21, 25, 23, 26}, []int64{11, 22}, 1, 26},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+			if err != nil {
+				t.Fatalf("store.Open() error: %v", err)
+			}
+			defer st.Close()
+			ctx := context.Background()
+
+			source := &fakeTrades{start: start, n: tt.available}
+			var stored []store.MarketTrade
+			for id := int64(1); id <= tt.stored; id++ {
+				tr := source.trade(id)
+				stored = append(stored, store.MarketTrade{ID: id, Price: tr.Price, Quantity: tr.Quantity, Time: tr.Time, BuyerIsMaker: tr.BuyerIsMaker})
+			}
+			if err := st.AddTrades(ctx, "BTCUSDT", stored); err != nil {
+				t.Fatalf("AddTrades() error: %v", err)
+			}
+
+			c := NewTradeCollector(st, source, manualStreams{}, clock.NewVirtual(start), time.Second)
+			c.resume(ctx, symbols)
+			c.connected(ctx, symbols)
+			for _, id := range tt.streamed {
+				source.mu.Lock()
+				source.n = max(source.n, id+1) // The exchange is always ahead of the stream
+				source.mu.Unlock()
+				c.handle(ctx, tradeFrame(source, id))
+			}
+			c.flush(ctx)
+
+			if fmt.Sprint(source.calls) != fmt.Sprint(tt.wantCalls) {
+				t.Errorf("requests from ids %v, want %v", source.calls, tt.wantCalls)
+			}
+			if last, err := st.LastTradeID(ctx, "BTCUSDT"); err != nil || last != tt.wantLast {
+				t.Errorf("LastTradeID() = %d, %v, want %d", last, err, tt.wantLast)
+			}
+			volumes, err := st.Volumes(ctx, "BTCUSDT", start.Add(-time.Hour), start.Add(24*time.Hour))
+			if err != nil {
+				t.Fatalf("Volumes() error: %v", err)
+			}
+			var total store.Volume
+			for _, v := range volumes {
+				total.Add(v)
+			}
+			// Every trade stored exactly once, half of them bought by takers
+			want := tt.wantLast - tt.wantFirst + 1
+			if total.Trades != int(want) || total.Volume != float64(want) || total.BuyVolume < float64(want/2) || total.BuyVolume > float64(want/2+1) {
+				t.Errorf("stored %d trades with volume %v and %v bought, want %d", total.Trades, total.Volume, total.BuyVolume, want)
+			}
+		})
+	}
+}
+
+func TestTradeCollectorFlushRetries(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+	ctx := context.Background()
+
+	source := &fakeTrades{start: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), n: 10}
+	c := NewTradeCollector(st, source, manualStreams{}, clock.NewVirtual(source.start), time.Second)
+	c.resume(ctx, []string{"BTCUSDT"})
+	c.buffer("BTCUSDT", []exchange.AggTrade{source.trade(1), source.trade(2)})
+
+	// The store fails the first flush, the trades are kept for the next one
+	failed, cancel := context.WithCancel(ctx)
+	cancel()
+	c.flush(failed)
+	c.buffer("BTCUSDT", []exchange.AggTrade{source.trade(3)})
+	c.flush(ctx)
+
+	volumes, err := st.Volumes(ctx, "BTCUSDT", source.start.Add(-time.Hour), source.start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Volumes() error: %v", err)
+	}
+	var total store.Volume
+	for _, v := range volumes {
+		total.Add(v)
+	}
+	if total.Trades != 3 || total.LastTradeID != 3 {
+		t.Errorf("stored %d trades up to %d, want 3 up to 3", total.Trades, total.LastTradeID)
+	}
+
+	// While the store keeps failing, only the newest maxPendingTrades are kept
+	more := make([]exchange.AggTrade, maxPendingTrades+5)
+	for i := range more {
+		more[i] = source.trade(int64(i) + 4)
+	}
+	c.buffer("BTCUSDT", more[:10])
+	c.flush(failed)
+	c.buffer("BTCUSDT", more[10:])
+	c.flush(failed)
+	if kept := c.pending["BTCUSDT"]; len(kept) != maxPendingTrades || kept[0].ID != 9 || kept[len(kept)-1].ID != more[len(more)-1].ID {
+		t.Errorf("kept %d trades, want the %d newest from 9", len(kept), maxPendingTrades)
+	}
+}
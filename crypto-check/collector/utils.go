@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"crypto-check/exchange"
+)
+
+func LoadConfig(fileName string) (Config, error) {
+	var config Config
+	configFile, err := os.Open(fileName)
+	if err != nil {
+		return config, err
+	}
+	defer configFile.Close()
+	err = json.NewDecoder(configFile).Decode(&config)
+	return config, err
+}
+
+func ValidateConfig(symbols []string, interval int) bool {
+	if len(symbols) == 0 {
+		return false // List of symbols cannot be empty
+	}
+	if interval <= 0 {
+		return false // Interval must be a positive integer
+	}
+	return true
+}
+
+// IntervalFor returns the update interval of a symbol in seconds
+func (c Config) IntervalFor(symbol string) int {
+	if interval, ok := c.SymbolIntervals[symbol]; ok && interval > 0 {
+		return interval
+	}
+	return c.UpdateInterval
+}
+
+// DerivativeSymbols returns the symbols whose perpetual futures are tracked
+func (c Config) DerivativeSymbols() []string {
+	if len(c.FuturesSymbols) > 0 {
+		return c.FuturesSymbols
+	}
+	return c.Symbols
+}
+
+// VenueURL returns the base URL configured for a venue, empty for its public API
+func (c Config) VenueURL(name string) string {
+	if c.Venues == nil {
+		return ""
+	}
+	return c.Venues.URLs[name]
+}
+
+// ExchangeOptions returns the exchange client settings of the config
+func (c Config) ExchangeOptions() exchange.Options {
+	return exchange.Options{
+		APIURL:      c.ApiUrl,
+		Timeout:     time.Duration(c.HTTPTimeout) * time.Second,
+		MaxRetries:  c.MaxRetries,
+		WeightLimit: c.WeightLimit,
+	}
+}
+
+// GroupSymbolsByInterval splits the symbols into groups that can share one
+// batch request, ordered by interval. Symbols keep their configured order.
+func GroupSymbolsByInterval(config Config) []SymbolGroup {
+	var groups []SymbolGroup
+	index := make(map[int]int)
+	for _, s := range config.Symbols {
+		interval := config.IntervalFor(s)
+		i, ok := index[interval]
+		if !ok {
+			i = len(groups)
+			index[interval] = i
+			groups = append(groups, SymbolGroup{Interval: interval})
+		}
+		groups[i].Symbols = append(groups[i].Symbols, s)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Interval < groups[j].Interval })
+	return groups
+}
+
+func FormatDisplayPrice(price float64) string {
+	if price < 1.0 {
+		return fmt.Sprintf("%.8f", price) // For small prices like doge, shiba, etc.
+	}
+	return fmt.Sprintf("%.2f", price) // For larger prices like BTC, ETH, etc.
+}
+
+func CalculatePercentageDiff(current, average float64) float64 {
+	if average == 0 {
+		return 0
+	}
+	diff := ((current - average) / average) * 100
+	return math.Round(diff*100) / 100
+}
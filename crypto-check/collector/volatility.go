@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"crypto-check/alerts"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+)
+
+// volatilityRefresh is how long a volatility from the analytics service is reused
+const volatilityRefresh = 15 * time.Minute
+
+const hoursPerYear = 365 * 24
+
+// sigmaState is what the sigma alert remembers about a symbol
+type sigmaState struct {
+	volatility float64   // Annualized close-to-close, 0 while unknown
+	checkedAt  time.Time // When the volatility was last requested
+	lastAt     time.Time // When the previous price was fetched
+}
+
+// sigmaAlerts measures price moves in standard deviations of each symbol's own returns
+type sigmaAlerts struct {
+	threshold float64
+	mu        sync.Mutex
+	symbols   map[string]*sigmaState
+}
+
+// AlertOnSigma replaces the dollar volatility alert with one that fires when a move
+// between two fetches is threshold or more standard deviations, using the realized
+// volatility reported by the analytics service. Call it before Run.
+func (m *Monitor) AlertOnSigma(threshold float64) {
+	m.sigma = &sigmaAlerts{threshold: threshold, symbols: make(map[string]*sigmaState)}
+}
+
+// checkSigma scales the annualized volatility of symbol to the time since its
+// previous fetch and compares the move from last against it. It is called for
+// every fetch, including the first, to know when the previous one happened.
+func (m *Monitor) checkSigma(ctx context.Context, symbol string, price, last float64, at time.Time) *alerts.Alert {
+	s := m.sigma
+	s.mu.Lock()
+	state, ok := s.symbols[symbol]
+	if !ok {
+		state = &sigmaState{}
+		s.symbols[symbol] = state
+	}
+	previous := state.lastAt
+	state.lastAt = at
+	volatility := state.volatility
+	now := m.clock.Now()
+	refresh := state.checkedAt.IsZero() || now.Sub(state.checkedAt) >= volatilityRefresh
+	if refresh {
+		state.checkedAt = now // Also on failure, so a short history is not asked for on every fetch
+	}
+	s.mu.Unlock()
+
+	if refresh {
+		// On failure the previous volatility stays in use
+		res, err := m.analytics.GetVolatility(ctx, &analyticsv1.GetVolatilityRequest{Symbol: symbol})
+		if err != nil {
+			log.Printf("[WARNING] [%s] Could not refresh the volatility for sigma alerts: %v", symbol, err)
+		} else {
+			volatility = res.CloseToClose
+			s.mu.Lock()
+			state.volatility = volatility
+			s.mu.Unlock()
+		}
+	}
+
+	elapsed := at.Sub(previous)
+	if previous.IsZero() || elapsed <= 0 || volatility == 0 {
+		return nil
+	}
+	sigma := volatility * math.Sqrt(elapsed.Hours()/hoursPerYear)
+	return alerts.CheckSigma(symbol, price, last, sigma, s.threshold, at)
+}
@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/clock"
+)
+
+func TestSigmaAlert(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMonitor(nil, fakeAnalytics{}, nil, clock.NewVirtual(start), 0, nil)
+	m.AlertOnSigma(3)
+
+	// Fetches 5 seconds apart, where one sigma is about 0.0199%
+	tests := []struct {
+		name  string
+		price float64
+		last  float64
+		wantZ float64 // 0 for no alert
+	}{
+		{"First fetch", 60000, 0, 0},
+		{"Small move", 60020, 60000, 0},
+		{"Jump up", 60060, 60020, 3.3463},
+		{"Drop", 59960, 60060, -8.3700},
+	}
+	for i, tt := range tests {
+		at := start.Add(time.Duration(i) * 5 * time.Second)
+		alert := m.checkSigma(context.Background(), "BTCUSDT", tt.price, tt.last, at)
+		if (alert != nil) != (tt.wantZ != 0) {
+			t.Fatalf("%s: checkSigma() = %+v, want alert %v", tt.name, alert, tt.wantZ != 0)
+		}
+		if alert != nil && (alert.Kind != alerts.KindSigma || math.Abs(alert.Change-tt.wantZ) > 1e-3) {
+			t.Errorf("%s: alert = %+v, want %.4f sigma", tt.name, alert, tt.wantZ)
+		}
+	}
+}
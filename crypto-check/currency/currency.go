@@ -0,0 +1,140 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUnknownSymbol = errors.New("cannot split symbol into base and quote")
+	ErrNoPath        = errors.New("no conversion path")
+)
+
+// quoteAssets are the quote currencies symbols can end with, longest match wins
+var quoteAssets = []string{
+	"USDT", "USDC", "FDUSD", "BUSD", "TUSD", "DAI",
+	"BTC", "ETH", "BNB",
+	"EUR", "GBP", "TRY", "BRL", "JPY", "AUD",
+}
+
+// symbolPattern matches exchange symbols like BTCUSDT
+var symbolPattern = regexp.MustCompile(`^[A-Z0-9]{2,20}$`)
+
+// ValidSymbol reports whether symbol looks like an exchange symbol such as BTCUSDT,
+// upper case letters and digits only. The quote asset does not have to be known.
+func ValidSymbol(symbol string) bool {
+	return symbolPattern.MatchString(symbol)
+}
+
+// Split returns the base and quote asset of a symbol such as BTCUSDT
+func Split(symbol string) (base, quote string, err error) {
+	symbol = strings.ToUpper(symbol)
+	for _, q := range quoteAssets {
+		if len(symbol) > len(q) && strings.HasSuffix(symbol, q) && len(q) > len(quote) {
+			base, quote = symbol[:len(symbol)-len(q)], q
+		}
+	}
+	if quote == "" {
+		return "", "", fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+	}
+	return base, quote, nil
+}
+
+// Conversion turns amounts of From into To. Path lists the assets passed through
+// and Pairs the tracked symbols whose prices were used.
+type Conversion struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Rate  float64  `json:"rate"`
+	Path  []string `json:"path"`
+	Pairs []string `json:"pairs"`
+}
+
+// Apply converts an amount of From
+func (c Conversion) Apply(amount float64) float64 {
+	return amount * c.Rate
+}
+
+type edge struct {
+	to   string
+	pair string
+	rate float64 // Units of to per unit of the source asset
+}
+
+// hop is how an asset was reached during the path search
+type hop struct {
+	prev string
+	edge edge
+}
+
+// Converter derives rates between assets from the prices of tracked pairs
+type Converter struct {
+	edges map[string][]edge
+}
+
+// NewConverter builds the rate graph from the latest price of every symbol.
+// Symbols that cannot be split or have no price are ignored.
+func NewConverter(prices map[string]float64) *Converter {
+	c := &Converter{edges: make(map[string][]edge)}
+	for symbol, price := range prices {
+		base, quote, err := Split(symbol)
+		if err != nil || price <= 0 {
+			continue
+		}
+		c.edges[base] = append(c.edges[base], edge{to: quote, pair: symbol, rate: price})
+		c.edges[quote] = append(c.edges[quote], edge{to: base, pair: symbol, rate: 1 / price})
+	}
+	// Map order is random, keep the chosen paths stable
+	for _, edges := range c.edges {
+		sort.Slice(edges, func(i, j int) bool { return edges[i].pair < edges[j].pair })
+	}
+	return c
+}
+
+// Convert finds the path through the fewest pairs from one asset to another
+func (c *Converter) Convert(from, to string) (Conversion, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return Conversion{From: from, To: to, Rate: 1, Path: []string{from}, Pairs: []string{}}, nil
+	}
+
+	// Breadth-first search, remembering the edge each asset was reached by
+	reached := map[string]hop{from: {}}
+	queue := []string{from}
+	for len(queue) > 0 {
+		asset := queue[0]
+		queue = queue[1:]
+		for _, e := range c.edges[asset] {
+			if _, ok := reached[e.to]; ok {
+				continue
+			}
+			reached[e.to] = hop{prev: asset, edge: e}
+			queue = append(queue, e.to)
+		}
+	}
+	if _, ok := reached[to]; !ok {
+		return Conversion{}, fmt.Errorf("%w from %s to %s", ErrNoPath, from, to)
+	}
+
+	conv := Conversion{From: from, To: to, Rate: 1}
+	for asset := to; asset != from; asset = reached[asset].prev {
+		h := reached[asset]
+		conv.Rate *= h.edge.rate
+		conv.Path = append([]string{asset}, conv.Path...)
+		conv.Pairs = append([]string{h.edge.pair}, conv.Pairs...)
+	}
+	conv.Path = append([]string{from}, conv.Path...)
+	return conv, nil
+}
+
+// ConvertSymbol converts amounts quoted in the quote asset of symbol
+func (c *Converter) ConvertSymbol(symbol, to string) (Conversion, error) {
+	_, quote, err := Split(symbol)
+	if err != nil {
+		return Conversion{}, err
+	}
+	return c.Convert(quote, to)
+}
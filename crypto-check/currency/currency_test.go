@@ -0,0 +1,98 @@
+package currency
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		symbol    string
+		wantBase  string
+		wantQuote string
+		wantErr   bool
+	}{
+		{"BTCUSDT", "BTC", "USDT", false},
+		{"ethbtc", "ETH", "BTC", false},
+		{"EURUSDT", "EUR", "USDT", false},
+		{"BTCFDUSD", "BTC", "FDUSD", false},
+		{"USDT", "", "", true},
+		{"FOOBAR", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			base, quote, err := Split(tt.symbol)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if base != tt.wantBase || quote != tt.wantQuote {
+				t.Errorf("Split() = %s, %s, want %s, %s", base, quote, tt.wantBase, tt.wantQuote)
+			}
+		})
+	}
+}
+
+func TestValidSymbol(t *testing.T) {
+	tests := []struct {
+		symbol string
+		want   bool
+	}{
+		{"BTCUSDT", true},
+		{"1000SATSUSDT", true},
+		{"btcusdt", false},
+		{"BTC-USDT", false},
+		{"B", false},
+		{"<img src=x>", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			if got := ValidSymbol(tt.symbol); got != tt.want {
+				t.Errorf("ValidSymbol(%q) = %v, want %v", tt.symbol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	c := NewConverter(map[string]float64{
+		"BTCUSDT": 60000,
+		"ETHUSDT": 3000,
+		"EURUSDT": 1.2,
+		"SOLBTC":  0.0025,
+	})
+
+	tests := []struct {
+		name      string
+		from, to  string
+		wantRate  float64
+		wantPairs []string
+		wantErr   error
+	}{
+		{"Same asset", "USDT", "USDT", 1, []string{}, nil},
+		{"Direct pair", "BTC", "USDT", 60000, []string{"BTCUSDT"}, nil},
+		{"Inverted pair", "USDT", "EUR", 1 / 1.2, []string{"EURUSDT"}, nil},
+		{"Cross rate", "ETH", "BTC", 0.05, []string{"ETHUSDT", "BTCUSDT"}, nil},
+		{"Two hops", "SOL", "EUR", 0.0025 * 60000 / 1.2, []string{"SOLBTC", "BTCUSDT", "EURUSDT"}, nil},
+		{"Untracked asset", "USDT", "GBP", 0, nil, ErrNoPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conv, err := c.Convert(tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Convert() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if math.Abs(conv.Rate-tt.wantRate) > 1e-9 || !slices.Equal(conv.Pairs, tt.wantPairs) {
+				t.Errorf("Convert() = %+v, want rate %g via %v", conv, tt.wantRate, tt.wantPairs)
+			}
+			if len(conv.Path) != len(conv.Pairs)+1 || conv.Path[0] != tt.from || conv.Path[len(conv.Path)-1] != tt.to {
+				t.Errorf("Convert() path = %v", conv.Path)
+			}
+		})
+	}
+}
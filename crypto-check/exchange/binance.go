@@ -0,0 +1,246 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/recording"
+)
+
+const (
+	defaultHTTPTimeout  = 10 * time.Second
+	defaultMaxRetries   = 3
+	defaultWeightLimit  = 5000 // Binance allows 6000 per minute, keep some headroom
+	defaultBanCooldown  = 2 * time.Minute
+	breakerThreshold    = 5
+	breakerCooldown     = 30 * time.Second
+	defaultBackoffBase  = 500 * time.Millisecond
+	defaultBackoffLimit = 30 * time.Second
+)
+
+// Client is a source of ticker prices
+type Client interface {
+	GetPrice(ctx context.Context, symbol string) (Ticker, error)
+	GetPrices(ctx context.Context, symbols []string) ([]Ticker, error)
+}
+
+// Ticker is the latest price of a symbol
+type Ticker struct {
+	Symbol string  `json:"symbol"`
+	Price  float64 `json:"price"`
+}
+
+// tickerPrice is the wire format, Binance sends prices as strings
+type tickerPrice struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+func (p tickerPrice) ticker() (Ticker, error) {
+	price, err := strconv.ParseFloat(p.Price, 64)
+	if err != nil {
+		return Ticker{}, fmt.Errorf("%w: price of %s: %v", ErrMalformedResponse, p.Symbol, err)
+	}
+	return Ticker{Symbol: p.Symbol, Price: price}, nil
+}
+
+// Options configures a Binance client. Zero values use the defaults.
+type Options struct {
+	APIURL      string        // Single symbol ticker URL, the symbol is appended to it
+	Timeout     time.Duration // Per request
+	MaxRetries  int
+	WeightLimit int // Request weight per minute before pausing
+}
+
+// Binance is shared by all fetchers, so rate limits and bans
+// reported for one symbol pause requests for every symbol
+type Binance struct {
+	apiUrl     string // Single symbol URL, the symbol is appended to it
+	tickerUrl  string // apiUrl without the query, accepts symbols=[...]
+	depthUrl   string // Order book snapshots, next to the ticker endpoint
+	tradesUrl  string // Aggregate trades, next to the ticker endpoint
+	quoteUrl   string // 24 hour tickers, next to the ticker endpoint
+	http       *http.Client
+	limiter    *RateLimiter
+	breaker    *CircuitBreaker
+	backoff    Backoff
+	maxRetries int
+}
+
+func NewBinance(opts Options) *Binance {
+	timeout := defaultHTTPTimeout
+	if opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+	maxRetries := defaultMaxRetries
+	if opts.MaxRetries > 0 {
+		maxRetries = opts.MaxRetries
+	}
+	weightLimit := defaultWeightLimit
+	if opts.WeightLimit > 0 {
+		weightLimit = opts.WeightLimit
+	}
+
+	tickerUrl := strings.SplitN(opts.APIURL, "?", 2)[0]
+	baseUrl := strings.TrimSuffix(tickerUrl, "/ticker/price")
+	return &Binance{
+		apiUrl:     opts.APIURL,
+		tickerUrl:  tickerUrl,
+		depthUrl:   baseUrl + "/depth",
+		tradesUrl:  baseUrl + "/aggTrades",
+		quoteUrl:   baseUrl + "/ticker/24hr",
+		http:       &http.Client{Timeout: timeout},
+		limiter:    NewRateLimiter(weightLimit),
+		breaker:    NewCircuitBreaker(breakerThreshold, breakerCooldown),
+		backoff:    Backoff{Base: defaultBackoffBase, Max: defaultBackoffLimit},
+		maxRetries: maxRetries,
+	}
+}
+
+// SetTransport replaces the HTTP transport, used to record or replay exchange traffic
+func (c *Binance) SetTransport(rt http.RoundTripper) {
+	c.http.Transport = rt
+}
+
+// GetPrice fetches the latest price for a symbol, retrying transient failures
+func (c *Binance) GetPrice(ctx context.Context, symbol string) (Ticker, error) {
+	var result tickerPrice
+	if err := c.getJSON(ctx, c.apiUrl+symbol, &result); err != nil {
+		return Ticker{}, err
+	}
+	return result.ticker()
+}
+
+// GetPrices fetches several symbols with one request. An empty list returns every
+// symbol on the exchange. The weight is 4 instead of 1 per symbol, so this is much
+// cheaper for large watchlists. Entries with an unreadable price are left out.
+func (c *Binance) GetPrices(ctx context.Context, symbols []string) ([]Ticker, error) {
+	endpoint := c.tickerUrl
+	if len(symbols) > 0 {
+		encoded, err := json.Marshal(symbols)
+		if err != nil {
+			return nil, err
+		}
+		endpoint += "?symbols=" + url.QueryEscape(string(encoded))
+	}
+
+	var result []tickerPrice
+	if err := c.getJSON(ctx, endpoint, &result); err != nil {
+		return nil, err
+	}
+
+	tickers := make([]Ticker, 0, len(result))
+	for _, p := range result {
+		ticker, err := p.ticker()
+		if err != nil {
+			log.Printf("[ERROR] [%s] %v", p.Symbol, err)
+			continue
+		}
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
+}
+
+func (c *Binance) getJSON(ctx context.Context, url string, v any) error {
+	for attempt := 0; ; attempt++ {
+		err := c.doJSON(ctx, url, v)
+		if err == nil || ctx.Err() != nil || !isRetryable(err) || attempt >= c.maxRetries {
+			return err
+		}
+
+		delay := c.backoff.Delay(attempt)
+		log.Printf("[WARNING] Request to %s failed (attempt %d/%d): %v. Retrying in %v",
+			url, attempt+1, c.maxRetries+1, err, delay.Round(time.Millisecond))
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Binance) doJSON(ctx context.Context, url string, v any) error {
+	release, err := c.breaker.Allow(time.Now())
+	if err != nil {
+		return err
+	}
+	defer release()
+	// A 429 pauses the limiter, so the retry waits here for Retry-After
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.http.Do(req)
+	now := time.Now()
+	if err != nil {
+		if ctx.Err() == nil {
+			c.breaker.Failure(now)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	c.limiter.Observe(resp.Header, now)
+
+	if resp.StatusCode >= 300 {
+		statusErr := &StatusError{
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), now),
+		}
+		switch {
+		case resp.StatusCode == http.StatusTeapot:
+			// 418 means the IP is banned, stop fetching for every symbol
+			cooldown := statusErr.RetryAfter
+			if cooldown == 0 {
+				cooldown = defaultBanCooldown
+			}
+			c.breaker.Trip(now.Add(cooldown))
+			log.Printf("[ERROR] Exchange banned our IP, pausing all requests for %v", cooldown)
+		case resp.StatusCode == http.StatusTooManyRequests:
+			cooldown := statusErr.RetryAfter
+			if cooldown == 0 {
+				cooldown = now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+			}
+			c.limiter.PauseUntil(now.Add(cooldown))
+			log.Printf("[WARNING] Rate limit exceeded, pausing all requests for %v", cooldown)
+		case resp.StatusCode >= 500:
+			c.breaker.Failure(now)
+		default:
+			c.breaker.Success() // The exchange is healthy, the request itself was bad
+		}
+		return statusErr
+	}
+
+	c.breaker.Success()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedResponse, err)
+	}
+	return nil
+}
+
+// isRetryable reports whether the request may succeed if repeated
+func isRetryable(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, recording.ErrReplayFinished) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	if errors.Is(err, ErrMalformedResponse) {
+		return false
+	}
+	return true // Network errors and timeouts
+}
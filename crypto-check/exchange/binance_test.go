@@ -0,0 +1,365 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient points a Binance client at an httptest stand-in and counts the requests it receives
+func newTestClient(t *testing.T, maxRetries int, handler func(w http.ResponseWriter, r *http.Request, n int)) (*Binance, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, int(hits.Add(1)))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewBinance(Options{
+		APIURL:  srv.URL + "/api/v3/ticker/price?symbol=",
+		Timeout: time.Second,
+	})
+	client.maxRetries = maxRetries // Options treat 0 as "use the default"
+	client.backoff = Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}
+	return client, &hits
+}
+
+func writePrice(w http.ResponseWriter) {
+	w.Write([]byte(`{"symbol":"BTCUSDT","price":"65000.00"}`))
+}
+
+func TestGetPriceSuccess(t *testing.T) {
+	client, hits := newTestClient(t, 3, func(w http.ResponseWriter, r *http.Request, n int) {
+		if got := r.URL.Query().Get("symbol"); got != "BTCUSDT" {
+			t.Errorf("symbol = %q, want BTCUSDT", got)
+		}
+		writePrice(w)
+	})
+
+	res, err := client.GetPrice(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatalf("GetPrice() error: %v", err)
+	}
+	if res.Price != 65000 || hits.Load() != 1 {
+		t.Errorf("got price %.2f after %d requests, want 65000.00 after 1", res.Price, hits.Load())
+	}
+}
+
+func TestGetPricesSingleRequest(t *testing.T) {
+	client, hits := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		if r.URL.Path != "/api/v3/ticker/price" {
+			t.Errorf("path = %s, want /api/v3/ticker/price", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("symbols"); got != `["BTCUSDT","ETHUSDT"]` {
+			t.Errorf("symbols = %s", got)
+		}
+		w.Write([]byte(`[{"symbol":"BTCUSDT","price":"65000.00"},{"symbol":"ETHUSDT","price":"3500.00"},{"symbol":"XRPUSDT","price":"n/a"}]`))
+	})
+
+	// The unreadable XRPUSDT price is dropped instead of failing the batch
+	res, err := client.GetPrices(context.Background(), []string{"BTCUSDT", "ETHUSDT"})
+	if err != nil {
+		t.Fatalf("GetPrices() error: %v", err)
+	}
+	if len(res) != 2 || res[1].Symbol != "ETHUSDT" || res[1].Price != 3500 {
+		t.Errorf("unexpected response: %+v", res)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("server got %d requests, want 1", hits.Load())
+	}
+}
+
+func TestGetPriceRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		maxRetries int
+		wantErr    bool
+		wantHits   int32
+	}{
+		{"Server error is retried", http.StatusInternalServerError, 3, false, 3},
+		{"Gives up after max retries", http.StatusBadGateway, 1, true, 2},
+		{"Bad request is not retried", http.StatusBadRequest, 3, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, hits := newTestClient(t, tt.maxRetries, func(w http.ResponseWriter, r *http.Request, n int) {
+				if n < 3 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				writePrice(w)
+			})
+
+			_, err := client.GetPrice(context.Background(), "BTCUSDT")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetPrice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if hits.Load() != tt.wantHits {
+				t.Errorf("server got %d requests, want %d", hits.Load(), tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestGetPriceMalformedJSON(t *testing.T) {
+	client, hits := newTestClient(t, 3, func(w http.ResponseWriter, r *http.Request, n int) {
+		w.Write([]byte(`{"symbol":"BTCUSDT","price":`))
+	})
+
+	if _, err := client.GetPrice(context.Background(), "BTCUSDT"); err == nil {
+		t.Fatal("expected decode error")
+	}
+	if hits.Load() != 1 {
+		t.Errorf("malformed JSON was retried %d times", hits.Load()-1)
+	}
+}
+
+func TestGetPriceRetryAfterPausesAllSymbols(t *testing.T) {
+	client, hits := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.GetPrice(context.Background(), "BTCUSDT")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter != 2*time.Second {
+		t.Fatalf("expected 429 with 2s Retry-After, got %v", err)
+	}
+
+	// Another symbol must wait for the pause instead of hitting the exchange
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetPrice(ctx, "ETHUSDT"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected to wait out the pause, got %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("server got %d requests during the pause, want 1", hits.Load())
+	}
+}
+
+func TestGetPriceUsedWeightPauses(t *testing.T) {
+	client, _ := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		w.Header().Set("X-MBX-USED-WEIGHT-1M", "5000")
+		writePrice(w)
+	})
+
+	if _, err := client.GetPrice(context.Background(), "BTCUSDT"); err != nil {
+		t.Fatalf("GetPrice() error: %v", err)
+	}
+	if client.limiter.UsedWeight() != 5000 {
+		t.Errorf("UsedWeight() = %d, want 5000", client.limiter.UsedWeight())
+	}
+	if !client.limiter.PausedUntil().After(time.Now()) {
+		t.Error("expected limiter to pause until the next minute")
+	}
+}
+
+func TestGetPriceBanOpensCircuit(t *testing.T) {
+	client, hits := newTestClient(t, 3, func(w http.ResponseWriter, r *http.Request, n int) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	if _, err := client.GetPrice(context.Background(), "BTCUSDT"); err == nil {
+		t.Fatal("expected ban error")
+	}
+	if _, err := client.GetPrice(context.Background(), "ETHUSDT"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("server got %d requests, want 1", hits.Load())
+	}
+}
+
+func TestGetPriceCancelledProbeReleasesCircuit(t *testing.T) {
+	client, hits := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		if n == 1 {
+			<-r.Context().Done() // The probe hangs until it is cancelled
+			return
+		}
+		writePrice(w)
+	})
+	client.breaker.Trip(time.Now().Add(-time.Second)) // Cooldown over, half-open
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetPrice(ctx, "BTCUSDT"); err == nil {
+		t.Fatal("expected the cancelled probe to fail")
+	}
+	if _, err := client.GetPrice(context.Background(), "BTCUSDT"); err != nil {
+		t.Fatalf("expected a new probe after the cancelled one, got %v", err)
+	}
+	if !client.breaker.OpenUntil().IsZero() {
+		t.Errorf("circuit open until %v, want closed after the probe succeeded", client.breaker.OpenUntil())
+	}
+	if hits.Load() != 2 {
+		t.Errorf("server got %d requests, want 2", hits.Load())
+	}
+}
+
+func TestGetPriceSingleProbeWhileOthersFinish(t *testing.T) {
+	hold := make(chan struct{})
+	client, hits := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		if n <= 2 {
+			select { // The first request and the probe hang
+			case <-hold:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		writePrice(w)
+	})
+
+	// A request let through while the circuit is closed
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.GetPrice(ctx, "BTCUSDT")
+		first <- err
+	}()
+	waitForHits(t, hits, 1)
+
+	// The circuit opens and cools down, then the probe goes out
+	client.breaker.Trip(time.Now().Add(-time.Second))
+	probe := make(chan error, 1)
+	go func() {
+		_, err := client.GetPrice(context.Background(), "ETHUSDT")
+		probe <- err
+	}()
+	waitForHits(t, hits, 2)
+
+	// The first request ending must not let a second probe through
+	cancel()
+	<-first
+	if _, err := client.GetPrice(context.Background(), "SOLUSDT"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("request during the probe = %v, want ErrCircuitOpen", err)
+	}
+
+	close(hold)
+	if err := <-probe; err != nil {
+		t.Fatalf("probe error: %v", err)
+	}
+	if _, err := client.GetPrice(context.Background(), "SOLUSDT"); err != nil {
+		t.Errorf("request after the probe succeeded = %v, want the circuit closed", err)
+	}
+	if hits.Load() != 3 {
+		t.Errorf("server got %d requests, want 3", hits.Load())
+	}
+}
+
+// waitForHits waits until the server received n requests
+func waitForHits(t *testing.T, hits *atomic.Int32, n int32) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for hits.Load() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("server got %d requests, want %d", hits.Load(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGetPriceTimeout(t *testing.T) {
+	client, _ := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	client.http.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	if _, err := client.GetPrice(context.Background(), "BTCUSDT"); err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request took %v, timeout was not applied", elapsed)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewCircuitBreaker(2, time.Minute)
+
+	b.Failure(now)
+	before, err := b.Allow(now)
+	if err != nil {
+		t.Fatalf("circuit opened before threshold: %v", err)
+	}
+	b.Failure(now)
+	if _, err := b.Allow(now); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected open circuit after threshold, got %v", err)
+	}
+
+	// After the cooldown a single probe is allowed
+	later := now.Add(2 * time.Minute)
+	release, err := b.Allow(later)
+	if err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if _, err := b.Allow(later); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected only one probe, got %v", err)
+	}
+	// A request let through before the circuit opened is not the probe
+	before()
+	if _, err := b.Allow(later); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected only one probe after another request ended, got %v", err)
+	}
+	// The probe ending without a verdict lets another one through
+	release()
+	release, err = b.Allow(later)
+	if err != nil {
+		t.Fatalf("expected a new probe after the release, got %v", err)
+	}
+	b.Success()
+	release()
+	if _, err := b.Allow(later); err != nil {
+		t.Errorf("expected closed circuit after successful probe, got %v", err)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Base: 100 * time.Millisecond, Max: time.Second}
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second}, // Capped at Max
+		{100, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := b.Delay(tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("Delay(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"garbage", 0},
+		{now.Add(time.Minute).Format(http.TimeFormat), time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/orderbook"
+)
+
+// DefaultDepthLimit is the number of levels per side requested for snapshots, the largest costing 5 weight
+const DefaultDepthLimit = 100
+
+// DepthSnapshot is an order book as of update LastUpdateID
+type DepthSnapshot struct {
+	LastUpdateID int64
+	Bids         []orderbook.Level
+	Asks         []orderbook.Level
+}
+
+// DepthUpdate is a diff of an order book covering the update ids FirstUpdateID to FinalUpdateID
+type DepthUpdate struct {
+	Symbol        string
+	Time          time.Time
+	FirstUpdateID int64
+	FinalUpdateID int64
+	Bids          []orderbook.Level // A zero quantity removes the level
+	Asks          []orderbook.Level
+}
+
+// DepthStream is the name of the diff depth stream of symbol
+func DepthStream(symbol string) string {
+	return strings.ToLower(symbol) + "@depth@100ms"
+}
+
+// depthSnapshot is the wire format of /api/v3/depth
+type depthSnapshot struct {
+	LastUpdateID int64      `json:"lastUpdateId"`
+	Bids         [][]string `json:"bids"`
+	Asks         [][]string `json:"asks"`
+}
+
+// depthUpdate is the wire format of a depthUpdate event
+type depthUpdate struct {
+	Event         string     `json:"e"`
+	EventTime     int64      `json:"E"`
+	Symbol        string     `json:"s"`
+	FirstUpdateID int64      `json:"U"`
+	FinalUpdateID int64      `json:"u"`
+	Bids          [][]string `json:"b"`
+	Asks          [][]string `json:"a"`
+}
+
+// GetDepth fetches an order book snapshot with up to limit levels per side, 0 for DefaultDepthLimit
+func (c *Binance) GetDepth(ctx context.Context, symbol string, limit int) (DepthSnapshot, error) {
+	if limit <= 0 {
+		limit = DefaultDepthLimit
+	}
+	endpoint := fmt.Sprintf("%s?symbol=%s&limit=%d", c.depthUrl, url.QueryEscape(symbol), limit)
+
+	var result depthSnapshot
+	if err := c.getJSON(ctx, endpoint, &result); err != nil {
+		return DepthSnapshot{}, err
+	}
+	bids, err := parseLevels(result.Bids)
+	if err != nil {
+		return DepthSnapshot{}, fmt.Errorf("%w: bids of %s: %v", ErrMalformedResponse, symbol, err)
+	}
+	asks, err := parseLevels(result.Asks)
+	if err != nil {
+		return DepthSnapshot{}, fmt.Errorf("%w: asks of %s: %v", ErrMalformedResponse, symbol, err)
+	}
+	return DepthSnapshot{LastUpdateID: result.LastUpdateID, Bids: bids, Asks: asks}, nil
+}
+
+// ParseDepthUpdate decodes a frame of a diff depth stream
+func ParseDepthUpdate(data []byte) (DepthUpdate, error) {
+	var raw depthUpdate
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return DepthUpdate{}, fmt.Errorf("%w: %v", ErrMalformedResponse, err)
+	}
+	if raw.Event != "depthUpdate" {
+		return DepthUpdate{}, fmt.Errorf("%w: unexpected event %q", ErrMalformedResponse, raw.Event)
+	}
+	bids, err := parseLevels(raw.Bids)
+	if err != nil {
+		return DepthUpdate{}, fmt.Errorf("%w: bids of %s: %v", ErrMalformedResponse, raw.Symbol, err)
+	}
+	asks, err := parseLevels(raw.Asks)
+	if err != nil {
+		return DepthUpdate{}, fmt.Errorf("%w: asks of %s: %v", ErrMalformedResponse, raw.Symbol, err)
+	}
+	return DepthUpdate{
+		Symbol:        raw.Symbol,
+		Time:          time.UnixMilli(raw.EventTime).UTC(),
+		FirstUpdateID: raw.FirstUpdateID,
+		FinalUpdateID: raw.FinalUpdateID,
+		Bids:          bids,
+		Asks:          asks,
+	}, nil
+}
+
+// parseLevels reads [price, quantity] pairs, Binance sends both as strings
+func parseLevels(raw [][]string) ([]orderbook.Level, error) {
+	levels := make([]orderbook.Level, 0, len(raw))
+	for _, pair := range raw {
+		if len(pair) < 2 {
+			return nil, fmt.Errorf("level %v has no quantity", pair)
+		}
+		price, err := strconv.ParseFloat(pair[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := strconv.ParseFloat(pair[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, orderbook.Level{Price: price, Quantity: quantity})
+	}
+	return levels, nil
+}
@@ -0,0 +1,135 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"crypto-check/orderbook"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestGetDepth(t *testing.T) {
+	client, _ := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		if r.URL.Path != "/api/v3/depth" || r.URL.Query().Get("symbol") != "BTCUSDT" || r.URL.Query().Get("limit") != "100" {
+			t.Errorf("request = %s, want the depth of BTCUSDT with limit 100", r.URL)
+		}
+		w.Write([]byte(`{"lastUpdateId":1027024,"bids":[["64999.50","1.25"],["64999.00","0.5"]],"asks":[["65000.00","2"]]}`))
+	})
+
+	depth, err := client.GetDepth(context.Background(), "BTCUSDT", 0)
+	if err != nil {
+		t.Fatalf("GetDepth() error: %v", err)
+	}
+	want := DepthSnapshot{
+		LastUpdateID: 1027024,
+		Bids:         []orderbook.Level{{Price: 64999.5, Quantity: 1.25}, {Price: 64999, Quantity: 0.5}},
+		Asks:         []orderbook.Level{{Price: 65000, Quantity: 2}},
+	}
+	if depth.LastUpdateID != want.LastUpdateID || len(depth.Bids) != 2 || depth.Bids[1] != want.Bids[1] || depth.Asks[0] != want.Asks[0] {
+		t.Errorf("GetDepth() = %+v, want %+v", depth, want)
+	}
+}
+
+func TestParseDepthUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		frame   string
+		wantErr bool
+	}{
+		{"Update", `{"e":"depthUpdate","E":1700000000000,"s":"BTCUSDT","U":157,"u":160,"b":[["64999.50","0.00000000"]],"a":[["65000.00","3"]]}`, false},
+		{"Other event", `{"e":"trade","E":1700000000000,"s":"BTCUSDT"}`, true},
+		{"Bad quantity", `{"e":"depthUpdate","E":1700000000000,"s":"BTCUSDT","U":1,"u":1,"b":[["64999.50","x"]],"a":[]}`, true},
+		{"Not JSON", `{"e":`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := ParseDepthUpdate([]byte(tt.frame))
+			if tt.wantErr {
+				if !errors.Is(err, ErrMalformedResponse) {
+					t.Errorf("ParseDepthUpdate() error = %v, want ErrMalformedResponse", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDepthUpdate() error: %v", err)
+			}
+			if update.Symbol != "BTCUSDT" || update.FirstUpdateID != 157 || update.FinalUpdateID != 160 ||
+				update.Bids[0] != (orderbook.Level{Price: 64999.5}) || update.Asks[0] != (orderbook.Level{Price: 65000, Quantity: 3}) ||
+				!update.Time.Equal(time.UnixMilli(1700000000000)) {
+				t.Errorf("ParseDepthUpdate() = %+v", update)
+			}
+		})
+	}
+}
+
+func TestWebSocketReconnects(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var sessions sync.WaitGroup
+	sessions.Add(2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("streams"); got != "btcusdt@depth@100ms/ethusdt@depth@100ms" {
+			t.Errorf("streams = %q", got)
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade() error: %v", err)
+			return
+		}
+		// Every connection sends one frame and a malformed one, then drops
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@depth@100ms","data":{"u":1}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`not json`))
+		conn.Close()
+		sessions.Done()
+	}))
+	defer srv.Close()
+
+	ws := NewWebSocket("ws" + strings.TrimPrefix(srv.URL, "http"))
+	ws.backoff = Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	var events []string
+	done := make(chan error)
+	go func() {
+		done <- ws.Run(ctx, []string{DepthStream("BTCUSDT"), DepthStream("ETHUSDT")},
+			func() {
+				mu.Lock()
+				events = append(events, "connected")
+				mu.Unlock()
+			},
+			func(f Frame) {
+				mu.Lock()
+				events = append(events, f.Stream+" "+string(f.Data))
+				mu.Unlock()
+			})
+	}()
+
+	sessions.Wait()
+	// Let the second session deliver its frame before stopping
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		mu.Lock()
+		n := len(events)
+		mu.Unlock()
+		if n >= 4 {
+			break
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() = %v, want context.Canceled", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"connected", `btcusdt@depth@100ms {"u":1}`, "connected", `btcusdt@depth@100ms {"u":1}`}
+	if len(events) < len(want) || strings.Join(events[:4], "|") != strings.Join(want, "|") {
+		t.Errorf("events = %q, want %q first", events, want)
+	}
+}
@@ -0,0 +1,111 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultFuturesURL is the USDⓈ-M perpetual futures API
+const DefaultFuturesURL = "https://fapi.binance.com"
+
+// PremiumIndex is the mark price and funding of a perpetual contract
+type PremiumIndex struct {
+	Symbol          string
+	MarkPrice       float64
+	IndexPrice      float64 // Average spot price across exchanges
+	FundingRate     float64 // Of the current funding interval, 0.0001 is 0.01%
+	NextFundingTime time.Time
+	Time            time.Time
+}
+
+// OpenInterest is the number of open contracts of a perpetual, in the base asset
+type OpenInterest struct {
+	Symbol   string
+	Quantity float64
+	Time     time.Time
+}
+
+// Futures reads Binance perpetual futures. It has its own rate limits, so it does
+// not share a Binance client with the spot API.
+type Futures struct {
+	client  *Binance
+	baseUrl string
+}
+
+// NewFutures creates a client of the futures API at baseURL, DefaultFuturesURL when empty.
+// The APIURL of opts is ignored.
+func NewFutures(baseURL string, opts Options) *Futures {
+	if baseURL == "" {
+		baseURL = DefaultFuturesURL
+	}
+	opts.APIURL = ""
+	return &Futures{client: NewBinance(opts), baseUrl: strings.TrimSuffix(baseURL, "/")}
+}
+
+// SetTransport replaces the HTTP transport, used to record or replay exchange traffic
+func (f *Futures) SetTransport(rt http.RoundTripper) {
+	f.client.SetTransport(rt)
+}
+
+// premiumIndex is the wire format of /fapi/v1/premiumIndex
+type premiumIndex struct {
+	Symbol          string `json:"symbol"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	LastFundingRate string `json:"lastFundingRate"`
+	NextFundingTime int64  `json:"nextFundingTime"`
+	Time            int64  `json:"time"`
+}
+
+// openInterest is the wire format of /fapi/v1/openInterest
+type openInterest struct {
+	Symbol       string `json:"symbol"`
+	OpenInterest string `json:"openInterest"`
+	Time         int64  `json:"time"`
+}
+
+// GetPremiumIndex fetches the mark price, index price and funding rate of symbol
+func (f *Futures) GetPremiumIndex(ctx context.Context, symbol string) (PremiumIndex, error) {
+	var result premiumIndex
+	if err := f.client.getJSON(ctx, f.baseUrl+"/fapi/v1/premiumIndex?symbol="+url.QueryEscape(symbol), &result); err != nil {
+		return PremiumIndex{}, err
+	}
+	mark, err := strconv.ParseFloat(result.MarkPrice, 64)
+	if err != nil {
+		return PremiumIndex{}, fmt.Errorf("%w: mark price of %s: %v", ErrMalformedResponse, symbol, err)
+	}
+	index, err := strconv.ParseFloat(result.IndexPrice, 64)
+	if err != nil {
+		return PremiumIndex{}, fmt.Errorf("%w: index price of %s: %v", ErrMalformedResponse, symbol, err)
+	}
+	funding, err := strconv.ParseFloat(result.LastFundingRate, 64)
+	if err != nil {
+		return PremiumIndex{}, fmt.Errorf("%w: funding rate of %s: %v", ErrMalformedResponse, symbol, err)
+	}
+	return PremiumIndex{
+		Symbol:          symbol,
+		MarkPrice:       mark,
+		IndexPrice:      index,
+		FundingRate:     funding,
+		NextFundingTime: time.UnixMilli(result.NextFundingTime).UTC(),
+		Time:            time.UnixMilli(result.Time).UTC(),
+	}, nil
+}
+
+// GetOpenInterest fetches the open interest of symbol
+func (f *Futures) GetOpenInterest(ctx context.Context, symbol string) (OpenInterest, error) {
+	var result openInterest
+	if err := f.client.getJSON(ctx, f.baseUrl+"/fapi/v1/openInterest?symbol="+url.QueryEscape(symbol), &result); err != nil {
+		return OpenInterest{}, err
+	}
+	quantity, err := strconv.ParseFloat(result.OpenInterest, 64)
+	if err != nil {
+		return OpenInterest{}, fmt.Errorf("%w: open interest of %s: %v", ErrMalformedResponse, symbol, err)
+	}
+	return OpenInterest{Symbol: symbol, Quantity: quantity, Time: time.UnixMilli(result.Time).UTC()}, nil
+}
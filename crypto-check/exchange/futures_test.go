@@ -0,0 +1,79 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestFutures(t *testing.T, handler http.HandlerFunc) *Futures {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	futures := NewFutures(srv.URL+"/", Options{Timeout: time.Second})
+	futures.client.backoff = Backoff{Base: time.Millisecond, Max: 5 * time.Millisecond}
+	return futures
+}
+
+func TestGetPremiumIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    PremiumIndex
+		wantErr bool
+	}{
+		{
+			"Valid",
+			`{"symbol":"BTCUSDT","markPrice":"60012.50","indexPrice":"60000.00","estimatedSettlePrice":"60001.0","lastFundingRate":"0.00010000","interestRate":"0.00010000","nextFundingTime":1700006400000,"time":1700000000000}`,
+			PremiumIndex{Symbol: "BTCUSDT", MarkPrice: 60012.5, IndexPrice: 60000, FundingRate: 0.0001,
+				NextFundingTime: time.UnixMilli(1700006400000).UTC(), Time: time.UnixMilli(1700000000000).UTC()},
+			false,
+		},
+		{"Bad funding rate", `{"symbol":"BTCUSDT","markPrice":"1","indexPrice":"1","lastFundingRate":""}`, PremiumIndex{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			futures := newTestFutures(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/fapi/v1/premiumIndex" || r.URL.Query().Get("symbol") != "BTCUSDT" {
+					t.Errorf("request = %s, want premiumIndex of BTCUSDT", r.URL)
+				}
+				w.Write([]byte(tt.body))
+			})
+
+			got, err := futures.GetPremiumIndex(context.Background(), "BTCUSDT")
+			if tt.wantErr {
+				if !errors.Is(err, ErrMalformedResponse) {
+					t.Errorf("GetPremiumIndex() error = %v, want ErrMalformedResponse", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPremiumIndex() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetPremiumIndex() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetOpenInterest(t *testing.T) {
+	futures := newTestFutures(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/openInterest" {
+			t.Errorf("path = %s, want /fapi/v1/openInterest", r.URL.Path)
+		}
+		w.Write([]byte(`{"openInterest":"10659.509","symbol":"BTCUSDT","time":1700000000000}`))
+	})
+
+	got, err := futures.GetOpenInterest(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatalf("GetOpenInterest() error: %v", err)
+	}
+	want := OpenInterest{Symbol: "BTCUSDT", Quantity: 10659.509, Time: time.UnixMilli(1700000000000).UTC()}
+	if got != want {
+		t.Errorf("GetOpenInterest() = %+v, want %+v", got, want)
+	}
+}
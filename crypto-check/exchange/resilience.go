@@ -0,0 +1,226 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the circuit breaker refuses a request
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// ErrMalformedResponse wraps JSON decoding failures, which are not worth retrying
+var ErrMalformedResponse = errors.New("malformed response")
+
+// StatusError describes a non-2xx response from the exchange
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration // Parsed from the Retry-After header, 0 if absent
+}
+
+func (e *StatusError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("unexpected HTTP status %d (retry after %v)", e.StatusCode, e.RetryAfter)
+	}
+	return fmt.Sprintf("unexpected HTTP status %d", e.StatusCode)
+}
+
+// Backoff calculates exponential retry delays with jitter
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+// Delay returns the wait before retry number attempt (starting at 0).
+// Half of the exponential delay is fixed and the other half is random,
+// so parallel fetchers don't retry in lockstep.
+func (b Backoff) Delay(attempt int) time.Duration {
+	d := b.Max
+	if attempt < 32 {
+		if exp := b.Base << attempt; exp > 0 && exp < b.Max {
+			d = exp
+		}
+	}
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// RateLimiter tracks Binance request weight and pauses for all symbols
+// when the limit is reached or the exchange asks us to slow down
+type RateLimiter struct {
+	mu          sync.Mutex
+	weightLimit int
+	usedWeight  int
+	pausedUntil time.Time
+}
+
+func NewRateLimiter(weightLimit int) *RateLimiter {
+	return &RateLimiter{weightLimit: weightLimit}
+}
+
+// Wait blocks until requests are allowed again or the context is cancelled
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	wait := time.Until(l.pausedUntil)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, wait)
+}
+
+// PauseUntil blocks all requests until t. Earlier deadlines never shorten an existing pause.
+func (l *RateLimiter) PauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// PausedUntil reports the end of the current pause (zero time if none)
+func (l *RateLimiter) PausedUntil() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pausedUntil
+}
+
+// UsedWeight returns the last weight reported by the exchange
+func (l *RateLimiter) UsedWeight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.usedWeight
+}
+
+// Observe reads the X-MBX-USED-WEIGHT headers. Binance counts weight per
+// minute, so when we hit the limit we wait until the next minute starts.
+func (l *RateLimiter) Observe(h http.Header, now time.Time) {
+	value := h.Get("X-MBX-USED-WEIGHT-1M")
+	if value == "" {
+		value = h.Get("X-MBX-USED-WEIGHT")
+	}
+	used, err := strconv.Atoi(value)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	l.usedWeight = used
+	l.mu.Unlock()
+
+	if l.weightLimit > 0 && used >= l.weightLimit {
+		l.PauseUntil(now.Truncate(time.Minute).Add(time.Minute))
+	}
+}
+
+// CircuitBreaker stops all fetching after repeated failures or an IP ban.
+// Once the cooldown is over a single probe request is let through:
+// success closes the circuit, failure opens it again.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+	probe     uint64 // Number of the last probe let through, so only its holder releases it
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow returns ErrCircuitOpen if requests are currently blocked. Otherwise the
+// caller must call release once the request is over: when the request is the
+// probe of a half-open circuit and got no verdict, e.g. because it was cancelled
+// or rate limited, release lets the next request probe again instead of the
+// circuit staying open for good. It does nothing for other requests.
+func (b *CircuitBreaker) Allow(now time.Time) (release func(), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return func() {}, nil
+	}
+	if now.Before(b.openUntil) || b.probing {
+		return nil, fmt.Errorf("%w until %s", ErrCircuitOpen, b.openUntil.Format("15:04:05"))
+	}
+	b.probing = true // Half-open: let exactly one request through
+	b.probe++
+	probe := b.probe
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.probe == probe {
+			b.probing = false
+		}
+	}, nil
+}
+
+// Success closes the circuit
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.probing = false
+}
+
+// Failure records a failed request and opens the circuit when the threshold is reached
+func (b *CircuitBreaker) Failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.probing || b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+		b.probing = false
+	}
+}
+
+// Trip opens the circuit until t regardless of the failure count (used for bans)
+func (b *CircuitBreaker) Trip(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.openUntil) {
+		b.openUntil = until
+	}
+	b.probing = false
+}
+
+// OpenUntil reports when the circuit closes again (zero time if closed)
+func (b *CircuitBreaker) OpenUntil() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openUntil
+}
+
+// parseRetryAfter supports the delay-seconds form used by Binance and HTTP dates
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
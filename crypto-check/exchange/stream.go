@@ -0,0 +1,159 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"crypto-check/recording"
+
+	"github.com/gorilla/websocket"
+)
+
+// streamReadTimeout drops connections that went silent, Binance pings every 20 seconds
+const streamReadTimeout = time.Minute
+
+// Frame is a message received on a WebSocket stream
+type Frame struct {
+	Stream string
+	Data   []byte
+}
+
+// Streams is a source of WebSocket frames
+type Streams interface {
+	// Run delivers the frames of streams to handle until ctx is done. connected is
+	// called before the frames of every new connection, since frames may have been
+	// missed in between.
+	Run(ctx context.Context, streams []string, connected func(), handle func(Frame)) error
+}
+
+// WebSocket subscribes to Binance combined streams and reconnects when the connection drops
+type WebSocket struct {
+	baseURL  string // e.g. wss://stream.binance.com:9443
+	backoff  Backoff
+	recorder *recording.Recorder
+}
+
+func NewWebSocket(baseURL string) *WebSocket {
+	return &WebSocket{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		backoff: Backoff{Base: defaultBackoffBase, Max: defaultBackoffLimit},
+	}
+}
+
+// Record stores every received frame, so the session can be replayed
+func (w *WebSocket) Record(r *recording.Recorder) {
+	w.recorder = r
+}
+
+func (w *WebSocket) Run(ctx context.Context, streams []string, connected func(), handle func(Frame)) error {
+	for attempt := 0; ; attempt++ {
+		established, err := w.session(ctx, streams, connected, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if established {
+			attempt = 0
+		}
+		delay := w.backoff.Delay(attempt)
+		log.Printf("[WARNING] Stream connection lost: %v. Reconnecting in %v", err, delay.Round(time.Millisecond))
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// session reads one connection until it fails, reporting whether it was established
+func (w *WebSocket) session(ctx context.Context, streams []string, connected func(), handle func(Frame)) (bool, error) {
+	url := w.baseURL + "/stream?streams=" + strings.Join(streams, "/")
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// Unblock the read below when ctx is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(5*time.Second))
+	})
+	log.Printf("[INFO] Connected to %d streams", len(streams))
+	connected()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return true, err
+		}
+		conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+
+		var envelope struct {
+			Stream string          `json:"stream"`
+			Data   json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &envelope); err != nil || envelope.Stream == "" {
+			log.Printf("[ERROR] %v", fmt.Errorf("%w: stream frame %.100s", ErrMalformedResponse, msg))
+			continue
+		}
+		if w.recorder != nil {
+			if err := w.recorder.RecordFrame(envelope.Stream, envelope.Data); err != nil {
+				log.Printf("[ERROR] Failed to record frame of %s: %v", envelope.Stream, err)
+			}
+		}
+		handle(Frame{Stream: envelope.Stream, Data: envelope.Data})
+	}
+}
+
+// ReplayStreams delivers the recorded frames of a replay instead of connecting
+func ReplayStreams(r *recording.Replay) Streams {
+	return replayStreams{r}
+}
+
+type replayStreams struct {
+	replay *recording.Replay
+}
+
+func (s replayStreams) Run(ctx context.Context, streams []string, connected func(), handle func(Frame)) error {
+	frames := make(chan Frame)
+	for _, stream := range streams {
+		ch := s.replay.Subscribe(stream)
+		go func() {
+			for {
+				select {
+				case data := <-ch:
+					select {
+					case frames <- Frame{Stream: stream, Data: data}:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	connected()
+	for {
+		select {
+		case f := <-frames:
+			handle(f)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
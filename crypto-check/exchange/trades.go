@@ -0,0 +1,104 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits of /api/v3/aggTrades, which costs 4 weight whatever the limit
+const (
+	DefaultTradesLimit = 500
+	MaxTradesLimit     = 1000
+)
+
+// AggTrade is a set of fills of one taker order at one price. Ids are consecutive
+// per symbol, so a gap between two of them means trades were missed.
+type AggTrade struct {
+	ID           int64
+	Symbol       string
+	Price        float64
+	Quantity     float64
+	Time         time.Time
+	BuyerIsMaker bool // The taker sold
+}
+
+// AggTradeStream is the name of the aggregate trade stream of symbol
+func AggTradeStream(symbol string) string {
+	return strings.ToLower(symbol) + "@aggTrade"
+}
+
+// aggTrade is the wire format shared by /api/v3/aggTrades and the aggTrade events.
+// Keys only differing in case are all declared, encoding/json would mix them up.
+type aggTrade struct {
+	Event        string `json:"e"`
+	EventTime    int64  `json:"E"`
+	Symbol       string `json:"s"`
+	ID           int64  `json:"a"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	TradeTime    int64  `json:"T"`
+	BuyerIsMaker bool   `json:"m"`
+	BestMatch    bool   `json:"M"`
+}
+
+func (t aggTrade) trade(symbol string) (AggTrade, error) {
+	price, err := strconv.ParseFloat(t.Price, 64)
+	if err != nil {
+		return AggTrade{}, fmt.Errorf("%w: price of trade %d of %s: %v", ErrMalformedResponse, t.ID, symbol, err)
+	}
+	quantity, err := strconv.ParseFloat(t.Quantity, 64)
+	if err != nil {
+		return AggTrade{}, fmt.Errorf("%w: quantity of trade %d of %s: %v", ErrMalformedResponse, t.ID, symbol, err)
+	}
+	return AggTrade{
+		ID:           t.ID,
+		Symbol:       symbol,
+		Price:        price,
+		Quantity:     quantity,
+		Time:         time.UnixMilli(t.TradeTime).UTC(),
+		BuyerIsMaker: t.BuyerIsMaker,
+	}, nil
+}
+
+// GetAggTrades fetches up to limit aggregate trades of symbol, 0 for DefaultTradesLimit,
+// starting at id fromID. Without fromID the most recent trades are returned.
+func (c *Binance) GetAggTrades(ctx context.Context, symbol string, fromID int64, limit int) ([]AggTrade, error) {
+	if limit <= 0 {
+		limit = DefaultTradesLimit
+	}
+	endpoint := fmt.Sprintf("%s?symbol=%s&limit=%d", c.tradesUrl, url.QueryEscape(symbol), min(limit, MaxTradesLimit))
+	if fromID > 0 {
+		endpoint += "&fromId=" + strconv.FormatInt(fromID, 10)
+	}
+
+	var result []aggTrade
+	if err := c.getJSON(ctx, endpoint, &result); err != nil {
+		return nil, err
+	}
+	trades := make([]AggTrade, 0, len(result))
+	for _, raw := range result {
+		trade, err := raw.trade(symbol)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// ParseAggTrade decodes a frame of an aggregate trade stream
+func ParseAggTrade(data []byte) (AggTrade, error) {
+	var raw aggTrade
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return AggTrade{}, fmt.Errorf("%w: %v", ErrMalformedResponse, err)
+	}
+	if raw.Event != "aggTrade" {
+		return AggTrade{}, fmt.Errorf("%w: unexpected event %q", ErrMalformedResponse, raw.Event)
+	}
+	return raw.trade(raw.Symbol)
+}
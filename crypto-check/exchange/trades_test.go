@@ -0,0 +1,70 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetAggTrades(t *testing.T) {
+	tests := []struct {
+		name    string
+		fromID  int64
+		limit   int
+		query   string
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{"Latest", 0, 0, "limit=500&symbol=BTCUSDT", `[{"a":26129,"p":"0.01633102","q":"4.70443515","f":27781,"l":27781,"T":1498793709153,"m":true,"M":true}]`, 1, false},
+		{"From id", 26130, 5000, "fromId=26130&limit=1000&symbol=BTCUSDT", `[]`, 0, false},
+		{"Bad price", 0, 0, "limit=500&symbol=BTCUSDT", `[{"a":1,"p":"x","q":"1","T":1,"m":false}]`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+				if r.URL.Path != "/api/v3/aggTrades" || r.URL.Query().Encode() != tt.query {
+					t.Errorf("request = %s, want aggTrades with %s", r.URL, tt.query)
+				}
+				w.Write([]byte(tt.body))
+			})
+
+			trades, err := client.GetAggTrades(context.Background(), "BTCUSDT", tt.fromID, tt.limit)
+			if tt.wantErr {
+				if !errors.Is(err, ErrMalformedResponse) {
+					t.Errorf("GetAggTrades() error = %v, want ErrMalformedResponse", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAggTrades() error: %v", err)
+			}
+			if len(trades) != tt.want {
+				t.Fatalf("got %d trades, want %d", len(trades), tt.want)
+			}
+			if tt.want > 0 {
+				want := AggTrade{ID: 26129, Symbol: "BTCUSDT", Price: 0.01633102, Quantity: 4.70443515, Time: time.UnixMilli(1498793709153).UTC(), BuyerIsMaker: true}
+				if trades[0] != want {
+					t.Errorf("GetAggTrades() = %+v, want %+v", trades[0], want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAggTrade(t *testing.T) {
+	trade, err := ParseAggTrade([]byte(`{"e":"aggTrade","E":1700000000001,"s":"ETHUSDT","a":7,"p":"3000.5","q":"0.25","f":10,"l":12,"T":1700000000000,"m":false,"M":true}`))
+	if err != nil {
+		t.Fatalf("ParseAggTrade() error: %v", err)
+	}
+	want := AggTrade{ID: 7, Symbol: "ETHUSDT", Price: 3000.5, Quantity: 0.25, Time: time.UnixMilli(1700000000000).UTC()}
+	if trade != want {
+		t.Errorf("ParseAggTrade() = %+v, want %+v", trade, want)
+	}
+
+	if _, err := ParseAggTrade([]byte(`{"e":"depthUpdate","s":"ETHUSDT"}`)); !errors.Is(err, ErrMalformedResponse) {
+		t.Errorf("ParseAggTrade() of another event error = %v, want ErrMalformedResponse", err)
+	}
+}
@@ -0,0 +1,220 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"crypto-check/currency"
+)
+
+// ErrNotListed is returned by a venue that does not trade the requested symbol
+var ErrNotListed = errors.New("symbol not listed")
+
+// Venue names
+const (
+	VenueBinance  = "binance"
+	VenueOKX      = "okx"
+	VenueBybit    = "bybit"
+	VenueCoinbase = "coinbase"
+)
+
+// Default public API of each venue other than Binance
+var defaultVenueURLs = map[string]string{
+	VenueOKX:      "https://www.okx.com",
+	VenueBybit:    "https://api.bybit.com",
+	VenueCoinbase: "https://api.exchange.coinbase.com",
+}
+
+// Quote is the last price of a symbol on one venue
+type Quote struct {
+	Venue  string
+	Symbol string
+	Price  float64
+	Volume float64   // Traded over the last 24 hours, in the base asset
+	Time   time.Time // As stamped by the venue
+}
+
+// Venue is an exchange quoting symbols named the Binance way, e.g. BTCUSDT
+type Venue interface {
+	Name() string
+	GetQuote(ctx context.Context, symbol string) (Quote, error)
+}
+
+// NewVenue creates the client of a venue other than Binance by name. An empty
+// baseURL uses the public API of the venue.
+func NewVenue(name, baseURL string, opts Options) (Venue, error) {
+	if baseURL == "" {
+		baseURL = defaultVenueURLs[name]
+	}
+	// A Binance client brings the retries, rate limiting and circuit breaker, every venue gets its own
+	opts.APIURL = ""
+	client := venueClient{client: NewBinance(opts), baseUrl: strings.TrimSuffix(baseURL, "/")}
+	switch name {
+	case VenueOKX:
+		return &OKX{client}, nil
+	case VenueBybit:
+		return &Bybit{client}, nil
+	case VenueCoinbase:
+		return &Coinbase{client}, nil
+	}
+	return nil, fmt.Errorf("unknown venue %q", name)
+}
+
+type venueClient struct {
+	client  *Binance
+	baseUrl string
+}
+
+// SetTransport replaces the HTTP transport, used to record or replay exchange traffic
+func (v venueClient) SetTransport(rt http.RoundTripper) {
+	v.client.SetTransport(rt)
+}
+
+// getJSON is like Binance.getJSON, except that a 404 means the symbol is not listed
+func (v venueClient) getJSON(ctx context.Context, path, symbol string, result any) error {
+	err := v.client.getJSON(ctx, v.baseUrl+path, result)
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotListed, symbol)
+	}
+	return err
+}
+
+// dashed turns BTCUSDT into BTC-USDT
+func dashed(symbol string) (string, error) {
+	base, quote, err := currency.Split(symbol)
+	if err != nil {
+		return "", err
+	}
+	return base + "-" + quote, nil
+}
+
+// parseQuote parses the price and volume strings of a venue response
+func parseQuote(venue, symbol, price, volume string, at time.Time) (Quote, error) {
+	p, err := strconv.ParseFloat(price, 64)
+	if err != nil {
+		return Quote{}, fmt.Errorf("%w: %s price of %s: %v", ErrMalformedResponse, venue, symbol, err)
+	}
+	v, err := strconv.ParseFloat(volume, 64)
+	if err != nil {
+		return Quote{}, fmt.Errorf("%w: %s volume of %s: %v", ErrMalformedResponse, venue, symbol, err)
+	}
+	return Quote{Venue: venue, Symbol: symbol, Price: p, Volume: v, Time: at}, nil
+}
+
+// ticker24h is the wire format of /api/v3/ticker/24hr
+type ticker24h struct {
+	Symbol    string `json:"symbol"`
+	LastPrice string `json:"lastPrice"`
+	Volume    string `json:"volume"`
+	CloseTime int64  `json:"closeTime"`
+}
+
+func (c *Binance) Name() string { return VenueBinance }
+
+// GetQuote fetches the last price and 24 hour volume of symbol
+func (c *Binance) GetQuote(ctx context.Context, symbol string) (Quote, error) {
+	var result ticker24h
+	if err := c.getJSON(ctx, c.quoteUrl+"?symbol="+url.QueryEscape(symbol), &result); err != nil {
+		return Quote{}, err
+	}
+	return parseQuote(VenueBinance, symbol, result.LastPrice, result.Volume, time.UnixMilli(result.CloseTime).UTC())
+}
+
+// OKX quotes symbols from its spot tickers
+type OKX struct {
+	venueClient
+}
+
+func (o *OKX) Name() string { return VenueOKX }
+
+// GetQuote fetches the last price and 24 hour volume of symbol from /api/v5/market/ticker
+func (o *OKX) GetQuote(ctx context.Context, symbol string) (Quote, error) {
+	instrument, err := dashed(symbol)
+	if err != nil {
+		return Quote{}, err
+	}
+	var result struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			Last   string `json:"last"`
+			Vol24h string `json:"vol24h"`
+			Ts     string `json:"ts"`
+		} `json:"data"`
+	}
+	if err := o.getJSON(ctx, "/api/v5/market/ticker?instId="+url.QueryEscape(instrument), symbol, &result); err != nil {
+		return Quote{}, err
+	}
+	// Unknown instruments come back as an error code with an empty list
+	if result.Code != "0" || len(result.Data) == 0 {
+		return Quote{}, fmt.Errorf("%w: %s on okx (code %s: %s)", ErrNotListed, symbol, result.Code, result.Msg)
+	}
+	ticker := result.Data[0]
+	ts, err := strconv.ParseInt(ticker.Ts, 10, 64)
+	if err != nil {
+		return Quote{}, fmt.Errorf("%w: okx time of %s: %v", ErrMalformedResponse, symbol, err)
+	}
+	return parseQuote(VenueOKX, symbol, ticker.Last, ticker.Vol24h, time.UnixMilli(ts).UTC())
+}
+
+// Bybit quotes symbols from its spot tickers, which are named like Binance's
+type Bybit struct {
+	venueClient
+}
+
+func (b *Bybit) Name() string { return VenueBybit }
+
+// GetQuote fetches the last price and 24 hour volume of symbol from /v5/market/tickers
+func (b *Bybit) GetQuote(ctx context.Context, symbol string) (Quote, error) {
+	var result struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+		Result  struct {
+			List []struct {
+				LastPrice string `json:"lastPrice"`
+				Volume24h string `json:"volume24h"`
+			} `json:"list"`
+		} `json:"result"`
+		Time int64 `json:"time"`
+	}
+	if err := b.getJSON(ctx, "/v5/market/tickers?category=spot&symbol="+url.QueryEscape(symbol), symbol, &result); err != nil {
+		return Quote{}, err
+	}
+	if result.RetCode != 0 || len(result.Result.List) == 0 {
+		return Quote{}, fmt.Errorf("%w: %s on bybit (code %d: %s)", ErrNotListed, symbol, result.RetCode, result.RetMsg)
+	}
+	ticker := result.Result.List[0]
+	return parseQuote(VenueBybit, symbol, ticker.LastPrice, ticker.Volume24h, time.UnixMilli(result.Time).UTC())
+}
+
+// Coinbase quotes symbols from the ticker of its exchange API
+type Coinbase struct {
+	venueClient
+}
+
+func (c *Coinbase) Name() string { return VenueCoinbase }
+
+// GetQuote fetches the last trade and 24 hour volume of symbol from /products/{id}/ticker.
+// The time is the one of the last trade, which lags on quiet products.
+func (c *Coinbase) GetQuote(ctx context.Context, symbol string) (Quote, error) {
+	product, err := dashed(symbol)
+	if err != nil {
+		return Quote{}, err
+	}
+	var result struct {
+		Price  string    `json:"price"`
+		Volume string    `json:"volume"`
+		Time   time.Time `json:"time"`
+	}
+	if err := c.getJSON(ctx, "/products/"+url.PathEscape(product)+"/ticker", symbol, &result); err != nil {
+		return Quote{}, err
+	}
+	return parseQuote(VenueCoinbase, symbol, result.Price, result.Volume, result.Time.UTC())
+}
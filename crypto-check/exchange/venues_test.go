@@ -0,0 +1,109 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVenueQuotes(t *testing.T) {
+	at := time.UnixMilli(1700000000000).UTC()
+	tests := []struct {
+		name    string
+		venue   string
+		symbol  string
+		path    string // Path and query requested
+		status  int
+		body    string
+		want    Quote
+		wantErr error
+	}{
+		{
+			"OKX", VenueOKX, "BTCUSDT", "/api/v5/market/ticker?instId=BTC-USDT", http.StatusOK,
+			`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","last":"60010.5","vol24h":"1234.5","ts":"1700000000000"}]}`,
+			Quote{Venue: VenueOKX, Symbol: "BTCUSDT", Price: 60010.5, Volume: 1234.5, Time: at}, nil,
+		},
+		{
+			"OKX unknown instrument", VenueOKX, "BTCUSDT", "/api/v5/market/ticker?instId=BTC-USDT", http.StatusOK,
+			`{"code":"51001","msg":"Instrument ID does not exist","data":[]}`, Quote{}, ErrNotListed,
+		},
+		{
+			"Bybit", VenueBybit, "ETHUSDT", "/v5/market/tickers?category=spot&symbol=ETHUSDT", http.StatusOK,
+			`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"symbol":"ETHUSDT","lastPrice":"3001","volume24h":"99"}]},"time":1700000000000}`,
+			Quote{Venue: VenueBybit, Symbol: "ETHUSDT", Price: 3001, Volume: 99, Time: at}, nil,
+		},
+		{
+			"Bybit bad price", VenueBybit, "ETHUSDT", "/v5/market/tickers?category=spot&symbol=ETHUSDT", http.StatusOK,
+			`{"retCode":0,"result":{"list":[{"lastPrice":"","volume24h":"1"}]},"time":1}`, Quote{}, ErrMalformedResponse,
+		},
+		{
+			"Coinbase", VenueCoinbase, "BTCUSDT", "/products/BTC-USDT/ticker", http.StatusOK,
+			`{"trade_id":1,"price":"59990.01","size":"0.1","time":"2023-11-14T22:13:20Z","bid":"59990","ask":"59991","volume":"321.5"}`,
+			Quote{Venue: VenueCoinbase, Symbol: "BTCUSDT", Price: 59990.01, Volume: 321.5, Time: at}, nil,
+		},
+		{
+			"Coinbase unknown product", VenueCoinbase, "BNBUSDT", "/products/BNB-USDT/ticker", http.StatusNotFound,
+			`{"message":"NotFound"}`, Quote{}, ErrNotListed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RequestURI() != tt.path {
+					t.Errorf("request = %s, want %s", r.URL.RequestURI(), tt.path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			venue, err := NewVenue(tt.venue, srv.URL, Options{Timeout: time.Second})
+			if err != nil {
+				t.Fatalf("NewVenue() error: %v", err)
+			}
+			if venue.Name() != tt.venue {
+				t.Errorf("Name() = %s, want %s", venue.Name(), tt.venue)
+			}
+			got, err := venue.GetQuote(context.Background(), tt.symbol)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetQuote() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetQuote() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetQuote() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinanceQuote(t *testing.T) {
+	client, _ := newTestClient(t, 0, func(w http.ResponseWriter, r *http.Request, n int) {
+		if r.URL.Path != "/api/v3/ticker/24hr" || r.URL.Query().Get("symbol") != "BTCUSDT" {
+			t.Errorf("request = %s, want the 24hr ticker of BTCUSDT", r.URL)
+		}
+		w.Write([]byte(`{"symbol":"BTCUSDT","lastPrice":"60000.00","volume":"5000.5","openTime":1699913600000,"closeTime":1700000000000}`))
+	})
+
+	got, err := client.GetQuote(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatalf("GetQuote() error: %v", err)
+	}
+	want := Quote{Venue: VenueBinance, Symbol: "BTCUSDT", Price: 60000, Volume: 5000.5, Time: time.UnixMilli(1700000000000).UTC()}
+	if got != want {
+		t.Errorf("GetQuote() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewVenueUnknown(t *testing.T) {
+	if _, err := NewVenue("mtgox", "", Options{}); err == nil {
+		t.Error("NewVenue() of an unknown venue succeeded")
+	}
+}
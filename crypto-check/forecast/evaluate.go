@@ -0,0 +1,68 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+)
+
+// Accuracy sums up the walk-forward forecasts made Step prices ahead
+type Accuracy struct {
+	Step      int
+	Forecasts int
+	MAE       float64
+	RMSE      float64
+	MAPE      float64 // Percent
+	Coverage  float64 // Fraction of prices inside the prediction interval
+	NaiveMAE  float64 // MAE of repeating the last price, the random walk a model has to beat
+}
+
+// Evaluate walks forward through prices, oldest first. At every origin the model
+// is fitted to the train prices before it, as Predict would be live, and its
+// forecasts for the next steps prices are compared with what followed. All steps
+// are measured on the same origins.
+func Evaluate(prices []float64, steps, train int, c Config) ([]Accuracy, error) {
+	if steps < 1 || train < 1 {
+		return nil, fmt.Errorf("%w: steps and train must be at least 1", ErrInvalidConfig)
+	}
+	if len(prices) < train+steps {
+		return nil, fmt.Errorf("%w: evaluating %d steps after %d prices needs %d, got %d",
+			ErrNotEnoughData, steps, train, train+steps, len(prices))
+	}
+
+	accuracy := make([]Accuracy, steps)
+	squared := make([]float64, steps)
+	absPercent := make([]float64, steps)
+	naive := make([]float64, steps)
+	for origin := train; origin+steps <= len(prices); origin++ {
+		res, err := Predict(prices[origin-train:origin], steps, c)
+		if err != nil {
+			return nil, err
+		}
+		last := prices[origin-1]
+		for h, p := range res.Points {
+			actual := prices[origin+h]
+			a := &accuracy[h]
+			a.Forecasts++
+			e := actual - p.Value
+			a.MAE += math.Abs(e)
+			squared[h] += e * e
+			absPercent[h] += math.Abs(e / actual)
+			naive[h] += math.Abs(actual - last)
+			if actual >= p.Lower && actual <= p.Upper {
+				a.Coverage++
+			}
+		}
+	}
+
+	for h := range accuracy {
+		a := &accuracy[h]
+		n := float64(a.Forecasts)
+		a.Step = h + 1
+		a.MAE /= n
+		a.RMSE = math.Sqrt(squared[h] / n)
+		a.MAPE = 100 * absPercent[h] / n
+		a.Coverage /= n
+		a.NaiveMAE = naive[h] / n
+	}
+	return accuracy, nil
+}
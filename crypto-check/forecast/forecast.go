@@ -0,0 +1,324 @@
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Models, all fitted to log prices so that intervals scale with the price
+const (
+	ModelEWMA = "ewma" // Simple exponential smoothing, a flat forecast at the smoothed level
+	ModelHolt = "holt" // Holt's linear trend, i.e. Holt-Winters without a season
+	ModelAR   = "ar"   // Autoregression of the log returns fitted by least squares
+)
+
+var (
+	ErrInvalidConfig = errors.New("invalid forecast config")
+	ErrNotEnoughData = errors.New("not enough prices")
+)
+
+// Config selects and tunes a model. Zero fields are fitted or take a default.
+type Config struct {
+	Model string  // ModelEWMA, ModelHolt or ModelAR, empty for ModelHolt
+	Alpha float64 // Level smoothing, 0 picks the value with the smallest one-step error
+	Beta  float64 // Trend smoothing of ModelHolt, 0 picks it the same way
+	Order int     // Lags of ModelAR, 0 for 2
+	Level float64 // Coverage of the prediction intervals, 0 for 0.95
+}
+
+func (c Config) withDefaults() (Config, error) {
+	if c.Model == "" {
+		c.Model = ModelHolt
+	}
+	if c.Order == 0 {
+		c.Order = 2
+	}
+	if c.Level == 0 {
+		c.Level = 0.95
+	}
+	switch {
+	case c.Model != ModelEWMA && c.Model != ModelHolt && c.Model != ModelAR:
+		return c, fmt.Errorf("%w: unknown model %q", ErrInvalidConfig, c.Model)
+	case c.Alpha < 0 || c.Alpha >= 1 || c.Beta < 0 || c.Beta >= 1:
+		return c, fmt.Errorf("%w: alpha and beta must be between 0 and 1", ErrInvalidConfig)
+	case c.Order < 1:
+		return c, fmt.Errorf("%w: order must be at least 1", ErrInvalidConfig)
+	case c.Level <= 0 || c.Level >= 1:
+		return c, fmt.Errorf("%w: level must be between 0 and 1", ErrInvalidConfig)
+	}
+	return c, nil
+}
+
+// minPrices is the history needed to fit c
+func (c Config) minPrices() int {
+	switch c.Model {
+	case ModelEWMA:
+		return 3
+	case ModelHolt:
+		return 4
+	default:
+		return 4 * (c.Order + 1) // Three returns per coefficient, on top of the first lags
+	}
+}
+
+// Point is the forecast Step prices ahead with its prediction interval
+type Point struct {
+	Step  int
+	Value float64
+	Lower float64
+	Upper float64
+}
+
+// Result is a fitted model and its forecast
+type Result struct {
+	Model        string
+	Alpha        float64   // ModelEWMA and ModelHolt
+	Beta         float64   // ModelHolt
+	Coefficients []float64 // ModelAR: the intercept, then one per lag
+	Sigma        float64   // Standard deviation of the one-step errors, in log price
+	Points       []Point
+}
+
+// fit is a model fitted to log prices
+type fit struct {
+	alpha, beta  float64
+	coefficients []float64
+	sse          float64   // Of the one-step errors
+	dof          int       // Errors minus fitted parameters
+	path         []float64 // Log price forecast per step
+	variance     []float64 // Forecast variance per step, in one-step variances
+}
+
+// Predict fits the model of c to prices, oldest first, and forecasts the next steps
+func Predict(prices []float64, steps int, c Config) (Result, error) {
+	c, err := c.withDefaults()
+	if err != nil {
+		return Result{}, err
+	}
+	if steps < 1 {
+		return Result{}, fmt.Errorf("%w: steps must be at least 1", ErrInvalidConfig)
+	}
+	if len(prices) < c.minPrices() {
+		return Result{}, fmt.Errorf("%w: %s needs %d, got %d", ErrNotEnoughData, c.Model, c.minPrices(), len(prices))
+	}
+	y := make([]float64, len(prices))
+	for i, p := range prices {
+		if p <= 0 {
+			return Result{}, fmt.Errorf("%w: price %v is not positive", ErrInvalidConfig, p)
+		}
+		y[i] = math.Log(p)
+	}
+
+	var f fit
+	switch c.Model {
+	case ModelEWMA:
+		f = fitSmoothing(y, steps, c.Alpha, 0, false)
+	case ModelHolt:
+		f = fitSmoothing(y, steps, c.Alpha, c.Beta, true)
+	default:
+		f = fitAR(y, steps, c.Order)
+	}
+
+	res := Result{Model: c.Model, Alpha: f.alpha, Beta: f.beta, Coefficients: f.coefficients}
+	if f.dof > 0 {
+		res.Sigma = math.Sqrt(f.sse / float64(f.dof))
+	}
+	z := math.Sqrt2 * math.Erfinv(c.Level)
+	for h := range steps {
+		spread := z * res.Sigma * math.Sqrt(f.variance[h])
+		res.Points = append(res.Points, Point{
+			Step:  h + 1,
+			Value: math.Exp(f.path[h]),
+			Lower: math.Exp(f.path[h] - spread),
+			Upper: math.Exp(f.path[h] + spread),
+		})
+	}
+	return res, nil
+}
+
+// grid holds the smoothing parameters tried when one is not given
+var grid = func() []float64 {
+	var g []float64
+	for i := 1; i < 20; i++ {
+		g = append(g, float64(i)/20)
+	}
+	return g
+}()
+
+// fitSmoothing fits simple exponential smoothing, or Holt's method with trend,
+// choosing unset parameters by the smallest sum of squared one-step errors
+func fitSmoothing(y []float64, steps int, alpha, beta float64, trend bool) fit {
+	alphas, betas := []float64{alpha}, []float64{beta}
+	if alpha == 0 {
+		alphas = grid
+	}
+	if !trend {
+		betas = []float64{0}
+	} else if beta == 0 {
+		betas = grid
+	}
+
+	best := fit{sse: math.Inf(1)}
+	var level, slope float64
+	for _, a := range alphas {
+		for _, b := range betas {
+			sse, l, s := smooth(y, a, b, trend)
+			if sse < best.sse {
+				best = fit{alpha: a, beta: b, sse: sse}
+				level, slope = l, s
+			}
+		}
+	}
+
+	n, params := len(y)-1, 1
+	if trend {
+		n, params = len(y)-2, 2
+	}
+	best.dof = n - params
+	for h := 1; h <= steps; h++ {
+		best.path = append(best.path, level+float64(h)*slope)
+		// Each future error moves the level by alpha and the slope by alpha*beta
+		v := 1.0
+		for j := 1; j < h; j++ {
+			c := best.alpha * (1 + float64(j)*best.beta)
+			v += c * c
+		}
+		best.variance = append(best.variance, v)
+	}
+	return best
+}
+
+// smooth runs the error correction form of the smoothing over y and returns the
+// sum of squared one-step errors with the final level and slope
+func smooth(y []float64, alpha, beta float64, trend bool) (sse, level, slope float64) {
+	level, first := y[0], 1
+	if trend {
+		level, slope, first = y[1], y[1]-y[0], 2
+	}
+	for _, v := range y[first:] {
+		e := v - (level + slope)
+		sse += e * e
+		level += slope + alpha*e
+		if trend {
+			slope += alpha * beta * e
+		}
+	}
+	return sse, level, slope
+}
+
+// fitAR fits r[t] = c + φ1 r[t-1] + ... + φp r[t-p] to the log returns by least squares
+func fitAR(y []float64, steps, order int) fit {
+	returns := make([]float64, len(y)-1)
+	for i := range returns {
+		returns[i] = y[i+1] - y[i]
+	}
+
+	// Normal equations of the regression on an intercept and the lags
+	k := order + 1
+	xtx := make([][]float64, k)
+	for i := range xtx {
+		xtx[i] = make([]float64, k)
+	}
+	xty := make([]float64, k)
+	row := make([]float64, k)
+	for t := order; t < len(returns); t++ {
+		row[0] = 1
+		for i := 1; i <= order; i++ {
+			row[i] = returns[t-i]
+		}
+		for i := range k {
+			xty[i] += row[i] * returns[t]
+			for j := range k {
+				xtx[i][j] += row[i] * row[j]
+			}
+		}
+	}
+	coefficients, ok := solve(xtx, xty)
+	if !ok {
+		// Lags that never vary, e.g. a flat price, leave a random walk with drift
+		coefficients = make([]float64, k)
+		coefficients[0] = mean(returns)
+	}
+
+	predict := func(history []float64, t int) float64 {
+		r := coefficients[0]
+		for i := 1; i <= order; i++ {
+			r += coefficients[i] * history[t-i]
+		}
+		return r
+	}
+	f := fit{coefficients: coefficients, dof: len(returns) - order - k}
+	for t := order; t < len(returns); t++ {
+		e := returns[t] - predict(returns, t)
+		f.sse += e * e
+	}
+
+	// A log price error is the sum of the return errors, each propagated by the
+	// impulse response psi of the autoregression
+	extended := append([]float64(nil), returns...)
+	psi := []float64{1}
+	level, cumulative, variance := y[len(y)-1], 0.0, 0.0
+	for h := 1; h <= steps; h++ {
+		r := predict(extended, len(extended))
+		extended = append(extended, r)
+		level += r
+		f.path = append(f.path, level)
+
+		cumulative += psi[h-1]
+		variance += cumulative * cumulative
+		f.variance = append(f.variance, variance)
+
+		var next float64
+		for i := 1; i <= min(order, h); i++ {
+			next += coefficients[i] * psi[h-i]
+		}
+		psi = append(psi, next)
+	}
+	return f
+}
+
+// solve solves a x = b by Gaussian elimination with partial pivoting. a and b are overwritten.
+func solve(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	for col := range n {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-15 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for r := col + 1; r < n; r++ {
+			factor := a[r][col] / a[col][col]
+			for c := col; c < n; c++ {
+				a[r][c] -= factor * a[col][c]
+			}
+			b[r] -= factor * b[col]
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		sum := b[r]
+		for c := r + 1; c < n; c++ {
+			sum -= a[r][c] * x[c]
+		}
+		x[r] = sum / a[r][r]
+	}
+	return x, true
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,142 @@
+package forecast
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// growth returns n prices rising 1% per step
+func growth(n int) []float64 {
+	prices := make([]float64, n)
+	for i := range prices {
+		prices[i] = 100 * math.Pow(1.01, float64(i))
+	}
+	return prices
+}
+
+// noisy returns n prices with a deterministic up and down pattern
+func noisy(n int) []float64 {
+	prices := make([]float64, n)
+	for i := range prices {
+		prices[i] = 100 * math.Exp(0.01*math.Sin(float64(i)*1.7)+0.002*math.Cos(float64(i)*0.3))
+	}
+	return prices
+}
+
+func TestPredict(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []float64
+		config Config
+		want   []float64
+	}{
+		{"Holt follows a steady trend", growth(30), Config{Model: ModelHolt}, []float64{100 * math.Pow(1.01, 30), 100 * math.Pow(1.01, 31)}},
+		{"EWMA is flat", []float64{50, 50, 50, 50}, Config{Model: ModelEWMA}, []float64{50, 50}},
+		{"AR on a steady trend", growth(30), Config{Model: ModelAR, Order: 1}, []float64{100 * math.Pow(1.01, 30), 100 * math.Pow(1.01, 31)}},
+		{"AR on a flat price", []float64{7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7}, Config{Model: ModelAR}, []float64{7, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := Predict(tt.prices, len(tt.want), tt.config)
+			if err != nil {
+				t.Fatalf("Predict() error: %v", err)
+			}
+			for i, p := range res.Points {
+				if math.Abs(p.Value-tt.want[i]) > 1e-6 || p.Lower > p.Value || p.Upper < p.Value {
+					t.Errorf("step %d = %+v, want %v", p.Step, p, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestARCoefficients(t *testing.T) {
+	// Returns following r[t] = 0.001 + 0.5 r[t-1] exactly
+	prices, r := []float64{100}, 0.01
+	for range 40 {
+		prices = append(prices, prices[len(prices)-1]*math.Exp(r))
+		r = 0.001 + 0.5*r
+	}
+	res, err := Predict(prices, 1, Config{Model: ModelAR, Order: 1})
+	if err != nil {
+		t.Fatalf("Predict() error: %v", err)
+	}
+	if math.Abs(res.Coefficients[0]-0.001) > 1e-9 || math.Abs(res.Coefficients[1]-0.5) > 1e-6 {
+		t.Errorf("coefficients = %v, want [0.001 0.5]", res.Coefficients)
+	}
+}
+
+func TestPredictionIntervals(t *testing.T) {
+	// With a fixed alpha the variance of step h is 1 + (h-1) alpha² one-step variances
+	res, err := Predict(noisy(60), 3, Config{Model: ModelEWMA, Alpha: 0.5})
+	if err != nil {
+		t.Fatalf("Predict() error: %v", err)
+	}
+	z := 1.959963984540054
+	for i, want := range []float64{1, 1.25, 1.5} {
+		p := res.Points[i]
+		if spread := math.Log(p.Upper / p.Value); math.Abs(spread-z*res.Sigma*math.Sqrt(want)) > 1e-12 {
+			t.Errorf("step %d spread = %v, want %v", p.Step, spread, z*res.Sigma*math.Sqrt(want))
+		}
+	}
+
+	// Intervals widen with the horizon for every model
+	for _, model := range []string{ModelEWMA, ModelHolt, ModelAR} {
+		res, err := Predict(noisy(60), 5, Config{Model: model})
+		if err != nil {
+			t.Fatalf("Predict(%s) error: %v", model, err)
+		}
+		if res.Sigma <= 0 {
+			t.Errorf("%s sigma = %v, want > 0", model, res.Sigma)
+		}
+		for i := 1; i < len(res.Points); i++ {
+			if res.Points[i].Upper-res.Points[i].Lower <= res.Points[i-1].Upper-res.Points[i-1].Lower {
+				t.Errorf("%s step %d interval is not wider than the one before: %+v", model, i+1, res.Points)
+			}
+		}
+	}
+}
+
+func TestPredictErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []float64
+		config Config
+		want   error
+	}{
+		{"Unknown model", growth(30), Config{Model: "arima"}, ErrInvalidConfig},
+		{"Alpha out of range", growth(30), Config{Alpha: 1.5}, ErrInvalidConfig},
+		{"Too few prices for holt", growth(3), Config{}, ErrNotEnoughData},
+		{"Too few prices for ar", growth(10), Config{Model: ModelAR, Order: 3}, ErrNotEnoughData},
+	}
+	for _, tt := range tests {
+		if _, err := Predict(tt.prices, 1, tt.config); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Predict() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	prices := growth(50)
+	accuracy, err := Evaluate(prices, 3, 20, Config{Model: ModelHolt})
+	if err != nil {
+		t.Fatalf("Evaluate() error: %v", err)
+	}
+	if len(accuracy) != 3 {
+		t.Fatalf("got %d steps, want 3", len(accuracy))
+	}
+	for _, a := range accuracy {
+		if a.Forecasts != 28 {
+			t.Errorf("step %d has %d forecasts, want 28", a.Step, a.Forecasts)
+		}
+		// The trend is exact, repeating the last price misses it by about a percent per step
+		if a.MAE > 1e-9 || a.MAPE > 1e-9 || a.NaiveMAE < float64(a.Step) {
+			t.Errorf("step %d = %+v, want a perfect forecast beating the naive one", a.Step, a)
+		}
+	}
+
+	if _, err := Evaluate(prices, 3, 48, Config{}); !errors.Is(err, ErrNotEnoughData) {
+		t.Errorf("Evaluate() with a short history error = %v, want %v", err, ErrNotEnoughData)
+	}
+}
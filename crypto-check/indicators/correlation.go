@@ -0,0 +1,110 @@
+package indicators
+
+import (
+	"math"
+	"sort"
+)
+
+// LogReturns returns ln(p[i]/p[i-1]) for prices ordered oldest first
+func LogReturns(prices []float64) []float64 {
+	if len(prices) < 2 {
+		return nil
+	}
+	returns := make([]float64, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		returns[i-1] = math.Log(prices[i] / prices[i-1])
+	}
+	return returns
+}
+
+// Pearson returns the linear correlation of two equally long series.
+// It is 0 when either series is constant.
+func Pearson(x, y []float64) float64 {
+	n := min(len(x), len(y))
+	if n < 2 {
+		return 0
+	}
+	mx, my := mean(x[:n]), mean(y[:n])
+	var cov, vx, vy float64
+	for i := 0; i < n; i++ {
+		dx, dy := x[i]-mx, y[i]-my
+		cov += dx * dy
+		vx += dx * dx
+		vy += dy * dy
+	}
+	if vx == 0 || vy == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(vx*vy)
+}
+
+// Spearman returns the rank correlation of two equally long series
+func Spearman(x, y []float64) float64 {
+	n := min(len(x), len(y))
+	return Pearson(ranks(x[:n]), ranks(y[:n]))
+}
+
+// Beta returns the sensitivity of asset returns to benchmark returns, cov/var.
+// It is 0 when the benchmark did not move.
+func Beta(asset, benchmark []float64) float64 {
+	n := min(len(asset), len(benchmark))
+	if n < 2 {
+		return 0
+	}
+	ma, mb := mean(asset[:n]), mean(benchmark[:n])
+	var cov, vb float64
+	for i := 0; i < n; i++ {
+		db := benchmark[i] - mb
+		cov += (asset[i] - ma) * db
+		vb += db * db
+	}
+	if vb == 0 {
+		return 0
+	}
+	return cov / vb
+}
+
+// RollingBeta returns the beta over each window of returns. Element i covers
+// returns i-window+1..i, so the result is window-1 shorter than the input.
+func RollingBeta(asset, benchmark []float64, window int) []float64 {
+	n := min(len(asset), len(benchmark))
+	if window < 2 || n < window {
+		return nil
+	}
+	betas := make([]float64, 0, n-window+1)
+	for i := window; i <= n; i++ {
+		betas = append(betas, Beta(asset[i-window:i], benchmark[i-window:i]))
+	}
+	return betas
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// ranks assigns 1-based ranks, ties share the average of their ranks
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return values[order[a]] < values[order[b]] })
+
+	r := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		avg := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			r[order[k]] = avg
+		}
+		i = j + 1
+	}
+	return r
+}
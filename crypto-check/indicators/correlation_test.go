@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCorrelation(t *testing.T) {
+	tests := []struct {
+		name         string
+		x, y         []float64
+		wantPearson  float64
+		wantSpearman float64
+	}{
+		{"Identical", []float64{1, 2, 3, 4}, []float64{1, 2, 3, 4}, 1, 1},
+		{"Opposite", []float64{1, 2, 3, 4}, []float64{8, 6, 4, 2}, -1, -1},
+		{"Monotonic but not linear", []float64{1, 2, 3, 4}, []float64{1, 4, 9, 100}, 0.8159778353068635, 1},
+		{"Ties share ranks", []float64{1, 1, 2, 3}, []float64{1, 2, 3, 4}, 0.9438798074485389, 0.9486832980505138},
+		{"Constant series", []float64{5, 5, 5}, []float64{1, 2, 3}, 0, 0},
+		{"Too short", []float64{1}, []float64{1}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Pearson(tt.x, tt.y); math.Abs(got-tt.wantPearson) > 1e-9 {
+				t.Errorf("Pearson() = %v, want %v", got, tt.wantPearson)
+			}
+			if got := Spearman(tt.x, tt.y); math.Abs(got-tt.wantSpearman) > 1e-9 {
+				t.Errorf("Spearman() = %v, want %v", got, tt.wantSpearman)
+			}
+		})
+	}
+}
+
+func TestBeta(t *testing.T) {
+	benchmark := []float64{0.01, -0.02, 0.03, 0.01, -0.01}
+	asset := make([]float64, len(benchmark))
+	for i, r := range benchmark {
+		asset[i] = 2 * r
+	}
+
+	if got := Beta(asset, benchmark); math.Abs(got-2) > 1e-9 {
+		t.Errorf("Beta() = %v, want 2", got)
+	}
+	rolling := RollingBeta(asset, benchmark, 3)
+	if len(rolling) != 3 {
+		t.Fatalf("RollingBeta() returned %d values, want 3", len(rolling))
+	}
+	for i, b := range rolling {
+		if math.Abs(b-2) > 1e-9 {
+			t.Errorf("RollingBeta()[%d] = %v, want 2", i, b)
+		}
+	}
+}
+
+func TestLogReturns(t *testing.T) {
+	got := LogReturns([]float64{100, 110, 99})
+	want := []float64{math.Log(1.1), math.Log(0.9)}
+	if len(got) != len(want) {
+		t.Fatalf("LogReturns() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("LogReturns()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
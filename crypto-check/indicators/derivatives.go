@@ -0,0 +1,22 @@
+package indicators
+
+import "time"
+
+// AnnualizedFunding turns a funding rate paid every interval into a yearly rate in
+// percent, without compounding. 0.01% every 8 hours is 10.95% a year.
+func AnnualizedFunding(rate float64, interval time.Duration) float64 {
+	if interval <= 0 {
+		return 0
+	}
+	periods := float64(365*24*time.Hour) / float64(interval)
+	return rate * periods * 100
+}
+
+// BasisBPS returns how far the futures price is above the spot price, in basis
+// points of the spot price. It is 0 without a spot price.
+func BasisBPS(futures, spot float64) float64 {
+	if spot == 0 {
+		return 0
+	}
+	return (futures - spot) / spot * 10000
+}
@@ -0,0 +1,48 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAnnualizedFunding(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		interval time.Duration
+		want     float64
+	}{
+		{"Eight hours", 0.0001, 8 * time.Hour, 10.95},
+		{"Four hours", 0.0001, 4 * time.Hour, 21.9},
+		{"Negative", -0.0005, 8 * time.Hour, -54.75},
+		{"No interval", 0.0001, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AnnualizedFunding(tt.rate, tt.interval); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("AnnualizedFunding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBasisBPS(t *testing.T) {
+	tests := []struct {
+		name    string
+		futures float64
+		spot    float64
+		want    float64
+	}{
+		{"Contango", 60060, 60000, 10},
+		{"Backwardation", 59940, 60000, -10},
+		{"No spot price", 60000, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BasisBPS(tt.futures, tt.spot); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("BasisBPS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
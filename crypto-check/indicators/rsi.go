@@ -1,5 +1,6 @@
-package main
+package indicators
 
+// CalculateRSI returns the RSI of prices ordered oldest first, using simple averages of the moves
 func CalculateRSI(prices []float64) float64 {
 	if len(prices) < 2 {
 		return 50.0 // Недостаточно данных
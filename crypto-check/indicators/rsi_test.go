@@ -0,0 +1,27 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateRSI(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []float64
+		want   float64
+	}{
+		{"Not enough data", []float64{100}, 50},
+		{"Only gains", []float64{100, 101, 102}, 100},
+		{"Only losses", []float64{102, 101, 100}, 0},
+		{"Mixed moves", []float64{60000, 60100, 60050, 60300, 60900}, 95},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateRSI(tt.prices); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CalculateRSI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
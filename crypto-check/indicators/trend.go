@@ -0,0 +1,105 @@
+package indicators
+
+import "math"
+
+// The series below have one value per input value, oldest first. Values before
+// a series has enough input to be computed are NaN.
+
+// SMA returns the simple moving average of the last period values
+func SMA(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	if period <= 0 {
+		return out
+	}
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+// EMA returns the exponential moving average with a smoothing of 2/(period+1),
+// started from the simple average of the first period values. NaN values at the
+// start of the input, e.g. of another series warming up, are skipped.
+func EMA(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	start := 0
+	for start < len(values) && math.IsNaN(values[start]) {
+		start++
+	}
+	if period <= 0 || len(values)-start < period {
+		return out
+	}
+	alpha := 2 / float64(period+1)
+	var sum float64
+	for _, v := range values[start : start+period] {
+		sum += v
+	}
+	i := start + period - 1
+	out[i] = sum / float64(period)
+	for i++; i < len(values); i++ {
+		out[i] = out[i-1] + alpha*(values[i]-out[i-1])
+	}
+	return out
+}
+
+// Bollinger returns the moving average of period values and the bands k
+// standard deviations above and below it
+func Bollinger(values []float64, period int, k float64) (middle, upper, lower []float64) {
+	middle = SMA(values, period)
+	upper, lower = nanSeries(len(values)), nanSeries(len(values))
+	for i := range values {
+		if math.IsNaN(middle[i]) {
+			continue
+		}
+		var variance float64
+		for _, v := range values[i-period+1 : i+1] {
+			variance += (v - middle[i]) * (v - middle[i])
+		}
+		sd := math.Sqrt(variance / float64(period))
+		upper[i], lower[i] = middle[i]+k*sd, middle[i]-k*sd
+	}
+	return middle, upper, lower
+}
+
+// RSISeries returns the RSI of the last period moves, computed as CalculateRSI does
+func RSISeries(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	if period <= 0 {
+		return out
+	}
+	for i := period; i < len(values); i++ {
+		out[i] = CalculateRSI(values[i-period : i+1])
+	}
+	return out
+}
+
+// MACD returns the gap between the fast and the slow EMA, its signal EMA and the
+// histogram of the gap over the signal
+func MACD(values []float64, fast, slow, signal int) (macd, signalLine, histogram []float64) {
+	fastEMA, slowEMA := EMA(values, fast), EMA(values, slow)
+	macd = make([]float64, len(values))
+	for i := range values {
+		macd[i] = fastEMA[i] - slowEMA[i] // NaN until both are warm
+	}
+	signalLine = EMA(macd, signal)
+	histogram = make([]float64, len(values))
+	for i := range values {
+		histogram[i] = macd[i] - signalLine[i]
+	}
+	return macd, signalLine, histogram
+}
+
+func nanSeries(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
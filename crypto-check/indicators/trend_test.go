@@ -0,0 +1,65 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMovingAverages(t *testing.T) {
+	nan := math.NaN()
+	middle, upper, lower := Bollinger([]float64{2, 4, 6}, 3, 2)
+	flatMiddle, flatUpper, _ := Bollinger([]float64{5, 5, 5, 5}, 3, 2)
+
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{"SMA", SMA([]float64{1, 2, 3, 4, 5}, 3), []float64{nan, nan, 2, 3, 4}},
+		{"SMA longer than the input", SMA([]float64{1, 2}, 3), []float64{nan, nan}},
+		{"EMA", EMA([]float64{2, 4, 6, 8, 4}, 3), []float64{nan, nan, 4, 6, 5}},
+		{"EMA of a warming up series", EMA([]float64{nan, 2, 4, 6, 8}, 3), []float64{nan, nan, nan, 4, 6}},
+		{"EMA without enough input", EMA([]float64{nan, 2, 4}, 3), []float64{nan, nan, nan}},
+		{"Bollinger middle", middle, []float64{nan, nan, 4}},
+		{"Bollinger upper", upper, []float64{nan, nan, 4 + 2*math.Sqrt(8.0/3)}},
+		{"Bollinger lower", lower, []float64{nan, nan, 4 - 2*math.Sqrt(8.0/3)}},
+		{"Bollinger of flat prices", flatUpper, flatMiddle},
+		{"RSI", RSISeries([]float64{1, 2, 3, 2, 3}, 2), []float64{nan, nan, 100, 50, 50}},
+		{"Empty", SMA(nil, 3), []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !equalSeries(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMACD(t *testing.T) {
+	// Both EMAs of a straight line lag it by a constant, half a step apart
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	macd, signal, histogram := MACD(values, 2, 3, 2)
+	if !math.IsNaN(macd[1]) || math.Abs(macd[2]-0.5) > 1e-12 || math.Abs(macd[9]-0.5) > 1e-12 {
+		t.Errorf("MACD = %v, want NaN until the slow EMA, then 0.5", macd)
+	}
+	if !math.IsNaN(signal[2]) || math.Abs(signal[9]-0.5) > 1e-12 {
+		t.Errorf("signal = %v, want NaN for one more value, then 0.5", signal)
+	}
+	if math.Abs(histogram[9]) > 1e-12 {
+		t.Errorf("histogram = %v, want 0", histogram)
+	}
+}
+
+// equalSeries compares series with a tolerance, NaN equal to NaN
+func equalSeries(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.IsNaN(got[i]) != math.IsNaN(want[i]) || math.Abs(got[i]-want[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package indicators
+
+import (
+	"math"
+	"time"
+)
+
+// Volatility regimes, from the percentile of the current estimate in the symbol's own history
+const (
+	RegimeUnknown = "UNKNOWN" // Not enough history to rank against
+	RegimeLow     = "LOW"
+	RegimeNormal  = "NORMAL"
+	RegimeHigh    = "HIGH"
+	RegimeExtreme = "EXTREME"
+)
+
+// MinRegimeSamples is the history needed before a regime is reported
+const MinRegimeSamples = 10
+
+// year is used to annualize, crypto trades around the clock
+const year = 365 * 24 * time.Hour
+
+// CloseToClose returns the sample standard deviation of the log returns of closes
+func CloseToClose(closes []float64) float64 {
+	returns := LogReturns(closes)
+	if len(returns) < 2 {
+		return 0
+	}
+	m := mean(returns)
+	var sum float64
+	for _, r := range returns {
+		sum += (r - m) * (r - m)
+	}
+	return math.Sqrt(sum / float64(len(returns)-1))
+}
+
+// Parkinson estimates the volatility per candle from the high-low ranges
+func Parkinson(highs, lows []float64) float64 {
+	n := min(len(highs), len(lows))
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		hl := math.Log(highs[i] / lows[i])
+		sum += hl * hl
+	}
+	return math.Sqrt(sum / (4 * float64(n) * math.Ln2))
+}
+
+// GarmanKlass estimates the volatility per candle from open, high, low and close
+func GarmanKlass(opens, highs, lows, closes []float64) float64 {
+	n := min(len(opens), len(highs), len(lows), len(closes))
+	if n == 0 {
+		return 0
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		hl := math.Log(highs[i] / lows[i])
+		co := math.Log(closes[i] / opens[i])
+		sum += 0.5*hl*hl - (2*math.Ln2-1)*co*co
+	}
+	return math.Sqrt(max(sum, 0) / float64(n))
+}
+
+// Annualize scales a volatility per interval to a year
+func Annualize(perInterval float64, interval time.Duration) float64 {
+	if interval <= 0 {
+		return 0
+	}
+	return perInterval * math.Sqrt(float64(year)/float64(interval))
+}
+
+// PercentileRank returns the percentage of history at or below value
+func PercentileRank(value float64, history []float64) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+	var below int
+	for _, h := range history {
+		if h <= value {
+			below++
+		}
+	}
+	return 100 * float64(below) / float64(len(history))
+}
+
+// Regime classifies a volatility percentile computed over samples estimates
+func Regime(percentile float64, samples int) string {
+	switch {
+	case samples < MinRegimeSamples:
+		return RegimeUnknown
+	case percentile < 20:
+		return RegimeLow
+	case percentile < 80:
+		return RegimeNormal
+	case percentile < 95:
+		return RegimeHigh
+	default:
+		return RegimeExtreme
+	}
+}
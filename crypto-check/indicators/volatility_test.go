@@ -0,0 +1,60 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestVolatilityEstimators(t *testing.T) {
+	opens := []float64{99, 100, 110, 99}
+	highs := []float64{105, 112, 111, 106}
+	lows := []float64{98, 101, 97, 99}
+	closes := []float64{100, 110, 99, 104}
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"Close to close", CloseToClose(closes), 0.10511838593738158},
+		{"Parkinson", Parkinson(highs, lows), 0.05875904636403277},
+		{"Garman-Klass", GarmanKlass(opens, highs, lows, closes), 0.05091983594762577},
+		{"Flat prices", CloseToClose([]float64{5, 5, 5}), 0},
+		{"Not enough closes", CloseToClose([]float64{5, 6}), 0},
+		{"Hourly to yearly", Annualize(0.01, time.Hour), 0.9359487165438073},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > 1e-12 {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegime(t *testing.T) {
+	history := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0}
+
+	tests := []struct {
+		value       float64
+		samples     []float64
+		wantPercent float64
+		wantRegime  string
+	}{
+		{0.05, history, 0, RegimeLow},
+		{0.5, history, 50, RegimeNormal},
+		{0.85, history, 80, RegimeHigh},
+		{2, history, 100, RegimeExtreme},
+		{2, history[:3], 100, RegimeUnknown},
+	}
+	for _, tt := range tests {
+		p := PercentileRank(tt.value, tt.samples)
+		if p != tt.wantPercent {
+			t.Errorf("PercentileRank(%v) = %v, want %v", tt.value, p, tt.wantPercent)
+		}
+		if got := Regime(p, len(tt.samples)); got != tt.wantRegime {
+			t.Errorf("Regime(%v, %d) = %s, want %s", p, len(tt.samples), got, tt.wantRegime)
+		}
+	}
+}
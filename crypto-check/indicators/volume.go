@@ -0,0 +1,46 @@
+package indicators
+
+// VWAP returns the average of prices weighted by volumes, 0 without volume
+func VWAP(prices, volumes []float64) float64 {
+	n := min(len(prices), len(volumes))
+	var notional, total float64
+	for i := 0; i < n; i++ {
+		notional += prices[i] * volumes[i]
+		total += volumes[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	return notional / total
+}
+
+// OBV returns the on-balance volume after each close: the volume is added when
+// the close rises, subtracted when it falls and ignored when it is unchanged.
+// The first close has nothing to compare to and starts at 0.
+func OBV(closes, volumes []float64) []float64 {
+	n := min(len(closes), len(volumes))
+	obv := make([]float64, n)
+	for i := 1; i < n; i++ {
+		obv[i] = obv[i-1]
+		switch {
+		case closes[i] > closes[i-1]:
+			obv[i] += volumes[i]
+		case closes[i] < closes[i-1]:
+			obv[i] -= volumes[i]
+		}
+	}
+	return obv
+}
+
+// CVD returns the cumulative volume delta after each period, the running total of
+// the volume bought by takers minus the volume they sold
+func CVD(buys, sells []float64) []float64 {
+	n := min(len(buys), len(sells))
+	cvd := make([]float64, n)
+	var total float64
+	for i := 0; i < n; i++ {
+		total += buys[i] - sells[i]
+		cvd[i] = total
+	}
+	return cvd
+}
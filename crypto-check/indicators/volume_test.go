@@ -0,0 +1,54 @@
+package indicators
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVWAP(t *testing.T) {
+	tests := []struct {
+		name    string
+		prices  []float64
+		volumes []float64
+		want    float64
+	}{
+		{"Weighted", []float64{100, 110}, []float64{3, 1}, 102.5},
+		{"Equal volumes", []float64{100, 110}, []float64{1, 1}, 105},
+		{"No volume", []float64{100, 110}, []float64{0, 0}, 0},
+		{"Empty", nil, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VWAP(tt.prices, tt.volumes); got != tt.want {
+				t.Errorf("VWAP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOBV(t *testing.T) {
+	tests := []struct {
+		name    string
+		closes  []float64
+		volumes []float64
+		want    []float64
+	}{
+		{"Up, down and flat", []float64{10, 11, 10.5, 10.5, 12}, []float64{5, 2, 3, 4, 1}, []float64{0, 2, -1, -1, 0}},
+		{"Single close", []float64{10}, []float64{5}, []float64{0}},
+		{"Empty", nil, nil, []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OBV(tt.closes, tt.volumes); !slices.Equal(got, tt.want) {
+				t.Errorf("OBV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCVD(t *testing.T) {
+	got := CVD([]float64{3, 1, 2}, []float64{1, 4, 2})
+	if want := []float64{2, -1, -1}; !slices.Equal(got, want) {
+		t.Errorf("CVD() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,571 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/analytics"
+	"crypto-check/api"
+	"crypto-check/clock"
+	"crypto-check/collector"
+	"crypto-check/exchange"
+	"crypto-check/orderbook"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+	"crypto-check/store"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Prices returned by the fake exchange, one entry per poll
+var (
+	btcPrices = []float64{60000, 60100, 60050, 60300, 60900}
+	ethPrices = []float64{3000, 3010, 3005, 3020, 3030}
+)
+
+// startAnalytics serves the analytics service over an in-memory listener
+func startAnalytics(t *testing.T, st *store.Store) analyticsv1.AnalyticsServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	// Per-symbol thresholds: ETH is only overbought from 90, BTC rockets from 1% above its average
+	classifier, err := signals.NewClassifier(signals.Config{}, map[string]signals.Config{
+		"ETHUSDT": {Overbought: 90},
+		"BTCUSDT": {Rocket: 1},
+	})
+	if err != nil {
+		t.Fatalf("NewClassifier() error: %v", err)
+	}
+	analyticsv1.RegisterAnalyticsServiceServer(s, analytics.NewServer(st, classifier))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return analyticsv1.NewAnalyticsServiceClient(conn)
+}
+
+// startExchange answers batch ticker requests with the next scripted prices
+func startExchange(t *testing.T) *httptest.Server {
+	t.Helper()
+	var n atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/ticker/price" || r.URL.Query().Get("symbols") == "" {
+			http.Error(w, `{"code":-1100,"msg":"unexpected request"}`, http.StatusBadRequest)
+			return
+		}
+		i := int(n.Add(1)-1) % len(btcPrices)
+		fmt.Fprintf(w, `[{"symbol":"BTCUSDT","price":"%.2f"},{"symbol":"ETHUSDT","price":"%.2f"}]`, btcPrices[i], ethPrices[i])
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCollectorAnalyticsAndAPI(t *testing.T) {
+	st, err := store.Open(filepath.Join(t.TempDir(), "crypto.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+
+	client := startAnalytics(t, st)
+	fakeExchange := startExchange(t)
+
+	config := collector.Config{
+		ApiUrl:         fakeExchange.URL + "/api/v3/ticker/price?symbol=",
+		Symbols:        []string{"BTCUSDT", "ETHUSDT"},
+		UpdateInterval: 5,
+		PollMode:       collector.PollModeBatch,
+		AlertThreshold: 200,
+	}
+
+	// The stats query compares against SQLite's own clock, so keep the virtual time close to it
+	start := time.Now().UTC().Truncate(5 * time.Second)
+	clk := clock.NewVirtual(start)
+
+	var mu sync.Mutex
+	var raised []alerts.Alert
+	stream := make(chan string)
+	monitor := collector.NewMonitor(st, client, exchange.NewBinance(config.ExchangeOptions()), clk, config.AlertThreshold, stream)
+	monitor.OnAlert(func(a alerts.Alert) {
+		mu.Lock()
+		raised = append(raised, a)
+		mu.Unlock()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		monitor.Run(ctx, config)
+		close(done)
+	}()
+
+	for slot := range btcPrices {
+		clk.BlockUntil(1)
+		next, ok := clk.NextDeadline()
+		if !ok {
+			t.Fatalf("no fetch scheduled for slot %d", slot)
+		}
+		clk.AdvanceTo(next)
+		for range config.Symbols {
+			select {
+			case <-stream:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for slot %d", slot)
+			}
+		}
+	}
+	cancel()
+	<-done
+
+	t.Run("Stored rows", func(t *testing.T) {
+		for symbol, want := range map[string][]float64{"BTCUSDT": btcPrices, "ETHUSDT": ethPrices} {
+			got, err := st.RecentPrices(context.Background(), symbol, 100)
+			if err != nil {
+				t.Fatalf("RecentPrices() error: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("%s prices = %v, want %v", symbol, got, want)
+			}
+		}
+	})
+
+	t.Run("RSI", func(t *testing.T) {
+		tests := []struct {
+			symbol         string
+			wantRSI        float64
+			wantSignal     analyticsv1.RSISignal
+			wantChange     float64
+			wantTrend      analyticsv1.PriceTrend
+			wantOverbought float64
+		}{
+			{"BTCUSDT", 95, analyticsv1.RSISignal_RSI_SIGNAL_OVERBOUGHT, 1.045, analyticsv1.PriceTrend_PRICE_TREND_ROCKET, 70},
+			{"ETHUSDT", 87.5, analyticsv1.RSISignal_RSI_SIGNAL_NEUTRAL, 0.564, analyticsv1.PriceTrend_PRICE_TREND_STABLE, 90},
+			{"XRPUSDT", 50, analyticsv1.RSISignal_RSI_SIGNAL_WAITING_FOR_DATA, 0, analyticsv1.PriceTrend_PRICE_TREND_WAITING_FOR_DATA, 70},
+		}
+		for _, tt := range tests {
+			res, err := client.GetRSI(context.Background(), &analyticsv1.GetRSIRequest{Symbol: tt.symbol, Period: 14})
+			if err != nil {
+				t.Fatalf("GetRSI(%s) error: %v", tt.symbol, err)
+			}
+			if math.Abs(res.RsiValue-tt.wantRSI) > 0.01 || res.Signal != tt.wantSignal {
+				t.Errorf("GetRSI(%s) = %.2f %s, want %.2f %s", tt.symbol, res.RsiValue, res.Signal, tt.wantRSI, tt.wantSignal)
+			}
+			if math.Abs(res.ChangePercent-tt.wantChange) > 0.001 || res.Trend != tt.wantTrend {
+				t.Errorf("GetRSI(%s) trend = %.3f%% %s, want %.3f%% %s", tt.symbol, res.ChangePercent, res.Trend, tt.wantChange, tt.wantTrend)
+			}
+			if res.Thresholds.GetOverbought() != tt.wantOverbought {
+				t.Errorf("GetRSI(%s) thresholds = %+v, want overbought %g", tt.symbol, res.Thresholds, tt.wantOverbought)
+			}
+		}
+	})
+
+	t.Run("Error details", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			call     func() error
+			wantCode codes.Code
+			want     string // Field of the BadRequest or name of the ResourceInfo detail
+		}{
+			{"Lower case symbol", func() error {
+				_, err := client.GetRSI(context.Background(), &analyticsv1.GetRSIRequest{Symbol: "btcusdt"})
+				return err
+			}, codes.InvalidArgument, "symbol"},
+			{"RSI period", func() error {
+				_, err := client.GetRSI(context.Background(), &analyticsv1.GetRSIRequest{Symbol: "BTCUSDT", Period: 1})
+				return err
+			}, codes.InvalidArgument, "period"},
+			{"Invalid end time", func() error {
+				_, err := client.GetVolatility(context.Background(), &analyticsv1.GetVolatilityRequest{Symbol: "BTCUSDT", EndTime: &timestamppb.Timestamp{Nanos: -1}})
+				return err
+			}, codes.InvalidArgument, "end_time"},
+			{"Repeated symbol", func() error {
+				_, err := client.GetCorrelation(context.Background(), &analyticsv1.GetCorrelationRequest{Symbols: []string{"BTCUSDT", "BTCUSDT"}})
+				return err
+			}, codes.InvalidArgument, "symbols[1]"},
+			{"Negative window", func() error {
+				_, err := client.GetLiquidity(context.Background(), &analyticsv1.GetLiquidityRequest{Symbol: "BTCUSDT", WindowSeconds: -1})
+				return err
+			}, codes.InvalidArgument, "window_seconds"},
+			{"No history", func() error {
+				_, err := client.Backtest(context.Background(), &analyticsv1.BacktestRequest{Symbol: "XRPUSDT"})
+				return err
+			}, codes.NotFound, "XRPUSDT"},
+		}
+		for _, tt := range tests {
+			st := status.Convert(tt.call())
+			if st.Code() != tt.wantCode {
+				t.Errorf("%s: code = %s, want %s (%s)", tt.name, st.Code(), tt.wantCode, st.Message())
+				continue
+			}
+			var got []string
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.BadRequest:
+					for _, v := range d.FieldViolations {
+						got = append(got, v.Field)
+					}
+				case *errdetails.ResourceInfo:
+					got = append(got, d.ResourceName)
+				}
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("%s: details name %v, want %s", tt.name, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("Backtest", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			req      *analyticsv1.BacktestRequest
+			wantCode codes.Code
+		}{
+			{"Stored history", &analyticsv1.BacktestRequest{Symbol: "BTCUSDT", RsiPeriod: 3}, codes.OK},
+			{"Unknown symbol", &analyticsv1.BacktestRequest{Symbol: "XRPUSDT"}, codes.NotFound},
+			{"Unknown strategy", &analyticsv1.BacktestRequest{Symbol: "BTCUSDT", Strategy: analyticsv1.BacktestStrategy(99)}, codes.InvalidArgument},
+		}
+		for _, tt := range tests {
+			res, err := client.Backtest(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("%s: Backtest() code = %s, want %s (%v)", tt.name, code, tt.wantCode, err)
+			}
+			if err != nil {
+				continue
+			}
+			// The RSI never drops to 30 in the scripted prices, so the cash stays untouched
+			if len(res.Equity) != len(btcPrices) || len(res.Trades) != 0 || res.FinalEquity != 10000 || res.Strategy != "rsi(3, 30/70)" {
+				t.Errorf("%s: unexpected result %v", tt.name, res)
+			}
+		}
+	})
+
+	t.Run("Correlation", func(t *testing.T) {
+		tests := []struct {
+			method   analyticsv1.CorrelationMethod
+			wantCorr float64
+		}{
+			{analyticsv1.CorrelationMethod_CORRELATION_METHOD_PEARSON, 0.5879563805191151},
+			{analyticsv1.CorrelationMethod_CORRELATION_METHOD_SPEARMAN, 0.4},
+		}
+		for _, tt := range tests {
+			res, err := client.GetCorrelation(context.Background(), &analyticsv1.GetCorrelationRequest{
+				Symbols:           []string{"BTCUSDT", "ETHUSDT"},
+				EndTime:           timestamppb.New(start.Add(time.Minute)), // The virtual clock ran ahead of now
+				WindowSeconds:     3600,
+				ResolutionSeconds: 5,
+				Method:            tt.method,
+				BetaWindow:        3,
+			})
+			if err != nil {
+				t.Fatalf("GetCorrelation(%s) error: %v", tt.method, err)
+			}
+			if res.Samples != 4 || res.Benchmark != "BTCUSDT" || len(res.Matrix) != 2 {
+				t.Fatalf("GetCorrelation(%s) = %v", tt.method, res)
+			}
+			if m := res.Matrix; m[0].Values[0] != 1 || math.Abs(m[0].Values[1]-tt.wantCorr) > 1e-9 || m[1].Values[0] != m[0].Values[1] {
+				t.Errorf("GetCorrelation(%s) matrix = %v, want %v off the diagonal", tt.method, m, tt.wantCorr)
+			}
+			eth := res.Betas[1]
+			if math.Abs(eth.Beta-0.36795573961304134) > 1e-9 || len(eth.Rolling) != 2 || !eth.Rolling[1].Time.AsTime().Equal(start.Add(20*time.Second)) {
+				t.Errorf("GetCorrelation(%s) ETH beta = %v", tt.method, eth)
+			}
+		}
+
+		_, err := client.GetCorrelation(context.Background(), &analyticsv1.GetCorrelationRequest{Symbols: []string{"BTCUSDT", "XRPUSDT"}})
+		if code := status.Code(err); code != codes.NotFound {
+			t.Errorf("GetCorrelation() with an unknown symbol code = %s, want NotFound", code)
+		}
+	})
+
+	t.Run("Volatility", func(t *testing.T) {
+		// One price per 5 second candle, so the range estimators see no movement
+		res, err := client.GetVolatility(context.Background(), &analyticsv1.GetVolatilityRequest{
+			Symbol:          "BTCUSDT",
+			IntervalSeconds: 5,
+			Window:          3,
+			EndTime:         timestamppb.New(start.Add(time.Minute)),
+		})
+		if err != nil {
+			t.Fatalf("GetVolatility() error: %v", err)
+		}
+		if res.Candles != 3 || math.Abs(res.CloseToClose-10.204880107431846) > 1e-9 || res.Parkinson != 0 || res.GarmanKlass != 0 {
+			t.Errorf("GetVolatility() = %v", res)
+		}
+		if res.HistorySamples != 3 || res.Percentile != 100 || res.Regime != analyticsv1.VolatilityRegime_VOLATILITY_REGIME_UNKNOWN {
+			t.Errorf("GetVolatility() ranking = %v", res)
+		}
+
+		_, err = client.GetVolatility(context.Background(), &analyticsv1.GetVolatilityRequest{Symbol: "BTCUSDT", Estimator: analyticsv1.VolatilityEstimator(99)})
+		if code := status.Code(err); code != codes.InvalidArgument {
+			t.Errorf("GetVolatility() with an unknown estimator code = %s, want InvalidArgument", code)
+		}
+	})
+
+	t.Run("Forecast", func(t *testing.T) {
+		req := &analyticsv1.ForecastRequest{
+			Symbol:          "BTCUSDT",
+			IntervalSeconds: 5,
+			Model:           analyticsv1.ForecastModel_FORECAST_MODEL_EWMA,
+			Alpha:           0.5,
+			Steps:           2,
+			EndTime:         timestamppb.New(start.Add(time.Minute)),
+		}
+		res, err := client.Forecast(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Forecast() error: %v", err)
+		}
+		if res.Candles != 5 || res.LastClose != 60900 || len(res.Points) != 2 {
+			t.Fatalf("Forecast() = %v", res)
+		}
+		p := res.Points[0]
+		if math.Abs(p.Value-60536.34673303679) > 1e-6 || p.Lower >= p.Value || p.Upper <= p.Value || !p.Time.AsTime().Equal(res.Time.AsTime().Add(5*time.Second)) {
+			t.Errorf("Forecast() first point = %v", p)
+		}
+
+		// Origins after the third and fourth candles
+		req.History, req.Steps = 3, 1
+		eval, err := client.EvaluateForecast(context.Background(), &analyticsv1.EvaluateForecastRequest{Forecast: req, Origins: 10})
+		if err != nil {
+			t.Fatalf("EvaluateForecast() error: %v", err)
+		}
+		if eval.Origins != 2 || len(eval.Steps) != 1 || math.Abs(eval.Steps[0].Mae-481.3090770282579) > 1e-6 || eval.Steps[0].NaiveMae != 425 {
+			t.Errorf("EvaluateForecast() = %v", eval)
+		}
+
+		_, err = client.Forecast(context.Background(), &analyticsv1.ForecastRequest{Symbol: "BTCUSDT", Model: analyticsv1.ForecastModel(99)})
+		if code := status.Code(err); code != codes.InvalidArgument {
+			t.Errorf("Forecast() with an unknown model code = %s, want InvalidArgument", code)
+		}
+	})
+
+	t.Run("Liquidity", func(t *testing.T) {
+		book := orderbook.New(1,
+			[]orderbook.Level{{Price: 60899, Quantity: 2}, {Price: 60800, Quantity: 5}},
+			[]orderbook.Level{{Price: 60901, Quantity: 1}, {Price: 61500, Quantity: 4}})
+		for i := range 2 {
+			snap, _ := book.Snapshot(start.Add(time.Duration(i)*time.Second), []float64{10, 100})
+			if err := st.InsertBookSnapshot(context.Background(), "BTCUSDT", snap); err != nil {
+				t.Fatalf("InsertBookSnapshot() error: %v", err)
+			}
+			book.Apply(2, 2, nil, []orderbook.Level{{Price: 60901, Quantity: 0}, {Price: 60903, Quantity: 1}})
+		}
+
+		res, err := client.GetLiquidity(context.Background(), &analyticsv1.GetLiquidityRequest{Symbol: "BTCUSDT", EndTime: timestamppb.New(start.Add(time.Minute))})
+		if err != nil {
+			t.Fatalf("GetLiquidity() error: %v", err)
+		}
+		if res.Snapshots != 2 || res.BestAsk != 60903 || res.Mid != 60901 || res.MaxSpreadBps != res.SpreadBps || res.AvgSpreadBps >= res.SpreadBps {
+			t.Fatalf("GetLiquidity() = %v", res)
+		}
+		// 61500 is about 98 bps above the mid, within 1% but not 0.1%
+		if len(res.Bands) != 2 || res.Bands[0].AskQuantity != 1 || res.Bands[1].AskQuantity != 5 || res.Bands[1].BidQuantity != 7 {
+			t.Errorf("GetLiquidity() bands = %v", res.Bands)
+		}
+
+		_, err = client.GetLiquidity(context.Background(), &analyticsv1.GetLiquidityRequest{Symbol: "ETHUSDT"})
+		if code := status.Code(err); code != codes.NotFound {
+			t.Errorf("GetLiquidity() without snapshots code = %s, want NotFound", code)
+		}
+	})
+
+	t.Run("Volume", func(t *testing.T) {
+		points, err := st.History(context.Background(), "BTCUSDT", start.Add(-time.Minute), start.Add(time.Minute))
+		if err != nil || len(points) == 0 {
+			t.Fatalf("History() = %v, %v", points, err)
+		}
+		// In the minute of the first stored price
+		at := points[0].Time
+		trades := []store.MarketTrade{
+			{ID: 1, Price: 60000, Quantity: 1, Time: at},
+			{ID: 2, Price: 60300, Quantity: 2, Time: at, BuyerIsMaker: true},
+		}
+		if err := st.AddTrades(context.Background(), "BTCUSDT", trades); err != nil {
+			t.Fatalf("AddTrades() error: %v", err)
+		}
+
+		res, err := client.GetVolume(context.Background(), &analyticsv1.GetVolumeRequest{
+			Symbol:          "BTCUSDT",
+			EndTime:         timestamppb.New(start.Add(time.Minute)),
+			WindowSeconds:   600,
+			IntervalSeconds: 60,
+		})
+		if err != nil {
+			t.Fatalf("GetVolume() error: %v", err)
+		}
+		if res.Volume != 3 || res.SellVolume != 2 || res.Vwap != 60200 || res.Cvd != -1 || math.Abs(res.BuyRatio-1.0/3) > 1e-12 || res.Trades != 2 {
+			t.Errorf("GetVolume() = %v", res)
+		}
+		if len(res.Candles) == 0 || res.Candles[0].Volume != 3 || res.Candles[0].Cvd != -1 || !res.Candles[0].Time.AsTime().Equal(at.Truncate(time.Minute)) {
+			t.Errorf("GetVolume() candles = %v", res.Candles)
+		}
+
+		_, err = client.GetVolume(context.Background(), &analyticsv1.GetVolumeRequest{Symbol: "ETHUSDT"})
+		if code := status.Code(err); code != codes.NotFound {
+			t.Errorf("GetVolume() without trades code = %s, want NotFound", code)
+		}
+	})
+
+	t.Run("Derivatives", func(t *testing.T) {
+		samples := []store.Derivative{
+			{Time: start.Add(-30 * time.Second), MarkPrice: 60060, IndexPrice: 60030, SpotPrice: 60000, FundingRate: 0.0001, NextFundingTime: start.Add(time.Hour), OpenInterest: 1000},
+			{Time: start, MarkPrice: 60000, IndexPrice: 60000, SpotPrice: 60000, FundingRate: 0.0003, NextFundingTime: start.Add(time.Hour), OpenInterest: 1100},
+		}
+		for _, d := range samples {
+			if err := st.InsertDerivative(context.Background(), "BTCUSDT", d); err != nil {
+				t.Fatalf("InsertDerivative() error: %v", err)
+			}
+		}
+
+		res, err := client.GetDerivatives(context.Background(), &analyticsv1.GetDerivativesRequest{
+			Symbol:        "BTCUSDT",
+			EndTime:       timestamppb.New(start.Add(time.Minute)),
+			WindowSeconds: 600,
+		})
+		if err != nil {
+			t.Fatalf("GetDerivatives() error: %v", err)
+		}
+		// 0.03% every 8 hours is 32.85% a year, the first sample is 10 bps above spot
+		if math.Abs(res.AnnualizedFunding-32.85) > 1e-9 || math.Abs(res.AverageAnnualizedFunding-21.9) > 1e-9 ||
+			res.BasisBps != 0 || math.Abs(res.AverageBasisBps-5) > 1e-9 || math.Abs(res.OpenInterestChange-10) > 1e-9 ||
+			res.OpenInterestNotional != 1100*60000 || len(res.Points) != 2 || !res.NextFundingTime.AsTime().Equal(start.Add(time.Hour)) {
+			t.Errorf("GetDerivatives() = %v", res)
+		}
+
+		_, err = client.GetDerivatives(context.Background(), &analyticsv1.GetDerivativesRequest{Symbol: "ETHUSDT"})
+		if code := status.Code(err); code != codes.NotFound {
+			t.Errorf("GetDerivatives() without samples code = %s, want NotFound", code)
+		}
+	})
+
+	t.Run("Alerts", func(t *testing.T) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// Moves of $250 and $600 cross the threshold, and 60900 is ~1.05% above the hourly
+		// average, beyond both the deviation limit and the rocket threshold of BTC
+		want := []struct {
+			kind  string
+			price float64
+			slot  int
+		}{
+			{alerts.KindVolatility, 60300, 3},
+			{alerts.KindDeviation, 60900, 4},
+			{alerts.KindTrend, 60900, 4},
+			{alerts.KindVolatility, 60900, 4},
+		}
+		if len(raised) != len(want) {
+			t.Fatalf("got %d alerts, want %d: %+v", len(raised), len(want), raised)
+		}
+		for i, w := range want {
+			a := raised[i]
+			at := start.Add(time.Duration(w.slot) * 5 * time.Second)
+			if a.Symbol != "BTCUSDT" || a.Kind != w.kind || a.Price != w.price || !a.Time.Equal(at) {
+				t.Errorf("alert %d = %s %s %.2f at %s, want BTCUSDT %s %.2f at %s", i, a.Symbol, a.Kind, a.Price, a.Time, w.kind, w.price, at)
+			}
+		}
+	})
+
+	t.Run("Stats API", func(t *testing.T) {
+		srv := httptest.NewServer(api.NewRouter(st, client, api.RouterConfig{}))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL + "/api/stats")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want 200", resp.StatusCode)
+		}
+
+		var stats []api.SymbolStats
+		if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		want := []api.SymbolStats{
+			{Symbol: "BTCUSDT", Price: 60900, AvgPrice: 60270, RSI: 95, Signal: signals.RSIOverbought, Trend: signals.TrendRocket},
+			{Symbol: "ETHUSDT", Price: 3030, AvgPrice: 3013, RSI: 87.5, Signal: signals.RSINeutral, Trend: signals.TrendStable},
+		}
+		if len(stats) != len(want) {
+			t.Fatalf("got %d stats, want %d: %+v", len(stats), len(want), stats)
+		}
+		for i, w := range want {
+			s := stats[i]
+			if s.Symbol != w.Symbol || s.Price != w.Price || math.Abs(s.AvgPrice-w.AvgPrice) > 0.01 || math.Abs(s.RSI-w.RSI) > 0.01 {
+				t.Errorf("stats[%d] = %+v, want %+v", i, s, w)
+			}
+			// The dashboard colours the RSI with the thresholds it was classified with
+			if s.Signal != w.Signal || s.Trend != w.Trend || s.Thresholds == nil {
+				t.Errorf("stats[%d] signals = %s %s %+v, want %s %s", i, s.Signal, s.Trend, s.Thresholds, w.Signal, w.Trend)
+			}
+		}
+		if stats[1].Thresholds != nil && stats[1].Thresholds.Overbought != 90 {
+			t.Errorf("ETHUSDT thresholds = %+v, want overbought 90", stats[1].Thresholds)
+		}
+
+		// Symbols default to every tracked one
+		resp, err = http.Get(srv.URL + "/api/correlation?window=1h&resolution=5s&to=" + start.Add(time.Minute).Format(time.RFC3339))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		var corr api.Correlation
+		if err := json.NewDecoder(resp.Body).Decode(&corr); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		if strings.Join(corr.Symbols, ",") != "BTCUSDT,ETHUSDT" || corr.Samples != 4 || math.Abs(corr.Matrix[0][1]-0.5879563805191151) > 1e-9 {
+			t.Errorf("correlation = %+v", corr)
+		}
+
+		// Cross rates come from the tracked pairs, EUR is not tracked
+		resp, err = http.Get(srv.URL + "/api/stats?quote=eth")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		stats = nil
+		if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		btc, eth := stats[0], stats[1]
+		if btc.Quote != "ETH" || math.Abs(btc.Price-60900.0/3030) > 1e-9 || btc.Conversion == nil || strings.Join(btc.Conversion.Pairs, ",") != "ETHUSDT" {
+			t.Errorf("BTCUSDT in ETH = %+v, conversion %+v", btc, btc.Conversion)
+		}
+		if eth.Price != 1 {
+			t.Errorf("ETHUSDT in ETH = %+v", eth)
+		}
+
+		resp, err = http.Get(srv.URL + "/api/stats?quote=EUR")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("quote=EUR status = %d, want 400", resp.StatusCode)
+		}
+	})
+}
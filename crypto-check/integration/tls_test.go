@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/analytics"
+	"crypto-check/certs"
+	analyticsv1 "crypto-check/pb/cryptocheck/analytics/v1"
+	"crypto-check/signals"
+	"crypto-check/store"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestAnalyticsOverMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	if err := certs.Generate(dir, []string{"analytics"}, time.Hour); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	st, err := store.Open(filepath.Join(t.TempDir(), "crypto.db"))
+	if err != nil {
+		t.Fatalf("store.Open() error: %v", err)
+	}
+	defer st.Close()
+	classifier, err := signals.NewClassifier(signals.Config{}, nil)
+	if err != nil {
+		t.Fatalf("NewClassifier() error: %v", err)
+	}
+
+	serverCreds, err := certs.ServerCredentials(certs.ServerFiles(dir))
+	if err != nil {
+		t.Fatalf("ServerCredentials() error: %v", err)
+	}
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.Creds(serverCreds))
+	analyticsv1.RegisterAnalyticsServiceServer(s, analytics.NewServer(st, classifier))
+	go s.Serve(lis)
+	defer s.Stop()
+
+	clientCreds := func(files certs.Files) credentials.TransportCredentials {
+		creds, err := certs.ClientCredentials(files, "")
+		if err != nil {
+			t.Fatalf("ClientCredentials() error: %v", err)
+		}
+		return creds
+	}
+	tests := []struct {
+		name     string
+		creds    credentials.TransportCredentials
+		wantCode codes.Code
+	}{
+		{"Client certificate", clientCreds(certs.ClientFiles(dir)), codes.OK},
+		{"No client certificate", clientCreds(certs.Files{CA: filepath.Join(dir, certs.CAFile)}), codes.Unavailable},
+		{"Plaintext", insecure.NewCredentials(), codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The host of the target is the name verified against the server certificate
+			conn, err := grpc.NewClient("passthrough:///analytics",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+				grpc.WithTransportCredentials(tt.creds))
+			if err != nil {
+				t.Fatalf("grpc.NewClient() error: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			res, err := analyticsv1.NewAnalyticsServiceClient(conn).GetRSI(ctx, &analyticsv1.GetRSIRequest{Symbol: "BTCUSDT"})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("GetRSI() code = %s, want %s (%v)", code, tt.wantCode, err)
+			}
+			if err == nil && res.Signal != analyticsv1.RSISignal_RSI_SIGNAL_WAITING_FOR_DATA {
+				t.Errorf("GetRSI() on an empty database = %v", res)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package orderbook
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrGap means updates were missed and the book has to be rebuilt from a new snapshot
+var ErrGap = errors.New("order book update out of sequence")
+
+// Level is a price and the quantity resting at it
+type Level struct {
+	Price    float64
+	Quantity float64
+}
+
+// Book is a local copy of an exchange order book, kept in sync with diff updates
+// numbered by the exchange. It is not safe for concurrent use.
+type Book struct {
+	lastUpdateID int64
+	bids         map[float64]float64
+	asks         map[float64]float64
+}
+
+// New creates a book from a snapshot that includes every update up to lastUpdateID
+func New(lastUpdateID int64, bids, asks []Level) *Book {
+	b := &Book{lastUpdateID: lastUpdateID, bids: make(map[float64]float64), asks: make(map[float64]float64)}
+	set(b.bids, bids)
+	set(b.asks, asks)
+	return b
+}
+
+// LastUpdateID is the id of the last update in the book
+func (b *Book) LastUpdateID() int64 {
+	return b.lastUpdateID
+}
+
+// Apply applies a diff covering the update ids first to final. Diffs the book
+// already contains are ignored, so the ones buffered while the snapshot was
+// fetched can all be replayed. A diff starting after the next id returns ErrGap.
+func (b *Book) Apply(first, final int64, bids, asks []Level) error {
+	if final <= b.lastUpdateID {
+		return nil
+	}
+	if first > b.lastUpdateID+1 {
+		return ErrGap
+	}
+	set(b.bids, bids)
+	set(b.asks, asks)
+	b.lastUpdateID = final
+	return nil
+}
+
+// Bids returns the bids, best first
+func (b *Book) Bids() []Level {
+	levels := sorted(b.bids)
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+	return levels
+}
+
+// Asks returns the asks, best first
+func (b *Book) Asks() []Level {
+	levels := sorted(b.asks)
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	return levels
+}
+
+// Snapshot summarizes the book with the depth within each of bandsBPS of the mid
+// price. It returns false while a side is empty.
+func (b *Book) Snapshot(at time.Time, bandsBPS []float64) (Snapshot, bool) {
+	bids, asks := b.Bids(), b.Asks()
+	if len(bids) == 0 || len(asks) == 0 {
+		return Snapshot{}, false
+	}
+	s := Snapshot{
+		Time:            at,
+		BestBid:         bids[0].Price,
+		BestBidQuantity: bids[0].Quantity,
+		BestAsk:         asks[0].Price,
+		BestAskQuantity: asks[0].Quantity,
+	}
+	mid := s.Mid()
+	for _, bps := range bandsBPS {
+		band := Band{BPS: bps}
+		for _, l := range bids {
+			if l.Price < mid*(1-bps/10000) {
+				break
+			}
+			band.BidQuantity += l.Quantity
+			band.BidNotional += l.Quantity * l.Price
+		}
+		for _, l := range asks {
+			if l.Price > mid*(1+bps/10000) {
+				break
+			}
+			band.AskQuantity += l.Quantity
+			band.AskNotional += l.Quantity * l.Price
+		}
+		s.Bands = append(s.Bands, band)
+	}
+	return s, true
+}
+
+// set updates levels, a zero quantity removes the level
+func set(side map[float64]float64, levels []Level) {
+	for _, l := range levels {
+		if l.Quantity == 0 {
+			delete(side, l.Price)
+		} else {
+			side[l.Price] = l.Quantity
+		}
+	}
+}
+
+func sorted(side map[float64]float64) []Level {
+	levels := make([]Level, 0, len(side))
+	for price, quantity := range side {
+		levels = append(levels, Level{Price: price, Quantity: quantity})
+	}
+	return levels
+}
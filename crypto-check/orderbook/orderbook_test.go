@@ -0,0 +1,92 @@
+package orderbook
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestApply(t *testing.T) {
+	book := New(100, []Level{{99, 1}, {98, 2}}, []Level{{101, 1}, {102, 3}})
+
+	tests := []struct {
+		name     string
+		first    int64
+		final    int64
+		bids     []Level
+		asks     []Level
+		wantErr  error
+		wantLast int64
+	}{
+		{"Already in the snapshot", 95, 100, []Level{{99, 50}}, nil, nil, 100},
+		{"Straddles the snapshot", 98, 103, []Level{{99, 1.5}}, []Level{{101, 0}}, nil, 103},
+		{"Next update", 104, 104, nil, []Level{{101.5, 2}}, nil, 104},
+		{"Missed updates", 106, 107, []Level{{100, 1}}, nil, ErrGap, 104},
+	}
+	for _, tt := range tests {
+		err := book.Apply(tt.first, tt.final, tt.bids, tt.asks)
+		if !errors.Is(err, tt.wantErr) || book.LastUpdateID() != tt.wantLast {
+			t.Errorf("%s: Apply() = %v with last id %d, want %v and %d", tt.name, err, book.LastUpdateID(), tt.wantErr, tt.wantLast)
+		}
+	}
+
+	wantBids := []Level{{99, 1.5}, {98, 2}}
+	wantAsks := []Level{{101.5, 2}, {102, 3}}
+	if got := book.Bids(); !equalLevels(got, wantBids) {
+		t.Errorf("Bids() = %v, want %v", got, wantBids)
+	}
+	if got := book.Asks(); !equalLevels(got, wantAsks) {
+		t.Errorf("Asks() = %v, want %v", got, wantAsks)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	book := New(1,
+		[]Level{{99.9, 2}, {99.5, 4}, {98, 10}},
+		[]Level{{100.1, 1}, {100.4, 1}, {103, 20}})
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	s, ok := book.Snapshot(at, []float64{10, 50, 500})
+	if !ok {
+		t.Fatal("Snapshot() = false, want a snapshot")
+	}
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"Mid", s.Mid(), 100},
+		{"Spread", s.Spread(), 0.2},
+		{"Spread in bps", s.SpreadBPS(), 20},
+		{"Top imbalance", s.Imbalance(), 1.0 / 3},
+		{"Bids within 0.1%", s.Bands[0].BidQuantity, 2},
+		{"Asks within 0.1%", s.Bands[0].AskQuantity, 1},
+		{"Bid notional within 0.5%", s.Bands[1].BidNotional, 99.9*2 + 99.5*4},
+		{"Asks within 0.5%", s.Bands[1].AskQuantity, 2},
+		{"Bids within 5%", s.Bands[2].BidQuantity, 16},
+		{"Imbalance within 0.5%", s.Bands[1].Imbalance(), (597.8 - 200.5) / (597.8 + 200.5)},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if _, ok := New(1, []Level{{99, 1}}, nil).Snapshot(at, DefaultBandsBPS); ok {
+		t.Error("Snapshot() of a one-sided book = true, want false")
+	}
+}
+
+func equalLevels(a, b []Level) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
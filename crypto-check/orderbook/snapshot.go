@@ -0,0 +1,70 @@
+package orderbook
+
+import "time"
+
+// DefaultBandsBPS are the depth bands stored when none are configured: 0.1%, 0.5%, 1%, 2% and 5%
+var DefaultBandsBPS = []float64{10, 50, 100, 200, 500}
+
+// Snapshot is the top of a book and its depth around the mid price
+type Snapshot struct {
+	Time            time.Time
+	BestBid         float64
+	BestBidQuantity float64
+	BestAsk         float64
+	BestAskQuantity float64
+	Bands           []Band // Narrowest first
+}
+
+// Band is the liquidity resting within BPS basis points of the mid price
+type Band struct {
+	BPS         float64 // 100 is 1%
+	BidQuantity float64
+	AskQuantity float64
+	BidNotional float64 // In the quote currency
+	AskNotional float64
+}
+
+func (s Snapshot) Mid() float64 {
+	return (s.BestBid + s.BestAsk) / 2
+}
+
+func (s Snapshot) Spread() float64 {
+	return s.BestAsk - s.BestBid
+}
+
+// SpreadBPS is the spread relative to the mid price, in basis points
+func (s Snapshot) SpreadBPS() float64 {
+	mid := s.Mid()
+	if mid == 0 {
+		return 0
+	}
+	return s.Spread() / mid * 10000
+}
+
+// Imbalance compares the quantities at the best bid and ask, from -1 when only
+// the ask has any to 1 when only the bid has
+func (s Snapshot) Imbalance() float64 {
+	return imbalance(s.BestBidQuantity, s.BestAskQuantity)
+}
+
+// Band returns the band of bps, if it was measured
+func (s Snapshot) Band(bps float64) (Band, bool) {
+	for _, b := range s.Bands {
+		if b.BPS == bps {
+			return b, true
+		}
+	}
+	return Band{}, false
+}
+
+// Imbalance compares the notional of both sides of the band, like Snapshot.Imbalance
+func (b Band) Imbalance() float64 {
+	return imbalance(b.BidNotional, b.AskNotional)
+}
+
+func imbalance(bid, ask float64) float64 {
+	if bid+ask == 0 {
+		return 0
+	}
+	return (bid - ask) / (bid + ask)
+}
@@ -0,0 +1,3692 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.0
+// source: cryptocheck/analytics/v1/analytics.proto
+
+package analyticsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// What the RSI says about a symbol
+type RSISignal int32
+
+const (
+	RSISignal_RSI_SIGNAL_UNSPECIFIED      RSISignal = 0
+	RSISignal_RSI_SIGNAL_WAITING_FOR_DATA RSISignal = 1 // Fewer than two prices stored
+	RSISignal_RSI_SIGNAL_NEUTRAL          RSISignal = 2
+	RSISignal_RSI_SIGNAL_OVERSOLD         RSISignal = 3 // At or below the oversold threshold, a buy signal
+	RSISignal_RSI_SIGNAL_OVERBOUGHT       RSISignal = 4 // At or above the overbought threshold, a sell signal
+)
+
+// Enum value maps for RSISignal.
+var (
+	RSISignal_name = map[int32]string{
+		0: "RSI_SIGNAL_UNSPECIFIED",
+		1: "RSI_SIGNAL_WAITING_FOR_DATA",
+		2: "RSI_SIGNAL_NEUTRAL",
+		3: "RSI_SIGNAL_OVERSOLD",
+		4: "RSI_SIGNAL_OVERBOUGHT",
+	}
+	RSISignal_value = map[string]int32{
+		"RSI_SIGNAL_UNSPECIFIED":      0,
+		"RSI_SIGNAL_WAITING_FOR_DATA": 1,
+		"RSI_SIGNAL_NEUTRAL":          2,
+		"RSI_SIGNAL_OVERSOLD":         3,
+		"RSI_SIGNAL_OVERBOUGHT":       4,
+	}
+)
+
+func (x RSISignal) Enum() *RSISignal {
+	p := new(RSISignal)
+	*p = x
+	return p
+}
+
+func (x RSISignal) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RSISignal) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[0].Descriptor()
+}
+
+func (RSISignal) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[0]
+}
+
+func (x RSISignal) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RSISignal.Descriptor instead.
+func (RSISignal) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+// Where the price stands against its hourly average
+type PriceTrend int32
+
+const (
+	PriceTrend_PRICE_TREND_UNSPECIFIED      PriceTrend = 0
+	PriceTrend_PRICE_TREND_WAITING_FOR_DATA PriceTrend = 1
+	PriceTrend_PRICE_TREND_STABLE           PriceTrend = 2
+	PriceTrend_PRICE_TREND_ROCKET           PriceTrend = 3 // At least rocket_percent above the average
+	PriceTrend_PRICE_TREND_CRASH            PriceTrend = 4 // At least crash_percent below the average
+)
+
+// Enum value maps for PriceTrend.
+var (
+	PriceTrend_name = map[int32]string{
+		0: "PRICE_TREND_UNSPECIFIED",
+		1: "PRICE_TREND_WAITING_FOR_DATA",
+		2: "PRICE_TREND_STABLE",
+		3: "PRICE_TREND_ROCKET",
+		4: "PRICE_TREND_CRASH",
+	}
+	PriceTrend_value = map[string]int32{
+		"PRICE_TREND_UNSPECIFIED":      0,
+		"PRICE_TREND_WAITING_FOR_DATA": 1,
+		"PRICE_TREND_STABLE":           2,
+		"PRICE_TREND_ROCKET":           3,
+		"PRICE_TREND_CRASH":            4,
+	}
+)
+
+func (x PriceTrend) Enum() *PriceTrend {
+	p := new(PriceTrend)
+	*p = x
+	return p
+}
+
+func (x PriceTrend) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceTrend) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[1].Descriptor()
+}
+
+func (PriceTrend) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[1]
+}
+
+func (x PriceTrend) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceTrend.Descriptor instead.
+func (PriceTrend) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+type BacktestStrategy int32
+
+const (
+	BacktestStrategy_BACKTEST_STRATEGY_UNSPECIFIED BacktestStrategy = 0 // The RSI strategy
+	BacktestStrategy_BACKTEST_STRATEGY_RSI         BacktestStrategy = 1 // Buys oversold and sells overbought
+)
+
+// Enum value maps for BacktestStrategy.
+var (
+	BacktestStrategy_name = map[int32]string{
+		0: "BACKTEST_STRATEGY_UNSPECIFIED",
+		1: "BACKTEST_STRATEGY_RSI",
+	}
+	BacktestStrategy_value = map[string]int32{
+		"BACKTEST_STRATEGY_UNSPECIFIED": 0,
+		"BACKTEST_STRATEGY_RSI":         1,
+	}
+)
+
+func (x BacktestStrategy) Enum() *BacktestStrategy {
+	p := new(BacktestStrategy)
+	*p = x
+	return p
+}
+
+func (x BacktestStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BacktestStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[2].Descriptor()
+}
+
+func (BacktestStrategy) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[2]
+}
+
+func (x BacktestStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BacktestStrategy.Descriptor instead.
+func (BacktestStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+type TradeSide int32
+
+const (
+	TradeSide_TRADE_SIDE_UNSPECIFIED TradeSide = 0
+	TradeSide_TRADE_SIDE_BUY         TradeSide = 1
+	TradeSide_TRADE_SIDE_SELL        TradeSide = 2
+)
+
+// Enum value maps for TradeSide.
+var (
+	TradeSide_name = map[int32]string{
+		0: "TRADE_SIDE_UNSPECIFIED",
+		1: "TRADE_SIDE_BUY",
+		2: "TRADE_SIDE_SELL",
+	}
+	TradeSide_value = map[string]int32{
+		"TRADE_SIDE_UNSPECIFIED": 0,
+		"TRADE_SIDE_BUY":         1,
+		"TRADE_SIDE_SELL":        2,
+	}
+)
+
+func (x TradeSide) Enum() *TradeSide {
+	p := new(TradeSide)
+	*p = x
+	return p
+}
+
+func (x TradeSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TradeSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[3].Descriptor()
+}
+
+func (TradeSide) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[3]
+}
+
+func (x TradeSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TradeSide.Descriptor instead.
+func (TradeSide) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+type CorrelationMethod int32
+
+const (
+	CorrelationMethod_CORRELATION_METHOD_UNSPECIFIED CorrelationMethod = 0 // Pearson
+	CorrelationMethod_CORRELATION_METHOD_PEARSON     CorrelationMethod = 1
+	CorrelationMethod_CORRELATION_METHOD_SPEARMAN    CorrelationMethod = 2 // Of the ranks, robust to outliers
+)
+
+// Enum value maps for CorrelationMethod.
+var (
+	CorrelationMethod_name = map[int32]string{
+		0: "CORRELATION_METHOD_UNSPECIFIED",
+		1: "CORRELATION_METHOD_PEARSON",
+		2: "CORRELATION_METHOD_SPEARMAN",
+	}
+	CorrelationMethod_value = map[string]int32{
+		"CORRELATION_METHOD_UNSPECIFIED": 0,
+		"CORRELATION_METHOD_PEARSON":     1,
+		"CORRELATION_METHOD_SPEARMAN":    2,
+	}
+)
+
+func (x CorrelationMethod) Enum() *CorrelationMethod {
+	p := new(CorrelationMethod)
+	*p = x
+	return p
+}
+
+func (x CorrelationMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CorrelationMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[4].Descriptor()
+}
+
+func (CorrelationMethod) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[4]
+}
+
+func (x CorrelationMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CorrelationMethod.Descriptor instead.
+func (CorrelationMethod) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+type VolatilityEstimator int32
+
+const (
+	VolatilityEstimator_VOLATILITY_ESTIMATOR_UNSPECIFIED    VolatilityEstimator = 0 // Close to close
+	VolatilityEstimator_VOLATILITY_ESTIMATOR_CLOSE_TO_CLOSE VolatilityEstimator = 1
+	VolatilityEstimator_VOLATILITY_ESTIMATOR_PARKINSON      VolatilityEstimator = 2 // From the highs and lows
+	VolatilityEstimator_VOLATILITY_ESTIMATOR_GARMAN_KLASS   VolatilityEstimator = 3 // From the opens, highs, lows and closes
+)
+
+// Enum value maps for VolatilityEstimator.
+var (
+	VolatilityEstimator_name = map[int32]string{
+		0: "VOLATILITY_ESTIMATOR_UNSPECIFIED",
+		1: "VOLATILITY_ESTIMATOR_CLOSE_TO_CLOSE",
+		2: "VOLATILITY_ESTIMATOR_PARKINSON",
+		3: "VOLATILITY_ESTIMATOR_GARMAN_KLASS",
+	}
+	VolatilityEstimator_value = map[string]int32{
+		"VOLATILITY_ESTIMATOR_UNSPECIFIED":    0,
+		"VOLATILITY_ESTIMATOR_CLOSE_TO_CLOSE": 1,
+		"VOLATILITY_ESTIMATOR_PARKINSON":      2,
+		"VOLATILITY_ESTIMATOR_GARMAN_KLASS":   3,
+	}
+)
+
+func (x VolatilityEstimator) Enum() *VolatilityEstimator {
+	p := new(VolatilityEstimator)
+	*p = x
+	return p
+}
+
+func (x VolatilityEstimator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VolatilityEstimator) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[5].Descriptor()
+}
+
+func (VolatilityEstimator) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[5]
+}
+
+func (x VolatilityEstimator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VolatilityEstimator.Descriptor instead.
+func (VolatilityEstimator) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+type VolatilityRegime int32
+
+const (
+	VolatilityRegime_VOLATILITY_REGIME_UNSPECIFIED VolatilityRegime = 0
+	VolatilityRegime_VOLATILITY_REGIME_UNKNOWN     VolatilityRegime = 1 // Not enough history to rank against
+	VolatilityRegime_VOLATILITY_REGIME_LOW         VolatilityRegime = 2 // Below the 20th percentile
+	VolatilityRegime_VOLATILITY_REGIME_NORMAL      VolatilityRegime = 3
+	VolatilityRegime_VOLATILITY_REGIME_HIGH        VolatilityRegime = 4 // From the 80th percentile
+	VolatilityRegime_VOLATILITY_REGIME_EXTREME     VolatilityRegime = 5 // From the 95th percentile
+)
+
+// Enum value maps for VolatilityRegime.
+var (
+	VolatilityRegime_name = map[int32]string{
+		0: "VOLATILITY_REGIME_UNSPECIFIED",
+		1: "VOLATILITY_REGIME_UNKNOWN",
+		2: "VOLATILITY_REGIME_LOW",
+		3: "VOLATILITY_REGIME_NORMAL",
+		4: "VOLATILITY_REGIME_HIGH",
+		5: "VOLATILITY_REGIME_EXTREME",
+	}
+	VolatilityRegime_value = map[string]int32{
+		"VOLATILITY_REGIME_UNSPECIFIED": 0,
+		"VOLATILITY_REGIME_UNKNOWN":     1,
+		"VOLATILITY_REGIME_LOW":         2,
+		"VOLATILITY_REGIME_NORMAL":      3,
+		"VOLATILITY_REGIME_HIGH":        4,
+		"VOLATILITY_REGIME_EXTREME":     5,
+	}
+)
+
+func (x VolatilityRegime) Enum() *VolatilityRegime {
+	p := new(VolatilityRegime)
+	*p = x
+	return p
+}
+
+func (x VolatilityRegime) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VolatilityRegime) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[6].Descriptor()
+}
+
+func (VolatilityRegime) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[6]
+}
+
+func (x VolatilityRegime) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VolatilityRegime.Descriptor instead.
+func (VolatilityRegime) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{6}
+}
+
+type ForecastModel int32
+
+const (
+	ForecastModel_FORECAST_MODEL_UNSPECIFIED ForecastModel = 0 // Holt
+	ForecastModel_FORECAST_MODEL_EWMA        ForecastModel = 1 // Simple exponential smoothing
+	ForecastModel_FORECAST_MODEL_HOLT        ForecastModel = 2 // Holt's linear trend
+	ForecastModel_FORECAST_MODEL_AR          ForecastModel = 3 // Autoregression of the returns
+)
+
+// Enum value maps for ForecastModel.
+var (
+	ForecastModel_name = map[int32]string{
+		0: "FORECAST_MODEL_UNSPECIFIED",
+		1: "FORECAST_MODEL_EWMA",
+		2: "FORECAST_MODEL_HOLT",
+		3: "FORECAST_MODEL_AR",
+	}
+	ForecastModel_value = map[string]int32{
+		"FORECAST_MODEL_UNSPECIFIED": 0,
+		"FORECAST_MODEL_EWMA":        1,
+		"FORECAST_MODEL_HOLT":        2,
+		"FORECAST_MODEL_AR":          3,
+	}
+)
+
+func (x ForecastModel) Enum() *ForecastModel {
+	p := new(ForecastModel)
+	*p = x
+	return p
+}
+
+func (x ForecastModel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ForecastModel) Descriptor() protoreflect.EnumDescriptor {
+	return file_cryptocheck_analytics_v1_analytics_proto_enumTypes[7].Descriptor()
+}
+
+func (ForecastModel) Type() protoreflect.EnumType {
+	return &file_cryptocheck_analytics_v1_analytics_proto_enumTypes[7]
+}
+
+func (x ForecastModel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ForecastModel.Descriptor instead.
+func (ForecastModel) EnumDescriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+// Thresholds the signals of a symbol were classified with
+type SignalThresholds struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Oversold      float64                `protobuf:"fixed64,1,opt,name=oversold,proto3" json:"oversold,omitempty"`
+	Overbought    float64                `protobuf:"fixed64,2,opt,name=overbought,proto3" json:"overbought,omitempty"`
+	RocketPercent float64                `protobuf:"fixed64,3,opt,name=rocket_percent,json=rocketPercent,proto3" json:"rocket_percent,omitempty"`
+	CrashPercent  float64                `protobuf:"fixed64,4,opt,name=crash_percent,json=crashPercent,proto3" json:"crash_percent,omitempty"` // Positive, the trend crashes at -crash_percent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalThresholds) Reset() {
+	*x = SignalThresholds{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalThresholds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalThresholds) ProtoMessage() {}
+
+func (x *SignalThresholds) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalThresholds.ProtoReflect.Descriptor instead.
+func (*SignalThresholds) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignalThresholds) GetOversold() float64 {
+	if x != nil {
+		return x.Oversold
+	}
+	return 0
+}
+
+func (x *SignalThresholds) GetOverbought() float64 {
+	if x != nil {
+		return x.Overbought
+	}
+	return 0
+}
+
+func (x *SignalThresholds) GetRocketPercent() float64 {
+	if x != nil {
+		return x.RocketPercent
+	}
+	return 0
+}
+
+func (x *SignalThresholds) GetCrashPercent() float64 {
+	if x != nil {
+		return x.CrashPercent
+	}
+	return 0
+}
+
+type GetRSIRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`  // Required
+	Period        int32                  `protobuf:"varint,2,opt,name=period,proto3" json:"period,omitempty"` // Prices the RSI is calculated over, 2 to 1000, 0 for 14
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRSIRequest) Reset() {
+	*x = GetRSIRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRSIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRSIRequest) ProtoMessage() {}
+
+func (x *GetRSIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRSIRequest.ProtoReflect.Descriptor instead.
+func (*GetRSIRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRSIRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetRSIRequest) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+type GetRSIResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	CurrentPrice  float64                `protobuf:"fixed64,2,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	RsiValue      float64                `protobuf:"fixed64,3,opt,name=rsi_value,json=rsiValue,proto3" json:"rsi_value,omitempty"` // 50 while waiting for data
+	Signal        RSISignal              `protobuf:"varint,4,opt,name=signal,proto3,enum=cryptocheck.analytics.v1.RSISignal" json:"signal,omitempty"`
+	ChangePercent float64                `protobuf:"fixed64,5,opt,name=change_percent,json=changePercent,proto3" json:"change_percent,omitempty"` // Of the current price from the average of the hour before it
+	Trend         PriceTrend             `protobuf:"varint,6,opt,name=trend,proto3,enum=cryptocheck.analytics.v1.PriceTrend" json:"trend,omitempty"`
+	Thresholds    *SignalThresholds      `protobuf:"bytes,7,opt,name=thresholds,proto3" json:"thresholds,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=time,proto3" json:"time,omitempty"` // Of the current price, unset while waiting for data
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRSIResponse) Reset() {
+	*x = GetRSIResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRSIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRSIResponse) ProtoMessage() {}
+
+func (x *GetRSIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRSIResponse.ProtoReflect.Descriptor instead.
+func (*GetRSIResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRSIResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetRSIResponse) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *GetRSIResponse) GetRsiValue() float64 {
+	if x != nil {
+		return x.RsiValue
+	}
+	return 0
+}
+
+func (x *GetRSIResponse) GetSignal() RSISignal {
+	if x != nil {
+		return x.Signal
+	}
+	return RSISignal_RSI_SIGNAL_UNSPECIFIED
+}
+
+func (x *GetRSIResponse) GetChangePercent() float64 {
+	if x != nil {
+		return x.ChangePercent
+	}
+	return 0
+}
+
+func (x *GetRSIResponse) GetTrend() PriceTrend {
+	if x != nil {
+		return x.Trend
+	}
+	return PriceTrend_PRICE_TREND_UNSPECIFIED
+}
+
+func (x *GetRSIResponse) GetThresholds() *SignalThresholds {
+	if x != nil {
+		return x.Thresholds
+	}
+	return nil
+}
+
+func (x *GetRSIResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type BacktestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                                           // Required
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`                    // Start of the history, unset for everything stored
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                          // End of the history, unset for up to now
+	IntervalSeconds int64                  `protobuf:"varint,4,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"` // Resample the history into closes of this interval, 0 uses every stored price
+	Strategy        BacktestStrategy       `protobuf:"varint,5,opt,name=strategy,proto3,enum=cryptocheck.analytics.v1.BacktestStrategy" json:"strategy,omitempty"`
+	RsiPeriod       int32                  `protobuf:"varint,6,opt,name=rsi_period,json=rsiPeriod,proto3" json:"rsi_period,omitempty"` // 0 for 14
+	Oversold        float64                `protobuf:"fixed64,7,opt,name=oversold,proto3" json:"oversold,omitempty"`                   // 0 for the signal threshold of the symbol
+	Overbought      float64                `protobuf:"fixed64,8,opt,name=overbought,proto3" json:"overbought,omitempty"`               // 0 for the signal threshold of the symbol
+	InitialCash     float64                `protobuf:"fixed64,9,opt,name=initial_cash,json=initialCash,proto3" json:"initial_cash,omitempty"`
+	FeeRate         float64                `protobuf:"fixed64,10,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"` // Fraction of the traded value
+	Slippage        float64                `protobuf:"fixed64,11,opt,name=slippage,proto3" json:"slippage,omitempty"`              // Fraction of the price
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BacktestRequest) Reset() {
+	*x = BacktestRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestRequest) ProtoMessage() {}
+
+func (x *BacktestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestRequest.ProtoReflect.Descriptor instead.
+func (*BacktestRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BacktestRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BacktestRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *BacktestRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *BacktestRequest) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *BacktestRequest) GetStrategy() BacktestStrategy {
+	if x != nil {
+		return x.Strategy
+	}
+	return BacktestStrategy_BACKTEST_STRATEGY_UNSPECIFIED
+}
+
+func (x *BacktestRequest) GetRsiPeriod() int32 {
+	if x != nil {
+		return x.RsiPeriod
+	}
+	return 0
+}
+
+func (x *BacktestRequest) GetOversold() float64 {
+	if x != nil {
+		return x.Oversold
+	}
+	return 0
+}
+
+func (x *BacktestRequest) GetOverbought() float64 {
+	if x != nil {
+		return x.Overbought
+	}
+	return 0
+}
+
+func (x *BacktestRequest) GetInitialCash() float64 {
+	if x != nil {
+		return x.InitialCash
+	}
+	return 0
+}
+
+func (x *BacktestRequest) GetFeeRate() float64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *BacktestRequest) GetSlippage() float64 {
+	if x != nil {
+		return x.Slippage
+	}
+	return 0
+}
+
+type EquityPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Equity        float64                `protobuf:"fixed64,2,opt,name=equity,proto3" json:"equity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EquityPoint) Reset() {
+	*x = EquityPoint{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EquityPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquityPoint) ProtoMessage() {}
+
+func (x *EquityPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquityPoint.ProtoReflect.Descriptor instead.
+func (*EquityPoint) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EquityPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *EquityPoint) GetEquity() float64 {
+	if x != nil {
+		return x.Equity
+	}
+	return 0
+}
+
+type Trade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Side          TradeSide              `protobuf:"varint,2,opt,name=side,proto3,enum=cryptocheck.analytics.v1.TradeSide" json:"side,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Fee           float64                `protobuf:"fixed64,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	Pnl           float64                `protobuf:"fixed64,6,opt,name=pnl,proto3" json:"pnl,omitempty"` // Realized by sells
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Trade) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Trade) GetSide() TradeSide {
+	if x != nil {
+		return x.Side
+	}
+	return TradeSide_TRADE_SIDE_UNSPECIFIED
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Trade) GetPnl() float64 {
+	if x != nil {
+		return x.Pnl
+	}
+	return 0
+}
+
+type BacktestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Strategy      string                 `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"` // Name with its parameters, e.g. rsi(14, 30/70)
+	InitialCash   float64                `protobuf:"fixed64,3,opt,name=initial_cash,json=initialCash,proto3" json:"initial_cash,omitempty"`
+	FinalEquity   float64                `protobuf:"fixed64,4,opt,name=final_equity,json=finalEquity,proto3" json:"final_equity,omitempty"`
+	TotalReturn   float64                `protobuf:"fixed64,5,opt,name=total_return,json=totalReturn,proto3" json:"total_return,omitempty"`
+	MaxDrawdown   float64                `protobuf:"fixed64,6,opt,name=max_drawdown,json=maxDrawdown,proto3" json:"max_drawdown,omitempty"`
+	Sharpe        float64                `protobuf:"fixed64,7,opt,name=sharpe,proto3" json:"sharpe,omitempty"`
+	Equity        []*EquityPoint         `protobuf:"bytes,8,rep,name=equity,proto3" json:"equity,omitempty"`
+	Trades        []*Trade               `protobuf:"bytes,9,rep,name=trades,proto3" json:"trades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BacktestResponse) Reset() {
+	*x = BacktestResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BacktestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BacktestResponse) ProtoMessage() {}
+
+func (x *BacktestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BacktestResponse.ProtoReflect.Descriptor instead.
+func (*BacktestResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BacktestResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BacktestResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *BacktestResponse) GetInitialCash() float64 {
+	if x != nil {
+		return x.InitialCash
+	}
+	return 0
+}
+
+func (x *BacktestResponse) GetFinalEquity() float64 {
+	if x != nil {
+		return x.FinalEquity
+	}
+	return 0
+}
+
+func (x *BacktestResponse) GetTotalReturn() float64 {
+	if x != nil {
+		return x.TotalReturn
+	}
+	return 0
+}
+
+func (x *BacktestResponse) GetMaxDrawdown() float64 {
+	if x != nil {
+		return x.MaxDrawdown
+	}
+	return 0
+}
+
+func (x *BacktestResponse) GetSharpe() float64 {
+	if x != nil {
+		return x.Sharpe
+	}
+	return 0
+}
+
+func (x *BacktestResponse) GetEquity() []*EquityPoint {
+	if x != nil {
+		return x.Equity
+	}
+	return nil
+}
+
+func (x *BacktestResponse) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+type GetCorrelationRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Symbols           []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`                                               // At least two, unique
+	EndTime           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                                // End of the window, unset for now
+	WindowSeconds     int64                  `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`             // Length of the window, 0 for 24 hours
+	ResolutionSeconds int64                  `protobuf:"varint,4,opt,name=resolution_seconds,json=resolutionSeconds,proto3" json:"resolution_seconds,omitempty"` // Grid the series are aligned to, 0 for 60 seconds
+	Method            CorrelationMethod      `protobuf:"varint,5,opt,name=method,proto3,enum=cryptocheck.analytics.v1.CorrelationMethod" json:"method,omitempty"`
+	Benchmark         string                 `protobuf:"bytes,6,opt,name=benchmark,proto3" json:"benchmark,omitempty"`                      // Symbol betas are measured against, empty for the first symbol
+	BetaWindow        int32                  `protobuf:"varint,7,opt,name=beta_window,json=betaWindow,proto3" json:"beta_window,omitempty"` // Returns per rolling beta value, 0 for 30
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetCorrelationRequest) Reset() {
+	*x = GetCorrelationRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCorrelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCorrelationRequest) ProtoMessage() {}
+
+func (x *GetCorrelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCorrelationRequest.ProtoReflect.Descriptor instead.
+func (*GetCorrelationRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCorrelationRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *GetCorrelationRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetCorrelationRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetCorrelationRequest) GetResolutionSeconds() int64 {
+	if x != nil {
+		return x.ResolutionSeconds
+	}
+	return 0
+}
+
+func (x *GetCorrelationRequest) GetMethod() CorrelationMethod {
+	if x != nil {
+		return x.Method
+	}
+	return CorrelationMethod_CORRELATION_METHOD_UNSPECIFIED
+}
+
+func (x *GetCorrelationRequest) GetBenchmark() string {
+	if x != nil {
+		return x.Benchmark
+	}
+	return ""
+}
+
+func (x *GetCorrelationRequest) GetBetaWindow() int32 {
+	if x != nil {
+		return x.BetaWindow
+	}
+	return 0
+}
+
+type CorrelationRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Values        []float64              `protobuf:"fixed64,2,rep,packed,name=values,proto3" json:"values,omitempty"` // Correlation with each requested symbol, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorrelationRow) Reset() {
+	*x = CorrelationRow{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorrelationRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorrelationRow) ProtoMessage() {}
+
+func (x *CorrelationRow) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorrelationRow.ProtoReflect.Descriptor instead.
+func (*CorrelationRow) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CorrelationRow) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CorrelationRow) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type BetaPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Beta          float64                `protobuf:"fixed64,2,opt,name=beta,proto3" json:"beta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BetaPoint) Reset() {
+	*x = BetaPoint{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BetaPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BetaPoint) ProtoMessage() {}
+
+func (x *BetaPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BetaPoint.ProtoReflect.Descriptor instead.
+func (*BetaPoint) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BetaPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *BetaPoint) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+type SymbolBeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Beta          float64                `protobuf:"fixed64,2,opt,name=beta,proto3" json:"beta,omitempty"` // Over the whole window
+	Rolling       []*BetaPoint           `protobuf:"bytes,3,rep,name=rolling,proto3" json:"rolling,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SymbolBeta) Reset() {
+	*x = SymbolBeta{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SymbolBeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymbolBeta) ProtoMessage() {}
+
+func (x *SymbolBeta) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymbolBeta.ProtoReflect.Descriptor instead.
+func (*SymbolBeta) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SymbolBeta) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SymbolBeta) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *SymbolBeta) GetRolling() []*BetaPoint {
+	if x != nil {
+		return x.Rolling
+	}
+	return nil
+}
+
+type GetCorrelationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbols       []string               `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	Method        CorrelationMethod      `protobuf:"varint,2,opt,name=method,proto3,enum=cryptocheck.analytics.v1.CorrelationMethod" json:"method,omitempty"`
+	Benchmark     string                 `protobuf:"bytes,3,opt,name=benchmark,proto3" json:"benchmark,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // First aligned sample
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Last aligned sample
+	Samples       int32                  `protobuf:"varint,6,opt,name=samples,proto3" json:"samples,omitempty"`                     // Aligned log returns per symbol
+	Matrix        []*CorrelationRow      `protobuf:"bytes,7,rep,name=matrix,proto3" json:"matrix,omitempty"`
+	Betas         []*SymbolBeta          `protobuf:"bytes,8,rep,name=betas,proto3" json:"betas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCorrelationResponse) Reset() {
+	*x = GetCorrelationResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCorrelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCorrelationResponse) ProtoMessage() {}
+
+func (x *GetCorrelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCorrelationResponse.ProtoReflect.Descriptor instead.
+func (*GetCorrelationResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetCorrelationResponse) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *GetCorrelationResponse) GetMethod() CorrelationMethod {
+	if x != nil {
+		return x.Method
+	}
+	return CorrelationMethod_CORRELATION_METHOD_UNSPECIFIED
+}
+
+func (x *GetCorrelationResponse) GetBenchmark() string {
+	if x != nil {
+		return x.Benchmark
+	}
+	return ""
+}
+
+func (x *GetCorrelationResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetCorrelationResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetCorrelationResponse) GetSamples() int32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *GetCorrelationResponse) GetMatrix() []*CorrelationRow {
+	if x != nil {
+		return x.Matrix
+	}
+	return nil
+}
+
+func (x *GetCorrelationResponse) GetBetas() []*SymbolBeta {
+	if x != nil {
+		return x.Betas
+	}
+	return nil
+}
+
+type GetVolatilityRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                                                          // Required
+	IntervalSeconds int64                  `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`                // Candle size, 0 for 1 hour
+	Window          int32                  `protobuf:"varint,3,opt,name=window,proto3" json:"window,omitempty"`                                                         // Candles per estimate, at least 3, 0 for 24
+	HistoryDays     int32                  `protobuf:"varint,4,opt,name=history_days,json=historyDays,proto3" json:"history_days,omitempty"`                            // History the estimate is ranked against, 0 for 30
+	Estimator       VolatilityEstimator    `protobuf:"varint,5,opt,name=estimator,proto3,enum=cryptocheck.analytics.v1.VolatilityEstimator" json:"estimator,omitempty"` // Estimator ranked
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                                         // End of the window, unset for now
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetVolatilityRequest) Reset() {
+	*x = GetVolatilityRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVolatilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVolatilityRequest) ProtoMessage() {}
+
+func (x *GetVolatilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVolatilityRequest.ProtoReflect.Descriptor instead.
+func (*GetVolatilityRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetVolatilityRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetVolatilityRequest) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *GetVolatilityRequest) GetWindow() int32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+func (x *GetVolatilityRequest) GetHistoryDays() int32 {
+	if x != nil {
+		return x.HistoryDays
+	}
+	return 0
+}
+
+func (x *GetVolatilityRequest) GetEstimator() VolatilityEstimator {
+	if x != nil {
+		return x.Estimator
+	}
+	return VolatilityEstimator_VOLATILITY_ESTIMATOR_UNSPECIFIED
+}
+
+func (x *GetVolatilityRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type GetVolatilityResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	IntervalSeconds int64                  `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	Candles         int32                  `protobuf:"varint,3,opt,name=candles,proto3" json:"candles,omitempty"`                                  // Candles in the current window
+	CloseToClose    float64                `protobuf:"fixed64,4,opt,name=close_to_close,json=closeToClose,proto3" json:"close_to_close,omitempty"` // Annualized, as a fraction
+	Parkinson       float64                `protobuf:"fixed64,5,opt,name=parkinson,proto3" json:"parkinson,omitempty"`                             // Annualized, as a fraction
+	GarmanKlass     float64                `protobuf:"fixed64,6,opt,name=garman_klass,json=garmanKlass,proto3" json:"garman_klass,omitempty"`      // Annualized, as a fraction
+	Estimator       VolatilityEstimator    `protobuf:"varint,7,opt,name=estimator,proto3,enum=cryptocheck.analytics.v1.VolatilityEstimator" json:"estimator,omitempty"`
+	Percentile      float64                `protobuf:"fixed64,8,opt,name=percentile,proto3" json:"percentile,omitempty"` // Rank of the estimator among its rolling values over the history, 0-100
+	Regime          VolatilityRegime       `protobuf:"varint,9,opt,name=regime,proto3,enum=cryptocheck.analytics.v1.VolatilityRegime" json:"regime,omitempty"`
+	HistorySamples  int32                  `protobuf:"varint,10,opt,name=history_samples,json=historySamples,proto3" json:"history_samples,omitempty"`
+	Time            *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=time,proto3" json:"time,omitempty"` // Start of the last candle
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetVolatilityResponse) Reset() {
+	*x = GetVolatilityResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVolatilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVolatilityResponse) ProtoMessage() {}
+
+func (x *GetVolatilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVolatilityResponse.ProtoReflect.Descriptor instead.
+func (*GetVolatilityResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetVolatilityResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetVolatilityResponse) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *GetVolatilityResponse) GetCandles() int32 {
+	if x != nil {
+		return x.Candles
+	}
+	return 0
+}
+
+func (x *GetVolatilityResponse) GetCloseToClose() float64 {
+	if x != nil {
+		return x.CloseToClose
+	}
+	return 0
+}
+
+func (x *GetVolatilityResponse) GetParkinson() float64 {
+	if x != nil {
+		return x.Parkinson
+	}
+	return 0
+}
+
+func (x *GetVolatilityResponse) GetGarmanKlass() float64 {
+	if x != nil {
+		return x.GarmanKlass
+	}
+	return 0
+}
+
+func (x *GetVolatilityResponse) GetEstimator() VolatilityEstimator {
+	if x != nil {
+		return x.Estimator
+	}
+	return VolatilityEstimator_VOLATILITY_ESTIMATOR_UNSPECIFIED
+}
+
+func (x *GetVolatilityResponse) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *GetVolatilityResponse) GetRegime() VolatilityRegime {
+	if x != nil {
+		return x.Regime
+	}
+	return VolatilityRegime_VOLATILITY_REGIME_UNSPECIFIED
+}
+
+func (x *GetVolatilityResponse) GetHistorySamples() int32 {
+	if x != nil {
+		return x.HistorySamples
+	}
+	return 0
+}
+
+func (x *GetVolatilityResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type ForecastRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                                           // Required
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                          // End of the history, unset for now
+	IntervalSeconds int64                  `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"` // Candle size, 0 for 5 minutes
+	History         int32                  `protobuf:"varint,4,opt,name=history,proto3" json:"history,omitempty"`                                        // Candles the model is fitted to, 0 for 288
+	Model           ForecastModel          `protobuf:"varint,5,opt,name=model,proto3,enum=cryptocheck.analytics.v1.ForecastModel" json:"model,omitempty"`
+	Steps           int32                  `protobuf:"varint,6,opt,name=steps,proto3" json:"steps,omitempty"`  // Candles ahead, 0 for 12
+	Level           float64                `protobuf:"fixed64,7,opt,name=level,proto3" json:"level,omitempty"` // Coverage of the prediction intervals, 0 for 0.95
+	Alpha           float64                `protobuf:"fixed64,8,opt,name=alpha,proto3" json:"alpha,omitempty"` // Level smoothing of ewma and holt, 0 to fit it
+	Beta            float64                `protobuf:"fixed64,9,opt,name=beta,proto3" json:"beta,omitempty"`   // Trend smoothing of holt, 0 to fit it
+	Order           int32                  `protobuf:"varint,10,opt,name=order,proto3" json:"order,omitempty"` // Lags of ar, 0 for 2
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ForecastRequest) Reset() {
+	*x = ForecastRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastRequest) ProtoMessage() {}
+
+func (x *ForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastRequest.ProtoReflect.Descriptor instead.
+func (*ForecastRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ForecastRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ForecastRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ForecastRequest) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetHistory() int32 {
+	if x != nil {
+		return x.History
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetModel() ForecastModel {
+	if x != nil {
+		return x.Model
+	}
+	return ForecastModel_FORECAST_MODEL_UNSPECIFIED
+}
+
+func (x *ForecastRequest) GetSteps() int32 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *ForecastRequest) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+type ForecastPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`     // Start of the forecast candle
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"` // Close
+	Lower         float64                `protobuf:"fixed64,3,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper         float64                `protobuf:"fixed64,4,opt,name=upper,proto3" json:"upper,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastPoint) Reset() {
+	*x = ForecastPoint{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastPoint) ProtoMessage() {}
+
+func (x *ForecastPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastPoint.ProtoReflect.Descriptor instead.
+func (*ForecastPoint) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ForecastPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ForecastPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ForecastPoint) GetLower() float64 {
+	if x != nil {
+		return x.Lower
+	}
+	return 0
+}
+
+func (x *ForecastPoint) GetUpper() float64 {
+	if x != nil {
+		return x.Upper
+	}
+	return 0
+}
+
+type ForecastResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Model           ForecastModel          `protobuf:"varint,2,opt,name=model,proto3,enum=cryptocheck.analytics.v1.ForecastModel" json:"model,omitempty"`
+	IntervalSeconds int64                  `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	Candles         int32                  `protobuf:"varint,4,opt,name=candles,proto3" json:"candles,omitempty"` // Candles the model was fitted to
+	Alpha           float64                `protobuf:"fixed64,5,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta            float64                `protobuf:"fixed64,6,opt,name=beta,proto3" json:"beta,omitempty"`
+	Coefficients    []float64              `protobuf:"fixed64,7,rep,packed,name=coefficients,proto3" json:"coefficients,omitempty"` // ar: the intercept, then one per lag
+	Sigma           float64                `protobuf:"fixed64,8,opt,name=sigma,proto3" json:"sigma,omitempty"`                      // Standard deviation of the one-step errors, in log price
+	Level           float64                `protobuf:"fixed64,9,opt,name=level,proto3" json:"level,omitempty"`
+	LastClose       float64                `protobuf:"fixed64,10,opt,name=last_close,json=lastClose,proto3" json:"last_close,omitempty"`
+	Time            *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=time,proto3" json:"time,omitempty"` // Start of the last candle
+	Points          []*ForecastPoint       `protobuf:"bytes,12,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ForecastResponse) Reset() {
+	*x = ForecastResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastResponse) ProtoMessage() {}
+
+func (x *ForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastResponse.ProtoReflect.Descriptor instead.
+func (*ForecastResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ForecastResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ForecastResponse) GetModel() ForecastModel {
+	if x != nil {
+		return x.Model
+	}
+	return ForecastModel_FORECAST_MODEL_UNSPECIFIED
+}
+
+func (x *ForecastResponse) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetCandles() int32 {
+	if x != nil {
+		return x.Candles
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetCoefficients() []float64 {
+	if x != nil {
+		return x.Coefficients
+	}
+	return nil
+}
+
+func (x *ForecastResponse) GetSigma() float64 {
+	if x != nil {
+		return x.Sigma
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetLastClose() float64 {
+	if x != nil {
+		return x.LastClose
+	}
+	return 0
+}
+
+func (x *ForecastResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ForecastResponse) GetPoints() []*ForecastPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type EvaluateForecastRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Forecast      *ForecastRequest       `protobuf:"bytes,1,opt,name=forecast,proto3" json:"forecast,omitempty"` // Required. Model and candles, history is the training window refitted at every origin
+	Origins       int32                  `protobuf:"varint,2,opt,name=origins,proto3" json:"origins,omitempty"`  // Forecast origins walked through, 0 for 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateForecastRequest) Reset() {
+	*x = EvaluateForecastRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateForecastRequest) ProtoMessage() {}
+
+func (x *EvaluateForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateForecastRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateForecastRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EvaluateForecastRequest) GetForecast() *ForecastRequest {
+	if x != nil {
+		return x.Forecast
+	}
+	return nil
+}
+
+func (x *EvaluateForecastRequest) GetOrigins() int32 {
+	if x != nil {
+		return x.Origins
+	}
+	return 0
+}
+
+type StepAccuracy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Step          int32                  `protobuf:"varint,1,opt,name=step,proto3" json:"step,omitempty"`
+	Forecasts     int32                  `protobuf:"varint,2,opt,name=forecasts,proto3" json:"forecasts,omitempty"`
+	Mae           float64                `protobuf:"fixed64,3,opt,name=mae,proto3" json:"mae,omitempty"`
+	Rmse          float64                `protobuf:"fixed64,4,opt,name=rmse,proto3" json:"rmse,omitempty"`
+	Mape          float64                `protobuf:"fixed64,5,opt,name=mape,proto3" json:"mape,omitempty"`                         // Percent
+	Coverage      float64                `protobuf:"fixed64,6,opt,name=coverage,proto3" json:"coverage,omitempty"`                 // Fraction of closes inside the prediction interval
+	NaiveMae      float64                `protobuf:"fixed64,7,opt,name=naive_mae,json=naiveMae,proto3" json:"naive_mae,omitempty"` // MAE of repeating the last close
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepAccuracy) Reset() {
+	*x = StepAccuracy{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepAccuracy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepAccuracy) ProtoMessage() {}
+
+func (x *StepAccuracy) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepAccuracy.ProtoReflect.Descriptor instead.
+func (*StepAccuracy) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StepAccuracy) GetStep() int32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *StepAccuracy) GetForecasts() int32 {
+	if x != nil {
+		return x.Forecasts
+	}
+	return 0
+}
+
+func (x *StepAccuracy) GetMae() float64 {
+	if x != nil {
+		return x.Mae
+	}
+	return 0
+}
+
+func (x *StepAccuracy) GetRmse() float64 {
+	if x != nil {
+		return x.Rmse
+	}
+	return 0
+}
+
+func (x *StepAccuracy) GetMape() float64 {
+	if x != nil {
+		return x.Mape
+	}
+	return 0
+}
+
+func (x *StepAccuracy) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+func (x *StepAccuracy) GetNaiveMae() float64 {
+	if x != nil {
+		return x.NaiveMae
+	}
+	return 0
+}
+
+type EvaluateForecastResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Model           ForecastModel          `protobuf:"varint,2,opt,name=model,proto3,enum=cryptocheck.analytics.v1.ForecastModel" json:"model,omitempty"`
+	IntervalSeconds int64                  `protobuf:"varint,3,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	Origins         int32                  `protobuf:"varint,4,opt,name=origins,proto3" json:"origins,omitempty"`
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"` // Start of the first forecast candle
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`       // Start of the last forecast candle
+	Steps           []*StepAccuracy        `protobuf:"bytes,7,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EvaluateForecastResponse) Reset() {
+	*x = EvaluateForecastResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateForecastResponse) ProtoMessage() {}
+
+func (x *EvaluateForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateForecastResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateForecastResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EvaluateForecastResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *EvaluateForecastResponse) GetModel() ForecastModel {
+	if x != nil {
+		return x.Model
+	}
+	return ForecastModel_FORECAST_MODEL_UNSPECIFIED
+}
+
+func (x *EvaluateForecastResponse) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *EvaluateForecastResponse) GetOrigins() int32 {
+	if x != nil {
+		return x.Origins
+	}
+	return 0
+}
+
+func (x *EvaluateForecastResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *EvaluateForecastResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *EvaluateForecastResponse) GetSteps() []*StepAccuracy {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type GetLiquidityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                                     // Required
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                    // End of the window, unset for now
+	WindowSeconds int64                  `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"` // Snapshots averaged, 0 for 1 hour
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLiquidityRequest) Reset() {
+	*x = GetLiquidityRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLiquidityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiquidityRequest) ProtoMessage() {}
+
+func (x *GetLiquidityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiquidityRequest.ProtoReflect.Descriptor instead.
+func (*GetLiquidityRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetLiquidityRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetLiquidityRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetLiquidityRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+type DepthBand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bps           float64                `protobuf:"fixed64,1,opt,name=bps,proto3" json:"bps,omitempty"` // Distance from the mid price, 100 is 1%
+	BidQuantity   float64                `protobuf:"fixed64,2,opt,name=bid_quantity,json=bidQuantity,proto3" json:"bid_quantity,omitempty"`
+	AskQuantity   float64                `protobuf:"fixed64,3,opt,name=ask_quantity,json=askQuantity,proto3" json:"ask_quantity,omitempty"`
+	BidNotional   float64                `protobuf:"fixed64,4,opt,name=bid_notional,json=bidNotional,proto3" json:"bid_notional,omitempty"` // In the quote currency
+	AskNotional   float64                `protobuf:"fixed64,5,opt,name=ask_notional,json=askNotional,proto3" json:"ask_notional,omitempty"`
+	Imbalance     float64                `protobuf:"fixed64,6,opt,name=imbalance,proto3" json:"imbalance,omitempty"` // Of the notional, from -1 (asks only) to 1 (bids only)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepthBand) Reset() {
+	*x = DepthBand{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepthBand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthBand) ProtoMessage() {}
+
+func (x *DepthBand) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthBand.ProtoReflect.Descriptor instead.
+func (*DepthBand) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DepthBand) GetBps() float64 {
+	if x != nil {
+		return x.Bps
+	}
+	return 0
+}
+
+func (x *DepthBand) GetBidQuantity() float64 {
+	if x != nil {
+		return x.BidQuantity
+	}
+	return 0
+}
+
+func (x *DepthBand) GetAskQuantity() float64 {
+	if x != nil {
+		return x.AskQuantity
+	}
+	return 0
+}
+
+func (x *DepthBand) GetBidNotional() float64 {
+	if x != nil {
+		return x.BidNotional
+	}
+	return 0
+}
+
+func (x *DepthBand) GetAskNotional() float64 {
+	if x != nil {
+		return x.AskNotional
+	}
+	return 0
+}
+
+func (x *DepthBand) GetImbalance() float64 {
+	if x != nil {
+		return x.Imbalance
+	}
+	return 0
+}
+
+type LiquidityPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Mid           float64                `protobuf:"fixed64,2,opt,name=mid,proto3" json:"mid,omitempty"`
+	SpreadBps     float64                `protobuf:"fixed64,3,opt,name=spread_bps,json=spreadBps,proto3" json:"spread_bps,omitempty"`
+	Imbalance     float64                `protobuf:"fixed64,4,opt,name=imbalance,proto3" json:"imbalance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LiquidityPoint) Reset() {
+	*x = LiquidityPoint{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LiquidityPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiquidityPoint) ProtoMessage() {}
+
+func (x *LiquidityPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiquidityPoint.ProtoReflect.Descriptor instead.
+func (*LiquidityPoint) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *LiquidityPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *LiquidityPoint) GetMid() float64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *LiquidityPoint) GetSpreadBps() float64 {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return 0
+}
+
+func (x *LiquidityPoint) GetImbalance() float64 {
+	if x != nil {
+		return x.Imbalance
+	}
+	return 0
+}
+
+type GetLiquidityResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Time            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"` // Latest snapshot
+	BestBid         float64                `protobuf:"fixed64,3,opt,name=best_bid,json=bestBid,proto3" json:"best_bid,omitempty"`
+	BestBidQuantity float64                `protobuf:"fixed64,4,opt,name=best_bid_quantity,json=bestBidQuantity,proto3" json:"best_bid_quantity,omitempty"`
+	BestAsk         float64                `protobuf:"fixed64,5,opt,name=best_ask,json=bestAsk,proto3" json:"best_ask,omitempty"`
+	BestAskQuantity float64                `protobuf:"fixed64,6,opt,name=best_ask_quantity,json=bestAskQuantity,proto3" json:"best_ask_quantity,omitempty"`
+	Mid             float64                `protobuf:"fixed64,7,opt,name=mid,proto3" json:"mid,omitempty"`
+	Spread          float64                `protobuf:"fixed64,8,opt,name=spread,proto3" json:"spread,omitempty"`
+	SpreadBps       float64                `protobuf:"fixed64,9,opt,name=spread_bps,json=spreadBps,proto3" json:"spread_bps,omitempty"`
+	Imbalance       float64                `protobuf:"fixed64,10,opt,name=imbalance,proto3" json:"imbalance,omitempty"` // Of the quantities at the best bid and ask
+	Bands           []*DepthBand           `protobuf:"bytes,11,rep,name=bands,proto3" json:"bands,omitempty"`
+	Snapshots       int32                  `protobuf:"varint,12,opt,name=snapshots,proto3" json:"snapshots,omitempty"` // In the window
+	AvgSpreadBps    float64                `protobuf:"fixed64,13,opt,name=avg_spread_bps,json=avgSpreadBps,proto3" json:"avg_spread_bps,omitempty"`
+	MaxSpreadBps    float64                `protobuf:"fixed64,14,opt,name=max_spread_bps,json=maxSpreadBps,proto3" json:"max_spread_bps,omitempty"`
+	AvgImbalance    float64                `protobuf:"fixed64,15,opt,name=avg_imbalance,json=avgImbalance,proto3" json:"avg_imbalance,omitempty"`
+	Series          []*LiquidityPoint      `protobuf:"bytes,16,rep,name=series,proto3" json:"series,omitempty"` // Oldest first
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetLiquidityResponse) Reset() {
+	*x = GetLiquidityResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLiquidityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLiquidityResponse) ProtoMessage() {}
+
+func (x *GetLiquidityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLiquidityResponse.ProtoReflect.Descriptor instead.
+func (*GetLiquidityResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetLiquidityResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetLiquidityResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *GetLiquidityResponse) GetBestBid() float64 {
+	if x != nil {
+		return x.BestBid
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetBestBidQuantity() float64 {
+	if x != nil {
+		return x.BestBidQuantity
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetBestAsk() float64 {
+	if x != nil {
+		return x.BestAsk
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetBestAskQuantity() float64 {
+	if x != nil {
+		return x.BestAskQuantity
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetMid() float64 {
+	if x != nil {
+		return x.Mid
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetSpread() float64 {
+	if x != nil {
+		return x.Spread
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetSpreadBps() float64 {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetImbalance() float64 {
+	if x != nil {
+		return x.Imbalance
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetBands() []*DepthBand {
+	if x != nil {
+		return x.Bands
+	}
+	return nil
+}
+
+func (x *GetLiquidityResponse) GetSnapshots() int32 {
+	if x != nil {
+		return x.Snapshots
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetAvgSpreadBps() float64 {
+	if x != nil {
+		return x.AvgSpreadBps
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetMaxSpreadBps() float64 {
+	if x != nil {
+		return x.MaxSpreadBps
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetAvgImbalance() float64 {
+	if x != nil {
+		return x.AvgImbalance
+	}
+	return 0
+}
+
+func (x *GetLiquidityResponse) GetSeries() []*LiquidityPoint {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+type GetVolumeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                                           // Required
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                          // End of the window, unset for now
+	WindowSeconds   int64                  `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`       // 0 for 24 hours
+	IntervalSeconds int64                  `protobuf:"varint,4,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"` // Candle size, 0 for 1 hour
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetVolumeRequest) Reset() {
+	*x = GetVolumeRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVolumeRequest) ProtoMessage() {}
+
+func (x *GetVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVolumeRequest.ProtoReflect.Descriptor instead.
+func (*GetVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetVolumeRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetVolumeRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetVolumeRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetVolumeRequest) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+type VolumeCandle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"` // Start of the candle
+	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close         float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Volume        float64                `protobuf:"fixed64,6,opt,name=volume,proto3" json:"volume,omitempty"`                        // In the base asset
+	BuyVolume     float64                `protobuf:"fixed64,7,opt,name=buy_volume,json=buyVolume,proto3" json:"buy_volume,omitempty"` // Bought by takers
+	QuoteVolume   float64                `protobuf:"fixed64,8,opt,name=quote_volume,json=quoteVolume,proto3" json:"quote_volume,omitempty"`
+	Trades        int32                  `protobuf:"varint,9,opt,name=trades,proto3" json:"trades,omitempty"`
+	Vwap          float64                `protobuf:"fixed64,10,opt,name=vwap,proto3" json:"vwap,omitempty"` // 0 without volume
+	Cvd           float64                `protobuf:"fixed64,11,opt,name=cvd,proto3" json:"cvd,omitempty"`   // Cumulative volume delta since the start of the window
+	Obv           float64                `protobuf:"fixed64,12,opt,name=obv,proto3" json:"obv,omitempty"`   // On-balance volume since the start of the window
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeCandle) Reset() {
+	*x = VolumeCandle{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeCandle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeCandle) ProtoMessage() {}
+
+func (x *VolumeCandle) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeCandle.ProtoReflect.Descriptor instead.
+func (*VolumeCandle) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *VolumeCandle) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *VolumeCandle) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetBuyVolume() float64 {
+	if x != nil {
+		return x.BuyVolume
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetQuoteVolume() float64 {
+	if x != nil {
+		return x.QuoteVolume
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetTrades() int32 {
+	if x != nil {
+		return x.Trades
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetVwap() float64 {
+	if x != nil {
+		return x.Vwap
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetCvd() float64 {
+	if x != nil {
+		return x.Cvd
+	}
+	return 0
+}
+
+func (x *VolumeCandle) GetObv() float64 {
+	if x != nil {
+		return x.Obv
+	}
+	return 0
+}
+
+type GetVolumeResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Symbol          string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Volume          float64                `protobuf:"fixed64,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	BuyVolume       float64                `protobuf:"fixed64,5,opt,name=buy_volume,json=buyVolume,proto3" json:"buy_volume,omitempty"`
+	SellVolume      float64                `protobuf:"fixed64,6,opt,name=sell_volume,json=sellVolume,proto3" json:"sell_volume,omitempty"`
+	QuoteVolume     float64                `protobuf:"fixed64,7,opt,name=quote_volume,json=quoteVolume,proto3" json:"quote_volume,omitempty"`
+	Trades          int32                  `protobuf:"varint,8,opt,name=trades,proto3" json:"trades,omitempty"`
+	Vwap            float64                `protobuf:"fixed64,9,opt,name=vwap,proto3" json:"vwap,omitempty"`                          // Over the window
+	Cvd             float64                `protobuf:"fixed64,10,opt,name=cvd,proto3" json:"cvd,omitempty"`                           // Taker buy minus sell volume over the window
+	BuyRatio        float64                `protobuf:"fixed64,11,opt,name=buy_ratio,json=buyRatio,proto3" json:"buy_ratio,omitempty"` // Share of the volume bought by takers
+	Obv             float64                `protobuf:"fixed64,12,opt,name=obv,proto3" json:"obv,omitempty"`                           // Of the last candle
+	IntervalSeconds int64                  `protobuf:"varint,13,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	Candles         []*VolumeCandle        `protobuf:"bytes,14,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetVolumeResponse) Reset() {
+	*x = GetVolumeResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVolumeResponse) ProtoMessage() {}
+
+func (x *GetVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVolumeResponse.ProtoReflect.Descriptor instead.
+func (*GetVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetVolumeResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetVolumeResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetVolumeResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetVolumeResponse) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetBuyVolume() float64 {
+	if x != nil {
+		return x.BuyVolume
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetSellVolume() float64 {
+	if x != nil {
+		return x.SellVolume
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetQuoteVolume() float64 {
+	if x != nil {
+		return x.QuoteVolume
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetTrades() int32 {
+	if x != nil {
+		return x.Trades
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetVwap() float64 {
+	if x != nil {
+		return x.Vwap
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetCvd() float64 {
+	if x != nil {
+		return x.Cvd
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetBuyRatio() float64 {
+	if x != nil {
+		return x.BuyRatio
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetObv() float64 {
+	if x != nil {
+		return x.Obv
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *GetVolumeResponse) GetCandles() []*VolumeCandle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+type GetDerivativesRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Symbol               string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`                                                            // Required
+	EndTime              *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                                           // End of the window, unset for now
+	WindowSeconds        int64                  `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`                        // 0 for 24 hours
+	FundingIntervalHours int32                  `protobuf:"varint,4,opt,name=funding_interval_hours,json=fundingIntervalHours,proto3" json:"funding_interval_hours,omitempty"` // Hours between funding payments, 0 for 8
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetDerivativesRequest) Reset() {
+	*x = GetDerivativesRequest{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDerivativesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDerivativesRequest) ProtoMessage() {}
+
+func (x *GetDerivativesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDerivativesRequest.ProtoReflect.Descriptor instead.
+func (*GetDerivativesRequest) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetDerivativesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetDerivativesRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *GetDerivativesRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetDerivativesRequest) GetFundingIntervalHours() int32 {
+	if x != nil {
+		return x.FundingIntervalHours
+	}
+	return 0
+}
+
+type DerivativesPoint struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Time              *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	MarkPrice         float64                `protobuf:"fixed64,2,opt,name=mark_price,json=markPrice,proto3" json:"mark_price,omitempty"`
+	SpotPrice         float64                `protobuf:"fixed64,3,opt,name=spot_price,json=spotPrice,proto3" json:"spot_price,omitempty"`                         // 0 when no spot price was stored yet
+	BasisBps          float64                `protobuf:"fixed64,4,opt,name=basis_bps,json=basisBps,proto3" json:"basis_bps,omitempty"`                            // Mark over spot, 0 without a spot price
+	FundingRate       float64                `protobuf:"fixed64,5,opt,name=funding_rate,json=fundingRate,proto3" json:"funding_rate,omitempty"`                   // Per funding interval
+	AnnualizedFunding float64                `protobuf:"fixed64,6,opt,name=annualized_funding,json=annualizedFunding,proto3" json:"annualized_funding,omitempty"` // Percent a year
+	OpenInterest      float64                `protobuf:"fixed64,7,opt,name=open_interest,json=openInterest,proto3" json:"open_interest,omitempty"`                // In the base asset
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DerivativesPoint) Reset() {
+	*x = DerivativesPoint{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DerivativesPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivativesPoint) ProtoMessage() {}
+
+func (x *DerivativesPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivativesPoint.ProtoReflect.Descriptor instead.
+func (*DerivativesPoint) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DerivativesPoint) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *DerivativesPoint) GetMarkPrice() float64 {
+	if x != nil {
+		return x.MarkPrice
+	}
+	return 0
+}
+
+func (x *DerivativesPoint) GetSpotPrice() float64 {
+	if x != nil {
+		return x.SpotPrice
+	}
+	return 0
+}
+
+func (x *DerivativesPoint) GetBasisBps() float64 {
+	if x != nil {
+		return x.BasisBps
+	}
+	return 0
+}
+
+func (x *DerivativesPoint) GetFundingRate() float64 {
+	if x != nil {
+		return x.FundingRate
+	}
+	return 0
+}
+
+func (x *DerivativesPoint) GetAnnualizedFunding() float64 {
+	if x != nil {
+		return x.AnnualizedFunding
+	}
+	return 0
+}
+
+func (x *DerivativesPoint) GetOpenInterest() float64 {
+	if x != nil {
+		return x.OpenInterest
+	}
+	return 0
+}
+
+type GetDerivativesResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Symbol                   string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Time                     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"` // Of the latest sample
+	MarkPrice                float64                `protobuf:"fixed64,3,opt,name=mark_price,json=markPrice,proto3" json:"mark_price,omitempty"`
+	IndexPrice               float64                `protobuf:"fixed64,4,opt,name=index_price,json=indexPrice,proto3" json:"index_price,omitempty"`
+	SpotPrice                float64                `protobuf:"fixed64,5,opt,name=spot_price,json=spotPrice,proto3" json:"spot_price,omitempty"`
+	Basis                    float64                `protobuf:"fixed64,6,opt,name=basis,proto3" json:"basis,omitempty"` // Mark minus spot price
+	BasisBps                 float64                `protobuf:"fixed64,7,opt,name=basis_bps,json=basisBps,proto3" json:"basis_bps,omitempty"`
+	PremiumBps               float64                `protobuf:"fixed64,8,opt,name=premium_bps,json=premiumBps,proto3" json:"premium_bps,omitempty"` // Mark over index price
+	FundingRate              float64                `protobuf:"fixed64,9,opt,name=funding_rate,json=fundingRate,proto3" json:"funding_rate,omitempty"`
+	AnnualizedFunding        float64                `protobuf:"fixed64,10,opt,name=annualized_funding,json=annualizedFunding,proto3" json:"annualized_funding,omitempty"`
+	NextFundingTime          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=next_funding_time,json=nextFundingTime,proto3" json:"next_funding_time,omitempty"`
+	OpenInterest             float64                `protobuf:"fixed64,12,opt,name=open_interest,json=openInterest,proto3" json:"open_interest,omitempty"`
+	OpenInterestNotional     float64                `protobuf:"fixed64,13,opt,name=open_interest_notional,json=openInterestNotional,proto3" json:"open_interest_notional,omitempty"` // At the mark price
+	OpenInterestChange       float64                `protobuf:"fixed64,14,opt,name=open_interest_change,json=openInterestChange,proto3" json:"open_interest_change,omitempty"`       // Percent since the first sample of the window
+	AverageAnnualizedFunding float64                `protobuf:"fixed64,15,opt,name=average_annualized_funding,json=averageAnnualizedFunding,proto3" json:"average_annualized_funding,omitempty"`
+	AverageBasisBps          float64                `protobuf:"fixed64,16,opt,name=average_basis_bps,json=averageBasisBps,proto3" json:"average_basis_bps,omitempty"` // Of the samples with a spot price
+	Points                   []*DerivativesPoint    `protobuf:"bytes,17,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GetDerivativesResponse) Reset() {
+	*x = GetDerivativesResponse{}
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDerivativesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDerivativesResponse) ProtoMessage() {}
+
+func (x *GetDerivativesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cryptocheck_analytics_v1_analytics_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDerivativesResponse.ProtoReflect.Descriptor instead.
+func (*GetDerivativesResponse) Descriptor() ([]byte, []int) {
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetDerivativesResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetDerivativesResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *GetDerivativesResponse) GetMarkPrice() float64 {
+	if x != nil {
+		return x.MarkPrice
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetIndexPrice() float64 {
+	if x != nil {
+		return x.IndexPrice
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetSpotPrice() float64 {
+	if x != nil {
+		return x.SpotPrice
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetBasis() float64 {
+	if x != nil {
+		return x.Basis
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetBasisBps() float64 {
+	if x != nil {
+		return x.BasisBps
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetPremiumBps() float64 {
+	if x != nil {
+		return x.PremiumBps
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetFundingRate() float64 {
+	if x != nil {
+		return x.FundingRate
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetAnnualizedFunding() float64 {
+	if x != nil {
+		return x.AnnualizedFunding
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetNextFundingTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextFundingTime
+	}
+	return nil
+}
+
+func (x *GetDerivativesResponse) GetOpenInterest() float64 {
+	if x != nil {
+		return x.OpenInterest
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetOpenInterestNotional() float64 {
+	if x != nil {
+		return x.OpenInterestNotional
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetOpenInterestChange() float64 {
+	if x != nil {
+		return x.OpenInterestChange
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetAverageAnnualizedFunding() float64 {
+	if x != nil {
+		return x.AverageAnnualizedFunding
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetAverageBasisBps() float64 {
+	if x != nil {
+		return x.AverageBasisBps
+	}
+	return 0
+}
+
+func (x *GetDerivativesResponse) GetPoints() []*DerivativesPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+var File_cryptocheck_analytics_v1_analytics_proto protoreflect.FileDescriptor
+
+const file_cryptocheck_analytics_v1_analytics_proto_rawDesc = "" +
+	"\n" +
+	"(cryptocheck/analytics/v1/analytics.proto\x12\x18cryptocheck.analytics.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9a\x01\n" +
+	"\x10SignalThresholds\x12\x1a\n" +
+	"\boversold\x18\x01 \x01(\x01R\boversold\x12\x1e\n" +
+	"\n" +
+	"overbought\x18\x02 \x01(\x01R\n" +
+	"overbought\x12%\n" +
+	"\x0erocket_percent\x18\x03 \x01(\x01R\rrocketPercent\x12#\n" +
+	"\rcrash_percent\x18\x04 \x01(\x01R\fcrashPercent\"?\n" +
+	"\rGetRSIRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\x05R\x06period\"\x86\x03\n" +
+	"\x0eGetRSIResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12#\n" +
+	"\rcurrent_price\x18\x02 \x01(\x01R\fcurrentPrice\x12\x1b\n" +
+	"\trsi_value\x18\x03 \x01(\x01R\brsiValue\x12;\n" +
+	"\x06signal\x18\x04 \x01(\x0e2#.cryptocheck.analytics.v1.RSISignalR\x06signal\x12%\n" +
+	"\x0echange_percent\x18\x05 \x01(\x01R\rchangePercent\x12:\n" +
+	"\x05trend\x18\x06 \x01(\x0e2$.cryptocheck.analytics.v1.PriceTrendR\x05trend\x12J\n" +
+	"\n" +
+	"thresholds\x18\a \x01(\v2*.cryptocheck.analytics.v1.SignalThresholdsR\n" +
+	"thresholds\x12.\n" +
+	"\x04time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\xc3\x03\n" +
+	"\x0fBacktestRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12)\n" +
+	"\x10interval_seconds\x18\x04 \x01(\x03R\x0fintervalSeconds\x12F\n" +
+	"\bstrategy\x18\x05 \x01(\x0e2*.cryptocheck.analytics.v1.BacktestStrategyR\bstrategy\x12\x1d\n" +
+	"\n" +
+	"rsi_period\x18\x06 \x01(\x05R\trsiPeriod\x12\x1a\n" +
+	"\boversold\x18\a \x01(\x01R\boversold\x12\x1e\n" +
+	"\n" +
+	"overbought\x18\b \x01(\x01R\n" +
+	"overbought\x12!\n" +
+	"\finitial_cash\x18\t \x01(\x01R\vinitialCash\x12\x19\n" +
+	"\bfee_rate\x18\n" +
+	" \x01(\x01R\afeeRate\x12\x1a\n" +
+	"\bslippage\x18\v \x01(\x01R\bslippage\"U\n" +
+	"\vEquityPoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06equity\x18\x02 \x01(\x01R\x06equity\"\xc6\x01\n" +
+	"\x05Trade\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x127\n" +
+	"\x04side\x18\x02 \x01(\x0e2#.cryptocheck.analytics.v1.TradeSideR\x04side\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x01R\bquantity\x12\x10\n" +
+	"\x03fee\x18\x05 \x01(\x01R\x03fee\x12\x10\n" +
+	"\x03pnl\x18\x06 \x01(\x01R\x03pnl\"\xe2\x02\n" +
+	"\x10BacktestResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\x12!\n" +
+	"\finitial_cash\x18\x03 \x01(\x01R\vinitialCash\x12!\n" +
+	"\ffinal_equity\x18\x04 \x01(\x01R\vfinalEquity\x12!\n" +
+	"\ftotal_return\x18\x05 \x01(\x01R\vtotalReturn\x12!\n" +
+	"\fmax_drawdown\x18\x06 \x01(\x01R\vmaxDrawdown\x12\x16\n" +
+	"\x06sharpe\x18\a \x01(\x01R\x06sharpe\x12=\n" +
+	"\x06equity\x18\b \x03(\v2%.cryptocheck.analytics.v1.EquityPointR\x06equity\x127\n" +
+	"\x06trades\x18\t \x03(\v2\x1f.cryptocheck.analytics.v1.TradeR\x06trades\"\xc2\x02\n" +
+	"\x15GetCorrelationRequest\x12\x18\n" +
+	"\asymbols\x18\x01 \x03(\tR\asymbols\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12%\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\x12-\n" +
+	"\x12resolution_seconds\x18\x04 \x01(\x03R\x11resolutionSeconds\x12C\n" +
+	"\x06method\x18\x05 \x01(\x0e2+.cryptocheck.analytics.v1.CorrelationMethodR\x06method\x12\x1c\n" +
+	"\tbenchmark\x18\x06 \x01(\tR\tbenchmark\x12\x1f\n" +
+	"\vbeta_window\x18\a \x01(\x05R\n" +
+	"betaWindow\"@\n" +
+	"\x0eCorrelationRow\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\x01R\x06values\"O\n" +
+	"\tBetaPoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04beta\x18\x02 \x01(\x01R\x04beta\"w\n" +
+	"\n" +
+	"SymbolBeta\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04beta\x18\x02 \x01(\x01R\x04beta\x12=\n" +
+	"\arolling\x18\x03 \x03(\v2#.cryptocheck.analytics.v1.BetaPointR\arolling\"\x9f\x03\n" +
+	"\x16GetCorrelationResponse\x12\x18\n" +
+	"\asymbols\x18\x01 \x03(\tR\asymbols\x12C\n" +
+	"\x06method\x18\x02 \x01(\x0e2+.cryptocheck.analytics.v1.CorrelationMethodR\x06method\x12\x1c\n" +
+	"\tbenchmark\x18\x03 \x01(\tR\tbenchmark\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x18\n" +
+	"\asamples\x18\x06 \x01(\x05R\asamples\x12@\n" +
+	"\x06matrix\x18\a \x03(\v2(.cryptocheck.analytics.v1.CorrelationRowR\x06matrix\x12:\n" +
+	"\x05betas\x18\b \x03(\v2$.cryptocheck.analytics.v1.SymbolBetaR\x05betas\"\x98\x02\n" +
+	"\x14GetVolatilityRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12)\n" +
+	"\x10interval_seconds\x18\x02 \x01(\x03R\x0fintervalSeconds\x12\x16\n" +
+	"\x06window\x18\x03 \x01(\x05R\x06window\x12!\n" +
+	"\fhistory_days\x18\x04 \x01(\x05R\vhistoryDays\x12K\n" +
+	"\testimator\x18\x05 \x01(\x0e2-.cryptocheck.analytics.v1.VolatilityEstimatorR\testimator\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\xe5\x03\n" +
+	"\x15GetVolatilityResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12)\n" +
+	"\x10interval_seconds\x18\x02 \x01(\x03R\x0fintervalSeconds\x12\x18\n" +
+	"\acandles\x18\x03 \x01(\x05R\acandles\x12$\n" +
+	"\x0eclose_to_close\x18\x04 \x01(\x01R\fcloseToClose\x12\x1c\n" +
+	"\tparkinson\x18\x05 \x01(\x01R\tparkinson\x12!\n" +
+	"\fgarman_klass\x18\x06 \x01(\x01R\vgarmanKlass\x12K\n" +
+	"\testimator\x18\a \x01(\x0e2-.cryptocheck.analytics.v1.VolatilityEstimatorR\testimator\x12\x1e\n" +
+	"\n" +
+	"percentile\x18\b \x01(\x01R\n" +
+	"percentile\x12B\n" +
+	"\x06regime\x18\t \x01(\x0e2*.cryptocheck.analytics.v1.VolatilityRegimeR\x06regime\x12'\n" +
+	"\x0fhistory_samples\x18\n" +
+	" \x01(\x05R\x0ehistorySamples\x12.\n" +
+	"\x04time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\xd0\x02\n" +
+	"\x0fForecastRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12)\n" +
+	"\x10interval_seconds\x18\x03 \x01(\x03R\x0fintervalSeconds\x12\x18\n" +
+	"\ahistory\x18\x04 \x01(\x05R\ahistory\x12=\n" +
+	"\x05model\x18\x05 \x01(\x0e2'.cryptocheck.analytics.v1.ForecastModelR\x05model\x12\x14\n" +
+	"\x05steps\x18\x06 \x01(\x05R\x05steps\x12\x14\n" +
+	"\x05level\x18\a \x01(\x01R\x05level\x12\x14\n" +
+	"\x05alpha\x18\b \x01(\x01R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\t \x01(\x01R\x04beta\x12\x14\n" +
+	"\x05order\x18\n" +
+	" \x01(\x05R\x05order\"\x81\x01\n" +
+	"\rForecastPoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x12\x14\n" +
+	"\x05lower\x18\x03 \x01(\x01R\x05lower\x12\x14\n" +
+	"\x05upper\x18\x04 \x01(\x01R\x05upper\"\xb8\x03\n" +
+	"\x10ForecastResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12=\n" +
+	"\x05model\x18\x02 \x01(\x0e2'.cryptocheck.analytics.v1.ForecastModelR\x05model\x12)\n" +
+	"\x10interval_seconds\x18\x03 \x01(\x03R\x0fintervalSeconds\x12\x18\n" +
+	"\acandles\x18\x04 \x01(\x05R\acandles\x12\x14\n" +
+	"\x05alpha\x18\x05 \x01(\x01R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\x06 \x01(\x01R\x04beta\x12\"\n" +
+	"\fcoefficients\x18\a \x03(\x01R\fcoefficients\x12\x14\n" +
+	"\x05sigma\x18\b \x01(\x01R\x05sigma\x12\x14\n" +
+	"\x05level\x18\t \x01(\x01R\x05level\x12\x1d\n" +
+	"\n" +
+	"last_close\x18\n" +
+	" \x01(\x01R\tlastClose\x12.\n" +
+	"\x04time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12?\n" +
+	"\x06points\x18\f \x03(\v2'.cryptocheck.analytics.v1.ForecastPointR\x06points\"z\n" +
+	"\x17EvaluateForecastRequest\x12E\n" +
+	"\bforecast\x18\x01 \x01(\v2).cryptocheck.analytics.v1.ForecastRequestR\bforecast\x12\x18\n" +
+	"\aorigins\x18\x02 \x01(\x05R\aorigins\"\xb3\x01\n" +
+	"\fStepAccuracy\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\x05R\x04step\x12\x1c\n" +
+	"\tforecasts\x18\x02 \x01(\x05R\tforecasts\x12\x10\n" +
+	"\x03mae\x18\x03 \x01(\x01R\x03mae\x12\x12\n" +
+	"\x04rmse\x18\x04 \x01(\x01R\x04rmse\x12\x12\n" +
+	"\x04mape\x18\x05 \x01(\x01R\x04mape\x12\x1a\n" +
+	"\bcoverage\x18\x06 \x01(\x01R\bcoverage\x12\x1b\n" +
+	"\tnaive_mae\x18\a \x01(\x01R\bnaiveMae\"\xe6\x02\n" +
+	"\x18EvaluateForecastResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12=\n" +
+	"\x05model\x18\x02 \x01(\x0e2'.cryptocheck.analytics.v1.ForecastModelR\x05model\x12)\n" +
+	"\x10interval_seconds\x18\x03 \x01(\x03R\x0fintervalSeconds\x12\x18\n" +
+	"\aorigins\x18\x04 \x01(\x05R\aorigins\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12<\n" +
+	"\x05steps\x18\a \x03(\v2&.cryptocheck.analytics.v1.StepAccuracyR\x05steps\"\x8b\x01\n" +
+	"\x13GetLiquidityRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12%\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\"\xc7\x01\n" +
+	"\tDepthBand\x12\x10\n" +
+	"\x03bps\x18\x01 \x01(\x01R\x03bps\x12!\n" +
+	"\fbid_quantity\x18\x02 \x01(\x01R\vbidQuantity\x12!\n" +
+	"\fask_quantity\x18\x03 \x01(\x01R\vaskQuantity\x12!\n" +
+	"\fbid_notional\x18\x04 \x01(\x01R\vbidNotional\x12!\n" +
+	"\fask_notional\x18\x05 \x01(\x01R\vaskNotional\x12\x1c\n" +
+	"\timbalance\x18\x06 \x01(\x01R\timbalance\"\x8f\x01\n" +
+	"\x0eLiquidityPoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x10\n" +
+	"\x03mid\x18\x02 \x01(\x01R\x03mid\x12\x1d\n" +
+	"\n" +
+	"spread_bps\x18\x03 \x01(\x01R\tspreadBps\x12\x1c\n" +
+	"\timbalance\x18\x04 \x01(\x01R\timbalance\"\xdf\x04\n" +
+	"\x14GetLiquidityResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x19\n" +
+	"\bbest_bid\x18\x03 \x01(\x01R\abestBid\x12*\n" +
+	"\x11best_bid_quantity\x18\x04 \x01(\x01R\x0fbestBidQuantity\x12\x19\n" +
+	"\bbest_ask\x18\x05 \x01(\x01R\abestAsk\x12*\n" +
+	"\x11best_ask_quantity\x18\x06 \x01(\x01R\x0fbestAskQuantity\x12\x10\n" +
+	"\x03mid\x18\a \x01(\x01R\x03mid\x12\x16\n" +
+	"\x06spread\x18\b \x01(\x01R\x06spread\x12\x1d\n" +
+	"\n" +
+	"spread_bps\x18\t \x01(\x01R\tspreadBps\x12\x1c\n" +
+	"\timbalance\x18\n" +
+	" \x01(\x01R\timbalance\x129\n" +
+	"\x05bands\x18\v \x03(\v2#.cryptocheck.analytics.v1.DepthBandR\x05bands\x12\x1c\n" +
+	"\tsnapshots\x18\f \x01(\x05R\tsnapshots\x12$\n" +
+	"\x0eavg_spread_bps\x18\r \x01(\x01R\favgSpreadBps\x12$\n" +
+	"\x0emax_spread_bps\x18\x0e \x01(\x01R\fmaxSpreadBps\x12#\n" +
+	"\ravg_imbalance\x18\x0f \x01(\x01R\favgImbalance\x12@\n" +
+	"\x06series\x18\x10 \x03(\v2(.cryptocheck.analytics.v1.LiquidityPointR\x06series\"\xb3\x01\n" +
+	"\x10GetVolumeRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12%\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\x12)\n" +
+	"\x10interval_seconds\x18\x04 \x01(\x03R\x0fintervalSeconds\"\xb8\x02\n" +
+	"\fVolumeCandle\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x01R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x01R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x01R\x03low\x12\x14\n" +
+	"\x05close\x18\x05 \x01(\x01R\x05close\x12\x16\n" +
+	"\x06volume\x18\x06 \x01(\x01R\x06volume\x12\x1d\n" +
+	"\n" +
+	"buy_volume\x18\a \x01(\x01R\tbuyVolume\x12!\n" +
+	"\fquote_volume\x18\b \x01(\x01R\vquoteVolume\x12\x16\n" +
+	"\x06trades\x18\t \x01(\x05R\x06trades\x12\x12\n" +
+	"\x04vwap\x18\n" +
+	" \x01(\x01R\x04vwap\x12\x10\n" +
+	"\x03cvd\x18\v \x01(\x01R\x03cvd\x12\x10\n" +
+	"\x03obv\x18\f \x01(\x01R\x03obv\"\xf2\x03\n" +
+	"\x11GetVolumeResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x16\n" +
+	"\x06volume\x18\x04 \x01(\x01R\x06volume\x12\x1d\n" +
+	"\n" +
+	"buy_volume\x18\x05 \x01(\x01R\tbuyVolume\x12\x1f\n" +
+	"\vsell_volume\x18\x06 \x01(\x01R\n" +
+	"sellVolume\x12!\n" +
+	"\fquote_volume\x18\a \x01(\x01R\vquoteVolume\x12\x16\n" +
+	"\x06trades\x18\b \x01(\x05R\x06trades\x12\x12\n" +
+	"\x04vwap\x18\t \x01(\x01R\x04vwap\x12\x10\n" +
+	"\x03cvd\x18\n" +
+	" \x01(\x01R\x03cvd\x12\x1b\n" +
+	"\tbuy_ratio\x18\v \x01(\x01R\bbuyRatio\x12\x10\n" +
+	"\x03obv\x18\f \x01(\x01R\x03obv\x12)\n" +
+	"\x10interval_seconds\x18\r \x01(\x03R\x0fintervalSeconds\x12@\n" +
+	"\acandles\x18\x0e \x03(\v2&.cryptocheck.analytics.v1.VolumeCandleR\acandles\"\xc3\x01\n" +
+	"\x15GetDerivativesRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12%\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\x124\n" +
+	"\x16funding_interval_hours\x18\x04 \x01(\x05R\x14fundingIntervalHours\"\x94\x02\n" +
+	"\x10DerivativesPoint\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1d\n" +
+	"\n" +
+	"mark_price\x18\x02 \x01(\x01R\tmarkPrice\x12\x1d\n" +
+	"\n" +
+	"spot_price\x18\x03 \x01(\x01R\tspotPrice\x12\x1b\n" +
+	"\tbasis_bps\x18\x04 \x01(\x01R\bbasisBps\x12!\n" +
+	"\ffunding_rate\x18\x05 \x01(\x01R\vfundingRate\x12-\n" +
+	"\x12annualized_funding\x18\x06 \x01(\x01R\x11annualizedFunding\x12#\n" +
+	"\ropen_interest\x18\a \x01(\x01R\fopenInterest\"\xe8\x05\n" +
+	"\x16GetDerivativesResponse\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1d\n" +
+	"\n" +
+	"mark_price\x18\x03 \x01(\x01R\tmarkPrice\x12\x1f\n" +
+	"\vindex_price\x18\x04 \x01(\x01R\n" +
+	"indexPrice\x12\x1d\n" +
+	"\n" +
+	"spot_price\x18\x05 \x01(\x01R\tspotPrice\x12\x14\n" +
+	"\x05basis\x18\x06 \x01(\x01R\x05basis\x12\x1b\n" +
+	"\tbasis_bps\x18\a \x01(\x01R\bbasisBps\x12\x1f\n" +
+	"\vpremium_bps\x18\b \x01(\x01R\n" +
+	"premiumBps\x12!\n" +
+	"\ffunding_rate\x18\t \x01(\x01R\vfundingRate\x12-\n" +
+	"\x12annualized_funding\x18\n" +
+	" \x01(\x01R\x11annualizedFunding\x12F\n" +
+	"\x11next_funding_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0fnextFundingTime\x12#\n" +
+	"\ropen_interest\x18\f \x01(\x01R\fopenInterest\x124\n" +
+	"\x16open_interest_notional\x18\r \x01(\x01R\x14openInterestNotional\x120\n" +
+	"\x14open_interest_change\x18\x0e \x01(\x01R\x12openInterestChange\x12<\n" +
+	"\x1aaverage_annualized_funding\x18\x0f \x01(\x01R\x18averageAnnualizedFunding\x12*\n" +
+	"\x11average_basis_bps\x18\x10 \x01(\x01R\x0faverageBasisBps\x12B\n" +
+	"\x06points\x18\x11 \x03(\v2*.cryptocheck.analytics.v1.DerivativesPointR\x06points*\x94\x01\n" +
+	"\tRSISignal\x12\x1a\n" +
+	"\x16RSI_SIGNAL_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bRSI_SIGNAL_WAITING_FOR_DATA\x10\x01\x12\x16\n" +
+	"\x12RSI_SIGNAL_NEUTRAL\x10\x02\x12\x17\n" +
+	"\x13RSI_SIGNAL_OVERSOLD\x10\x03\x12\x19\n" +
+	"\x15RSI_SIGNAL_OVERBOUGHT\x10\x04*\x92\x01\n" +
+	"\n" +
+	"PriceTrend\x12\x1b\n" +
+	"\x17PRICE_TREND_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cPRICE_TREND_WAITING_FOR_DATA\x10\x01\x12\x16\n" +
+	"\x12PRICE_TREND_STABLE\x10\x02\x12\x16\n" +
+	"\x12PRICE_TREND_ROCKET\x10\x03\x12\x15\n" +
+	"\x11PRICE_TREND_CRASH\x10\x04*P\n" +
+	"\x10BacktestStrategy\x12!\n" +
+	"\x1dBACKTEST_STRATEGY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BACKTEST_STRATEGY_RSI\x10\x01*P\n" +
+	"\tTradeSide\x12\x1a\n" +
+	"\x16TRADE_SIDE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eTRADE_SIDE_BUY\x10\x01\x12\x13\n" +
+	"\x0fTRADE_SIDE_SELL\x10\x02*x\n" +
+	"\x11CorrelationMethod\x12\"\n" +
+	"\x1eCORRELATION_METHOD_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aCORRELATION_METHOD_PEARSON\x10\x01\x12\x1f\n" +
+	"\x1bCORRELATION_METHOD_SPEARMAN\x10\x02*\xaf\x01\n" +
+	"\x13VolatilityEstimator\x12$\n" +
+	" VOLATILITY_ESTIMATOR_UNSPECIFIED\x10\x00\x12'\n" +
+	"#VOLATILITY_ESTIMATOR_CLOSE_TO_CLOSE\x10\x01\x12\"\n" +
+	"\x1eVOLATILITY_ESTIMATOR_PARKINSON\x10\x02\x12%\n" +
+	"!VOLATILITY_ESTIMATOR_GARMAN_KLASS\x10\x03*\xc8\x01\n" +
+	"\x10VolatilityRegime\x12!\n" +
+	"\x1dVOLATILITY_REGIME_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19VOLATILITY_REGIME_UNKNOWN\x10\x01\x12\x19\n" +
+	"\x15VOLATILITY_REGIME_LOW\x10\x02\x12\x1c\n" +
+	"\x18VOLATILITY_REGIME_NORMAL\x10\x03\x12\x1a\n" +
+	"\x16VOLATILITY_REGIME_HIGH\x10\x04\x12\x1d\n" +
+	"\x19VOLATILITY_REGIME_EXTREME\x10\x05*x\n" +
+	"\rForecastModel\x12\x1e\n" +
+	"\x1aFORECAST_MODEL_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13FORECAST_MODEL_EWMA\x10\x01\x12\x17\n" +
+	"\x13FORECAST_MODEL_HOLT\x10\x02\x12\x15\n" +
+	"\x11FORECAST_MODEL_AR\x10\x032\xe1\a\n" +
+	"\x10AnalyticsService\x12[\n" +
+	"\x06GetRSI\x12'.cryptocheck.analytics.v1.GetRSIRequest\x1a(.cryptocheck.analytics.v1.GetRSIResponse\x12a\n" +
+	"\bBacktest\x12).cryptocheck.analytics.v1.BacktestRequest\x1a*.cryptocheck.analytics.v1.BacktestResponse\x12s\n" +
+	"\x0eGetCorrelation\x12/.cryptocheck.analytics.v1.GetCorrelationRequest\x1a0.cryptocheck.analytics.v1.GetCorrelationResponse\x12p\n" +
+	"\rGetVolatility\x12..cryptocheck.analytics.v1.GetVolatilityRequest\x1a/.cryptocheck.analytics.v1.GetVolatilityResponse\x12a\n" +
+	"\bForecast\x12).cryptocheck.analytics.v1.ForecastRequest\x1a*.cryptocheck.analytics.v1.ForecastResponse\x12y\n" +
+	"\x10EvaluateForecast\x121.cryptocheck.analytics.v1.EvaluateForecastRequest\x1a2.cryptocheck.analytics.v1.EvaluateForecastResponse\x12m\n" +
+	"\fGetLiquidity\x12-.cryptocheck.analytics.v1.GetLiquidityRequest\x1a..cryptocheck.analytics.v1.GetLiquidityResponse\x12d\n" +
+	"\tGetVolume\x12*.cryptocheck.analytics.v1.GetVolumeRequest\x1a+.cryptocheck.analytics.v1.GetVolumeResponse\x12s\n" +
+	"\x0eGetDerivatives\x12/.cryptocheck.analytics.v1.GetDerivativesRequest\x1a0.cryptocheck.analytics.v1.GetDerivativesResponseB6Z4crypto-check/pb/cryptocheck/analytics/v1;analyticsv1b\x06proto3"
+
+var (
+	file_cryptocheck_analytics_v1_analytics_proto_rawDescOnce sync.Once
+	file_cryptocheck_analytics_v1_analytics_proto_rawDescData []byte
+)
+
+func file_cryptocheck_analytics_v1_analytics_proto_rawDescGZIP() []byte {
+	file_cryptocheck_analytics_v1_analytics_proto_rawDescOnce.Do(func() {
+		file_cryptocheck_analytics_v1_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cryptocheck_analytics_v1_analytics_proto_rawDesc), len(file_cryptocheck_analytics_v1_analytics_proto_rawDesc)))
+	})
+	return file_cryptocheck_analytics_v1_analytics_proto_rawDescData
+}
+
+var file_cryptocheck_analytics_v1_analytics_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_cryptocheck_analytics_v1_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_cryptocheck_analytics_v1_analytics_proto_goTypes = []any{
+	(RSISignal)(0),                   // 0: cryptocheck.analytics.v1.RSISignal
+	(PriceTrend)(0),                  // 1: cryptocheck.analytics.v1.PriceTrend
+	(BacktestStrategy)(0),            // 2: cryptocheck.analytics.v1.BacktestStrategy
+	(TradeSide)(0),                   // 3: cryptocheck.analytics.v1.TradeSide
+	(CorrelationMethod)(0),           // 4: cryptocheck.analytics.v1.CorrelationMethod
+	(VolatilityEstimator)(0),         // 5: cryptocheck.analytics.v1.VolatilityEstimator
+	(VolatilityRegime)(0),            // 6: cryptocheck.analytics.v1.VolatilityRegime
+	(ForecastModel)(0),               // 7: cryptocheck.analytics.v1.ForecastModel
+	(*SignalThresholds)(nil),         // 8: cryptocheck.analytics.v1.SignalThresholds
+	(*GetRSIRequest)(nil),            // 9: cryptocheck.analytics.v1.GetRSIRequest
+	(*GetRSIResponse)(nil),           // 10: cryptocheck.analytics.v1.GetRSIResponse
+	(*BacktestRequest)(nil),          // 11: cryptocheck.analytics.v1.BacktestRequest
+	(*EquityPoint)(nil),              // 12: cryptocheck.analytics.v1.EquityPoint
+	(*Trade)(nil),                    // 13: cryptocheck.analytics.v1.Trade
+	(*BacktestResponse)(nil),         // 14: cryptocheck.analytics.v1.BacktestResponse
+	(*GetCorrelationRequest)(nil),    // 15: cryptocheck.analytics.v1.GetCorrelationRequest
+	(*CorrelationRow)(nil),           // 16: cryptocheck.analytics.v1.CorrelationRow
+	(*BetaPoint)(nil),                // 17: cryptocheck.analytics.v1.BetaPoint
+	(*SymbolBeta)(nil),               // 18: cryptocheck.analytics.v1.SymbolBeta
+	(*GetCorrelationResponse)(nil),   // 19: cryptocheck.analytics.v1.GetCorrelationResponse
+	(*GetVolatilityRequest)(nil),     // 20: cryptocheck.analytics.v1.GetVolatilityRequest
+	(*GetVolatilityResponse)(nil),    // 21: cryptocheck.analytics.v1.GetVolatilityResponse
+	(*ForecastRequest)(nil),          // 22: cryptocheck.analytics.v1.ForecastRequest
+	(*ForecastPoint)(nil),            // 23: cryptocheck.analytics.v1.ForecastPoint
+	(*ForecastResponse)(nil),         // 24: cryptocheck.analytics.v1.ForecastResponse
+	(*EvaluateForecastRequest)(nil),  // 25: cryptocheck.analytics.v1.EvaluateForecastRequest
+	(*StepAccuracy)(nil),             // 26: cryptocheck.analytics.v1.StepAccuracy
+	(*EvaluateForecastResponse)(nil), // 27: cryptocheck.analytics.v1.EvaluateForecastResponse
+	(*GetLiquidityRequest)(nil),      // 28: cryptocheck.analytics.v1.GetLiquidityRequest
+	(*DepthBand)(nil),                // 29: cryptocheck.analytics.v1.DepthBand
+	(*LiquidityPoint)(nil),           // 30: cryptocheck.analytics.v1.LiquidityPoint
+	(*GetLiquidityResponse)(nil),     // 31: cryptocheck.analytics.v1.GetLiquidityResponse
+	(*GetVolumeRequest)(nil),         // 32: cryptocheck.analytics.v1.GetVolumeRequest
+	(*VolumeCandle)(nil),             // 33: cryptocheck.analytics.v1.VolumeCandle
+	(*GetVolumeResponse)(nil),        // 34: cryptocheck.analytics.v1.GetVolumeResponse
+	(*GetDerivativesRequest)(nil),    // 35: cryptocheck.analytics.v1.GetDerivativesRequest
+	(*DerivativesPoint)(nil),         // 36: cryptocheck.analytics.v1.DerivativesPoint
+	(*GetDerivativesResponse)(nil),   // 37: cryptocheck.analytics.v1.GetDerivativesResponse
+	(*timestamppb.Timestamp)(nil),    // 38: google.protobuf.Timestamp
+}
+var file_cryptocheck_analytics_v1_analytics_proto_depIdxs = []int32{
+	0,  // 0: cryptocheck.analytics.v1.GetRSIResponse.signal:type_name -> cryptocheck.analytics.v1.RSISignal
+	1,  // 1: cryptocheck.analytics.v1.GetRSIResponse.trend:type_name -> cryptocheck.analytics.v1.PriceTrend
+	8,  // 2: cryptocheck.analytics.v1.GetRSIResponse.thresholds:type_name -> cryptocheck.analytics.v1.SignalThresholds
+	38, // 3: cryptocheck.analytics.v1.GetRSIResponse.time:type_name -> google.protobuf.Timestamp
+	38, // 4: cryptocheck.analytics.v1.BacktestRequest.start_time:type_name -> google.protobuf.Timestamp
+	38, // 5: cryptocheck.analytics.v1.BacktestRequest.end_time:type_name -> google.protobuf.Timestamp
+	2,  // 6: cryptocheck.analytics.v1.BacktestRequest.strategy:type_name -> cryptocheck.analytics.v1.BacktestStrategy
+	38, // 7: cryptocheck.analytics.v1.EquityPoint.time:type_name -> google.protobuf.Timestamp
+	38, // 8: cryptocheck.analytics.v1.Trade.time:type_name -> google.protobuf.Timestamp
+	3,  // 9: cryptocheck.analytics.v1.Trade.side:type_name -> cryptocheck.analytics.v1.TradeSide
+	12, // 10: cryptocheck.analytics.v1.BacktestResponse.equity:type_name -> cryptocheck.analytics.v1.EquityPoint
+	13, // 11: cryptocheck.analytics.v1.BacktestResponse.trades:type_name -> cryptocheck.analytics.v1.Trade
+	38, // 12: cryptocheck.analytics.v1.GetCorrelationRequest.end_time:type_name -> google.protobuf.Timestamp
+	4,  // 13: cryptocheck.analytics.v1.GetCorrelationRequest.method:type_name -> cryptocheck.analytics.v1.CorrelationMethod
+	38, // 14: cryptocheck.analytics.v1.BetaPoint.time:type_name -> google.protobuf.Timestamp
+	17, // 15: cryptocheck.analytics.v1.SymbolBeta.rolling:type_name -> cryptocheck.analytics.v1.BetaPoint
+	4,  // 16: cryptocheck.analytics.v1.GetCorrelationResponse.method:type_name -> cryptocheck.analytics.v1.CorrelationMethod
+	38, // 17: cryptocheck.analytics.v1.GetCorrelationResponse.start_time:type_name -> google.protobuf.Timestamp
+	38, // 18: cryptocheck.analytics.v1.GetCorrelationResponse.end_time:type_name -> google.protobuf.Timestamp
+	16, // 19: cryptocheck.analytics.v1.GetCorrelationResponse.matrix:type_name -> cryptocheck.analytics.v1.CorrelationRow
+	18, // 20: cryptocheck.analytics.v1.GetCorrelationResponse.betas:type_name -> cryptocheck.analytics.v1.SymbolBeta
+	5,  // 21: cryptocheck.analytics.v1.GetVolatilityRequest.estimator:type_name -> cryptocheck.analytics.v1.VolatilityEstimator
+	38, // 22: cryptocheck.analytics.v1.GetVolatilityRequest.end_time:type_name -> google.protobuf.Timestamp
+	5,  // 23: cryptocheck.analytics.v1.GetVolatilityResponse.estimator:type_name -> cryptocheck.analytics.v1.VolatilityEstimator
+	6,  // 24: cryptocheck.analytics.v1.GetVolatilityResponse.regime:type_name -> cryptocheck.analytics.v1.VolatilityRegime
+	38, // 25: cryptocheck.analytics.v1.GetVolatilityResponse.time:type_name -> google.protobuf.Timestamp
+	38, // 26: cryptocheck.analytics.v1.ForecastRequest.end_time:type_name -> google.protobuf.Timestamp
+	7,  // 27: cryptocheck.analytics.v1.ForecastRequest.model:type_name -> cryptocheck.analytics.v1.ForecastModel
+	38, // 28: cryptocheck.analytics.v1.ForecastPoint.time:type_name -> google.protobuf.Timestamp
+	7,  // 29: cryptocheck.analytics.v1.ForecastResponse.model:type_name -> cryptocheck.analytics.v1.ForecastModel
+	38, // 30: cryptocheck.analytics.v1.ForecastResponse.time:type_name -> google.protobuf.Timestamp
+	23, // 31: cryptocheck.analytics.v1.ForecastResponse.points:type_name -> cryptocheck.analytics.v1.ForecastPoint
+	22, // 32: cryptocheck.analytics.v1.EvaluateForecastRequest.forecast:type_name -> cryptocheck.analytics.v1.ForecastRequest
+	7,  // 33: cryptocheck.analytics.v1.EvaluateForecastResponse.model:type_name -> cryptocheck.analytics.v1.ForecastModel
+	38, // 34: cryptocheck.analytics.v1.EvaluateForecastResponse.start_time:type_name -> google.protobuf.Timestamp
+	38, // 35: cryptocheck.analytics.v1.EvaluateForecastResponse.end_time:type_name -> google.protobuf.Timestamp
+	26, // 36: cryptocheck.analytics.v1.EvaluateForecastResponse.steps:type_name -> cryptocheck.analytics.v1.StepAccuracy
+	38, // 37: cryptocheck.analytics.v1.GetLiquidityRequest.end_time:type_name -> google.protobuf.Timestamp
+	38, // 38: cryptocheck.analytics.v1.LiquidityPoint.time:type_name -> google.protobuf.Timestamp
+	38, // 39: cryptocheck.analytics.v1.GetLiquidityResponse.time:type_name -> google.protobuf.Timestamp
+	29, // 40: cryptocheck.analytics.v1.GetLiquidityResponse.bands:type_name -> cryptocheck.analytics.v1.DepthBand
+	30, // 41: cryptocheck.analytics.v1.GetLiquidityResponse.series:type_name -> cryptocheck.analytics.v1.LiquidityPoint
+	38, // 42: cryptocheck.analytics.v1.GetVolumeRequest.end_time:type_name -> google.protobuf.Timestamp
+	38, // 43: cryptocheck.analytics.v1.VolumeCandle.time:type_name -> google.protobuf.Timestamp
+	38, // 44: cryptocheck.analytics.v1.GetVolumeResponse.start_time:type_name -> google.protobuf.Timestamp
+	38, // 45: cryptocheck.analytics.v1.GetVolumeResponse.end_time:type_name -> google.protobuf.Timestamp
+	33, // 46: cryptocheck.analytics.v1.GetVolumeResponse.candles:type_name -> cryptocheck.analytics.v1.VolumeCandle
+	38, // 47: cryptocheck.analytics.v1.GetDerivativesRequest.end_time:type_name -> google.protobuf.Timestamp
+	38, // 48: cryptocheck.analytics.v1.DerivativesPoint.time:type_name -> google.protobuf.Timestamp
+	38, // 49: cryptocheck.analytics.v1.GetDerivativesResponse.time:type_name -> google.protobuf.Timestamp
+	38, // 50: cryptocheck.analytics.v1.GetDerivativesResponse.next_funding_time:type_name -> google.protobuf.Timestamp
+	36, // 51: cryptocheck.analytics.v1.GetDerivativesResponse.points:type_name -> cryptocheck.analytics.v1.DerivativesPoint
+	9,  // 52: cryptocheck.analytics.v1.AnalyticsService.GetRSI:input_type -> cryptocheck.analytics.v1.GetRSIRequest
+	11, // 53: cryptocheck.analytics.v1.AnalyticsService.Backtest:input_type -> cryptocheck.analytics.v1.BacktestRequest
+	15, // 54: cryptocheck.analytics.v1.AnalyticsService.GetCorrelation:input_type -> cryptocheck.analytics.v1.GetCorrelationRequest
+	20, // 55: cryptocheck.analytics.v1.AnalyticsService.GetVolatility:input_type -> cryptocheck.analytics.v1.GetVolatilityRequest
+	22, // 56: cryptocheck.analytics.v1.AnalyticsService.Forecast:input_type -> cryptocheck.analytics.v1.ForecastRequest
+	25, // 57: cryptocheck.analytics.v1.AnalyticsService.EvaluateForecast:input_type -> cryptocheck.analytics.v1.EvaluateForecastRequest
+	28, // 58: cryptocheck.analytics.v1.AnalyticsService.GetLiquidity:input_type -> cryptocheck.analytics.v1.GetLiquidityRequest
+	32, // 59: cryptocheck.analytics.v1.AnalyticsService.GetVolume:input_type -> cryptocheck.analytics.v1.GetVolumeRequest
+	35, // 60: cryptocheck.analytics.v1.AnalyticsService.GetDerivatives:input_type -> cryptocheck.analytics.v1.GetDerivativesRequest
+	10, // 61: cryptocheck.analytics.v1.AnalyticsService.GetRSI:output_type -> cryptocheck.analytics.v1.GetRSIResponse
+	14, // 62: cryptocheck.analytics.v1.AnalyticsService.Backtest:output_type -> cryptocheck.analytics.v1.BacktestResponse
+	19, // 63: cryptocheck.analytics.v1.AnalyticsService.GetCorrelation:output_type -> cryptocheck.analytics.v1.GetCorrelationResponse
+	21, // 64: cryptocheck.analytics.v1.AnalyticsService.GetVolatility:output_type -> cryptocheck.analytics.v1.GetVolatilityResponse
+	24, // 65: cryptocheck.analytics.v1.AnalyticsService.Forecast:output_type -> cryptocheck.analytics.v1.ForecastResponse
+	27, // 66: cryptocheck.analytics.v1.AnalyticsService.EvaluateForecast:output_type -> cryptocheck.analytics.v1.EvaluateForecastResponse
+	31, // 67: cryptocheck.analytics.v1.AnalyticsService.GetLiquidity:output_type -> cryptocheck.analytics.v1.GetLiquidityResponse
+	34, // 68: cryptocheck.analytics.v1.AnalyticsService.GetVolume:output_type -> cryptocheck.analytics.v1.GetVolumeResponse
+	37, // 69: cryptocheck.analytics.v1.AnalyticsService.GetDerivatives:output_type -> cryptocheck.analytics.v1.GetDerivativesResponse
+	61, // [61:70] is the sub-list for method output_type
+	52, // [52:61] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
+}
+
+func init() { file_cryptocheck_analytics_v1_analytics_proto_init() }
+func file_cryptocheck_analytics_v1_analytics_proto_init() {
+	if File_cryptocheck_analytics_v1_analytics_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cryptocheck_analytics_v1_analytics_proto_rawDesc), len(file_cryptocheck_analytics_v1_analytics_proto_rawDesc)),
+			NumEnums:      8,
+			NumMessages:   30,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cryptocheck_analytics_v1_analytics_proto_goTypes,
+		DependencyIndexes: file_cryptocheck_analytics_v1_analytics_proto_depIdxs,
+		EnumInfos:         file_cryptocheck_analytics_v1_analytics_proto_enumTypes,
+		MessageInfos:      file_cryptocheck_analytics_v1_analytics_proto_msgTypes,
+	}.Build()
+	File_cryptocheck_analytics_v1_analytics_proto = out.File
+	file_cryptocheck_analytics_v1_analytics_proto_goTypes = nil
+	file_cryptocheck_analytics_v1_analytics_proto_depIdxs = nil
+}
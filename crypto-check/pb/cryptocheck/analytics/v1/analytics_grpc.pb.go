@@ -0,0 +1,451 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v7.34.0
+// source: cryptocheck/analytics/v1/analytics.proto
+
+package analyticsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AnalyticsService_GetRSI_FullMethodName           = "/cryptocheck.analytics.v1.AnalyticsService/GetRSI"
+	AnalyticsService_Backtest_FullMethodName         = "/cryptocheck.analytics.v1.AnalyticsService/Backtest"
+	AnalyticsService_GetCorrelation_FullMethodName   = "/cryptocheck.analytics.v1.AnalyticsService/GetCorrelation"
+	AnalyticsService_GetVolatility_FullMethodName    = "/cryptocheck.analytics.v1.AnalyticsService/GetVolatility"
+	AnalyticsService_Forecast_FullMethodName         = "/cryptocheck.analytics.v1.AnalyticsService/Forecast"
+	AnalyticsService_EvaluateForecast_FullMethodName = "/cryptocheck.analytics.v1.AnalyticsService/EvaluateForecast"
+	AnalyticsService_GetLiquidity_FullMethodName     = "/cryptocheck.analytics.v1.AnalyticsService/GetLiquidity"
+	AnalyticsService_GetVolume_FullMethodName        = "/cryptocheck.analytics.v1.AnalyticsService/GetVolume"
+	AnalyticsService_GetDerivatives_FullMethodName   = "/cryptocheck.analytics.v1.AnalyticsService/GetDerivatives"
+)
+
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Errors carry google.rpc details: InvalidArgument a BadRequest naming the
+// rejected fields, NotFound a ResourceInfo naming the symbol without data, and
+// FailedPrecondition a PreconditionFailure when there is data but too little of it.
+type AnalyticsServiceClient interface {
+	// RSI of the latest prices of a symbol, classified with its signal thresholds
+	GetRSI(ctx context.Context, in *GetRSIRequest, opts ...grpc.CallOption) (*GetRSIResponse, error)
+	// Replays the stored prices of a symbol through a strategy
+	Backtest(ctx context.Context, in *BacktestRequest, opts ...grpc.CallOption) (*BacktestResponse, error)
+	// Correlation of the log returns of several symbols and their beta against a benchmark
+	GetCorrelation(ctx context.Context, in *GetCorrelationRequest, opts ...grpc.CallOption) (*GetCorrelationResponse, error)
+	// Annualized realized volatility ranked against its own history
+	GetVolatility(ctx context.Context, in *GetVolatilityRequest, opts ...grpc.CallOption) (*GetVolatilityResponse, error)
+	// Next closes of a symbol with prediction intervals
+	Forecast(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*ForecastResponse, error)
+	// Walks a forecast model forward through the stored history
+	EvaluateForecast(ctx context.Context, in *EvaluateForecastRequest, opts ...grpc.CallOption) (*EvaluateForecastResponse, error)
+	// Latest order book snapshot and its statistics over a window
+	GetLiquidity(ctx context.Context, in *GetLiquidityRequest, opts ...grpc.CallOption) (*GetLiquidityResponse, error)
+	// Traded volume, VWAP and CVD over a window
+	GetVolume(ctx context.Context, in *GetVolumeRequest, opts ...grpc.CallOption) (*GetVolumeResponse, error)
+	// Funding, basis and open interest of the perpetual futures of a symbol
+	GetDerivatives(ctx context.Context, in *GetDerivativesRequest, opts ...grpc.CallOption) (*GetDerivativesResponse, error)
+}
+
+type analyticsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
+	return &analyticsServiceClient{cc}
+}
+
+func (c *analyticsServiceClient) GetRSI(ctx context.Context, in *GetRSIRequest, opts ...grpc.CallOption) (*GetRSIResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRSIResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetRSI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) Backtest(ctx context.Context, in *BacktestRequest, opts ...grpc.CallOption) (*BacktestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BacktestResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_Backtest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetCorrelation(ctx context.Context, in *GetCorrelationRequest, opts ...grpc.CallOption) (*GetCorrelationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCorrelationResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetCorrelation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetVolatility(ctx context.Context, in *GetVolatilityRequest, opts ...grpc.CallOption) (*GetVolatilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVolatilityResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetVolatility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) Forecast(ctx context.Context, in *ForecastRequest, opts ...grpc.CallOption) (*ForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForecastResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_Forecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) EvaluateForecast(ctx context.Context, in *EvaluateForecastRequest, opts ...grpc.CallOption) (*EvaluateForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateForecastResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_EvaluateForecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetLiquidity(ctx context.Context, in *GetLiquidityRequest, opts ...grpc.CallOption) (*GetLiquidityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLiquidityResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetLiquidity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetVolume(ctx context.Context, in *GetVolumeRequest, opts ...grpc.CallOption) (*GetVolumeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVolumeResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetVolume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetDerivatives(ctx context.Context, in *GetDerivativesRequest, opts ...grpc.CallOption) (*GetDerivativesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDerivativesResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetDerivatives_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AnalyticsServiceServer is the server API for AnalyticsService service.
+// All implementations must embed UnimplementedAnalyticsServiceServer
+// for forward compatibility.
+//
+// Errors carry google.rpc details: InvalidArgument a BadRequest naming the
+// rejected fields, NotFound a ResourceInfo naming the symbol without data, and
+// FailedPrecondition a PreconditionFailure when there is data but too little of it.
+type AnalyticsServiceServer interface {
+	// RSI of the latest prices of a symbol, classified with its signal thresholds
+	GetRSI(context.Context, *GetRSIRequest) (*GetRSIResponse, error)
+	// Replays the stored prices of a symbol through a strategy
+	Backtest(context.Context, *BacktestRequest) (*BacktestResponse, error)
+	// Correlation of the log returns of several symbols and their beta against a benchmark
+	GetCorrelation(context.Context, *GetCorrelationRequest) (*GetCorrelationResponse, error)
+	// Annualized realized volatility ranked against its own history
+	GetVolatility(context.Context, *GetVolatilityRequest) (*GetVolatilityResponse, error)
+	// Next closes of a symbol with prediction intervals
+	Forecast(context.Context, *ForecastRequest) (*ForecastResponse, error)
+	// Walks a forecast model forward through the stored history
+	EvaluateForecast(context.Context, *EvaluateForecastRequest) (*EvaluateForecastResponse, error)
+	// Latest order book snapshot and its statistics over a window
+	GetLiquidity(context.Context, *GetLiquidityRequest) (*GetLiquidityResponse, error)
+	// Traded volume, VWAP and CVD over a window
+	GetVolume(context.Context, *GetVolumeRequest) (*GetVolumeResponse, error)
+	// Funding, basis and open interest of the perpetual futures of a symbol
+	GetDerivatives(context.Context, *GetDerivativesRequest) (*GetDerivativesResponse, error)
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+// UnimplementedAnalyticsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAnalyticsServiceServer struct{}
+
+func (UnimplementedAnalyticsServiceServer) GetRSI(context.Context, *GetRSIRequest) (*GetRSIResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRSI not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) Backtest(context.Context, *BacktestRequest) (*BacktestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Backtest not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetCorrelation(context.Context, *GetCorrelationRequest) (*GetCorrelationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCorrelation not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetVolatility(context.Context, *GetVolatilityRequest) (*GetVolatilityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVolatility not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) Forecast(context.Context, *ForecastRequest) (*ForecastResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Forecast not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) EvaluateForecast(context.Context, *EvaluateForecastRequest) (*EvaluateForecastResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EvaluateForecast not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetLiquidity(context.Context, *GetLiquidityRequest) (*GetLiquidityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLiquidity not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetVolume(context.Context, *GetVolumeRequest) (*GetVolumeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVolume not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetDerivatives(context.Context, *GetDerivativesRequest) (*GetDerivativesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDerivatives not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
+func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
+// result in compilation errors.
+type UnsafeAnalyticsServiceServer interface {
+	mustEmbedUnimplementedAnalyticsServiceServer()
+}
+
+func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
+	// If the following call panics, it indicates UnimplementedAnalyticsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
+}
+
+func _AnalyticsService_GetRSI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRSIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetRSI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetRSI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetRSI(ctx, req.(*GetRSIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_Backtest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BacktestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).Backtest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_Backtest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).Backtest(ctx, req.(*BacktestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetCorrelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCorrelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetCorrelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetCorrelation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetCorrelation(ctx, req.(*GetCorrelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetVolatility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolatilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetVolatility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetVolatility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetVolatility(ctx, req.(*GetVolatilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_Forecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).Forecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_Forecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).Forecast(ctx, req.(*ForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_EvaluateForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).EvaluateForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_EvaluateForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).EvaluateForecast(ctx, req.(*EvaluateForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetLiquidity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiquidityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetLiquidity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetLiquidity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetLiquidity(ctx, req.(*GetLiquidityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetVolume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetVolume(ctx, req.(*GetVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetDerivatives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDerivativesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetDerivatives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetDerivatives_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetDerivatives(ctx, req.(*GetDerivativesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cryptocheck.analytics.v1.AnalyticsService",
+	HandlerType: (*AnalyticsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRSI",
+			Handler:    _AnalyticsService_GetRSI_Handler,
+		},
+		{
+			MethodName: "Backtest",
+			Handler:    _AnalyticsService_Backtest_Handler,
+		},
+		{
+			MethodName: "GetCorrelation",
+			Handler:    _AnalyticsService_GetCorrelation_Handler,
+		},
+		{
+			MethodName: "GetVolatility",
+			Handler:    _AnalyticsService_GetVolatility_Handler,
+		},
+		{
+			MethodName: "Forecast",
+			Handler:    _AnalyticsService_Forecast_Handler,
+		},
+		{
+			MethodName: "EvaluateForecast",
+			Handler:    _AnalyticsService_EvaluateForecast_Handler,
+		},
+		{
+			MethodName: "GetLiquidity",
+			Handler:    _AnalyticsService_GetLiquidity_Handler,
+		},
+		{
+			MethodName: "GetVolume",
+			Handler:    _AnalyticsService_GetVolume_Handler,
+		},
+		{
+			MethodName: "GetDerivatives",
+			Handler:    _AnalyticsService_GetDerivatives_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cryptocheck/analytics/v1/analytics.proto",
+}
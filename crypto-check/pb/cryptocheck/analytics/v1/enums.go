@@ -0,0 +1,40 @@
+package analyticsv1
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Hand written, the rest of the package is generated from proto/cryptocheck/analytics/v1
+
+// enumPrefix is the prefix shared by the values of an enum, e.g. CORRELATION_METHOD_
+func enumPrefix(d protoreflect.EnumDescriptor) string {
+	return strings.TrimSuffix(string(d.Values().ByNumber(0).Name()), "UNSPECIFIED")
+}
+
+// EnumName returns the name of an enum value without the prefix of its type, e.g.
+// SPEARMAN for CORRELATION_METHOD_SPEARMAN. The unspecified value has no name.
+func EnumName(e protoreflect.Enum) string {
+	value := e.Descriptor().Values().ByNumber(e.Number())
+	if value == nil || e.Number() == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(string(value.Name()), enumPrefix(e.Descriptor()))
+}
+
+// ParseEnum returns the value of E named name without the prefix of E, in any
+// case, e.g. CORRELATION_METHOD_SPEARMAN for "spearman". The unspecified value
+// is not parsed, like EnumName does not name it.
+func ParseEnum[E interface {
+	~int32
+	protoreflect.Enum
+}](name string) (E, bool) {
+	var zero E
+	d := zero.Descriptor()
+	value := d.Values().ByName(protoreflect.Name(enumPrefix(d) + strings.ToUpper(name)))
+	if value == nil || value.Number() == 0 {
+		return zero, false
+	}
+	return E(value.Number()), true
+}
@@ -0,0 +1,50 @@
+package analyticsv1
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestEnumName(t *testing.T) {
+	tests := []struct {
+		name  string
+		value protoreflect.Enum
+		want  string
+	}{
+		{"Method", CorrelationMethod_CORRELATION_METHOD_SPEARMAN, "SPEARMAN"},
+		{"Multi word", VolatilityEstimator_VOLATILITY_ESTIMATOR_CLOSE_TO_CLOSE, "CLOSE_TO_CLOSE"},
+		{"Acronym prefix", RSISignal_RSI_SIGNAL_OVERSOLD, "OVERSOLD"},
+		{"Unspecified", ForecastModel_FORECAST_MODEL_UNSPECIFIED, ""},
+		{"Unknown number", ForecastModel(99), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EnumName(tt.value); got != tt.want {
+				t.Errorf("EnumName(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEnum(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   TradeSide
+		wantOK bool
+	}{
+		{"BUY", TradeSide_TRADE_SIDE_BUY, true},
+		{"sell", TradeSide_TRADE_SIDE_SELL, true},
+		{"", TradeSide_TRADE_SIDE_UNSPECIFIED, false},
+		{"unspecified", TradeSide_TRADE_SIDE_UNSPECIFIED, false},
+		{"hold", TradeSide_TRADE_SIDE_UNSPECIFIED, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseEnum[TradeSide](tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ParseEnum(%q) = %s, %v, want %s, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,121 +0,0 @@
-// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
-// versions:
-// - protoc-gen-go-grpc v1.6.1
-// - protoc             v7.34.0
-// source: proto/exchange.proto
-
-package pb
-
-import (
-	context "context"
-	grpc "google.golang.org/grpc"
-	codes "google.golang.org/grpc/codes"
-	status "google.golang.org/grpc/status"
-)
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.64.0 or later.
-const _ = grpc.SupportPackageIsVersion9
-
-const (
-	AnalyticsService_GetRSI_FullMethodName = "/pb.AnalyticsService/GetRSI"
-)
-
-// AnalyticsServiceClient is the client API for AnalyticsService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type AnalyticsServiceClient interface {
-	GetRSI(ctx context.Context, in *AnalyticRequest, opts ...grpc.CallOption) (*AnalyticResponse, error)
-}
-
-type analyticsServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewAnalyticsServiceClient(cc grpc.ClientConnInterface) AnalyticsServiceClient {
-	return &analyticsServiceClient{cc}
-}
-
-func (c *analyticsServiceClient) GetRSI(ctx context.Context, in *AnalyticRequest, opts ...grpc.CallOption) (*AnalyticResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AnalyticResponse)
-	err := c.cc.Invoke(ctx, AnalyticsService_GetRSI_FullMethodName, in, out, cOpts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
-}
-
-// AnalyticsServiceServer is the server API for AnalyticsService service.
-// All implementations must embed UnimplementedAnalyticsServiceServer
-// for forward compatibility.
-type AnalyticsServiceServer interface {
-	GetRSI(context.Context, *AnalyticRequest) (*AnalyticResponse, error)
-	mustEmbedUnimplementedAnalyticsServiceServer()
-}
-
-// UnimplementedAnalyticsServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedAnalyticsServiceServer struct{}
-
-func (UnimplementedAnalyticsServiceServer) GetRSI(context.Context, *AnalyticRequest) (*AnalyticResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetRSI not implemented")
-}
-func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
-func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
-
-// UnsafeAnalyticsServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to AnalyticsServiceServer will
-// result in compilation errors.
-type UnsafeAnalyticsServiceServer interface {
-	mustEmbedUnimplementedAnalyticsServiceServer()
-}
-
-func RegisterAnalyticsServiceServer(s grpc.ServiceRegistrar, srv AnalyticsServiceServer) {
-	// If the following call panics, it indicates UnimplementedAnalyticsServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
-	}
-	s.RegisterService(&AnalyticsService_ServiceDesc, srv)
-}
-
-func _AnalyticsService_GetRSI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AnalyticRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AnalyticsServiceServer).GetRSI(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: AnalyticsService_GetRSI_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AnalyticsServiceServer).GetRSI(ctx, req.(*AnalyticRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-// AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.AnalyticsService",
-	HandlerType: (*AnalyticsServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetRSI",
-			Handler:    _AnalyticsService_GetRSI_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/exchange.proto",
-}
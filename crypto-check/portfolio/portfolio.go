@@ -0,0 +1,280 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Trade sides
+const (
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+)
+
+// Trade sources
+const (
+	SourceManual = "manual" // Entered through the API
+	SourcePaper  = "paper"  // Placed by the paper trader on RSI signals
+)
+
+// Cost basis methods
+const (
+	MethodFIFO    = "fifo"    // Sells consume the oldest lots first
+	MethodAverage = "average" // Every unit costs the average price paid
+)
+
+var (
+	ErrInvalidTrade  = errors.New("invalid trade")
+	ErrUnknownMethod = errors.New("unknown cost basis method")
+)
+
+// Trade is a recorded fill. Holdings imported from elsewhere are recorded as buys.
+type Trade struct {
+	ID       int64     `json:"id"`
+	Symbol   string    `json:"symbol"`
+	Side     string    `json:"side"`
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price"`
+	Fee      float64   `json:"fee"` // In the quote currency
+	Time     time.Time `json:"time"`
+	Source   string    `json:"source"`
+}
+
+// Validate checks the fields a caller has to provide
+func (t Trade) Validate() error {
+	switch {
+	case t.Symbol == "":
+		return fmt.Errorf("%w: symbol is required", ErrInvalidTrade)
+	case t.Side != SideBuy && t.Side != SideSell:
+		return fmt.Errorf("%w: side must be %s or %s", ErrInvalidTrade, SideBuy, SideSell)
+	case t.Quantity <= 0:
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidTrade)
+	case t.Price <= 0:
+		return fmt.Errorf("%w: price must be positive", ErrInvalidTrade)
+	case t.Fee < 0:
+		return fmt.Errorf("%w: fee cannot be negative", ErrInvalidTrade)
+	}
+	return nil
+}
+
+// Position is the open holding of one symbol
+type Position struct {
+	Symbol        string  `json:"symbol"`
+	Quantity      float64 `json:"quantity"`
+	CostBasis     float64 `json:"cost_basis"` // What the open quantity cost, fees included
+	AvgCost       float64 `json:"avg_cost"`
+	MarketPrice   float64 `json:"market_price"` // 0 when no price is stored yet
+	MarketValue   float64 `json:"market_value"`
+	RealizedPnL   float64 `json:"realized_pnl"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+}
+
+// Summary is the whole portfolio valued at the latest prices
+type Summary struct {
+	Method        string     `json:"method"`
+	Positions     []Position `json:"positions"`
+	CostBasis     float64    `json:"cost_basis"`
+	MarketValue   float64    `json:"market_value"`
+	RealizedPnL   float64    `json:"realized_pnl"`
+	UnrealizedPnL float64    `json:"unrealized_pnl"`
+}
+
+// lot is a part of a position bought at one price
+type lot struct {
+	quantity float64
+	cost     float64 // Per unit, buy fee included
+}
+
+// book replays trades of one symbol
+type book struct {
+	method   string
+	lots     []lot // A single lot holding the average in average mode
+	realized float64
+}
+
+func (b *book) quantity() float64 {
+	var q float64
+	for _, l := range b.lots {
+		q += l.quantity
+	}
+	return q
+}
+
+func (b *book) costBasis() float64 {
+	var c float64
+	for _, l := range b.lots {
+		c += l.quantity * l.cost
+	}
+	return c
+}
+
+func (b *book) apply(t Trade) error {
+	if t.Side == SideBuy {
+		unitCost := (t.Quantity*t.Price + t.Fee) / t.Quantity
+		if b.method == MethodAverage && len(b.lots) > 0 {
+			total := b.quantity() + t.Quantity
+			b.lots[0] = lot{quantity: total, cost: (b.costBasis() + t.Quantity*unitCost) / total}
+		} else {
+			b.lots = append(b.lots, lot{quantity: t.Quantity, cost: unitCost})
+		}
+		return nil
+	}
+
+	// Allow for rounding in quantities that were added up from several buys
+	if t.Quantity > b.quantity()*(1+1e-9) {
+		return fmt.Errorf("%w: selling %g %s but only %g held at %s",
+			ErrInvalidTrade, t.Quantity, t.Symbol, b.quantity(), t.Time.Format(time.RFC3339))
+	}
+
+	proceeds := t.Quantity*t.Price - t.Fee
+	var cost float64
+	remaining := t.Quantity
+	for remaining > 0 && len(b.lots) > 0 {
+		take := min(remaining, b.lots[0].quantity)
+		cost += take * b.lots[0].cost
+		b.lots[0].quantity -= take
+		remaining -= take
+		if b.lots[0].quantity <= 1e-12 {
+			b.lots = b.lots[1:]
+		}
+	}
+	b.realized += proceeds - cost
+	return nil
+}
+
+// replay groups trades by symbol and applies them in time order
+func replay(trades []Trade, method string) (map[string]*book, error) {
+	if method == "" {
+		method = MethodFIFO
+	}
+	if method != MethodFIFO && method != MethodAverage {
+		return nil, fmt.Errorf("%w %q", ErrUnknownMethod, method)
+	}
+
+	sorted := append([]Trade(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	books := make(map[string]*book)
+	for _, t := range sorted {
+		b, ok := books[t.Symbol]
+		if !ok {
+			b = &book{method: method}
+			books[t.Symbol] = b
+		}
+		if err := b.apply(t); err != nil {
+			return nil, err
+		}
+	}
+	return books, nil
+}
+
+// Compute derives positions and PnL from trades, valued at prices (latest price per symbol).
+// Symbols without a price are valued at cost.
+func Compute(trades []Trade, prices map[string]float64, method string) (Summary, error) {
+	books, err := replay(trades, method)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	summary := Summary{Method: method, Positions: []Position{}}
+	if summary.Method == "" {
+		summary.Method = MethodFIFO
+	}
+	for symbol, b := range books {
+		p := Position{
+			Symbol:      symbol,
+			Quantity:    b.quantity(),
+			CostBasis:   b.costBasis(),
+			MarketPrice: prices[symbol],
+			RealizedPnL: b.realized,
+		}
+		if p.Quantity > 0 {
+			p.AvgCost = p.CostBasis / p.Quantity
+		}
+		p.MarketValue = p.CostBasis
+		if p.MarketPrice > 0 {
+			p.MarketValue = p.Quantity * p.MarketPrice
+		}
+		p.UnrealizedPnL = p.MarketValue - p.CostBasis
+
+		summary.Positions = append(summary.Positions, p)
+		summary.CostBasis += p.CostBasis
+		summary.MarketValue += p.MarketValue
+		summary.RealizedPnL += p.RealizedPnL
+		summary.UnrealizedPnL += p.UnrealizedPnL
+	}
+
+	sort.Slice(summary.Positions, func(i, j int) bool { return summary.Positions[i].Symbol < summary.Positions[j].Symbol })
+	return summary, nil
+}
+
+// PricePoint is a stored price used to value the portfolio in the past
+type PricePoint struct {
+	Time  time.Time
+	Price float64
+}
+
+// ValuePoint is the portfolio value at one time
+type ValuePoint struct {
+	Time        time.Time `json:"time"`
+	MarketValue float64   `json:"market_value"`
+	CostBasis   float64   `json:"cost_basis"`
+	RealizedPnL float64   `json:"realized_pnl"`
+}
+
+// ValueHistory values the holdings at each of times using the last price at or
+// before that time. prices must be sorted by time for every symbol. When rate is
+// set, the amounts of each symbol are multiplied by rate(symbol, time).
+func ValueHistory(trades []Trade, prices map[string][]PricePoint, times []time.Time, method string, rate func(symbol string, at time.Time) float64) ([]ValuePoint, error) {
+	sorted := append([]Trade(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	// Check the method and that the trades are consistent once, up front
+	if _, err := replay(sorted, method); err != nil {
+		return nil, err
+	}
+
+	if method == "" {
+		method = MethodFIFO
+	}
+	history := make([]ValuePoint, 0, len(times))
+	books := make(map[string]*book)
+	cursors := make(map[string]int)
+	next := 0
+	for _, at := range times {
+		for ; next < len(sorted) && !sorted[next].Time.After(at); next++ {
+			t := sorted[next]
+			if books[t.Symbol] == nil {
+				books[t.Symbol] = &book{method: method}
+			}
+			books[t.Symbol].apply(t) // Already validated above
+		}
+
+		point := ValuePoint{Time: at}
+		for symbol, b := range books {
+			series := prices[symbol]
+			i := cursors[symbol]
+			for i < len(series) && !series[i].Time.After(at) {
+				i++
+			}
+			cursors[symbol] = i
+
+			cost := b.costBasis()
+			value := cost // Valued at cost until a price is known
+			if i > 0 {
+				value = b.quantity() * series[i-1].Price
+			}
+			factor := 1.0
+			if rate != nil {
+				factor = rate(symbol, at)
+			}
+			point.MarketValue += value * factor
+			point.CostBasis += cost * factor
+			point.RealizedPnL += b.realized * factor
+		}
+		history = append(history, point)
+	}
+	return history, nil
+}
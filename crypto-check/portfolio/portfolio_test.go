@@ -0,0 +1,142 @@
+package portfolio
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func trade(side string, quantity, price, fee float64, hours int) Trade {
+	return Trade{Symbol: "BTCUSDT", Side: side, Quantity: quantity, Price: price, Fee: fee, Time: start.Add(time.Duration(hours) * time.Hour)}
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestCompute(t *testing.T) {
+	// Two lots at 100 and 200, then half of the holding is sold at 300
+	trades := []Trade{
+		trade(SideSell, 1, 300, 0, 2), // Out of order on purpose, trades are applied by time
+		trade(SideBuy, 1, 100, 0, 0),
+		trade(SideBuy, 1, 200, 0, 1),
+	}
+	prices := map[string]float64{"BTCUSDT": 250}
+
+	tests := []struct {
+		method         string
+		wantCost       float64
+		wantRealized   float64
+		wantUnrealized float64
+	}{
+		{MethodFIFO, 200, 200, 50},
+		{MethodAverage, 150, 150, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			s, err := Compute(trades, prices, tt.method)
+			if err != nil {
+				t.Fatalf("Compute() error: %v", err)
+			}
+			if len(s.Positions) != 1 {
+				t.Fatalf("got %d positions, want 1", len(s.Positions))
+			}
+			p := s.Positions[0]
+			if p.Quantity != 1 || !almostEqual(p.CostBasis, tt.wantCost) || !almostEqual(p.RealizedPnL, tt.wantRealized) || !almostEqual(p.UnrealizedPnL, tt.wantUnrealized) {
+				t.Errorf("position = %+v", p)
+			}
+			if !almostEqual(s.MarketValue, 250) || !almostEqual(s.RealizedPnL+s.UnrealizedPnL, 250) {
+				t.Errorf("summary = %+v", s)
+			}
+		})
+	}
+}
+
+func TestComputeFees(t *testing.T) {
+	s, err := Compute([]Trade{trade(SideBuy, 2, 100, 2, 0), trade(SideSell, 1, 110, 1, 1)}, nil, MethodFIFO)
+	if err != nil {
+		t.Fatalf("Compute() error: %v", err)
+	}
+	p := s.Positions[0]
+	// Units cost 101 with the buy fee, the sale nets 109
+	if !almostEqual(p.AvgCost, 101) || !almostEqual(p.RealizedPnL, 8) {
+		t.Errorf("position = %+v", p)
+	}
+	// Without a stored price the open unit is valued at cost
+	if !almostEqual(p.MarketValue, 101) || p.UnrealizedPnL != 0 {
+		t.Errorf("position without a price = %+v", p)
+	}
+}
+
+func TestComputeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		trades []Trade
+		method string
+		want   error
+	}{
+		{"Selling more than held", []Trade{trade(SideBuy, 1, 100, 0, 0), trade(SideSell, 2, 100, 0, 1)}, MethodFIFO, ErrInvalidTrade},
+		{"Selling before buying", []Trade{trade(SideBuy, 1, 100, 0, 1), trade(SideSell, 1, 100, 0, 0)}, MethodFIFO, ErrInvalidTrade},
+		{"Unknown method", nil, "lifo", ErrUnknownMethod},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compute(tt.trades, nil, tt.method); !errors.Is(err, tt.want) {
+				t.Errorf("Compute() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		trade Trade
+		ok    bool
+	}{
+		{"Valid", trade(SideBuy, 1, 100, 0, 0), true},
+		{"Missing symbol", Trade{Side: SideBuy, Quantity: 1, Price: 1}, false},
+		{"Bad side", trade("HOLD", 1, 100, 0, 0), false},
+		{"Zero quantity", trade(SideBuy, 0, 100, 0, 0), false},
+		{"Negative fee", trade(SideBuy, 1, 100, -1, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.trade.Validate(); (err == nil) != tt.ok {
+				t.Errorf("Validate() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestValueHistory(t *testing.T) {
+	trades := []Trade{trade(SideBuy, 2, 100, 0, 1), trade(SideSell, 1, 150, 0, 3)}
+	prices := map[string][]PricePoint{"BTCUSDT": {
+		{start.Add(2 * time.Hour), 120},
+		{start.Add(3 * time.Hour), 150},
+		{start.Add(4 * time.Hour), 90},
+	}}
+	times := []time.Time{start, start.Add(time.Hour), start.Add(2 * time.Hour), start.Add(3 * time.Hour), start.Add(4 * time.Hour)}
+
+	history, err := ValueHistory(trades, prices, times, MethodFIFO, nil)
+	if err != nil {
+		t.Fatalf("ValueHistory() error: %v", err)
+	}
+
+	want := []ValuePoint{
+		{MarketValue: 0, CostBasis: 0},
+		{MarketValue: 200, CostBasis: 200}, // No price yet, valued at cost
+		{MarketValue: 240, CostBasis: 200},
+		{MarketValue: 150, CostBasis: 100, RealizedPnL: 50},
+		{MarketValue: 90, CostBasis: 100, RealizedPnL: 50},
+	}
+	for i, w := range want {
+		got := history[i]
+		if !almostEqual(got.MarketValue, w.MarketValue) || !almostEqual(got.CostBasis, w.CostBasis) || !almostEqual(got.RealizedPnL, w.RealizedPnL) {
+			t.Errorf("point %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
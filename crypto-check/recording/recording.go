@@ -0,0 +1,146 @@
+package recording
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Kinds of recorded events
+const (
+	EventHTTP      = "http"
+	EventWebSocket = "ws"
+)
+
+// Event is one raw exchange response, stored as a line of gzipped JSON
+type Event struct {
+	Time   time.Time         `json:"time"`
+	Kind   string            `json:"kind"`
+	URL    string            `json:"url,omitempty"`    // HTTP request URL
+	Status int               `json:"status,omitempty"` // HTTP status code
+	Header map[string]string `json:"header,omitempty"`
+	Stream string            `json:"stream,omitempty"` // WebSocket stream name
+	Body   string            `json:"body"`             // Raw payload, even if it is not valid JSON
+}
+
+// Recorder captures raw exchange traffic so production issues can be replayed later
+type Recorder struct {
+	mu   sync.Mutex
+	now  func() time.Time // Virtual clocks pass their own Now
+	file *os.File
+	gz   *gzip.Writer
+	enc  *json.Encoder
+}
+
+func NewRecorder(path string, now func() time.Time) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(file)
+	return &Recorder{now: now, file: file, gz: gz, enc: json.NewEncoder(gz)}, nil
+}
+
+// Record appends an event. Every event is flushed so a crash loses at most the last one.
+func (r *Recorder) Record(event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(event); err != nil {
+		return err
+	}
+	return r.gz.Flush()
+}
+
+// RecordFrame stores a WebSocket message received on stream
+func (r *Recorder) RecordFrame(stream string, data []byte) error {
+	return r.Record(Event{Time: r.now(), Kind: EventWebSocket, Stream: stream, Body: string(data)})
+}
+
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.gz.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+// Transport wraps base so every HTTP response is recorded before the client sees it
+func (r *Recorder) Transport(base http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{base: base, recorder: r}
+}
+
+type recordingTransport struct {
+	base     http.RoundTripper
+	recorder *Recorder
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sent := t.recorder.now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := make(map[string]string, len(resp.Header))
+	for name := range resp.Header {
+		header[name] = resp.Header.Get(name)
+	}
+
+	event := Event{
+		Time:   sent,
+		Kind:   EventHTTP,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: header,
+		Body:   string(body),
+	}
+	if err := t.recorder.Record(event); err != nil {
+		log.Printf("[ERROR] Failed to record response from %s: %v", req.URL, err)
+	}
+	return resp, nil
+}
+
+// Read loads all events of a recording in file order
+func Read(path string) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var events []Event
+	dec := json.NewDecoder(gz)
+	for {
+		var event Event
+		err := dec.Decode(&event)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break // A truncated last line means the recorder was killed mid-write
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,223 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"crypto-check/clock"
+)
+
+// ErrReplayFinished is returned when a recording has no response left for a request
+var ErrReplayFinished = errors.New("replay finished")
+
+// Replay feeds a recording back into the collector. Time comes from a virtual
+// clock that follows the recording, either in real time scaled by speed (1 is
+// the original pace, 10 is ten times faster) or one event per Step call.
+type Replay struct {
+	clock *clock.Virtual
+	speed float64
+	end   time.Time
+
+	mu          sync.Mutex
+	responses   map[string][]Event // HTTP responses by request path and query
+	cursors     map[string]int
+	frames      []Event // WebSocket frames in time order
+	nextFrame   int
+	subscribers map[string][]chan []byte
+}
+
+func OpenReplay(path string, speed float64) (*Replay, error) {
+	events, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.New("recording is empty")
+	}
+	return NewReplay(events, speed), nil
+}
+
+func NewReplay(events []Event, speed float64) *Replay {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+
+	r := &Replay{
+		speed:       speed,
+		responses:   make(map[string][]Event),
+		cursors:     make(map[string]int),
+		subscribers: make(map[string][]chan []byte),
+	}
+	for _, event := range events {
+		switch event.Kind {
+		case EventHTTP:
+			u, err := url.Parse(event.URL)
+			if err != nil {
+				continue
+			}
+			key := requestKey(u)
+			r.responses[key] = append(r.responses[key], event)
+		case EventWebSocket:
+			r.frames = append(r.frames, event)
+		}
+	}
+
+	// Start at the beginning of the second of the first event, so the first slot is not skipped
+	var start time.Time
+	if len(events) > 0 {
+		start = events[0].Time.Truncate(time.Second)
+		r.end = events[len(events)-1].Time
+	}
+	r.clock = clock.NewVirtual(start)
+	return r
+}
+
+// Clock is the virtual clock the collector has to be scheduled on
+func (r *Replay) Clock() *clock.Virtual {
+	return r.clock
+}
+
+// Transport serves recorded HTTP responses instead of calling the exchange
+func (r *Replay) Transport() http.RoundTripper {
+	return replayTransport{r}
+}
+
+// Subscribe returns a channel receiving the recorded frames of a WebSocket stream.
+// Delivery blocks until the frame is received, which keeps replays deterministic.
+func (r *Replay) Subscribe(stream string) <-chan []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ch := make(chan []byte)
+	r.subscribers[stream] = append(r.subscribers[stream], ch)
+	return ch
+}
+
+// Step moves the virtual clock to the next timer or frame and delivers everything due.
+// It returns false once the recording is exhausted.
+func (r *Replay) Step() bool {
+	next, ok := r.nextEvent()
+	if !ok {
+		return false
+	}
+	r.clock.AdvanceTo(next)
+
+	for {
+		r.mu.Lock()
+		if r.nextFrame >= len(r.frames) || r.frames[r.nextFrame].Time.After(next) {
+			r.mu.Unlock()
+			return true
+		}
+		frame := r.frames[r.nextFrame]
+		r.nextFrame++
+		subscribers := r.subscribers[frame.Stream]
+		r.mu.Unlock()
+
+		for _, ch := range subscribers {
+			ch <- []byte(frame.Body)
+		}
+	}
+}
+
+// Run replays the recording at the configured speed until it ends or ctx is cancelled
+func (r *Replay) Run(ctx context.Context) error {
+	if r.speed <= 0 {
+		return errors.New("replay speed must be positive, use Step for step-by-step replay")
+	}
+	for {
+		next, ok := r.nextEvent()
+		if !ok {
+			return ErrReplayFinished
+		}
+		wait := time.NewTimer(time.Duration(float64(next.Sub(r.clock.Now())) / r.speed))
+		select {
+		case <-ctx.Done():
+			wait.Stop()
+			return ctx.Err()
+		case <-wait.C:
+		}
+		r.Step()
+	}
+}
+
+// nextEvent returns the time of the next timer or frame, as long as it is within the recording
+func (r *Replay) nextEvent() (time.Time, bool) {
+	next, ok := r.clock.NextDeadline()
+
+	r.mu.Lock()
+	if r.nextFrame < len(r.frames) {
+		frameTime := r.frames[r.nextFrame].Time
+		if !ok || frameTime.Before(next) {
+			next, ok = frameTime, true
+		}
+	}
+	r.mu.Unlock()
+
+	if !ok || next.After(r.end) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// response picks the recorded response closest to the virtual time of the request.
+// Responses are used once and in order, so recorded retries play back as retries.
+func (r *Replay) response(key string) (Event, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list := r.responses[key]
+	i := r.cursors[key]
+	if i >= len(list) {
+		return Event{}, false
+	}
+	now := r.clock.Now()
+	for i+1 < len(list) && absDuration(list[i+1].Time.Sub(now)) <= absDuration(list[i].Time.Sub(now)) {
+		i++
+	}
+	r.cursors[key] = i + 1
+	return list[i], true
+}
+
+type replayTransport struct {
+	replay *Replay
+}
+
+func (t replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	event, ok := t.replay.response(requestKey(req.URL))
+	if !ok {
+		return nil, ErrReplayFinished
+	}
+
+	header := make(http.Header, len(event.Header))
+	for name, value := range event.Header {
+		header.Set(name, value)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(event.Status) + " " + http.StatusText(event.Status),
+		StatusCode:    event.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(event.Body))),
+		ContentLength: int64(len(event.Body)),
+		Request:       req,
+	}, nil
+}
+
+// requestKey ignores the host, so a recording from Binance replays against any base URL
+func requestKey(u *url.URL) string {
+	return u.Path + "?" + u.RawQuery
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,32 @@
+package recording
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplayPicksClosestResponse(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := "/api/v3/ticker/price?symbol=BTCUSDT"
+	events := []Event{
+		{Time: start.Add(100 * time.Millisecond), Kind: EventHTTP, URL: "https://api.binance.com" + key, Status: 500, Body: "oops"},
+		{Time: start.Add(600 * time.Millisecond), Kind: EventHTTP, URL: "https://api.binance.com" + key, Status: 200, Body: "first"},
+		{Time: start.Add(5100 * time.Millisecond), Kind: EventHTTP, URL: "https://api.binance.com" + key, Status: 200, Body: "second"},
+	}
+	replay := NewReplay(events, 0)
+
+	// Recorded retries come back in order for the same slot
+	for _, want := range []string{"oops", "first"} {
+		if event, ok := replay.response(key); !ok || event.Body != want {
+			t.Fatalf("response() = %q, want %q", event.Body, want)
+		}
+	}
+
+	replay.Clock().AdvanceTo(start.Add(5 * time.Second))
+	if event, ok := replay.response(key); !ok || event.Body != "second" {
+		t.Fatalf("response() = %q, want second", event.Body)
+	}
+	if _, ok := replay.response(key); ok {
+		t.Error("expected the recording to be exhausted")
+	}
+}
@@ -0,0 +1,165 @@
+package signals
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// RSI is what the RSI of a symbol says about it
+type RSI string
+
+const (
+	RSIWaiting    RSI = "WAITING_FOR_DATA" // Fewer than two prices stored
+	RSINeutral    RSI = "NEUTRAL"
+	RSIOversold   RSI = "OVERSOLD"   // At or below the oversold threshold, a buy signal
+	RSIOverbought RSI = "OVERBOUGHT" // At or above the overbought threshold, a sell signal
+)
+
+// The gRPC API names the enum values after the signals with these prefixes
+const (
+	rsiEnumPrefix   = "RSI_SIGNAL_"
+	trendEnumPrefix = "PRICE_TREND_"
+)
+
+// Enum returns the name of the RSISignal value of the gRPC API
+func (r RSI) Enum() string {
+	return rsiEnumPrefix + string(r)
+}
+
+// ParseRSI returns the signal of an RSISignal value name
+func ParseRSI(enum string) RSI {
+	return RSI(strings.TrimPrefix(enum, rsiEnumPrefix))
+}
+
+// Trend is where the price stands against its hourly average
+type Trend string
+
+const (
+	TrendWaiting Trend = "WAITING_FOR_DATA" // No average yet
+	TrendStable  Trend = "STABLE"
+	TrendRocket  Trend = "ROCKET" // At least the rocket threshold above the average
+	TrendCrash   Trend = "CRASH"  // At least the crash threshold below the average
+)
+
+// Enum returns the name of the PriceTrend value of the gRPC API
+func (t Trend) Enum() string {
+	return trendEnumPrefix + string(t)
+}
+
+// ParseTrend returns the trend of a PriceTrend value name
+func ParseTrend(enum string) Trend {
+	return Trend(strings.TrimPrefix(enum, trendEnumPrefix))
+}
+
+// Config holds the thresholds a symbol is classified with. Zero fields take the defaults.
+type Config struct {
+	Oversold   float64 `json:"oversold"`   // RSI at or below which the symbol is oversold
+	Overbought float64 `json:"overbought"` // RSI at or above which the symbol is overbought
+	Rocket     float64 `json:"rocket"`     // Percent above the hourly average
+	Crash      float64 `json:"crash"`      // Percent below the hourly average, as a positive number
+}
+
+// DefaultConfig returns the settings used for zero fields
+func DefaultConfig() Config {
+	return Config{Oversold: 30, Overbought: 70, Rocket: 5, Crash: 5}
+}
+
+// Merge returns c with the non-zero fields of o
+func (c Config) Merge(o Config) Config {
+	if o.Oversold != 0 {
+		c.Oversold = o.Oversold
+	}
+	if o.Overbought != 0 {
+		c.Overbought = o.Overbought
+	}
+	if o.Rocket != 0 {
+		c.Rocket = o.Rocket
+	}
+	if o.Crash != 0 {
+		c.Crash = o.Crash
+	}
+	return c
+}
+
+// Validate reports thresholds that cannot classify anything sensibly
+func (c Config) Validate() error {
+	if c.Oversold < 0 || c.Overbought > 100 || c.Oversold >= c.Overbought {
+		return fmt.Errorf("rsi thresholds %g/%g must satisfy 0 <= oversold < overbought <= 100", c.Oversold, c.Overbought)
+	}
+	if c.Rocket <= 0 || c.Crash <= 0 {
+		return fmt.Errorf("rocket %g and crash %g must be positive percentages", c.Rocket, c.Crash)
+	}
+	return nil
+}
+
+// RSI classifies an RSI value
+func (c Config) RSI(rsi float64) RSI {
+	switch {
+	case rsi >= c.Overbought:
+		return RSIOverbought
+	case rsi <= c.Oversold:
+		return RSIOversold
+	}
+	return RSINeutral
+}
+
+// Trend classifies the percent change of the price from its hourly average
+func (c Config) Trend(change float64) Trend {
+	switch {
+	case change >= c.Rocket:
+		return TrendRocket
+	case change <= -c.Crash:
+		return TrendCrash
+	}
+	return TrendStable
+}
+
+// Classifier holds the thresholds of every symbol
+type Classifier struct {
+	defaults  Config
+	overrides map[string]Config
+}
+
+// NewClassifier validates the thresholds. Zero fields of defaults take DefaultConfig,
+// and overrides replace the non-zero fields per symbol.
+func NewClassifier(defaults Config, overrides map[string]Config) (*Classifier, error) {
+	c := &Classifier{defaults: DefaultConfig().Merge(defaults), overrides: overrides}
+	if err := c.defaults.Validate(); err != nil {
+		return nil, err
+	}
+	for symbol := range overrides {
+		if err := c.ConfigFor(symbol).Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", symbol, err)
+		}
+	}
+	return c, nil
+}
+
+// ConfigFor returns the thresholds of symbol
+func (c *Classifier) ConfigFor(symbol string) Config {
+	return c.defaults.Merge(c.overrides[symbol])
+}
+
+// File is the part of config.json with the thresholds
+type File struct {
+	Signals       Config            `json:"signals"`        // Thresholds of every symbol
+	SymbolSignals map[string]Config `json:"symbol_signals"` // Optional per-symbol override of the non-zero fields of Signals
+}
+
+// Load reads the thresholds of the config file. A missing file gives the defaults.
+func Load(fileName string) (*Classifier, error) {
+	var file File
+	data, err := os.ReadFile(fileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%s: %w", fileName, err)
+		}
+	}
+	return NewClassifier(file.Signals, file.SymbolSignals)
+}
@@ -0,0 +1,113 @@
+package signals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigRSI(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		rsi    float64
+		want   RSI
+	}{
+		{"Neutral", DefaultConfig(), 50, RSINeutral},
+		{"Exactly overbought", DefaultConfig(), 70, RSIOverbought},
+		{"Overbought", DefaultConfig(), 85, RSIOverbought},
+		{"Exactly oversold", DefaultConfig(), 30, RSIOversold},
+		{"Oversold", DefaultConfig(), 12, RSIOversold},
+		{"Wider band", Config{Oversold: 20, Overbought: 80}, 75, RSINeutral},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.RSI(tt.rsi); got != tt.want {
+				t.Errorf("RSI(%g) = %s, want %s", tt.rsi, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigTrend(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		change float64
+		want   Trend
+	}{
+		{"High growth", DefaultConfig(), 7.5, TrendRocket},
+		{"Small growth", DefaultConfig(), 2.1, TrendStable},
+		{"Big drop", DefaultConfig(), -10.2, TrendCrash},
+		{"Small drop", DefaultConfig(), -1.5, TrendStable},
+		{"Exactly five", DefaultConfig(), 5.0, TrendRocket},
+		{"Exactly minus five", DefaultConfig(), -5.0, TrendCrash},
+		{"Asymmetric", Config{Rocket: 10, Crash: 2}, -3, TrendCrash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.Trend(tt.change); got != tt.want {
+				t.Errorf("Trend(%g) = %s, want %s", tt.change, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaults  Config
+		overrides map[string]Config
+		symbol    string
+		want      Config
+		wantErr   bool
+	}{
+		{"Defaults", Config{}, nil, "BTCUSDT", DefaultConfig(), false},
+		{"Partial defaults", Config{Rocket: 3}, nil, "BTCUSDT", Config{Oversold: 30, Overbought: 70, Rocket: 3, Crash: 5}, false},
+		{"Override", Config{}, map[string]Config{"DOGEUSDT": {Overbought: 80, Crash: 10}}, "DOGEUSDT", Config{Oversold: 30, Overbought: 80, Rocket: 5, Crash: 10}, false},
+		{"Other symbol", Config{}, map[string]Config{"DOGEUSDT": {Overbought: 80}}, "BTCUSDT", DefaultConfig(), false},
+		{"Crossed thresholds", Config{Oversold: 75}, nil, "", Config{}, true},
+		{"Crossed override", Config{}, map[string]Config{"DOGEUSDT": {Overbought: 20}}, "", Config{}, true},
+		{"Negative crash", Config{Crash: -5}, nil, "", Config{}, true},
+		{"Above 100", Config{Overbought: 120}, nil, "", Config{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClassifier(tt.defaults, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClassifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.ConfigFor(tt.symbol); got != tt.want {
+				t.Errorf("ConfigFor(%s) = %+v, want %+v", tt.symbol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	config := `{"symbols": ["BTCUSDT"], "signals": {"oversold": 25}, "symbol_signals": {"DOGEUSDT": {"rocket": 8}}}`
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if got := c.ConfigFor("DOGEUSDT"); got != (Config{Oversold: 25, Overbought: 70, Rocket: 8, Crash: 5}) {
+		t.Errorf("ConfigFor(DOGEUSDT) = %+v", got)
+	}
+
+	c, err = Load(filepath.Join(dir, "missing.json"))
+	if err != nil {
+		t.Fatalf("Load(missing) error: %v", err)
+	}
+	if got := c.ConfigFor("BTCUSDT"); got != DefaultConfig() {
+		t.Errorf("ConfigFor(BTCUSDT) without a file = %+v, want the defaults", got)
+	}
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"crypto-check/alerts"
+)
+
+// InsertAlert stores an alert raised by the price analysis
+func (s *Store) InsertAlert(ctx context.Context, a alerts.Alert) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO alerts (symbol, kind, price, change, timestamp, message) VALUES(?, ?, ?, ?, ?, ?)",
+		a.Symbol, a.Kind, a.Price, a.Change, a.Time.UTC(), a.Message)
+	return err
+}
+
+// AlertsBetween returns up to limit alerts of symbol raised from from up to to, newest first
+func (s *Store) AlertsBetween(ctx context.Context, symbol string, from, to time.Time, limit int) ([]alerts.Alert, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT symbol, kind, price, change, timestamp, message FROM alerts
+		WHERE symbol = ? AND timestamp >= ? AND timestamp <= ? ORDER BY timestamp DESC, id DESC LIMIT ?`,
+		symbol, from.UTC(), to.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	raised := []alerts.Alert{}
+	for rows.Next() {
+		var a alerts.Alert
+		if err := rows.Scan(&a.Symbol, &a.Kind, &a.Price, &a.Change, &a.Time, &a.Message); err != nil {
+			return nil, err
+		}
+		raised = append(raised, a)
+	}
+	return raised, rows.Err()
+}
@@ -0,0 +1,190 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"crypto-check/auth"
+)
+
+// ErrLastAdmin is returned when revoking the only active admin key, which would
+// lock everyone out of key management
+var ErrLastAdmin = errors.New("the last admin key cannot be revoked")
+
+// touchInterval throttles last_used updates to one write per key and interval
+const touchInterval = time.Minute
+
+const keyColumns = "k.id, k.name, k.role, k.prefix, k.created_at, k.last_used, k.revoked_at"
+
+// InsertKey stores a key under the hash of its secret and returns it with its ID
+func (s *Store) InsertKey(ctx context.Context, k auth.Key, hash string) (auth.Key, error) {
+	k.CreatedAt = k.CreatedAt.UTC()
+	res, err := s.db.ExecContext(ctx, "INSERT INTO api_keys (name, role, prefix, hash, created_at) VALUES(?, ?, ?, ?, ?)",
+		k.Name, k.Role, k.Prefix, hash, k.CreatedAt)
+	if err != nil {
+		return k, err
+	}
+	k.ID, err = res.LastInsertId()
+	return k, err
+}
+
+// KeyByHash returns the active key whose secret hashes to hash, ErrNotFound if
+// there is none
+func (s *Store) KeyByHash(ctx context.Context, hash string) (auth.Key, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+keyColumns+" FROM api_keys k WHERE k.hash = ? AND k.revoked_at IS NULL", hash)
+	return scanKey(row)
+}
+
+// Keys returns every key, revoked ones included, oldest first
+func (s *Store) Keys(ctx context.Context) ([]auth.Key, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+keyColumns+" FROM api_keys k ORDER BY k.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []auth.Key{}
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// HasAdmin reports whether there is an active admin key
+func (s *Store) HasAdmin(ctx context.Context) (bool, error) {
+	n, err := countAdmins(ctx, s.db)
+	return n > 0, err
+}
+
+// RevokeKey revokes an active key and ends its sessions. It returns ErrNotFound
+// if there is no such key and ErrLastAdmin if it is the only active admin key.
+func (s *Store) RevokeKey(ctx context.Context, id int64, at time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var role auth.Role
+	err = tx.QueryRowContext(ctx, "SELECT role FROM api_keys WHERE id = ? AND revoked_at IS NULL", id).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if role == auth.RoleAdmin {
+		n, err := countAdmins(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if n <= 1 {
+			return ErrLastAdmin
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE api_keys SET revoked_at = ? WHERE id = ?", at.UTC(), id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM sessions WHERE key_id = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// TouchKey records that a key was used at, at most once per touchInterval
+func (s *Store) TouchKey(ctx context.Context, id int64, at time.Time) error {
+	at = at.UTC()
+	_, err := s.db.ExecContext(ctx, "UPDATE api_keys SET last_used = ? WHERE id = ? AND (last_used IS NULL OR last_used < ?)",
+		at, id, at.Add(-touchInterval))
+	return err
+}
+
+// InsertSession stores a dashboard session of a key under the hash of its token
+func (s *Store) InsertSession(ctx context.Context, hash string, keyID int64, expires time.Time) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO sessions (hash, key_id, expires) VALUES(?, ?, ?)", hash, keyID, expires.UTC())
+	return err
+}
+
+// SessionKey returns the key of the session whose token hashes to hash, ErrNotFound
+// if there is none, it expired before now or its key was revoked. Expired
+// sessions are deleted on the way.
+func (s *Store) SessionKey(ctx context.Context, hash string, now time.Time) (auth.Key, error) {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE expires <= ?", now.UTC()); err != nil {
+		return auth.Key{}, err
+	}
+	row := s.db.QueryRowContext(ctx, "SELECT "+keyColumns+` FROM sessions s
+		JOIN api_keys k ON k.id = s.key_id
+		WHERE s.hash = ? AND k.revoked_at IS NULL`, hash)
+	return scanKey(row)
+}
+
+// DeleteSession ends a session, deleting one that does not exist is no error
+func (s *Store) DeleteSession(ctx context.Context, hash string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE hash = ?", hash)
+	return err
+}
+
+// InsertAudit appends an entry to the audit log
+func (s *Store) InsertAudit(ctx context.Context, e auth.AuditEntry) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO audit_log (timestamp, actor, key_id, role, method, path, status, remote_addr) VALUES(?, ?, ?, ?, ?, ?, ?, ?)",
+		e.Time.UTC(), e.Actor, e.KeyID, e.Role, e.Method, e.Path, e.Status, e.RemoteAddr)
+	return err
+}
+
+// AuditLog returns up to limit entries recorded after since, newest first
+func (s *Store) AuditLog(ctx context.Context, since time.Time, limit int) ([]auth.AuditEntry, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, timestamp, actor, key_id, role, method, path, status, remote_addr FROM audit_log
+		WHERE timestamp > ? ORDER BY timestamp DESC, id DESC LIMIT ?`, since.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []auth.AuditEntry{}
+	for rows.Next() {
+		var e auth.AuditEntry
+		if err := rows.Scan(&e.ID, &e.Time, &e.Actor, &e.KeyID, &e.Role, &e.Method, &e.Path, &e.Status, &e.RemoteAddr); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func countAdmins(ctx context.Context, q querier) (int, error) {
+	var n int
+	err := q.QueryRowContext(ctx, "SELECT COUNT(*) FROM api_keys WHERE role = ? AND revoked_at IS NULL", auth.RoleAdmin).Scan(&n)
+	return n, err
+}
+
+// scanKey reads the keyColumns of a row, sql.ErrNoRows becomes ErrNotFound
+func scanKey(row interface{ Scan(...any) error }) (auth.Key, error) {
+	var k auth.Key
+	var lastUsed, revoked sql.NullTime
+	err := row.Scan(&k.ID, &k.Name, &k.Role, &k.Prefix, &k.CreatedAt, &lastUsed, &revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return k, ErrNotFound
+	}
+	if err != nil {
+		return k, err
+	}
+	if lastUsed.Valid {
+		k.LastUsed = &lastUsed.Time
+	}
+	if revoked.Valid {
+		k.RevokedAt = &revoked.Time
+	}
+	return k, nil
+}
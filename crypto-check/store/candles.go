@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// Candle summarizes the prices stored during one interval. Prices are polled,
+// so the high and low are those of the polls rather than of every trade.
+type Candle struct {
+	Time  time.Time // Start of the interval
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+	Ticks int // Prices stored in the interval
+
+	// Traded volume, zero unless trades are collected. Volumes are stored per
+	// TradeBucket, so a shorter interval gets the whole bucket it starts in.
+	Volume      float64 // In the base asset
+	BuyVolume   float64 // Bought by takers
+	QuoteVolume float64
+	Trades      int
+}
+
+// Candles builds candles of interval from the prices stored in (from, to] and
+// the volumes traded during them
+func (s *Store) Candles(ctx context.Context, symbol string, from, to time.Time, interval time.Duration) ([]Candle, error) {
+	points, err := s.History(ctx, symbol, from, to)
+	if err != nil {
+		return nil, err
+	}
+	candles := BuildCandles(points, interval)
+	if len(candles) == 0 {
+		return candles, nil
+	}
+	// Whole candles, the first one may start before from
+	volumes, err := s.Volumes(ctx, symbol, candles[0].Time.Add(-time.Nanosecond), to)
+	if err != nil {
+		return nil, err
+	}
+	AddVolumes(candles, volumes, interval)
+	return candles, nil
+}
+
+// AddVolumes adds volumes ordered oldest first to the candles of interval they fall in
+func AddVolumes(candles []Candle, volumes []Volume, interval time.Duration) {
+	i := 0
+	for _, v := range volumes {
+		start := v.Time.Truncate(interval)
+		for i < len(candles) && candles[i].Time.Before(start) {
+			i++
+		}
+		if i == len(candles) {
+			return
+		}
+		if c := &candles[i]; c.Time.Equal(start) {
+			c.Volume += v.Volume
+			c.BuyVolume += v.BuyVolume
+			c.QuoteVolume += v.QuoteVolume
+			c.Trades += v.Trades
+		}
+	}
+}
+
+// BuildCandles groups points ordered oldest first into candles of interval.
+// Intervals without a stored price are skipped.
+func BuildCandles(points []Point, interval time.Duration) []Candle {
+	var candles []Candle
+	for _, p := range points {
+		start := p.Time.Truncate(interval)
+		if n := len(candles); n > 0 && candles[n-1].Time.Equal(start) {
+			c := &candles[n-1]
+			c.High = max(c.High, p.Price)
+			c.Low = min(c.Low, p.Price)
+			c.Close = p.Price
+			c.Ticks++
+			continue
+		}
+		candles = append(candles, Candle{Time: start, Open: p.Price, High: p.Price, Low: p.Price, Close: p.Price, Ticks: 1})
+	}
+	return candles
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// Derivative is a sample of the perpetual futures contract of a symbol
+type Derivative struct {
+	Time            time.Time
+	MarkPrice       float64
+	IndexPrice      float64
+	SpotPrice       float64 // Last stored spot price of the symbol, 0 when there was none
+	FundingRate     float64 // Per funding interval
+	NextFundingTime time.Time
+	OpenInterest    float64 // In the base asset
+}
+
+// InsertDerivative stores a futures sample of symbol
+func (s *Store) InsertDerivative(ctx context.Context, symbol string, d Derivative) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO derivatives
+		(symbol, timestamp, mark_price, index_price, spot_price, funding_rate, next_funding_time, open_interest)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?)`,
+		symbol, d.Time.UTC(), d.MarkPrice, d.IndexPrice, d.SpotPrice, d.FundingRate, d.NextFundingTime.UTC(), d.OpenInterest)
+	return err
+}
+
+// Derivatives returns the futures samples of symbol taken after from and up to to, oldest first
+func (s *Store) Derivatives(ctx context.Context, symbol string, from, to time.Time) ([]Derivative, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT timestamp, mark_price, index_price, spot_price, funding_rate, next_funding_time, open_interest
+		FROM derivatives WHERE symbol = ? AND timestamp > ? AND timestamp <= ? ORDER BY timestamp, id`,
+		symbol, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []Derivative
+	for rows.Next() {
+		var d Derivative
+		if err := rows.Scan(&d.Time, &d.MarkPrice, &d.IndexPrice, &d.SpotPrice, &d.FundingRate, &d.NextFundingTime, &d.OpenInterest); err != nil {
+			return nil, err
+		}
+		samples = append(samples, d)
+	}
+	return samples, rows.Err()
+}
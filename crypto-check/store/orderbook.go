@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"crypto-check/orderbook"
+)
+
+// InsertBookSnapshot stores the top of book and depth bands of symbol
+func (s *Store) InsertBookSnapshot(ctx context.Context, symbol string, snap orderbook.Snapshot) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		"INSERT INTO order_book (symbol, timestamp, best_bid, best_bid_quantity, best_ask, best_ask_quantity) VALUES(?, ?, ?, ?, ?, ?)",
+		symbol, snap.Time.UTC(), snap.BestBid, snap.BestBidQuantity, snap.BestAsk, snap.BestAskQuantity)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, b := range snap.Bands {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO depth_bands (snapshot_id, bps, bid_quantity, ask_quantity, bid_notional, ask_notional) VALUES(?, ?, ?, ?, ?, ?)",
+			id, b.BPS, b.BidQuantity, b.AskQuantity, b.BidNotional, b.AskNotional); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// BookSnapshots returns the order book snapshots of symbol taken after from and up
+// to to, oldest first, with their bands narrowest first
+func (s *Store) BookSnapshots(ctx context.Context, symbol string, from, to time.Time) ([]orderbook.Snapshot, error) {
+	query := `SELECT o.id, o.timestamp, o.best_bid, o.best_bid_quantity, o.best_ask, o.best_ask_quantity,
+		b.bps, b.bid_quantity, b.ask_quantity, b.bid_notional, b.ask_notional
+		FROM order_book o LEFT JOIN depth_bands b ON b.snapshot_id = o.id
+		WHERE o.symbol = ? AND o.timestamp > ? AND o.timestamp <= ?
+		ORDER BY o.timestamp, o.id, b.bps`
+	rows, err := s.db.QueryContext(ctx, query, symbol, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []orderbook.Snapshot
+	lastID := int64(-1)
+	for rows.Next() {
+		var id int64
+		var snap orderbook.Snapshot
+		var bps, bidQuantity, askQuantity, bidNotional, askNotional sql.NullFloat64
+		if err := rows.Scan(&id, &snap.Time, &snap.BestBid, &snap.BestBidQuantity, &snap.BestAsk, &snap.BestAskQuantity,
+			&bps, &bidQuantity, &askQuantity, &bidNotional, &askNotional); err != nil {
+			return nil, err
+		}
+		if id != lastID {
+			snapshots = append(snapshots, snap)
+			lastID = id
+		}
+		if bps.Valid {
+			last := &snapshots[len(snapshots)-1]
+			last.Bands = append(last.Bands, orderbook.Band{
+				BPS:         bps.Float64,
+				BidQuantity: bidQuantity.Float64,
+				AskQuantity: askQuantity.Float64,
+				BidNotional: bidNotional.Float64,
+				AskNotional: askNotional.Float64,
+			})
+		}
+	}
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"crypto-check/venues"
+)
+
+// InsertSpread stores a round of venue quotes of a symbol with its reference price
+func (s *Store) InsertSpread(ctx context.Context, sp venues.Spread) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		"INSERT INTO spreads (symbol, timestamp, reference, method, spread_bps, low_venue, high_venue) VALUES(?, ?, ?, ?, ?, ?, ?)",
+		sp.Symbol, sp.Time.UTC(), sp.Reference, sp.Method, sp.SpreadBPS, sp.Low, sp.High)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for i, q := range sp.Quotes {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO venue_quotes
+			(spread_id, position, venue, price, volume, updated, deviation_bps, outlier, stale, error)
+			VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, i, q.Venue, q.Price, q.Volume, q.Updated.UTC(), q.DeviationBPS, q.Outlier, q.Stale, q.Error); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Spreads returns up to limit spreads taken after since, newest first. An empty
+// symbol returns those of every symbol.
+func (s *Store) Spreads(ctx context.Context, symbol string, since time.Time, limit int) ([]venues.Spread, error) {
+	where := "s.timestamp > ?"
+	args := []any{since.UTC()}
+	if symbol != "" {
+		where += " AND s.symbol = ?"
+		args = append(args, symbol)
+	}
+	return s.spreads(ctx, where, "s.timestamp DESC, s.id DESC", limit, args...)
+}
+
+// LatestSpreads returns the last spread of every symbol, or of symbol only when it is not empty
+func (s *Store) LatestSpreads(ctx context.Context, symbol string) ([]venues.Spread, error) {
+	where := "s.id IN (SELECT MAX(id) FROM spreads GROUP BY symbol)"
+	var args []any
+	if symbol != "" {
+		where += " AND s.symbol = ?"
+		args = append(args, symbol)
+	}
+	return s.spreads(ctx, where, "s.symbol", -1, args...)
+}
+
+// spreads loads up to limit spreads matching where in order, -1 for all, with their
+// quotes. Both refer to the spreads table as s.
+func (s *Store) spreads(ctx context.Context, where, order string, limit int, args ...any) ([]venues.Spread, error) {
+	query := `SELECT s.id, s.symbol, s.timestamp, s.reference, s.method, s.spread_bps, s.low_venue, s.high_venue,
+		q.venue, q.price, q.volume, q.updated, q.deviation_bps, q.outlier, q.stale, q.error
+		FROM (SELECT * FROM spreads s WHERE ` + where + ` ORDER BY ` + order + ` LIMIT ?) s
+		LEFT JOIN venue_quotes q ON q.spread_id = s.id
+		ORDER BY ` + order + `, q.position`
+	rows, err := s.db.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	spreads := []venues.Spread{}
+	index := make(map[int64]int)
+	type quoteRow struct {
+		venue, errMsg  sql.NullString
+		price, volume  sql.NullFloat64
+		deviation      sql.NullFloat64
+		updated        sql.NullTime
+		outlier, stale sql.NullBool
+	}
+	for rows.Next() {
+		var id int64
+		var sp venues.Spread
+		var q quoteRow
+		if err := rows.Scan(&id, &sp.Symbol, &sp.Time, &sp.Reference, &sp.Method, &sp.SpreadBPS, &sp.Low, &sp.High,
+			&q.venue, &q.price, &q.volume, &q.updated, &q.deviation, &q.outlier, &q.stale, &q.errMsg); err != nil {
+			return nil, err
+		}
+		i, ok := index[id]
+		if !ok {
+			sp.Quotes = []venues.Quote{}
+			spreads = append(spreads, sp)
+			i = len(spreads) - 1
+			index[id] = i
+		}
+		if q.venue.Valid {
+			spreads[i].Quotes = append(spreads[i].Quotes, venues.Quote{
+				Venue:        q.venue.String,
+				Price:        q.price.Float64,
+				Volume:       q.volume.Float64,
+				Updated:      q.updated.Time,
+				DeviationBPS: q.deviation.Float64,
+				Outlier:      q.outlier.Bool,
+				Stale:        q.stale.Bool,
+				Error:        q.errMsg.String,
+			})
+		}
+	}
+	return spreads, rows.Err()
+}
@@ -0,0 +1,451 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"crypto-check/anomaly"
+	"crypto-check/portfolio"
+
+	_ "github.com/glebarez/go-sqlite"
+)
+
+// ErrNotFound is returned when a row to update or delete does not exist
+var ErrNotFound = errors.New("not found")
+
+// Store is the price history shared by the collector and the analytics service
+type Store struct {
+	db *sql.DB
+}
+
+// Point is one stored price
+type Point struct {
+	Symbol string
+	Price  float64
+	Time   time.Time
+}
+
+// Snapshot is the latest price of a symbol together with its recent average
+type Snapshot struct {
+	Symbol   string
+	Price    float64
+	AvgPrice float64
+}
+
+// Open opens the SQLite database at path and creates the schema if needed
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	CREATE TABLE IF NOT EXISTS price_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		symbol TEXT,
+		price REAL,
+		timestamp DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS portfolio_trades (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		symbol TEXT NOT NULL,
+		side TEXT NOT NULL,
+		quantity REAL NOT NULL,
+		price REAL NOT NULL,
+		fee REAL NOT NULL DEFAULT 0,
+		timestamp DATETIME NOT NULL,
+		source TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS anomalies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		symbol TEXT NOT NULL,
+		method TEXT NOT NULL,
+		score REAL NOT NULL,
+		threshold REAL NOT NULL,
+		price REAL NOT NULL,
+		return REAL NOT NULL,
+		timestamp DATETIME NOT NULL,
+		explanation TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS order_book (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		symbol TEXT NOT NULL,
+		timestamp DATETIME NOT NULL,
+		best_bid REAL NOT NULL,
+		best_bid_quantity REAL NOT NULL,
+		best_ask REAL NOT NULL,
+		best_ask_quantity REAL NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS order_book_symbol_timestamp ON order_book (symbol, timestamp);
+	CREATE TABLE IF NOT EXISTS depth_bands (
+		snapshot_id INTEGER NOT NULL REFERENCES order_book (id),
+		bps REAL NOT NULL,
+		bid_quantity REAL NOT NULL,
+		ask_quantity REAL NOT NULL,
+		bid_notional REAL NOT NULL,
+		ask_notional REAL NOT NULL,
+		PRIMARY KEY (snapshot_id, bps)
+	);
+	CREATE TABLE IF NOT EXISTS trade_volume (
+		symbol TEXT NOT NULL,
+		timestamp DATETIME NOT NULL,
+		volume REAL NOT NULL,
+		buy_volume REAL NOT NULL,
+		quote_volume REAL NOT NULL,
+		buy_quote_volume REAL NOT NULL,
+		trades INTEGER NOT NULL,
+		last_trade_id INTEGER NOT NULL,
+		PRIMARY KEY (symbol, timestamp)
+	) WITHOUT ROWID;
+	CREATE TABLE IF NOT EXISTS derivatives (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		symbol TEXT NOT NULL,
+		timestamp DATETIME NOT NULL,
+		mark_price REAL NOT NULL,
+		index_price REAL NOT NULL,
+		spot_price REAL NOT NULL,
+		funding_rate REAL NOT NULL,
+		next_funding_time DATETIME NOT NULL,
+		open_interest REAL NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS derivatives_symbol_timestamp ON derivatives (symbol, timestamp);
+	CREATE TABLE IF NOT EXISTS spreads (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		symbol TEXT NOT NULL,
+		timestamp DATETIME NOT NULL,
+		reference REAL NOT NULL,
+		method TEXT NOT NULL,
+		spread_bps REAL NOT NULL,
+		low_venue TEXT NOT NULL,
+		high_venue TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS spreads_symbol_timestamp ON spreads (symbol, timestamp);
+	CREATE TABLE IF NOT EXISTS venue_quotes (
+		spread_id INTEGER NOT NULL REFERENCES spreads(id),
+		position INTEGER NOT NULL,
+		venue TEXT NOT NULL,
+		price REAL NOT NULL,
+		volume REAL NOT NULL,
+		updated DATETIME NOT NULL,
+		deviation_bps REAL NOT NULL,
+		outlier BOOLEAN NOT NULL,
+		stale BOOLEAN NOT NULL,
+		error TEXT NOT NULL,
+		PRIMARY KEY (spread_id, position)
+	);
+	CREATE TABLE IF NOT EXISTS api_keys (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		role TEXT NOT NULL,
+		prefix TEXT NOT NULL,
+		hash TEXT NOT NULL UNIQUE,
+		created_at DATETIME NOT NULL,
+		last_used DATETIME,
+		revoked_at DATETIME
+	);
+	CREATE TABLE IF NOT EXISTS sessions (
+		hash TEXT PRIMARY KEY,
+		key_id INTEGER NOT NULL REFERENCES api_keys(id),
+		expires DATETIME NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS audit_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		timestamp DATETIME NOT NULL,
+		actor TEXT NOT NULL,
+		key_id INTEGER NOT NULL,
+		role TEXT NOT NULL,
+		method TEXT NOT NULL,
+		path TEXT NOT NULL,
+		status INTEGER NOT NULL,
+		remote_addr TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS audit_log_timestamp ON audit_log (timestamp);
+	CREATE TABLE IF NOT EXISTS alerts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		symbol TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		price REAL NOT NULL,
+		change REAL NOT NULL,
+		timestamp DATETIME NOT NULL,
+		message TEXT NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS alerts_symbol_timestamp ON alerts (symbol, timestamp);`
+
+	if _, err := db.Exec(query); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// InsertPrice stores a price under the time it was fetched for
+func (s *Store) InsertPrice(ctx context.Context, symbol string, price float64, at time.Time) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO price_history (symbol, price, timestamp) VALUES(?, ?, ?)",
+		symbol, price, at.UTC())
+	return err
+}
+
+// AveragePrice averages the prices stored after since. The caller passes the
+// time explicitly so replays with a virtual clock see their own history.
+func (s *Store) AveragePrice(ctx context.Context, symbol string, since time.Time) (float64, error) {
+	var avgPrice sql.NullFloat64 // Use NullFloat64 to handle cases where there might be no data in the database for the given period
+
+	// SQL query: calculate the average (AVG) for the period from since up to now
+	query := `
+		SELECT AVG(price)
+		FROM price_history
+		WHERE symbol = ? AND timestamp > ?`
+
+	err := s.db.QueryRowContext(ctx, query, symbol, since.UTC()).Scan(&avgPrice)
+	if err != nil {
+		return 0, err
+	}
+
+	if !avgPrice.Valid {
+		return 0, nil // No data available for the given symbol and time period, return 0 as average price
+	}
+
+	return avgPrice.Float64, nil
+}
+
+// RecentPrices returns up to limit of the latest prices of a symbol, oldest first
+func (s *Store) RecentPrices(ctx context.Context, symbol string, limit int) ([]float64, error) {
+	// ORDER BY timestamp DESC gives us the most recent ones on top
+	query := `SELECT price FROM price_history WHERE symbol = ? ORDER BY timestamp DESC LIMIT ?`
+	rows, err := s.db.QueryContext(ctx, query, symbol, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prices []float64
+	for rows.Next() {
+		var p float64
+		if err := rows.Scan(&p); err != nil {
+			continue
+		}
+		prices = append(prices, p)
+	}
+
+	// Indicators need [Old -> New], turning the slice over
+	for i, j := 0, len(prices)-1; i < j; i, j = i+1, j-1 {
+		prices[i], prices[j] = prices[j], prices[i]
+	}
+	return prices, rows.Err()
+}
+
+// LastPrice returns the latest stored price of a symbol, with ok false when there is none
+func (s *Store) LastPrice(ctx context.Context, symbol string) (p Point, ok bool, err error) {
+	err = s.db.QueryRowContext(ctx,
+		"SELECT symbol, price, timestamp FROM price_history WHERE symbol = ? ORDER BY timestamp DESC LIMIT 1", symbol).
+		Scan(&p.Symbol, &p.Price, &p.Time)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Point{}, false, nil
+	}
+	return p, err == nil, err
+}
+
+// History returns the prices of a symbol stored after from and up to to, in
+// insertion order. A zero to means up to now.
+func (s *Store) History(ctx context.Context, symbol string, from, to time.Time) ([]Point, error) {
+	query := "SELECT symbol, price, timestamp FROM price_history WHERE symbol = ? AND timestamp > ?"
+	args := []any{symbol, from.UTC()}
+	if !to.IsZero() {
+		query += " AND timestamp <= ?"
+		args = append(args, to.UTC())
+	}
+	rows, err := s.db.QueryContext(ctx, query+" ORDER BY id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []Point
+	for rows.Next() {
+		var p Point
+		if err := rows.Scan(&p.Symbol, &p.Price, &p.Time); err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// LatestStats returns the last stored price of every symbol
+func (s *Store) LatestStats(ctx context.Context) ([]Snapshot, error) {
+
+	query := `
+        SELECT
+            t1.symbol,
+            t1.price,
+            (SELECT AVG(price) FROM price_history
+             WHERE symbol = t1.symbol AND timestamp > datetime('now', '-100 hours')) as avg_price
+        FROM price_history t1
+        WHERE t1.id IN (SELECT MAX(id) FROM price_history GROUP BY symbol)
+		ORDER BY t1.symbol ASC`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []Snapshot
+	for rows.Next() {
+		var s Snapshot
+		var avg sql.NullFloat64
+		if err := rows.Scan(&s.Symbol, &s.Price, &avg); err != nil {
+			log.Printf("[ERROR] Scan error: %v", err)
+			continue
+		}
+		if avg.Valid {
+			s.AvgPrice = avg.Float64
+		}
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
+
+// LatestPrices returns the last stored price of every symbol
+func (s *Store) LatestPrices(ctx context.Context) (map[string]float64, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT symbol, price FROM price_history WHERE id IN (SELECT MAX(id) FROM price_history GROUP BY symbol)")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := make(map[string]float64)
+	for rows.Next() {
+		var symbol string
+		var price float64
+		if err := rows.Scan(&symbol, &price); err != nil {
+			return nil, err
+		}
+		prices[symbol] = price
+	}
+	return prices, rows.Err()
+}
+
+// InsertTrade records a portfolio trade and returns it with its ID
+func (s *Store) InsertTrade(ctx context.Context, t portfolio.Trade) (portfolio.Trade, error) {
+	res, err := s.db.ExecContext(ctx,
+		"INSERT INTO portfolio_trades (symbol, side, quantity, price, fee, timestamp, source) VALUES(?, ?, ?, ?, ?, ?, ?)",
+		t.Symbol, t.Side, t.Quantity, t.Price, t.Fee, t.Time.UTC(), t.Source)
+	if err != nil {
+		return t, err
+	}
+	t.ID, err = res.LastInsertId()
+	return t, err
+}
+
+// Trades returns every portfolio trade in time order
+func (s *Store) Trades(ctx context.Context) ([]portfolio.Trade, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, symbol, side, quantity, price, fee, timestamp, source FROM portfolio_trades ORDER BY timestamp, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trades := []portfolio.Trade{}
+	for rows.Next() {
+		var t portfolio.Trade
+		if err := rows.Scan(&t.ID, &t.Symbol, &t.Side, &t.Quantity, &t.Price, &t.Fee, &t.Time, &t.Source); err != nil {
+			return nil, err
+		}
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
+// TradesBetween returns the portfolio trades of symbol made from from up to to, in time order
+func (s *Store) TradesBetween(ctx context.Context, symbol string, from, to time.Time) ([]portfolio.Trade, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, symbol, side, quantity, price, fee, timestamp, source FROM portfolio_trades WHERE symbol = ? AND timestamp >= ? AND timestamp <= ? ORDER BY timestamp, id",
+		symbol, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trades := []portfolio.Trade{}
+	for rows.Next() {
+		var t portfolio.Trade
+		if err := rows.Scan(&t.ID, &t.Symbol, &t.Side, &t.Quantity, &t.Price, &t.Fee, &t.Time, &t.Source); err != nil {
+			return nil, err
+		}
+		trades = append(trades, t)
+	}
+	return trades, rows.Err()
+}
+
+// DeleteTrade removes a trade, used to correct manual entries
+func (s *Store) DeleteTrade(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM portfolio_trades WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// InsertAnomaly stores a detected anomaly and returns it with its ID
+func (s *Store) InsertAnomaly(ctx context.Context, e anomaly.Event) (anomaly.Event, error) {
+	res, err := s.db.ExecContext(ctx,
+		"INSERT INTO anomalies (symbol, method, score, threshold, price, return, timestamp, explanation) VALUES(?, ?, ?, ?, ?, ?, ?, ?)",
+		e.Symbol, e.Method, e.Score, e.Threshold, e.Price, e.Return, e.Time.UTC(), e.Explanation)
+	if err != nil {
+		return e, err
+	}
+	e.ID, err = res.LastInsertId()
+	return e, err
+}
+
+// Anomalies returns up to limit anomalies detected after since, newest first.
+// An empty symbol returns those of every symbol.
+func (s *Store) Anomalies(ctx context.Context, symbol string, since time.Time, limit int) ([]anomaly.Event, error) {
+	where := "timestamp > ?"
+	args := []any{since.UTC()}
+	if symbol != "" {
+		where += " AND symbol = ?"
+		args = append(args, symbol)
+	}
+	return s.anomalies(ctx, where, limit, args...)
+}
+
+// AnomaliesBetween returns up to limit anomalies of symbol detected from from up
+// to to, newest first
+func (s *Store) AnomaliesBetween(ctx context.Context, symbol string, from, to time.Time, limit int) ([]anomaly.Event, error) {
+	return s.anomalies(ctx, "symbol = ? AND timestamp >= ? AND timestamp <= ?", limit, symbol, from.UTC(), to.UTC())
+}
+
+// anomalies loads up to limit anomalies matching where, newest first
+func (s *Store) anomalies(ctx context.Context, where string, limit int, args ...any) ([]anomaly.Event, error) {
+	query := "SELECT id, symbol, method, score, threshold, price, return, timestamp, explanation FROM anomalies WHERE " +
+		where + " ORDER BY timestamp DESC, id DESC LIMIT ?"
+	rows, err := s.db.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []anomaly.Event{}
+	for rows.Next() {
+		var e anomaly.Event
+		if err := rows.Scan(&e.ID, &e.Symbol, &e.Method, &e.Score, &e.Threshold, &e.Price, &e.Return, &e.Time, &e.Explanation); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,577 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"crypto-check/alerts"
+	"crypto-check/anomaly"
+	"crypto-check/auth"
+	"crypto-check/orderbook"
+	"crypto-check/portfolio"
+	"crypto-check/venues"
+)
+
+func TestStorePrices(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, price := range []float64{100, 110, 120, 130} {
+		if err := st.InsertPrice(ctx, "BTCUSDT", price, start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("InsertPrice() error: %v", err)
+		}
+	}
+	if err := st.InsertPrice(ctx, "ETHUSDT", 10, start); err != nil {
+		t.Fatalf("InsertPrice() error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		since time.Time
+		want  float64
+	}{
+		{"All prices", start.Add(-time.Second), 115},
+		{"Last two minutes", start.Add(90 * time.Second), 125},
+		{"Nothing stored yet", start.Add(time.Hour), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := st.AveragePrice(ctx, "BTCUSDT", tt.since)
+			if err != nil || got != tt.want {
+				t.Errorf("AveragePrice() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	recent, err := st.RecentPrices(ctx, "BTCUSDT", 3)
+	if err != nil || len(recent) != 3 || recent[0] != 110 || recent[2] != 130 {
+		t.Errorf("RecentPrices() = %v, %v, want [110 120 130]", recent, err)
+	}
+
+	history, err := st.History(ctx, "BTCUSDT", start, start.Add(2*time.Minute))
+	if err != nil || len(history) != 2 || !history[0].Time.Equal(start.Add(time.Minute)) {
+		t.Errorf("History() = %+v, %v", history, err)
+	}
+
+	last, ok, err := st.LastPrice(ctx, "BTCUSDT")
+	if err != nil || !ok || last.Price != 130 || !last.Time.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("LastPrice() = %+v, %v, %v", last, ok, err)
+	}
+	if _, ok, err := st.LastPrice(ctx, "XRPUSDT"); err != nil || ok {
+		t.Errorf("LastPrice(XRPUSDT) = %v, %v, want nothing stored", ok, err)
+	}
+
+	prices, err := st.LatestPrices(ctx)
+	if err != nil || len(prices) != 2 || prices["BTCUSDT"] != 130 {
+		t.Errorf("LatestPrices() = %v, %v", prices, err)
+	}
+
+	latest, err := st.LatestStats(ctx)
+	if err != nil || len(latest) != 2 || latest[0].Symbol != "BTCUSDT" || latest[0].Price != 130 || latest[1].Price != 10 {
+		t.Errorf("LatestStats() = %+v, %v", latest, err)
+	}
+}
+
+func TestStoreTrades(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	sell, err := st.InsertTrade(ctx, portfolio.Trade{Symbol: "BTCUSDT", Side: portfolio.SideSell, Quantity: 1, Price: 110, Time: start.Add(time.Hour), Source: portfolio.SourceManual})
+	if err != nil {
+		t.Fatalf("InsertTrade() error: %v", err)
+	}
+	buy, err := st.InsertTrade(ctx, portfolio.Trade{Symbol: "BTCUSDT", Side: portfolio.SideBuy, Quantity: 2, Price: 100, Fee: 0.2, Time: start, Source: portfolio.SourcePaper})
+	if err != nil {
+		t.Fatalf("InsertTrade() error: %v", err)
+	}
+
+	trades, err := st.Trades(ctx)
+	if err != nil {
+		t.Fatalf("Trades() error: %v", err)
+	}
+	if len(trades) != 2 || trades[0].ID != buy.ID || trades[0].Fee != 0.2 || !trades[0].Time.Equal(start) || trades[1].ID != sell.ID {
+		t.Errorf("Trades() = %+v", trades)
+	}
+
+	st.InsertTrade(ctx, portfolio.Trade{Symbol: "ETHUSDT", Side: portfolio.SideBuy, Quantity: 1, Price: 10, Time: start, Source: portfolio.SourceManual})
+	between, err := st.TradesBetween(ctx, "BTCUSDT", start.Add(time.Minute), start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("TradesBetween() error: %v", err)
+	}
+	if len(between) != 1 || between[0].ID != sell.ID {
+		t.Errorf("TradesBetween() = %+v, want the sell only", between)
+	}
+
+	if err := st.DeleteTrade(ctx, sell.ID); err != nil {
+		t.Errorf("DeleteTrade() error: %v", err)
+	}
+	if err := st.DeleteTrade(ctx, sell.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteTrade() of a deleted trade = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStoreAnomalies(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, symbol := range []string{"BTCUSDT", "ETHUSDT", "BTCUSDT"} {
+		e := anomaly.Event{Symbol: symbol, Method: anomaly.MethodZScore, Score: 5, Threshold: 4, Price: 100, Return: 0.02, Time: start.Add(time.Duration(i) * time.Minute), Explanation: "spike"}
+		if _, err := st.InsertAnomaly(ctx, e); err != nil {
+			t.Fatalf("InsertAnomaly() error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		symbol   string
+		since    time.Time
+		limit    int
+		wantMins []int // Minutes after start, newest first
+	}{
+		{"Every symbol", "", start.Add(-time.Second), 10, []int{2, 1, 0}},
+		{"One symbol", "BTCUSDT", start.Add(-time.Second), 10, []int{2, 0}},
+		{"Since", "", start, 10, []int{2, 1}},
+		{"Limit", "", start.Add(-time.Second), 1, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := st.Anomalies(ctx, tt.symbol, tt.since, tt.limit)
+			if err != nil {
+				t.Fatalf("Anomalies() error: %v", err)
+			}
+			if len(events) != len(tt.wantMins) {
+				t.Fatalf("Anomalies() = %+v, want %d events", events, len(tt.wantMins))
+			}
+			for i, m := range tt.wantMins {
+				if !events[i].Time.Equal(start.Add(time.Duration(m)*time.Minute)) || events[i].Explanation != "spike" || events[i].ID == 0 {
+					t.Errorf("event %d = %+v, want the one at +%dm", i, events[i], m)
+				}
+			}
+		})
+	}
+
+	// The range is bounded in the query, so the limit only counts anomalies inside it
+	events, err := st.AnomaliesBetween(ctx, "BTCUSDT", start, start.Add(time.Minute), 1)
+	if err != nil {
+		t.Fatalf("AnomaliesBetween() error: %v", err)
+	}
+	if len(events) != 1 || !events[0].Time.Equal(start) {
+		t.Errorf("AnomaliesBetween() = %+v, want the one at start", events)
+	}
+}
+
+func TestStoreAlerts(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, kind := range []string{alerts.KindDeviation, alerts.KindSigma, alerts.KindTrend} {
+		a := alerts.Alert{Symbol: "BTCUSDT", Kind: kind, Price: 100, Change: 1.5, Time: start.Add(time.Duration(i) * time.Minute), Message: kind + " alert"}
+		if err := st.InsertAlert(ctx, a); err != nil {
+			t.Fatalf("InsertAlert() error: %v", err)
+		}
+	}
+	st.InsertAlert(ctx, alerts.Alert{Symbol: "ETHUSDT", Kind: alerts.KindSpread, Time: start})
+
+	tests := []struct {
+		name      string
+		symbol    string
+		from, to  time.Time
+		limit     int
+		wantKinds []string // Newest first
+	}{
+		{"Every alert of a symbol", "BTCUSDT", start, start.Add(time.Hour), 10, []string{alerts.KindTrend, alerts.KindSigma, alerts.KindDeviation}},
+		{"Bounded by to", "BTCUSDT", start, start.Add(time.Minute), 1, []string{alerts.KindSigma}},
+		{"Other symbol", "ETHUSDT", start, start, 10, []string{alerts.KindSpread}},
+		{"None in the range", "BTCUSDT", start.Add(time.Hour), start.Add(2 * time.Hour), 10, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raised, err := st.AlertsBetween(ctx, tt.symbol, tt.from, tt.to, tt.limit)
+			if err != nil {
+				t.Fatalf("AlertsBetween() error: %v", err)
+			}
+			kinds := []string{}
+			for _, a := range raised {
+				kinds = append(kinds, a.Kind)
+			}
+			if fmt.Sprint(kinds) != fmt.Sprint(tt.wantKinds) {
+				t.Errorf("AlertsBetween() kinds = %v, want %v", kinds, tt.wantKinds)
+			}
+		})
+	}
+}
+
+// On a host east of UTC, times in the local zone and in UTC must select the same rows
+func TestStoreLocalTimes(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC+9", 9*60*60)
+	t.Cleanup(func() { time.Local = local })
+
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	// Stored in the local zone like time.Now(), queried in UTC like a parsed from=...Z
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, price := range []float64{100, 110, 120, 130} {
+		at := start.Add(time.Duration(i) * time.Minute).Local()
+		if err := st.InsertPrice(ctx, "BTCUSDT", price, at); err != nil {
+			t.Fatalf("InsertPrice() error: %v", err)
+		}
+		if _, err := st.InsertTrade(ctx, portfolio.Trade{Symbol: "BTCUSDT", Side: portfolio.SideBuy, Quantity: 1, Price: price, Time: at, Source: portfolio.SourcePaper}); err != nil {
+			t.Fatalf("InsertTrade() error: %v", err)
+		}
+		if _, err := st.InsertAnomaly(ctx, anomaly.Event{Symbol: "BTCUSDT", Method: anomaly.MethodZScore, Score: 5, Threshold: 4, Price: price, Time: at}); err != nil {
+			t.Fatalf("InsertAnomaly() error: %v", err)
+		}
+	}
+
+	from, to := start.Add(30*time.Second), start.Add(2*time.Minute)
+	history, err := st.History(ctx, "BTCUSDT", from, to)
+	if err != nil || len(history) != 2 || history[0].Price != 110 {
+		t.Errorf("History() = %+v, %v, want 110 and 120", history, err)
+	}
+	if avg, err := st.AveragePrice(ctx, "BTCUSDT", from); err != nil || avg != 120 {
+		t.Errorf("AveragePrice() = %v, %v, want 120", avg, err)
+	}
+	if trades, err := st.TradesBetween(ctx, "BTCUSDT", from, to); err != nil || len(trades) != 2 {
+		t.Errorf("TradesBetween() = %+v, %v, want 2 trades", trades, err)
+	}
+	if events, err := st.AnomaliesBetween(ctx, "BTCUSDT", from, to, 10); err != nil || len(events) != 2 {
+		t.Errorf("AnomaliesBetween() = %+v, %v, want 2 anomalies", events, err)
+	}
+	if events, err := st.Anomalies(ctx, "BTCUSDT", from.Local(), 10); err != nil || len(events) != 3 {
+		t.Errorf("Anomalies() = %+v, %v, want 3 anomalies", events, err)
+	}
+}
+
+func TestStoreBookSnapshots(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	snapshots := []orderbook.Snapshot{
+		{Time: start, BestBid: 99, BestBidQuantity: 1, BestAsk: 101, BestAskQuantity: 2, Bands: []orderbook.Band{
+			{BPS: 50, BidQuantity: 3, AskQuantity: 4, BidNotional: 297, AskNotional: 404},
+			{BPS: 10, BidQuantity: 1, AskQuantity: 2, BidNotional: 99, AskNotional: 202},
+		}},
+		{Time: start.Add(time.Second), BestBid: 100, BestBidQuantity: 1, BestAsk: 102, BestAskQuantity: 1},
+	}
+	for _, snap := range snapshots {
+		if err := st.InsertBookSnapshot(ctx, "BTCUSDT", snap); err != nil {
+			t.Fatalf("InsertBookSnapshot() error: %v", err)
+		}
+	}
+	if err := st.InsertBookSnapshot(ctx, "ETHUSDT", snapshots[0]); err != nil {
+		t.Fatalf("InsertBookSnapshot() error: %v", err)
+	}
+
+	got, err := st.BookSnapshots(ctx, "BTCUSDT", start.Add(-time.Second), start.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("BookSnapshots() error: %v", err)
+	}
+	if len(got) != 2 || !got[0].Time.Equal(start) || got[1].BestAsk != 102 || len(got[1].Bands) != 0 {
+		t.Fatalf("BookSnapshots() = %+v", got)
+	}
+	if len(got[0].Bands) != 2 || got[0].Bands[0] != snapshots[0].Bands[1] || got[0].Bands[1] != snapshots[0].Bands[0] {
+		t.Errorf("bands = %+v, want both, narrowest first", got[0].Bands)
+	}
+
+	if got, err := st.BookSnapshots(ctx, "BTCUSDT", start, start.Add(time.Minute)); err != nil || len(got) != 1 {
+		t.Errorf("BookSnapshots() after the first = %+v, %v, want the second only", got, err)
+	}
+}
+
+func TestStoreTradeVolumes(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	batches := [][]MarketTrade{
+		{
+			{ID: 1, Price: 100, Quantity: 2, Time: start.Add(10 * time.Second)},
+			{ID: 2, Price: 101, Quantity: 1, Time: start.Add(20 * time.Second), BuyerIsMaker: true},
+		},
+		// Same bucket as the first batch, then the next minute
+		{
+			{ID: 3, Price: 102, Quantity: 1, Time: start.Add(50 * time.Second)},
+			{ID: 4, Price: 103, Quantity: 4, Time: start.Add(70 * time.Second), BuyerIsMaker: true},
+		},
+	}
+	for _, trades := range batches {
+		if err := st.AddTrades(ctx, "BTCUSDT", trades); err != nil {
+			t.Fatalf("AddTrades() error: %v", err)
+		}
+	}
+	for _, p := range []float64{100, 102, 103} {
+		if err := st.InsertPrice(ctx, "BTCUSDT", p, start.Add(time.Duration(p-99)*25*time.Second)); err != nil {
+			t.Fatalf("InsertPrice() error: %v", err)
+		}
+	}
+
+	volumes, err := st.Volumes(ctx, "BTCUSDT", start.Add(-time.Second), start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Volumes() error: %v", err)
+	}
+	if len(volumes) != 2 {
+		t.Fatalf("got %d volumes, want 2", len(volumes))
+	}
+	first := volumes[0]
+	if !first.Time.Equal(start) || first.Volume != 4 || first.BuyVolume != 3 || first.QuoteVolume != 403 || first.Trades != 3 || first.LastTradeID != 3 {
+		t.Errorf("first minute = %+v", first)
+	}
+	if first.Delta() != 2 || first.VWAP() != 100.75 {
+		t.Errorf("Delta() = %v, VWAP() = %v, want 2 and 100.75", first.Delta(), first.VWAP())
+	}
+	if id, err := st.LastTradeID(ctx, "BTCUSDT"); err != nil || id != 4 {
+		t.Errorf("LastTradeID() = %d, %v, want 4", id, err)
+	}
+	if id, err := st.LastTradeID(ctx, "ETHUSDT"); err != nil || id != 0 {
+		t.Errorf("LastTradeID() without trades = %d, %v, want 0", id, err)
+	}
+
+	// Prices at 0:25, 1:15 and 1:40 give two one minute candles with the volume of their minute
+	candles, err := st.Candles(ctx, "BTCUSDT", start, start.Add(time.Hour), time.Minute)
+	if err != nil {
+		t.Fatalf("Candles() error: %v", err)
+	}
+	if len(candles) != 2 || candles[0].Volume != 4 || candles[0].Trades != 3 || candles[1].Volume != 4 || candles[1].BuyVolume != 0 || candles[1].QuoteVolume != 412 {
+		t.Errorf("Candles() = %+v", candles)
+	}
+}
+
+func TestStoreDerivatives(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 3 {
+		d := Derivative{
+			Time:            start.Add(time.Duration(i) * time.Minute),
+			MarkPrice:       60000 + float64(i),
+			IndexPrice:      59990,
+			SpotPrice:       59995,
+			FundingRate:     0.0001,
+			NextFundingTime: start.Add(4 * time.Hour),
+			OpenInterest:    1000 + float64(i),
+		}
+		if err := st.InsertDerivative(ctx, "BTCUSDT", d); err != nil {
+			t.Fatalf("InsertDerivative() error: %v", err)
+		}
+	}
+
+	// From is exclusive, so the first sample is left out
+	samples, err := st.Derivatives(ctx, "BTCUSDT", start, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Derivatives() error: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("got %d samples, want 2", len(samples))
+	}
+	last := samples[1]
+	if !last.Time.Equal(start.Add(2*time.Minute)) || last.MarkPrice != 60002 || last.OpenInterest != 1002 || !last.NextFundingTime.Equal(start.Add(4*time.Hour)) {
+		t.Errorf("last sample = %+v", last)
+	}
+	if other, err := st.Derivatives(ctx, "ETHUSDT", start.Add(-time.Hour), start.Add(time.Hour)); err != nil || len(other) != 0 {
+		t.Errorf("Derivatives() of another symbol = %v, %v, want none", other, err)
+	}
+}
+
+func TestStoreSpreads(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, symbol := range []string{"BTCUSDT", "ETHUSDT", "BTCUSDT"} {
+		at := start.Add(time.Duration(i) * time.Minute)
+		sp := venues.Spread{
+			Symbol: symbol, Time: at, Reference: 100 + float64(i), Method: venues.MethodMedian, SpreadBPS: 10, Low: "binance", High: "okx",
+			Quotes: []venues.Quote{
+				{Venue: "binance", Price: 100, Updated: at},
+				{Venue: "okx", Price: 100.1, Updated: at, DeviationBPS: 5},
+				{Venue: "bybit", Updated: at, Stale: true, Error: "symbol not listed"},
+			},
+		}
+		if err := st.InsertSpread(ctx, sp); err != nil {
+			t.Fatalf("InsertSpread() error: %v", err)
+		}
+	}
+
+	latest, err := st.LatestSpreads(ctx, "")
+	if err != nil {
+		t.Fatalf("LatestSpreads() error: %v", err)
+	}
+	if len(latest) != 2 || latest[0].Symbol != "BTCUSDT" || latest[0].Reference != 102 || latest[1].Symbol != "ETHUSDT" {
+		t.Fatalf("LatestSpreads() = %+v, want the last BTCUSDT and ETHUSDT spreads", latest)
+	}
+	quotes := latest[0].Quotes
+	if len(quotes) != 3 || quotes[0].Venue != "binance" || quotes[1].DeviationBPS != 5 || !quotes[2].Stale || quotes[2].Error != "symbol not listed" ||
+		!quotes[1].Updated.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("quotes = %+v", quotes)
+	}
+
+	history, err := st.Spreads(ctx, "BTCUSDT", start.Add(-time.Second), 10)
+	if err != nil {
+		t.Fatalf("Spreads() error: %v", err)
+	}
+	if len(history) != 2 || !history[0].Time.Equal(start.Add(2*time.Minute)) || len(history[1].Quotes) != 3 {
+		t.Errorf("Spreads() = %+v, want both BTCUSDT spreads newest first", history)
+	}
+	if limited, err := st.Spreads(ctx, "", time.Time{}, 1); err != nil || len(limited) != 1 || limited[0].Reference != 102 {
+		t.Errorf("Spreads() with a limit = %+v, %v, want the newest one", limited, err)
+	}
+}
+
+func TestStoreAuth(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer st.Close()
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if ok, err := st.HasAdmin(ctx); err != nil || ok {
+		t.Fatalf("HasAdmin() on an empty store = %v, %v", ok, err)
+	}
+	admin, err := st.InsertKey(ctx, auth.Key{Name: "admin", Role: auth.RoleAdmin, Prefix: "cck_a", CreatedAt: start}, "hash-a")
+	if err != nil {
+		t.Fatalf("InsertKey() error: %v", err)
+	}
+	viewer, err := st.InsertKey(ctx, auth.Key{Name: "viewer", Role: auth.RoleViewer, Prefix: "cck_v", CreatedAt: start}, "hash-v")
+	if err != nil {
+		t.Fatalf("InsertKey() error: %v", err)
+	}
+	if ok, err := st.HasAdmin(ctx); err != nil || !ok {
+		t.Errorf("HasAdmin() = %v, %v, want true", ok, err)
+	}
+	if k, err := st.KeyByHash(ctx, "hash-v"); err != nil || k.ID != viewer.ID || k.Role != auth.RoleViewer || !k.CreatedAt.Equal(start) {
+		t.Errorf("KeyByHash() = %+v, %v, want the viewer key", k, err)
+	}
+	if _, err := st.KeyByHash(ctx, "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("KeyByHash() of an unknown hash error = %v, want ErrNotFound", err)
+	}
+
+	// Uses are recorded at most once a minute
+	for _, at := range []time.Time{start.Add(time.Hour), start.Add(time.Hour + 30*time.Second)} {
+		if err := st.TouchKey(ctx, viewer.ID, at); err != nil {
+			t.Fatalf("TouchKey() error: %v", err)
+		}
+	}
+	if k, _ := st.KeyByHash(ctx, "hash-v"); k.LastUsed == nil || !k.LastUsed.Equal(start.Add(time.Hour)) {
+		t.Errorf("LastUsed = %v, want the first use", k.LastUsed)
+	}
+
+	if err := st.InsertSession(ctx, "session", viewer.ID, start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("InsertSession() error: %v", err)
+	}
+	if k, err := st.SessionKey(ctx, "session", start.Add(time.Hour)); err != nil || k.ID != viewer.ID {
+		t.Errorf("SessionKey() = %+v, %v, want the viewer key", k, err)
+	}
+	if _, err := st.SessionKey(ctx, "session", start.Add(3*time.Hour)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SessionKey() after expiry error = %v, want ErrNotFound", err)
+	}
+
+	if err := st.RevokeKey(ctx, admin.ID, start); !errors.Is(err, ErrLastAdmin) {
+		t.Errorf("RevokeKey() of the last admin error = %v, want ErrLastAdmin", err)
+	}
+	if err := st.InsertSession(ctx, "session", viewer.ID, start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("InsertSession() error: %v", err)
+	}
+	if err := st.RevokeKey(ctx, viewer.ID, start.Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeKey() error: %v", err)
+	}
+	if err := st.RevokeKey(ctx, viewer.ID, start.Add(time.Hour)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RevokeKey() twice error = %v, want ErrNotFound", err)
+	}
+	if _, err := st.KeyByHash(ctx, "hash-v"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("KeyByHash() of a revoked key error = %v, want ErrNotFound", err)
+	}
+	if _, err := st.SessionKey(ctx, "session", start); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SessionKey() of a revoked key error = %v, want ErrNotFound", err)
+	}
+	keys, err := st.Keys(ctx)
+	if err != nil || len(keys) != 2 || !keys[0].Active() || keys[1].RevokedAt == nil {
+		t.Errorf("Keys() = %+v, %v, want the admin and the revoked viewer", keys, err)
+	}
+
+	for i, status := range []int{201, 403} {
+		e := auth.AuditEntry{Time: start.Add(time.Duration(i) * time.Minute), Actor: "admin", KeyID: admin.ID, Role: auth.RoleAdmin,
+			Method: "POST", Path: "/api/keys", Status: status, RemoteAddr: "127.0.0.1:1234"}
+		if err := st.InsertAudit(ctx, e); err != nil {
+			t.Fatalf("InsertAudit() error: %v", err)
+		}
+	}
+	entries, err := st.AuditLog(ctx, start, 10)
+	if err != nil || len(entries) != 1 || entries[0].Status != 403 || entries[0].KeyID != admin.ID {
+		t.Errorf("AuditLog() = %+v, %v, want the entry after start", entries, err)
+	}
+}
+
+func TestBuildCandles(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	points := []Point{
+		{Price: 100, Time: start.Add(10 * time.Second)},
+		{Price: 105, Time: start.Add(20 * time.Second)},
+		{Price: 95, Time: start.Add(40 * time.Second)},
+		{Price: 98, Time: start.Add(50 * time.Second)},
+		{Price: 99, Time: start.Add(3 * time.Minute)}, // Two empty minutes in between
+	}
+
+	want := []Candle{
+		{Time: start, Open: 100, High: 105, Low: 95, Close: 98, Ticks: 4},
+		{Time: start.Add(3 * time.Minute), Open: 99, High: 99, Low: 99, Close: 99, Ticks: 1},
+	}
+	got := BuildCandles(points, time.Minute)
+	if len(got) != len(want) {
+		t.Fatalf("BuildCandles() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("candle %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// TradeBucket is the resolution trades are aggregated to before being stored
+const TradeBucket = time.Minute
+
+// MarketTrade is an exchange trade, as opposed to a portfolio trade
+type MarketTrade struct {
+	ID           int64
+	Price        float64
+	Quantity     float64
+	Time         time.Time
+	BuyerIsMaker bool // The taker sold
+}
+
+// Volume is the traded volume of a symbol over a period, split by taker side
+type Volume struct {
+	Time           time.Time // Start of the period
+	Volume         float64   // In the base asset
+	BuyVolume      float64   // Bought by takers
+	QuoteVolume    float64
+	BuyQuoteVolume float64
+	Trades         int
+	LastTradeID    int64
+}
+
+// SellVolume is the volume sold by takers
+func (v Volume) SellVolume() float64 {
+	return v.Volume - v.BuyVolume
+}
+
+// Delta is the taker buy volume minus the taker sell volume
+func (v Volume) Delta() float64 {
+	return v.BuyVolume - v.SellVolume()
+}
+
+// VWAP is the volume weighted average price, 0 without volume
+func (v Volume) VWAP() float64 {
+	if v.Volume == 0 {
+		return 0
+	}
+	return v.QuoteVolume / v.Volume
+}
+
+// Add accumulates o into v, keeping the start of v
+func (v *Volume) Add(o Volume) {
+	v.Volume += o.Volume
+	v.BuyVolume += o.BuyVolume
+	v.QuoteVolume += o.QuoteVolume
+	v.BuyQuoteVolume += o.BuyQuoteVolume
+	v.Trades += o.Trades
+	v.LastTradeID = max(v.LastTradeID, o.LastTradeID)
+}
+
+// AddTrades adds trades of symbol to the volume of their TradeBucket. Only the
+// totals are stored, so the caller must not add the same trade twice.
+func (s *Store) AddTrades(ctx context.Context, symbol string, trades []MarketTrade) error {
+	var buckets []Volume
+	for _, t := range trades {
+		start := t.Time.UTC().Truncate(TradeBucket)
+		if n := len(buckets); n == 0 || !buckets[n-1].Time.Equal(start) {
+			buckets = append(buckets, Volume{Time: start})
+		}
+		b := &buckets[len(buckets)-1]
+		quote := t.Price * t.Quantity
+		b.Add(Volume{Volume: t.Quantity, QuoteVolume: quote, Trades: 1, LastTradeID: t.ID})
+		if !t.BuyerIsMaker {
+			b.BuyVolume += t.Quantity
+			b.BuyQuoteVolume += quote
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, b := range buckets {
+		_, err := tx.ExecContext(ctx, `INSERT INTO trade_volume
+			(symbol, timestamp, volume, buy_volume, quote_volume, buy_quote_volume, trades, last_trade_id)
+			VALUES(?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (symbol, timestamp) DO UPDATE SET
+				volume = volume + excluded.volume,
+				buy_volume = buy_volume + excluded.buy_volume,
+				quote_volume = quote_volume + excluded.quote_volume,
+				buy_quote_volume = buy_quote_volume + excluded.buy_quote_volume,
+				trades = trades + excluded.trades,
+				last_trade_id = max(last_trade_id, excluded.last_trade_id)`,
+			symbol, b.Time, b.Volume, b.BuyVolume, b.QuoteVolume, b.BuyQuoteVolume, b.Trades, b.LastTradeID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Volumes returns the TradeBucket volumes of symbol starting after from and up
+// to to, oldest first
+func (s *Store) Volumes(ctx context.Context, symbol string, from, to time.Time) ([]Volume, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT timestamp, volume, buy_volume, quote_volume, buy_quote_volume, trades, last_trade_id
+		FROM trade_volume WHERE symbol = ? AND timestamp > ? AND timestamp <= ? ORDER BY timestamp`,
+		symbol, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var volumes []Volume
+	for rows.Next() {
+		var v Volume
+		if err := rows.Scan(&v.Time, &v.Volume, &v.BuyVolume, &v.QuoteVolume, &v.BuyQuoteVolume, &v.Trades, &v.LastTradeID); err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, v)
+	}
+	return volumes, rows.Err()
+}
+
+// LastTradeID returns the id of the last trade added for symbol, 0 if there is none
+func (s *Store) LastTradeID(ctx context.Context, symbol string) (int64, error) {
+	var id int64
+	err := s.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(last_trade_id), 0) FROM trade_volume WHERE symbol = ?", symbol).Scan(&id)
+	return id, err
+}
@@ -0,0 +1,204 @@
+package venues
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reference price methods
+const (
+	MethodMedian = "median" // Middle price of the healthy venues, ignores a single bad feed
+	MethodVWAP   = "vwap"   // Healthy prices weighted by their 24 hour volume
+)
+
+// Config selects the venues compared and tunes the checks. Zero fields take the defaults.
+type Config struct {
+	Exchanges      []string          `json:"exchanges"`        // Venues quoted next to Binance: okx, bybit, coinbase
+	URLs           map[string]string `json:"urls"`             // Optional base URL per venue, e.g. the mock exchange
+	Interval       int               `json:"interval"`         // Seconds between rounds of quotes
+	Method         string            `json:"method"`           // MethodMedian or MethodVWAP
+	OutlierBPS     float64           `json:"outlier_bps"`      // Distance from the median making a venue an outlier
+	StaleAfter     int               `json:"stale_after"`      // Seconds without a price change making a feed stale
+	SpreadAlertBPS float64           `json:"spread_alert_bps"` // Alert on spreads between healthy venues from this size, 0 disables it
+}
+
+// DefaultConfig returns the settings used for zero fields
+func DefaultConfig() Config {
+	return Config{Interval: 10, Method: MethodMedian, OutlierBPS: 100, StaleAfter: 60}
+}
+
+// WithDefaults fills zero fields from DefaultConfig
+func (c Config) WithDefaults() Config {
+	d := DefaultConfig()
+	if c.Interval <= 0 {
+		c.Interval = d.Interval
+	}
+	if c.Method != MethodVWAP {
+		c.Method = d.Method
+	}
+	if c.OutlierBPS <= 0 {
+		c.OutlierBPS = d.OutlierBPS
+	}
+	if c.StaleAfter <= 0 {
+		c.StaleAfter = d.StaleAfter
+	}
+	return c
+}
+
+// Quote is the price of a symbol on one venue as judged against the others
+type Quote struct {
+	Venue        string    `json:"venue"`
+	Price        float64   `json:"price"`
+	Volume       float64   `json:"volume"`          // 24 hours, in the base asset
+	Updated      time.Time `json:"updated"`         // When the price last changed
+	DeviationBPS float64   `json:"deviation_bps"`   // From the median of the fresh venues
+	Outlier      bool      `json:"outlier"`         // Too far from the median
+	Stale        bool      `json:"stale"`           // Not updated for StaleAfter, or failed
+	Error        string    `json:"error,omitempty"` // Why the venue could not be quoted
+}
+
+// Healthy reports whether the quote takes part in the reference price and the spread
+func (q Quote) Healthy() bool {
+	return !q.Stale && !q.Outlier
+}
+
+// Spread is the consolidated price of a symbol over the venues and the gap between them
+type Spread struct {
+	Symbol    string    `json:"symbol"`
+	Time      time.Time `json:"time"`
+	Reference float64   `json:"reference"` // 0 without a healthy venue
+	Method    string    `json:"method"`
+	SpreadBPS float64   `json:"spread_bps"` // Highest over lowest healthy price, 0 with fewer than two
+	Low       string    `json:"low"`        // Venue to buy on
+	High      string    `json:"high"`       // Venue to sell on
+	Quotes    []Quote   `json:"quotes"`
+}
+
+// feed is what the aggregator remembers about a venue quoting a symbol
+type feed struct {
+	price   float64
+	updated time.Time
+}
+
+// Aggregator consolidates the quotes of every venue. It remembers the last price
+// of each feed, so one repeating the same price goes stale even when the venue
+// stamps it as fresh.
+type Aggregator struct {
+	config Config
+	mu     sync.Mutex
+	feeds  map[string]feed // By symbol and venue
+}
+
+func NewAggregator(config Config) *Aggregator {
+	return &Aggregator{config: config.WithDefaults(), feeds: make(map[string]feed)}
+}
+
+// Config returns the settings in use, defaults included
+func (a *Aggregator) Config() Config {
+	return a.config
+}
+
+// Aggregate judges one round of quotes of symbol taken at now. Quotes with an
+// Error are kept as stale. Updated is the time stamped by the venue, zero when
+// it gives none, and is replaced with the time the price last changed.
+func (a *Aggregator) Aggregate(symbol string, quotes []Quote, now time.Time) Spread {
+	staleAfter := time.Duration(a.config.StaleAfter) * time.Second
+	result := Spread{Symbol: symbol, Time: now, Method: a.config.Method, Quotes: make([]Quote, len(quotes))}
+
+	a.mu.Lock()
+	for i, q := range quotes {
+		if q.Error != "" {
+			q.Stale = true
+			result.Quotes[i] = q
+			continue
+		}
+		if q.Updated.IsZero() || q.Updated.After(now) {
+			q.Updated = now
+		}
+		key := symbol + "/" + q.Venue
+		if last, ok := a.feeds[key]; ok && last.price == q.Price {
+			q.Updated = last.updated
+		}
+		a.feeds[key] = feed{price: q.Price, updated: q.Updated}
+		q.Stale = now.Sub(q.Updated) > staleAfter
+		result.Quotes[i] = q
+	}
+	a.mu.Unlock()
+
+	var fresh []float64
+	for _, q := range result.Quotes {
+		if !q.Stale {
+			fresh = append(fresh, q.Price)
+		}
+	}
+	if len(fresh) == 0 {
+		return result
+	}
+	mid := median(fresh)
+	for i := range result.Quotes {
+		q := &result.Quotes[i]
+		if q.Stale {
+			continue
+		}
+		q.DeviationBPS = (q.Price - mid) / mid * 10000
+		// With two venues there is no telling which one is wrong
+		q.Outlier = len(fresh) >= 3 && math.Abs(q.DeviationBPS) > a.config.OutlierBPS
+	}
+
+	var healthy []Quote
+	for _, q := range result.Quotes {
+		if q.Healthy() {
+			healthy = append(healthy, q)
+		}
+	}
+	result.Reference = reference(healthy, a.config.Method)
+	if len(healthy) >= 2 {
+		low, high := healthy[0], healthy[0]
+		for _, q := range healthy[1:] {
+			if q.Price < low.Price {
+				low = q
+			}
+			if q.Price > high.Price {
+				high = q
+			}
+		}
+		result.Low, result.High = low.Venue, high.Venue
+		result.SpreadBPS = (high.Price - low.Price) / low.Price * 10000
+	}
+	return result
+}
+
+// reference returns the consolidated price of quotes, falling back to the median
+// when they have no volume to weigh them with
+func reference(quotes []Quote, method string) float64 {
+	if len(quotes) == 0 {
+		return 0
+	}
+	if method == MethodVWAP {
+		var notional, volume float64
+		for _, q := range quotes {
+			notional += q.Price * q.Volume
+			volume += q.Volume
+		}
+		if volume > 0 {
+			return notional / volume
+		}
+	}
+	prices := make([]float64, len(quotes))
+	for i, q := range quotes {
+		prices[i] = q.Price
+	}
+	return median(prices)
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,102 @@
+package venues
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAggregate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		method       string
+		quotes       []Quote
+		wantRef      float64
+		wantSpread   float64
+		wantLow      string
+		wantHigh     string
+		wantOutliers []string
+		wantStale    []string
+	}{
+		{
+			"Median of three", MethodMedian,
+			[]Quote{{Venue: "binance", Price: 100}, {Venue: "okx", Price: 100.2}, {Venue: "bybit", Price: 100.1}},
+			100.1, 20, "binance", "okx", nil, nil,
+		},
+		{
+			"Volume weighted", MethodVWAP,
+			[]Quote{{Venue: "binance", Price: 100, Volume: 3}, {Venue: "okx", Price: 104, Volume: 1}},
+			101, 400, "binance", "okx", nil, nil,
+		},
+		{
+			"Outlier left out", MethodMedian,
+			[]Quote{{Venue: "binance", Price: 100}, {Venue: "okx", Price: 100.1}, {Venue: "bybit", Price: 102}},
+			100.05, 10, "binance", "okx", []string{"bybit"}, nil,
+		},
+		{
+			"Two venues cannot be outliers", MethodMedian,
+			[]Quote{{Venue: "binance", Price: 100}, {Venue: "okx", Price: 102}},
+			101, 200, "binance", "okx", nil, nil,
+		},
+		{
+			"Stale and failed feeds", MethodMedian,
+			[]Quote{
+				{Venue: "binance", Price: 100},
+				{Venue: "okx", Price: 100.1},
+				{Venue: "coinbase", Price: 90, Updated: now.Add(-5 * time.Minute)},
+				{Venue: "bybit", Error: "symbol not listed"},
+			},
+			100.05, 10, "binance", "okx", nil, []string{"coinbase", "bybit"},
+		},
+		{
+			"Nothing healthy", MethodMedian,
+			[]Quote{{Venue: "bybit", Error: "timeout"}},
+			0, 0, "", "", nil, []string{"bybit"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spread := NewAggregator(Config{Method: tt.method}).Aggregate("BTCUSDT", tt.quotes, now)
+			if math.Abs(spread.Reference-tt.wantRef) > 1e-9 || math.Abs(spread.SpreadBPS-tt.wantSpread) > 1e-6 {
+				t.Errorf("reference = %v, spread = %v bps, want %v and %v bps", spread.Reference, spread.SpreadBPS, tt.wantRef, tt.wantSpread)
+			}
+			if spread.Low != tt.wantLow || spread.High != tt.wantHigh {
+				t.Errorf("low = %q, high = %q, want %q and %q", spread.Low, spread.High, tt.wantLow, tt.wantHigh)
+			}
+			var outliers, stale []string
+			for _, q := range spread.Quotes {
+				if q.Outlier {
+					outliers = append(outliers, q.Venue)
+				}
+				if q.Stale {
+					stale = append(stale, q.Venue)
+				}
+			}
+			if !slices.Equal(outliers, tt.wantOutliers) || !slices.Equal(stale, tt.wantStale) {
+				t.Errorf("outliers = %v, stale = %v, want %v and %v", outliers, stale, tt.wantOutliers, tt.wantStale)
+			}
+		})
+	}
+}
+
+func TestAggregateRepeatedPriceGoesStale(t *testing.T) {
+	a := NewAggregator(Config{StaleAfter: 30})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var spread Spread
+	// The venue stamps every quote as fresh but its price never moves
+	for i := range 5 {
+		at := start.Add(time.Duration(i) * 10 * time.Second)
+		spread = a.Aggregate("BTCUSDT", []Quote{
+			{Venue: "binance", Price: 100 + float64(i)/100, Updated: at},
+			{Venue: "okx", Price: 100, Updated: at},
+		}, at)
+	}
+	if q := spread.Quotes[1]; !q.Stale || !q.Updated.Equal(start) {
+		t.Errorf("okx = %+v, want stale since %v", q, start)
+	}
+	if spread.Quotes[0].Stale || spread.SpreadBPS != 0 {
+		t.Errorf("spread = %+v, want binance alone and healthy", spread)
+	}
+}
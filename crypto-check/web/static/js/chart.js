@@ -0,0 +1,449 @@
+// Canvas charts of the dashboard: the sparklines of the cards and the price
+// chart of the symbol pages
+
+const chartColors = {
+    up: '#10b981', // Green
+    down: '#ef4444', // Red
+    ma: '#38bdf8', // Blue
+    band: 'rgba(167, 139, 250, 0.7)', // Violet
+    bandFill: 'rgba(167, 139, 250, 0.08)',
+    volume: 'rgba(148, 163, 184, 0.18)',
+    signal: '#f59e0b', // Amber
+    anomaly: '#f59e0b',
+    alert: '#f472b6', // Pink
+    grid: 'rgba(255, 255, 255, 0.06)',
+    cross: 'rgba(255, 255, 255, 0.35)',
+    text: '#94a3b8',
+};
+
+// Sizes the backing store of canvas to its CSS size and returns a context
+// drawing in CSS pixels
+function sizeCanvas(canvas) {
+    const ratio = window.devicePixelRatio || 1;
+    const width = canvas.clientWidth, height = canvas.clientHeight;
+    if (canvas.width !== Math.round(width * ratio) || canvas.height !== Math.round(height * ratio)) {
+        canvas.width = Math.round(width * ratio);
+        canvas.height = Math.round(height * ratio);
+    }
+    const ctx = canvas.getContext('2d');
+    ctx.setTransform(ratio, 0, 0, ratio, 0, 0);
+    ctx.clearRect(0, 0, width, height);
+    return { ctx, width, height };
+}
+
+// Draws values as a line, green when the last is above the first and red otherwise
+function drawSparkline(canvas, values) {
+    const { ctx, width, height } = sizeCanvas(canvas);
+    if (!values || values.length < 2) return;
+    const low = Math.min(...values), high = Math.max(...values);
+    const range = high - low || 1;
+    const x = i => i / (values.length - 1) * width;
+    const y = v => height - 2 - (v - low) / range * (height - 4);
+    const color = values[values.length - 1] >= values[0] ? chartColors.up : chartColors.down;
+
+    ctx.beginPath();
+    values.forEach((v, i) => i === 0 ? ctx.moveTo(x(i), y(v)) : ctx.lineTo(x(i), y(v)));
+    ctx.strokeStyle = color;
+    ctx.lineWidth = 1.5;
+    ctx.stroke();
+    ctx.lineTo(width, height);
+    ctx.lineTo(0, height);
+    ctx.closePath();
+    ctx.globalAlpha = 0.12;
+    ctx.fillStyle = color;
+    ctx.fill();
+    ctx.globalAlpha = 1;
+}
+
+// Prices keep more decimals the smaller they are
+function formatPrice(value) {
+    if (Math.abs(value) >= 1000) return value.toLocaleString(undefined, { maximumFractionDigits: 2 });
+    if (Math.abs(value) >= 1) return value.toLocaleString(undefined, { maximumFractionDigits: 4 });
+    return value.toLocaleString(undefined, { maximumSignificantDigits: 6 });
+}
+
+const minVisibleCandles = 10;
+const defaultVisibleCandles = 120;
+const axisWidth = 72; // Right of the plot, for the value labels
+const timeAxisHeight = 22;
+const paneGap = 8;
+
+// PriceChart draws the candles of GET /api/chart with the moving average and
+// Bollinger overlays, volume bars, markers, and RSI and MACD panes below. The
+// wheel zooms around the cursor, dragging pans and a double click resets.
+class PriceChart {
+    constructor(canvas, tooltip) {
+        this.canvas = canvas;
+        this.tooltip = tooltip;
+        this.data = null;
+        this.options = { ma: true, bollinger: true, rsi: true, macd: true, markers: true };
+        this.count = 0; // Candles shown
+        this.end = 0; // Index after the last candle shown
+        this.following = true; // Whether new candles scroll into view
+        this.mouse = null;
+        this.drag = null;
+
+        canvas.addEventListener('wheel', event => this.onWheel(event), { passive: false });
+        canvas.addEventListener('mousedown', event => {
+            this.drag = { x: event.offsetX, end: this.end };
+            canvas.parentElement.classList.add('dragging');
+        });
+        window.addEventListener('mouseup', () => {
+            this.drag = null;
+            canvas.parentElement.classList.remove('dragging');
+        });
+        canvas.addEventListener('mousemove', event => this.onMove(event));
+        canvas.addEventListener('mouseleave', () => {
+            this.mouse = null;
+            this.draw();
+        });
+        canvas.addEventListener('dblclick', () => {
+            this.reset();
+            this.draw();
+        });
+        new ResizeObserver(() => this.draw()).observe(canvas);
+    }
+
+    // setData shows a chart response, keeping the zoom when only new candles came in
+    setData(data) {
+        const same = this.data && this.data.symbol === data.symbol && this.data.interval === data.interval;
+        this.data = data;
+        if (!same) {
+            this.reset();
+        } else if (this.following) {
+            this.end = data.candles.length;
+        } else {
+            this.end = Math.min(this.end, data.candles.length);
+        }
+        this.draw();
+    }
+
+    setOptions(options) {
+        Object.assign(this.options, options);
+        this.draw();
+    }
+
+    reset() {
+        const n = this.data ? this.data.candles.length : 0;
+        this.count = Math.min(n, defaultVisibleCandles);
+        this.end = n;
+        this.following = true;
+    }
+
+    visible() {
+        return { start: Math.max(0, this.end - this.count), end: this.end };
+    }
+
+    onWheel(event) {
+        if (!this.data || this.data.candles.length === 0) return;
+        event.preventDefault();
+        const n = this.data.candles.length;
+        const plotWidth = this.canvas.clientWidth - axisWidth;
+        const at = Math.min(Math.max(event.offsetX / plotWidth, 0), 1);
+        const { start } = this.visible();
+        const anchor = start + at * this.count; // Candle under the cursor stays put
+        const factor = event.deltaY > 0 ? 1.2 : 1 / 1.2;
+        const count = Math.round(Math.min(Math.max(this.count * factor, Math.min(minVisibleCandles, n)), n));
+        const end = Math.round(anchor - at * count) + count;
+        this.count = count;
+        this.end = Math.min(Math.max(end, count), n);
+        this.following = this.end === n;
+        this.draw();
+    }
+
+    onMove(event) {
+        this.mouse = { x: event.offsetX, y: event.offsetY };
+        if (this.drag && this.data) {
+            const n = this.data.candles.length;
+            const candleWidth = (this.canvas.clientWidth - axisWidth) / Math.max(this.count, 1);
+            const shift = Math.round((event.offsetX - this.drag.x) / candleWidth);
+            this.end = Math.min(Math.max(this.drag.end - shift, this.count), n);
+            this.following = this.end === n;
+        }
+        this.draw();
+    }
+
+    // layout splits the height between the price pane and the enabled sub-panes
+    layout(height) {
+        const subPanes = ['rsi', 'macd'].filter(name => this.options[name]);
+        const available = height - timeAxisHeight;
+        const subHeight = subPanes.length ? Math.round(available * 0.2) : 0;
+        const panes = { price: { top: 0, height: available - subPanes.length * (subHeight + paneGap) } };
+        let top = panes.price.height + paneGap;
+        subPanes.forEach(name => {
+            panes[name] = { top, height: subHeight };
+            top += subHeight + paneGap;
+        });
+        return panes;
+    }
+
+    draw() {
+        const { ctx, width, height } = sizeCanvas(this.canvas);
+        this.tooltip.hidden = true;
+        if (!this.data || this.data.candles.length === 0 || width <= axisWidth) return;
+
+        const { start, end } = this.visible();
+        const candles = this.data.candles.slice(start, end);
+        const ind = this.data.indicators;
+        const plotWidth = width - axisWidth;
+        const candleWidth = plotWidth / this.count;
+        const x = i => (i - start + 0.5) * candleWidth;
+        const panes = this.layout(height);
+        ctx.font = '11px system-ui, sans-serif';
+        ctx.textBaseline = 'middle';
+
+        // Price pane, scaled to the candles and the bands shown
+        let low = Math.min(...candles.map(c => c.low)), high = Math.max(...candles.map(c => c.high));
+        if (this.options.bollinger) {
+            for (let i = start; i < end; i++) {
+                if (ind.bollinger_upper[i] !== null) high = Math.max(high, ind.bollinger_upper[i]);
+                if (ind.bollinger_lower[i] !== null) low = Math.min(low, ind.bollinger_lower[i]);
+            }
+        }
+        const pad = (high - low) * 0.06 || Math.abs(high) * 0.01 || 1;
+        const price = this.scale(panes.price, low - pad, high + pad);
+        this.grid(ctx, price, plotWidth, niceTicks(low, high, 5), formatPrice);
+
+        const maxVolume = Math.max(...candles.map(c => c.volume));
+        if (maxVolume > 0) {
+            ctx.fillStyle = chartColors.volume;
+            const volumeHeight = panes.price.height * 0.2;
+            candles.forEach((c, k) => {
+                const h = c.volume / maxVolume * volumeHeight;
+                ctx.fillRect(x(start + k) - candleWidth * 0.35, panes.price.top + panes.price.height - h, candleWidth * 0.7, h);
+            });
+        }
+        if (this.options.bollinger) {
+            this.band(ctx, ind.bollinger_upper, ind.bollinger_lower, start, end, x, price.y);
+        }
+        candles.forEach((c, k) => {
+            const color = c.close >= c.open ? chartColors.up : chartColors.down;
+            const cx = x(start + k);
+            ctx.strokeStyle = ctx.fillStyle = color;
+            ctx.lineWidth = 1;
+            ctx.beginPath();
+            ctx.moveTo(cx, price.y(c.high));
+            ctx.lineTo(cx, price.y(c.low));
+            ctx.stroke();
+            const top = price.y(Math.max(c.open, c.close)), bottom = price.y(Math.min(c.open, c.close));
+            const bodyWidth = Math.max(1, candleWidth * 0.7);
+            ctx.fillRect(cx - bodyWidth / 2, top, bodyWidth, Math.max(1, bottom - top));
+        });
+        if (this.options.ma) {
+            this.line(ctx, ind.ma, start, end, x, price.y, chartColors.ma);
+        }
+        if (this.options.markers) {
+            this.markers(ctx, start, end, x, price.y);
+        }
+
+        if (this.options.rsi) {
+            const rsi = this.scale(panes.rsi, 0, 100);
+            this.grid(ctx, rsi, plotWidth, [30, 50, 70], v => v.toFixed(0));
+            this.line(ctx, ind.rsi, start, end, x, rsi.y, chartColors.band);
+            this.label(ctx, panes.rsi, 'RSI (14)');
+        }
+        if (this.options.macd) {
+            let extent = 0;
+            for (let i = start; i < end; i++) {
+                for (const series of [ind.macd, ind.macd_signal, ind.macd_histogram]) {
+                    if (series[i] !== null) extent = Math.max(extent, Math.abs(series[i]));
+                }
+            }
+            extent = extent * 1.1 || 1;
+            const macd = this.scale(panes.macd, -extent, extent);
+            this.grid(ctx, macd, plotWidth, [0], formatPrice);
+            for (let i = start; i < end; i++) {
+                const v = ind.macd_histogram[i];
+                if (v === null) continue;
+                ctx.fillStyle = v >= 0 ? chartColors.up : chartColors.down;
+                const y0 = macd.y(0), y1 = macd.y(v);
+                ctx.fillRect(x(i) - candleWidth * 0.35, Math.min(y0, y1), candleWidth * 0.7, Math.abs(y1 - y0));
+            }
+            this.line(ctx, ind.macd, start, end, x, macd.y, chartColors.ma);
+            this.line(ctx, ind.macd_signal, start, end, x, macd.y, chartColors.signal);
+            this.label(ctx, panes.macd, 'MACD (12, 26, 9)');
+        }
+
+        this.timeAxis(ctx, candles, start, x, plotWidth, height);
+        this.crosshair(ctx, start, end, candleWidth, plotWidth, height);
+    }
+
+    // scale maps values of a pane to y coordinates, higher values up
+    scale(pane, low, high) {
+        return { pane, low, high, y: v => pane.top + (high - v) / (high - low) * pane.height };
+    }
+
+    grid(ctx, scale, plotWidth, ticks, format) {
+        ctx.strokeStyle = chartColors.grid;
+        ctx.fillStyle = chartColors.text;
+        ctx.textAlign = 'left';
+        ctx.lineWidth = 1;
+        ticks.forEach(v => {
+            const y = Math.round(scale.y(v)) + 0.5;
+            if (y < scale.pane.top || y > scale.pane.top + scale.pane.height) return;
+            ctx.beginPath();
+            ctx.moveTo(0, y);
+            ctx.lineTo(plotWidth, y);
+            ctx.stroke();
+            ctx.fillText(format(v), plotWidth + 6, y);
+        });
+    }
+
+    label(ctx, pane, text) {
+        ctx.fillStyle = chartColors.text;
+        ctx.textAlign = 'left';
+        ctx.fillText(text, 4, pane.top + 8);
+    }
+
+    // line draws a series, leaving gaps where it is null
+    line(ctx, series, start, end, x, y, color) {
+        ctx.strokeStyle = color;
+        ctx.lineWidth = 1.5;
+        ctx.beginPath();
+        let drawing = false;
+        for (let i = start; i < end; i++) {
+            if (series[i] === null) {
+                drawing = false;
+                continue;
+            }
+            drawing ? ctx.lineTo(x(i), y(series[i])) : ctx.moveTo(x(i), y(series[i]));
+            drawing = true;
+        }
+        ctx.stroke();
+    }
+
+    band(ctx, upper, lower, start, end, x, y) {
+        const shown = [];
+        for (let i = start; i < end; i++) {
+            if (upper[i] !== null && lower[i] !== null) shown.push(i);
+        }
+        if (shown.length > 1) {
+            ctx.beginPath();
+            shown.forEach((i, k) => k === 0 ? ctx.moveTo(x(i), y(upper[i])) : ctx.lineTo(x(i), y(upper[i])));
+            shown.slice().reverse().forEach(i => ctx.lineTo(x(i), y(lower[i])));
+            ctx.closePath();
+            ctx.fillStyle = chartColors.bandFill;
+            ctx.fill();
+        }
+        this.line(ctx, upper, start, end, x, y, chartColors.band);
+        this.line(ctx, lower, start, end, x, y, chartColors.band);
+    }
+
+    // markers draws buys below the candle, sells above it, and alerts and anomalies at their price
+    markers(ctx, start, end, x, y) {
+        ctx.textAlign = 'center';
+        ctx.font = '12px system-ui, sans-serif';
+        this.data.markers.forEach(m => {
+            const i = this.candleAt(m.time);
+            if (i < start || i >= end) return;
+            const c = this.data.candles[i];
+            if (m.kind === 'buy') {
+                ctx.fillStyle = chartColors.up;
+                ctx.fillText('▲', x(i), y(c.low) + 10);
+            } else if (m.kind === 'sell') {
+                ctx.fillStyle = chartColors.down;
+                ctx.fillText('▼', x(i), y(c.high) - 10);
+            } else if (m.kind === 'alert') {
+                ctx.fillStyle = chartColors.alert;
+                ctx.fillText('●', x(i), y(m.price));
+            } else {
+                ctx.fillStyle = chartColors.anomaly;
+                ctx.fillText('◆', x(i), y(m.price));
+            }
+        });
+        ctx.font = '11px system-ui, sans-serif';
+    }
+
+    // candleAt returns the index of the candle a time falls in, -1 if none
+    candleAt(time) {
+        const t = Date.parse(time), candles = this.data.candles;
+        let lo = 0, hi = candles.length - 1, found = -1;
+        while (lo <= hi) {
+            const mid = (lo + hi) >> 1;
+            if (Date.parse(candles[mid].time) <= t) {
+                found = mid;
+                lo = mid + 1;
+            } else {
+                hi = mid - 1;
+            }
+        }
+        if (found >= 0 && t - Date.parse(candles[found].time) >= this.data.interval_seconds * 1000) return -1;
+        return found;
+    }
+
+    timeAxis(ctx, candles, start, x, plotWidth, height) {
+        const every = Math.max(1, Math.ceil(110 / (plotWidth / this.count)));
+        ctx.fillStyle = chartColors.text;
+        ctx.textAlign = 'center';
+        candles.forEach((c, k) => {
+            if ((start + k) % every !== 0) return;
+            ctx.fillText(this.formatTime(c.time), x(start + k), height - timeAxisHeight / 2);
+        });
+    }
+
+    formatTime(time) {
+        const d = new Date(time);
+        if (this.data.interval_seconds >= 86400) return d.toLocaleDateString(undefined, { month: 'short', day: 'numeric' });
+        const clock = d.toLocaleTimeString(undefined, { hour: '2-digit', minute: '2-digit' });
+        if (this.data.interval_seconds >= 3600) return d.toLocaleDateString(undefined, { month: 'short', day: 'numeric' }) + ' ' + clock;
+        return clock;
+    }
+
+    // crosshair marks the candle under the mouse and lists its values in the tooltip
+    crosshair(ctx, start, end, candleWidth, plotWidth, height) {
+        if (!this.mouse || this.drag || this.mouse.x >= plotWidth) return;
+        const i = start + Math.floor(this.mouse.x / candleWidth);
+        if (i < start || i >= end) return;
+        const cx = (i - start + 0.5) * candleWidth;
+
+        ctx.strokeStyle = chartColors.cross;
+        ctx.setLineDash([4, 4]);
+        ctx.beginPath();
+        ctx.moveTo(cx, 0);
+        ctx.lineTo(cx, height - timeAxisHeight);
+        ctx.moveTo(0, this.mouse.y);
+        ctx.lineTo(plotWidth, this.mouse.y);
+        ctx.stroke();
+        ctx.setLineDash([]);
+
+        const c = this.data.candles[i], ind = this.data.indicators;
+        const lines = [
+            new Date(c.time).toLocaleString(),
+            `O ${formatPrice(c.open)}  H ${formatPrice(c.high)}`,
+            `L ${formatPrice(c.low)}  C ${formatPrice(c.close)}`,
+        ];
+        if (c.volume > 0) lines.push(`Volume ${c.volume.toLocaleString(undefined, { maximumFractionDigits: 4 })}`);
+        if (this.options.ma && ind.ma[i] !== null) lines.push(`MA (${ind.ma_period}) ${formatPrice(ind.ma[i])}`);
+        if (this.options.bollinger && ind.bollinger_upper[i] !== null) {
+            lines.push(`BB (${ind.bollinger_period}) ${formatPrice(ind.bollinger_lower[i])} - ${formatPrice(ind.bollinger_upper[i])}`);
+        }
+        if (this.options.rsi && ind.rsi[i] !== null) lines.push(`RSI ${ind.rsi[i].toFixed(2)}`);
+        if (this.options.macd && ind.macd[i] !== null) {
+            const signal = ind.macd_signal[i] === null ? '-' : formatPrice(ind.macd_signal[i]);
+            lines.push(`MACD ${formatPrice(ind.macd[i])}  Signal ${signal}`);
+        }
+        if (this.options.markers) {
+            this.data.markers.filter(m => this.candleAt(m.time) === i).forEach(m => lines.push(m.kind === 'alert' ? m.text : `${m.kind.toUpperCase()}: ${m.text}`));
+        }
+
+        // Text only, the marker texts come from the data
+        this.tooltip.textContent = lines.join('\n');
+        this.tooltip.hidden = false;
+        const left = cx + 16 + this.tooltip.offsetWidth > plotWidth ? cx - 16 - this.tooltip.offsetWidth : cx + 16;
+        this.tooltip.style.left = Math.max(0, left) + 'px';
+    }
+}
+
+// niceTicks returns about count round values between low and high
+function niceTicks(low, high, count) {
+    const range = high - low;
+    if (!(range > 0)) return [low];
+    const rough = range / count;
+    const magnitude = Math.pow(10, Math.floor(Math.log10(rough)));
+    const step = [1, 2, 5, 10].map(m => m * magnitude).find(s => s >= rough);
+    const ticks = [];
+    for (let v = Math.ceil(low / step) * step; v <= high; v += step) {
+        ticks.push(Number(v.toPrecision(12)));
+    }
+    return ticks;
+}
@@ -0,0 +1,204 @@
+// Open the dashboard with ?quote=EUR (or BTC, ...) to convert every value
+const quote = new URLSearchParams(location.search).get('quote');
+const query = quote ? '?quote=' + encodeURIComponent(quote) : '';
+const currencySigns = { USDT: '$', USDC: '$', FDUSD: '$', BUSD: '$', TUSD: '$', DAI: '$', EUR: '€', GBP: '£', JPY: '¥' };
+
+// Signals are classified by the analytics service with the thresholds of each symbol
+const signalColors = {
+    OVERBOUGHT: '#ef4444', // Red
+    OVERSOLD: '#10b981', // Green
+    NEUTRAL: '#38bdf8', // Blue
+    ROCKET: '#10b981',
+    CRASH: '#ef4444',
+    STABLE: '#94a3b8',
+};
+function signalColor(signal) {
+    return signalColors[signal] || '#94a3b8'; // Grey for loading state
+}
+function rsiLabel(coin) {
+    if (!coin.thresholds) return 'RSI (14)';
+    return `RSI (14) ${coin.signal}, ${coin.thresholds.oversold}/${coin.thresholds.overbought}`;
+}
+function trendLabel(coin) {
+    if (!coin.trend || coin.trend === 'WAITING_FOR_DATA') return '';
+    const t = coin.thresholds;
+    return `<span class="avg-label" style="color: ${signalColor(coin.trend)}">${coin.trend} ${coin.change_percent >= 0 ? '+' : ''}${coin.change_percent.toFixed(2)}% VS 1H (+${t.rocket}% / -${t.crash}%)</span>`;
+}
+async function updateStats() {
+    try {
+        const response = await api('/api/stats' + query);
+        const data = await response.json();
+        const container = document.getElementById('dashboard');
+        const timeSpan = document.getElementById('time');
+
+        container.innerHTML = ''; 
+
+        data.forEach(coin => {
+            const card = document.createElement('div');
+            card.className = 'card';
+            // Symbols are set as text, the markup below only holds numbers and labels of the server
+            const link = textElement('a', 'symbol', coin.symbol);
+            link.href = '/symbol/' + encodeURIComponent(coin.symbol) + query;
+            const sparkline = textElement('canvas', 'sparkline');
+            sparkline.dataset.symbol = coin.symbol;
+            card.append(link, textElement('div', 'price', money(coin.current_price, coin.quote)), sparkline);
+            card.insertAdjacentHTML('beforeend', `
+                <div style="margin-bottom: 12px; padding: 8px; background: rgba(0,0,0,0.2); border-radius: 8px;">
+                    <span class="avg-label">${rsiLabel(coin)}</span>
+                    <span class="rsi-value" style="font-size: 1.2rem; font-weight: 700; color: ${signalColor(coin.signal)}">
+                        ${coin.signal && coin.signal !== 'WAITING_FOR_DATA' ? coin.rsi.toFixed(2) : 'CALCING...'}
+                    </span>
+                    ${trendLabel(coin)}
+                </div>
+
+                <div style="margin-bottom: 12px;">
+                    <span class="avg-label">${forecastLabel(coin.forecast)}</span>
+                    <span class="avg-value">${coin.forecast ? money(coin.forecast.value, coin.quote) : 'CALCING...'}</span>
+                    ${coin.forecast ? `<span class="avg-label">${money(coin.forecast.lower, coin.quote)} - ${money(coin.forecast.upper, coin.quote)}</span>` : ''}
+                </div>
+
+                ${coin.liquidity ? `<div style="margin-bottom: 12px;">
+                    <span class="avg-label">SPREAD ${coin.liquidity.spread_bps.toFixed(2)} BPS, IMBALANCE ${coin.liquidity.imbalance.toFixed(2)}</span>
+                    ${depthLabel(coin.liquidity, coin.quote)}
+                </div>` : ''}
+
+                <div>
+                    <span class="avg-label">1H ROLLING AVERAGE</span>
+                    <span class="avg-value">${money(coin.avg_price_1h, coin.quote)}</span>
+                </div>
+            `);
+            container.appendChild(card);
+        });
+        drawSparklines();
+
+        timeSpan.innerText = new Date().toLocaleTimeString('en-CA', { hour12: true });
+    } catch (err) {
+        console.error('Update failed:', err);
+    }
+}
+
+// Closes of the last 24 hours by symbol, fetched less often than the stats
+let sparklines = {};
+async function updateSparklines() {
+    try {
+        const response = await api('/api/sparklines');
+        if (!response.ok) return;
+        sparklines = await response.json();
+        drawSparklines();
+    } catch (err) {
+        console.error('Sparklines update failed:', err);
+    }
+}
+function drawSparklines() {
+    document.querySelectorAll('canvas.sparkline').forEach(canvas => drawSparkline(canvas, sparklines[canvas.dataset.symbol]));
+}
+
+// textElement creates a tag with text, never parsed as markup
+function textElement(tag, className, text) {
+    const e = document.createElement(tag);
+    if (className) e.className = className;
+    if (text !== undefined) e.textContent = text;
+    return e;
+}
+
+function forecastLabel(forecast) {
+    if (!forecast) return 'NEXT CANDLE FORECAST';
+    return `NEXT ${forecast.interval_seconds / 60}M FORECAST (${forecast.model.toUpperCase()}, ${Math.round(forecast.level * 100)}%)`;
+}
+
+// Depth within 1% of the mid, or the widest band measured
+function depthLabel(liquidity, quote) {
+    const bands = liquidity.bands || [];
+    const band = bands.find(b => b.bps === 100) || bands[bands.length - 1];
+    if (!band) return '';
+    return `<span class="avg-label">DEPTH ±${band.bps / 100}%: ${money(band.bid, quote)} / ${money(band.ask, quote)}</span>`;
+}
+
+function money(value, currency) {
+    const sign = currencySigns[currency || 'USDT'];
+    if (sign) {
+        return sign + value.toLocaleString(undefined, { minimumFractionDigits: 2, maximumFractionDigits: 4 });
+    }
+    return value.toLocaleString(undefined, { maximumFractionDigits: 8 }) + ' ' + currency;
+}
+function pnl(element, value, currency) {
+    element.innerText = (value >= 0 ? '+' : '-') + money(Math.abs(value), currency);
+    element.className = value >= 0 ? 'price-up' : 'price-down';
+}
+async function updatePortfolio() {
+    try {
+        const response = await api('/api/portfolio' + query);
+        const data = await response.json();
+        const currency = data.quote;
+
+        document.getElementById('pf-value').innerText = money(data.market_value, currency);
+        document.getElementById('pf-cost').innerText = money(data.cost_basis, currency);
+        pnl(document.getElementById('pf-unrealized'), data.unrealized_pnl, currency);
+        pnl(document.getElementById('pf-realized'), data.realized_pnl, currency);
+
+        const rows = document.getElementById('positions');
+        rows.innerHTML = '';
+        data.positions.forEach(p => {
+            const row = document.createElement('tr');
+            row.appendChild(textElement('td', 'symbol', p.symbol));
+            row.insertAdjacentHTML('beforeend', `
+                <td>${p.quantity.toLocaleString(undefined, { maximumFractionDigits: 8 })}</td>
+                <td>${money(p.avg_cost, currency)}</td>
+                <td>${p.market_price ? money(p.market_price, currency) : '-'}</td>
+                <td>${money(p.market_value, currency)}</td>
+                <td></td>
+                <td></td>
+            `);
+            pnl(row.children[5], p.unrealized_pnl, currency);
+            pnl(row.children[6], p.realized_pnl, currency);
+            rows.appendChild(row);
+        });
+    } catch (err) {
+        console.error('Portfolio update failed:', err);
+    }
+}
+
+// Blue for moving together, red for moving apart
+function heatColor(value) {
+    const alpha = Math.min(Math.abs(value), 1) * 0.85 + 0.05;
+    return value >= 0 ? `rgba(56, 189, 248, ${alpha})` : `rgba(239, 68, 68, ${alpha})`;
+}
+async function updateCorrelation() {
+    const info = document.getElementById('corr-info');
+    try {
+        const response = await api('/api/correlation?window=24h&resolution=5m');
+        if (!response.ok) {
+            info.innerText = await response.text();
+            return;
+        }
+        const data = await response.json();
+        info.innerText = `${data.method} correlation of ${data.samples} five-minute log returns, beta vs ${data.benchmark}`;
+
+        const table = document.getElementById('heatmap');
+        table.innerHTML = '';
+        const head = table.insertRow();
+        head.append(textElement('th'), ...data.symbols.map(s => textElement('th', '', s)), textElement('th', '', 'Beta'));
+        data.matrix.forEach((row, i) => {
+            const tr = table.insertRow();
+            tr.appendChild(textElement('th', 'symbol', data.symbols[i]));
+            row.forEach(value => {
+                const td = tr.insertCell();
+                td.innerText = value.toFixed(2);
+                td.style.background = heatColor(value);
+            });
+            const beta = tr.insertCell();
+            beta.className = 'beta';
+            beta.innerText = data.betas[i].beta.toFixed(2);
+        });
+    } catch (err) {
+        console.error('Correlation update failed:', err);
+    }
+}
+
+// Start the initial update and set interval for auto-refresh
+updateStats();
+updatePortfolio();
+setInterval(() => { updateStats(); updatePortfolio(); }, 5000);
+updateCorrelation();
+updateSparklines();
+setInterval(() => { updateCorrelation(); updateSparklines(); }, 60000);
@@ -0,0 +1,18 @@
+document.getElementById('login').addEventListener('submit', async event => {
+    event.preventDefault();
+    const error = document.getElementById('error');
+    try {
+        const response = await fetch('/api/session', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ key: document.getElementById('key').value }),
+        });
+        if (!response.ok) {
+            error.innerText = await response.text();
+            return;
+        }
+        location.reload();
+    } catch (err) {
+        error.innerText = 'The server could not be reached';
+    }
+});
@@ -0,0 +1,18 @@
+// Shared by the pages of a logged in dashboard
+
+// An expired session reloads the page, which shows the login form
+async function api(url) {
+    const response = await fetch(url);
+    if (response.status === 401) location.reload();
+    return response;
+}
+
+// The logout link is only there for a logged in key
+const logout = document.getElementById('logout');
+if (logout) {
+    logout.addEventListener('click', async event => {
+        event.preventDefault();
+        await fetch('/api/session', { method: 'DELETE' });
+        location.reload();
+    });
+}
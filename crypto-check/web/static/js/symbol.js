@@ -0,0 +1,54 @@
+// The chart page of one symbol, e.g. /symbol/BTCUSDT
+const symbol = document.body.dataset.symbol;
+// Like the dashboard, ?quote=EUR (or BTC, ...) converts the prices
+const quote = new URLSearchParams(location.search).get('quote');
+const chart = new PriceChart(document.getElementById('chart-canvas'), document.getElementById('chart-tooltip'));
+let interval = '5m';
+
+async function updateChart() {
+    const info = document.getElementById('chart-info');
+    try {
+        let url = `/api/chart?symbol=${encodeURIComponent(symbol)}&interval=${interval}`;
+        if (quote) url += '&quote=' + encodeURIComponent(quote);
+        const response = await api(url);
+        if (!response.ok) {
+            info.innerText = await response.text();
+            return;
+        }
+        const data = await response.json();
+        if (data.interval !== interval) return; // Answer to a request made before switching
+        chart.setData(data);
+
+        const candles = data.candles;
+        if (candles.length === 0) {
+            info.innerText = 'No prices in this range yet';
+            return;
+        }
+        const first = candles[0].open, last = candles[candles.length - 1].close;
+        const change = (last - first) / first * 100;
+        document.getElementById('last-price').innerText = `${formatPrice(last)} ${data.quote}`;
+        const label = document.getElementById('range-change');
+        label.innerText = `${change >= 0 ? '+' : ''}${change.toFixed(2)}% over ${candles.length} candles`;
+        label.style.color = change >= 0 ? chartColors.up : chartColors.down;
+        info.innerText = `${candles.length} ${data.interval} candles, MA (${data.indicators.ma_period}), Bollinger (${data.indicators.bollinger_period}, 2σ), ${data.markers.length} markers${data.conversion ? `, converted through ${(data.conversion.path || []).join(' > ')}` : ''}`;
+        document.getElementById('time').innerText = new Date().toLocaleTimeString('en-CA', { hour12: true });
+    } catch (err) {
+        console.error('Chart update failed:', err);
+    }
+}
+
+document.querySelectorAll('#intervals button').forEach(button => {
+    button.addEventListener('click', () => {
+        document.querySelectorAll('#intervals button').forEach(b => b.classList.toggle('active', b === button));
+        interval = button.dataset.interval;
+        updateChart();
+    });
+});
+
+const toggles = { ma: 'show-ma', bollinger: 'show-bollinger', rsi: 'show-rsi', macd: 'show-macd', markers: 'show-markers' };
+Object.entries(toggles).forEach(([option, id]) => {
+    document.getElementById(id).addEventListener('change', event => chart.setOptions({ [option]: event.target.checked }));
+});
+
+updateChart();
+setInterval(updateChart, 30000);
@@ -0,0 +1,224 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+//go:embed templates static
+var embedded embed.FS
+
+// contentSecurityPolicy only allows the dashboard's own scripts, so markup
+// injected into a page cannot run any. Inline style attributes are still used.
+const contentSecurityPolicy = "default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'; img-src 'self' data:; frame-ancestors 'none'"
+
+// Site holds the page templates and the static files of the dashboard
+type Site struct {
+	fsys   fs.FS // With a templates and a static directory
+	reload bool
+
+	mu      sync.Mutex
+	current *bundle
+}
+
+// bundle is what is loaded from fsys at once
+type bundle struct {
+	pages  *template.Template
+	assets map[string]*asset // By path under static/
+}
+
+// asset is a static file ready to serve
+type asset struct {
+	body        []byte
+	gzipped     []byte // Nil when compressing does not pay off
+	contentType string
+	hash        string // Of body, the ETag and the version in asset URLs
+}
+
+// Embedded returns the site built into the binary. The built in files are
+// loaded by the tests, so like template.Must it only panics on a broken build.
+func Embedded() *Site {
+	s, err := newSite(embedded, false)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Dir returns the site in dir, read again on every request so that changes to
+// templates, scripts and styles show on the next reload. It is meant for development.
+func Dir(dir string) (*Site, error) {
+	return newSite(os.DirFS(dir), true)
+}
+
+func newSite(fsys fs.FS, reload bool) (*Site, error) {
+	b, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Site{fsys: fsys, reload: reload, current: b}, nil
+}
+
+// bundle returns the loaded files, loading them again first in development. A
+// broken file keeps the previous version, so a typo does not take the page down.
+func (s *Site) bundle() *bundle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reload {
+		b, err := load(s.fsys)
+		if err != nil {
+			log.Printf("[ERROR] Could not reload the dashboard, keeping the previous version: %v", err)
+		} else {
+			s.current = b
+		}
+	}
+	return s.current
+}
+
+// load reads the static files, then parses the templates with an asset
+// function turning a static path into its versioned URL
+func load(fsys fs.FS) (*bundle, error) {
+	b := &bundle{assets: make(map[string]*asset)}
+	err := fs.WalkDir(fsys, "static", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		b.assets[strings.TrimPrefix(name, "static/")] = newAsset(name, body)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{
+		"asset": func(name string) (string, error) {
+			a, ok := b.assets[name]
+			if !ok {
+				return "", fmt.Errorf("no static file %s", name)
+			}
+			return "/static/" + name + "?v=" + a.hash, nil
+		},
+	}
+	b.pages, err = template.New("").Funcs(funcs).ParseFS(fsys, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func newAsset(name string, body []byte) *asset {
+	sum := sha256.Sum256(body)
+	a := &asset{body: body, hash: hex.EncodeToString(sum[:8])}
+	if a.contentType = mime.TypeByExtension(path.Ext(name)); a.contentType == "" {
+		a.contentType = http.DetectContentType(body)
+	}
+	if compressible(a.contentType) {
+		var buf bytes.Buffer
+		zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		zw.Write(body)
+		zw.Close()
+		if buf.Len() < len(body) {
+			a.gzipped = buf.Bytes()
+		}
+	}
+	return a
+}
+
+func compressible(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") || strings.Contains(contentType, "javascript") ||
+		strings.Contains(contentType, "json") || strings.Contains(contentType, "svg")
+}
+
+// Render writes the page template name, e.g. "index.html", with data and status
+func (s *Site) Render(w http.ResponseWriter, status int, name string, data any) {
+	var buf bytes.Buffer
+	if err := s.bundle().pages.ExecuteTemplate(&buf, name, data); err != nil {
+		log.Printf("[ERROR] Template error: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("Content-Security-Policy", contentSecurityPolicy)
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+// ServeHTTP serves the static files under /static/. URLs with the version of
+// the asset function are cached for good, others are revalidated with the ETag.
+// Compressible files are sent gzipped to clients accepting it.
+func (s *Site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a, ok := s.bundle().assets[strings.TrimPrefix(r.URL.Path, "/static/")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	h := w.Header()
+	body, etag := a.body, `"`+a.hash+`"`
+	if a.gzipped != nil {
+		h.Add("Vary", "Accept-Encoding")
+		if acceptsGzip(r) {
+			body, etag = a.gzipped, `"`+a.hash+`-gz"`
+			h.Set("Content-Encoding", "gzip")
+		}
+	}
+	h.Set("ETag", etag)
+	h.Set("Content-Type", a.contentType)
+	h.Set("X-Content-Type-Options", "nosniff")
+	if r.URL.Query().Get("v") == a.hash && !s.reload {
+		h.Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		h.Set("Cache-Control", "no-cache")
+	}
+
+	if matchesETag(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Length", fmt.Sprint(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") && strings.ReplaceAll(params, " ", "") != "q=0" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesETag reports whether an If-None-Match header lists etag, weak or not
+func matchesETag(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			return true
+		}
+	}
+	return false
+}
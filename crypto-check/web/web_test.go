@@ -0,0 +1,191 @@
+package web
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"crypto-check/auth"
+)
+
+func TestRender(t *testing.T) {
+	site := Embedded()
+	tests := []struct {
+		name       string
+		page       string
+		data       any
+		wantStatus int
+		want       []string
+		notWant    []string
+	}{
+		{"Dashboard", "index.html", struct{ Key *auth.Key }{}, http.StatusOK,
+			[]string{`/static/css/dashboard.css?v=`, `/static/js/dashboard.js?v=`}, []string{"Log out"}},
+		{"Logged in", "index.html", struct{ Key *auth.Key }{&auth.Key{Name: `<script>alert(1)</script>`, Role: auth.RoleViewer}}, http.StatusOK,
+			[]string{"Log out", "&lt;script&gt;alert(1)&lt;/script&gt; (viewer)"}, []string{"<script>alert"}},
+		{"Symbol page", "symbol.html", struct {
+			Symbol string
+			Key    *auth.Key
+		}{"BTCUSDT", nil}, http.StatusOK,
+			[]string{`data-symbol="BTCUSDT"`, `/static/js/chart.js?v=`, `/static/js/symbol.js?v=`}, []string{"Log out"}},
+		{"Login form", "login.html", nil, http.StatusUnauthorized,
+			[]string{`/static/js/login.js?v=`, `id="key"`}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			site.Render(rec, tt.wantStatus, tt.page, tt.data)
+			body := rec.Body.String()
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Render() = %d, want %d: %s", rec.Code, tt.wantStatus, body)
+			}
+			if csp := rec.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "script-src 'self'") {
+				t.Errorf("Content-Security-Policy = %q", csp)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("page does not contain %q", want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(body, notWant) {
+					t.Errorf("page contains %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestStatic(t *testing.T) {
+	site := Embedded()
+	rec := httptest.NewRecorder()
+	site.Render(rec, http.StatusOK, "index.html", nil)
+	versioned := regexp.MustCompile(`/static/js/dashboard\.js\?v=[0-9a-f]+`).FindString(rec.Body.String())
+	if versioned == "" {
+		t.Fatalf("no versioned script URL in the dashboard")
+	}
+	plain, _ := os.ReadFile("static/js/dashboard.js")
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		header       map[string]string
+		wantStatus   int
+		wantGzip     bool
+		wantCache    string
+		wantPlainLen bool
+	}{
+		{"Versioned", "GET", versioned, nil, http.StatusOK, false, "immutable", true},
+		{"Gzipped", "GET", versioned, map[string]string{"Accept-Encoding": "br, gzip"}, http.StatusOK, true, "immutable", false},
+		{"Gzip refused", "GET", versioned, map[string]string{"Accept-Encoding": "gzip;q=0"}, http.StatusOK, false, "immutable", true},
+		{"Unversioned", "GET", "/static/js/dashboard.js", nil, http.StatusOK, false, "no-cache", true},
+		{"Old version", "GET", "/static/js/dashboard.js?v=0000", nil, http.StatusOK, false, "no-cache", true},
+		{"Head", "HEAD", versioned, nil, http.StatusOK, false, "immutable", false},
+		{"Unknown file", "GET", "/static/js/missing.js", nil, http.StatusNotFound, false, "", false},
+		{"Template is not static", "GET", "/static/../templates/index.html", nil, http.StatusNotFound, false, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			site.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.wantStatus)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Encoding") == "gzip"; got != tt.wantGzip {
+				t.Errorf("gzipped = %v, want %v", got, tt.wantGzip)
+			}
+			if cache := rec.Header().Get("Cache-Control"); !strings.Contains(cache, tt.wantCache) {
+				t.Errorf("Cache-Control = %q, want %s", cache, tt.wantCache)
+			}
+			if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/javascript") {
+				t.Errorf("Content-Type = %q", rec.Header().Get("Content-Type"))
+			}
+			body := rec.Body.Bytes()
+			if tt.wantGzip {
+				zr, err := gzip.NewReader(bytes.NewReader(body))
+				if err != nil {
+					t.Fatalf("gzip.NewReader() error: %v", err)
+				}
+				body, _ = io.ReadAll(zr)
+			}
+			if tt.wantPlainLen || tt.wantGzip {
+				if !bytes.Equal(body, plain) {
+					t.Errorf("body of %d bytes is not static/js/dashboard.js", len(body))
+				}
+			}
+
+			// The ETag of the response revalidates it
+			again := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.header {
+				again.Header.Set(k, v)
+			}
+			again.Header.Set("If-None-Match", `W/`+rec.Header().Get("ETag"))
+			rec = httptest.NewRecorder()
+			site.ServeHTTP(rec, again)
+			if rec.Code != http.StatusNotModified {
+				t.Errorf("revalidation = %d, want 304", rec.Code)
+			}
+		})
+	}
+}
+
+func TestDirReload(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	render := func(site *Site) string {
+		rec := httptest.NewRecorder()
+		site.Render(rec, http.StatusOK, "index.html", nil)
+		return rec.Body.String()
+	}
+	write("templates/index.html", `<link href="{{asset "site.css"}}">v1`)
+	write("static/site.css", "body { color: red; }")
+	site, err := Dir(dir)
+	if err != nil {
+		t.Fatalf("Dir() error: %v", err)
+	}
+	first := render(site)
+	if !strings.HasSuffix(first, "v1") {
+		t.Fatalf("page = %q", first)
+	}
+
+	// Edits show on the next request, with a new version for the changed style
+	write("templates/index.html", `<link href="{{asset "site.css"}}">v2`)
+	write("static/site.css", "body { color: blue; }")
+	second := render(site)
+	if !strings.HasSuffix(second, "v2") || second[:len(second)-2] == first[:len(first)-2] {
+		t.Errorf("page after edits = %q, was %q", second, first)
+	}
+
+	// A broken template keeps the previous version
+	write("templates/index.html", `{{asset "missing.css"`)
+	if got := render(site); got != second {
+		t.Errorf("page with a broken template = %q, want the previous one", got)
+	}
+
+	if _, err := Dir(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("Dir() of a missing directory returned no error")
+	}
+}